@@ -0,0 +1,173 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// analyzerCommands are the external analyzers given with --analyzer: an
+// executable followed by its arguments, separated by spaces.
+var analyzerCommands []string
+
+// analyzerSchemaVersion is the version of the AnalyzerInput document and of
+// the output analyzers return.
+const analyzerSchemaVersion = 1
+
+// Severities of analyzer findings. check fails on error findings only.
+var analyzerSeverities = []string{"error", "warning", "info"}
+
+// AnalyzerInput is the document written to an analyzer's stdin.
+type AnalyzerInput struct {
+	SchemaVersion int            `json:"schemaVersion"`
+	MainModules   []string       `json:"mainModules"`
+	Nodes         []analyzerNode `json:"nodes"`
+	Edges         []graphEdge    `json:"edges"`
+	// Graph is the "go mod graph" output the nodes and edges were built
+	// from, before exclusions.
+	Graph string `json:"graph"`
+}
+
+// analyzerNode is a module as seen by analyzers.
+type analyzerNode struct {
+	graphNode
+	Version string `json:"version,omitempty"`
+}
+
+// AnalyzerFinding is a single result reported by an analyzer.
+type AnalyzerFinding struct {
+	// Analyzer is the base name of the analyzer's executable, filled in
+	// by depstat.
+	Analyzer string   `json:"analyzer"`
+	Rule     string   `json:"rule"`
+	Severity string   `json:"severity"`
+	Module   string   `json:"module,omitempty"`
+	Message  string   `json:"message"`
+	Path     []string `json:"path,omitempty"`
+}
+
+// analyzerOutput is the document an analyzer writes to stdout.
+type analyzerOutput struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	Findings      []AnalyzerFinding `json:"findings"`
+}
+
+// buildAnalyzerInput assembles the graph document handed to analyzers.
+func buildAnalyzerInput(depGraph *DependencyOverview) AnalyzerInput {
+	nodes, edges := buildGraphTopology(depGraph)
+	input := AnalyzerInput{
+		SchemaVersion: analyzerSchemaVersion,
+		MainModules:   depGraph.MainModules,
+		Nodes:         make([]analyzerNode, 0, len(nodes)),
+		Edges:         edges,
+		Graph:         depGraph.rawGraph,
+	}
+	for _, n := range nodes {
+		input.Nodes = append(input.Nodes, analyzerNode{graphNode: n, Version: depGraph.Versions[n.Module]})
+	}
+	if input.Edges == nil {
+		input.Edges = []graphEdge{}
+	}
+	return input
+}
+
+// runAnalyzers runs every analyzer command with the graph on stdin and
+// returns their findings, sorted by analyzer, module and message.
+func runAnalyzers(commands []string, depGraph *DependencyOverview) ([]AnalyzerFinding, error) {
+	if len(commands) == 0 {
+		return nil, nil
+	}
+	raw, err := json.Marshal(buildAnalyzerInput(depGraph))
+	if err != nil {
+		return nil, err
+	}
+	var findings []AnalyzerFinding
+	for _, command := range commands {
+		fields := strings.Fields(command)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("--analyzer: empty command")
+		}
+		name := filepath.Base(fields[0])
+		c := exec.Command(fields[0], fields[1:]...)
+		c.Stdin = bytes.NewReader(raw)
+		var stderr bytes.Buffer
+		c.Stderr = &stderr
+		logCommand(c)
+		out, err := c.Output()
+		if err != nil {
+			return nil, fmt.Errorf("analyzer %s failed: %w: %s", name, err, strings.TrimSpace(stderr.String()))
+		}
+		found, err := parseAnalyzerOutput(name, out)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, found...)
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Analyzer != b.Analyzer {
+			return a.Analyzer < b.Analyzer
+		}
+		if a.Module != b.Module {
+			return a.Module < b.Module
+		}
+		return a.Message < b.Message
+	})
+	return findings, nil
+}
+
+// parseAnalyzerOutput validates the output of the named analyzer. A
+// missing severity means error.
+func parseAnalyzerOutput(name string, out []byte) ([]AnalyzerFinding, error) {
+	var parsed analyzerOutput
+	if err := json.Unmarshal(out, &parsed); err != nil {
+		return nil, fmt.Errorf("analyzer %s: invalid output: %w", name, err)
+	}
+	if parsed.SchemaVersion != analyzerSchemaVersion {
+		return nil, fmt.Errorf("analyzer %s: unsupported schemaVersion %d (want %d)", name, parsed.SchemaVersion, analyzerSchemaVersion)
+	}
+	for i := range parsed.Findings {
+		f := &parsed.Findings[i]
+		f.Analyzer = name
+		if f.Severity == "" {
+			f.Severity = "error"
+		}
+		if !contains(analyzerSeverities, f.Severity) {
+			return nil, fmt.Errorf("analyzer %s: finding %d: severity must be one of: %s", name, i, strings.Join(analyzerSeverities, ", "))
+		}
+		if f.Message == "" {
+			return nil, fmt.Errorf("analyzer %s: finding %d has no message", name, i)
+		}
+	}
+	return parsed.Findings, nil
+}
+
+// analyzerViolation converts a finding to a policy violation, whose rule
+// is prefixed with the analyzer name.
+func analyzerViolation(f AnalyzerFinding) PolicyViolation {
+	rule := f.Analyzer
+	if f.Rule != "" {
+		rule += "/" + f.Rule
+	}
+	return PolicyViolation{Rule: rule, Module: f.Module, Message: f.Message, Path: f.Path}
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestParseAnalyzerOutput(t *testing.T) {
+	got, err := parseAnalyzerOutput("org-rules", []byte(`{"schemaVersion": 1, "findings": [
+		{"rule": "no-forks", "module": "github.com/fork/x", "message": "forked module", "path": ["a", "github.com/fork/x"]},
+		{"rule": "pin", "severity": "warning", "message": "unpinned"}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []AnalyzerFinding{
+		{Analyzer: "org-rules", Rule: "no-forks", Severity: "error", Module: "github.com/fork/x", Message: "forked module", Path: []string{"a", "github.com/fork/x"}},
+		{Analyzer: "org-rules", Rule: "pin", Severity: "warning", Message: "unpinned"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if v := analyzerViolation(got[0]); v.Rule != "org-rules/no-forks" {
+		t.Errorf("violation rule = %q", v.Rule)
+	}
+
+	for _, bad := range []string{
+		`not json`,
+		`{"findings": []}`,
+		`{"schemaVersion": 1, "findings": [{"message": "x", "severity": "fatal"}]}`,
+		`{"schemaVersion": 1, "findings": [{"rule": "r"}]}`,
+	} {
+		if _, err := parseAnalyzerOutput("a", []byte(bad)); err == nil {
+			t.Errorf("%s: expected an error", bad)
+		}
+	}
+}
+
+func TestRunAnalyzers(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script analyzer")
+	}
+	script := filepath.Join(t.TempDir(), "org-rules")
+	// report the input back, so the test sees what the analyzer received
+	body := `#!/bin/sh
+input=$(cat)
+case "$input" in
+*'"module":"b"'*) echo '{"schemaVersion":1,"findings":[{"rule":"'"$1"'","module":"b","message":"saw b"}]}' ;;
+*) echo '{"schemaVersion":1,"findings":[]}' ;;
+esac
+`
+	if err := os.WriteFile(script, []byte(body), 0755); err != nil {
+		t.Fatal(err)
+	}
+	depGraph := generateGraph("a b@v1.0.0\n", []string{"a"})
+	findings, err := runAnalyzers([]string{script + " seen"}, &depGraph)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []AnalyzerFinding{{Analyzer: "org-rules", Rule: "seen", Severity: "error", Module: "b", Message: "saw b"}}
+	if !reflect.DeepEqual(findings, want) {
+		t.Errorf("got %+v, want %+v", findings, want)
+	}
+
+	failing := filepath.Join(t.TempDir(), "fail")
+	if err := os.WriteFile(failing, []byte("#!/bin/sh\necho broken >&2\nexit 1\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runAnalyzers([]string{failing}, &depGraph); err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Errorf("expected the analyzer's stderr in the error, got %v", err)
+	}
+}
@@ -23,7 +23,6 @@ import (
 	"io"
 	"net/http"
 	"os"
-	"os/exec"
 	"regexp"
 	"sort"
 	"strings"
@@ -49,9 +48,16 @@ type ArchivedResult struct {
 
 // goModule represents a Go module dependency from `go list -m -json`.
 type goModule struct {
-	Path    string `json:"Path"`
-	Version string `json:"Version,omitempty"`
-	Main    bool   `json:"Main,omitempty"`
+	Path       string    `json:"Path"`
+	Version    string    `json:"Version,omitempty"`
+	Main       bool      `json:"Main,omitempty"`
+	Indirect   bool      `json:"Indirect,omitempty"`
+	Dir        string    `json:"Dir,omitempty"`
+	GoVersion  string    `json:"GoVersion,omitempty"`
+	Replace    *goModule `json:"Replace,omitempty"`
+	Update     *goModule `json:"Update,omitempty"`
+	Deprecated string    `json:"Deprecated,omitempty"`
+	Retracted  []string  `json:"Retracted,omitempty"`
 }
 
 // graphQL types for GitHub API responses.
@@ -117,7 +123,7 @@ Requires a GitHub token via --github-token-path or the GITHUB_TOKEN environment
 // otherwise falls back to the GITHUB_TOKEN environment variable.
 func resolveGitHubToken() (string, error) {
 	if githubTokenPath != "" {
-		data, err := os.ReadFile(githubTokenPath)
+		data, err := readInputFile("--github-token-path", githubTokenPath)
 		if err != nil {
 			return "", fmt.Errorf("reading github token from %s: %w", githubTokenPath, err)
 		}
@@ -139,7 +145,10 @@ func resolveGitHubToken() (string, error) {
 
 func runArchived(cmd *cobra.Command, args []string) error {
 	if len(args) != 0 {
-		return fmt.Errorf("archived does not take any arguments")
+		return withExitCode(ExitUsage, fmt.Errorf("archived does not take any arguments"))
+	}
+	if err := requireNetwork("archived (GitHub API)"); err != nil {
+		return err
 	}
 
 	token, err := resolveGitHubToken()
@@ -153,7 +162,7 @@ func runArchived(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("listing modules: %w", err)
 	}
 	if archivedSplitTestOnly {
-		return fmt.Errorf("--split-test-only not supported for archived")
+		return withExitCode(ExitUsage, fmt.Errorf("--split-test-only not supported for archived"))
 	}
 
 	// Separate direct github.com paths from vanity URLs
@@ -174,8 +183,8 @@ func runArchived(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	fmt.Fprintf(os.Stderr, "  %d direct GitHub repos\n", len(githubRepos))
-	fmt.Fprintf(os.Stderr, "  %d vanity/non-GitHub modules to resolve...\n", len(vanityModules))
+	infof("  %d direct GitHub repos\n", len(githubRepos))
+	infof("  %d vanity/non-GitHub modules to resolve...\n", len(vanityModules))
 
 	// Phase 2: resolve vanity URLs to GitHub repos
 	resolved, unresolved := resolveVanityURLs(vanityModules)
@@ -183,11 +192,11 @@ func runArchived(cmd *cobra.Command, args []string) error {
 		githubRepos[repo] = append(githubRepos[repo], mods...)
 	}
 
-	fmt.Fprintf(os.Stderr, "  Resolved %d vanity URLs to GitHub repos\n", len(resolved))
+	infof("  Resolved %d vanity URLs to GitHub repos\n", len(resolved))
 	if len(unresolved) > 0 {
-		fmt.Fprintf(os.Stderr, "  Could not resolve %d modules (non-GitHub or unavailable)\n", len(unresolved))
+		warnf("could not resolve %d modules (non-GitHub or unavailable)\n", len(unresolved))
 		for _, u := range unresolved {
-			fmt.Fprintf(os.Stderr, "    - %s\n", u)
+			logf(levelWarn, "    - %s\n", u)
 		}
 	}
 
@@ -198,7 +207,7 @@ func runArchived(cmd *cobra.Command, args []string) error {
 	}
 	sort.Strings(repos)
 
-	fmt.Fprintf(os.Stderr, "\nChecking %d unique GitHub repos for archived status...\n", len(repos))
+	infof("\nChecking %d unique GitHub repos for archived status...\n", len(repos))
 	archivedSet, warnings := checkArchivedRepos(repos, token)
 
 	// Build output
@@ -272,30 +281,31 @@ func outputArchivedText(result ArchivedResult, warnings []string) error {
 // and returns parsed module info. If selectedMainModules is non-empty, it
 // filters to dependencies reachable from those main modules.
 func listAllModules(selectedMainModules []string) ([]goModule, error) {
-	goListCmd := exec.Command("go", "list", "-m", "-json", "all")
-	if dir != "" {
-		goListCmd.Dir = dir
-	}
-	goListCmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod")
+	return listAllModulesWithFlags(selectedMainModules)
+}
+
+// goListModulesEnv is the environment go list -m -json all runs with unless
+// overridden: outside any workspace, and allowed to update go.mod.
+var goListModulesEnv = []string{"GOWORK=off", "GOFLAGS=-mod=mod"}
+
+// listAllModulesWithFlags is listAllModules with extra `go list -m` flags
+// (e.g. -u, -retracted) inserted before -json.
+func listAllModulesWithFlags(selectedMainModules []string, flags ...string) ([]goModule, error) {
+	args := append([]string{"list", "-m"}, flags...)
+	args = append(args, "-json", "all")
+	goListCmd := goCommand(args, goListModulesEnv...)
 
 	var stdout, stderr bytes.Buffer
 	goListCmd.Stdout = &stdout
 	goListCmd.Stderr = &stderr
 
 	if err := goListCmd.Run(); err != nil {
-		return nil, fmt.Errorf("%v: %s", err, stderr.String())
+		return nil, fmt.Errorf("%v: %s%s", err, stderr.String(), offlineHint())
 	}
 
-	var modules []goModule
-	dec := json.NewDecoder(&stdout)
-	for {
-		var mod goModule
-		if err := dec.Decode(&mod); err == io.EOF {
-			break
-		} else if err != nil {
-			return nil, fmt.Errorf("parsing go list output: %v", err)
-		}
-		modules = append(modules, mod)
+	modules, err := decodeGoModules(&stdout)
+	if err != nil {
+		return nil, err
 	}
 	if len(selectedMainModules) == 0 {
 		return modules, nil
@@ -303,7 +313,7 @@ func listAllModules(selectedMainModules []string) ([]goModule, error) {
 
 	depGraph := getDepInfo(selectedMainModules)
 	if len(depGraph.MainModules) == 0 {
-		return nil, fmt.Errorf("no main modules remain after exclusions; adjust --exclude-modules or --mainModules")
+		return nil, errNoMainModules
 	}
 	reachable := make(map[string]bool)
 	for _, dep := range getAllDeps(depGraph.DirectDepList, depGraph.TransDepList) {
@@ -323,6 +333,23 @@ func listAllModules(selectedMainModules []string) ([]goModule, error) {
 	return filtered, nil
 }
 
+// decodeGoModules parses the concatenated JSON objects printed by
+// `go list -m -json`.
+func decodeGoModules(r io.Reader) ([]goModule, error) {
+	var modules []goModule
+	dec := json.NewDecoder(r)
+	for {
+		var mod goModule
+		if err := dec.Decode(&mod); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("parsing go list output: %v", err)
+		}
+		modules = append(modules, mod)
+	}
+	return modules, nil
+}
+
 // extractGitHubRepo extracts "owner/repo" from a github.com module path.
 func extractGitHubRepo(modPath string) string {
 	parts := strings.Split(modPath, "/")
@@ -484,7 +511,7 @@ func checkArchivedRepos(repos []string, token string) (archivedSet map[string]bo
 		}
 		warnings = append(warnings, warn...)
 
-		fmt.Fprintf(os.Stderr, "  Checked %d/%d repos...\n", end, len(repos))
+		infof("  Checked %d/%d repos...\n", end, len(repos))
 	}
 	return archivedSet, warnings
 }
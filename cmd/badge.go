@@ -0,0 +1,270 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var badgeMetric string
+var badgeLabel string
+var badgeColor string
+var badgeThresholds []string
+var badgeAll bool
+var badgeOutputFile string
+var badgeOutputDir string
+
+// badgeMetrics lists the supported metrics in the order --all emits them,
+// together with their default labels.
+var badgeMetrics = []struct {
+	name  string
+	label string
+}{
+	{"deps", "deps"},
+	{"direct", "direct deps"},
+	{"transitive", "transitive deps"},
+	{"depth", "max depth"},
+	{"test-only", "test-only"},
+}
+
+var badgeColors = map[string]string{
+	"brightgreen": "#4c1",
+	"green":       "#97ca00",
+	"yellow":      "#dfb317",
+	"orange":      "#fe7d37",
+	"red":         "#e05d44",
+	"blue":        "#007ec6",
+	"lightgrey":   "#9f9f9f",
+}
+
+// badgeThreshold colors a badge yellow at or above warn and red at or above
+// fail; below warn it is green.
+type badgeThreshold struct {
+	warn int
+	fail int
+}
+
+var badgeCmd = &cobra.Command{
+	Use:   "badge",
+	Short: "Generate shields.io-style SVG badges for dependency metrics",
+	Long: `Produces a flat SVG badge such as "deps: 213" or "max depth: 14" that can be
+committed and embedded in a README.
+
+Supported metrics: deps, direct, transitive, depth, test-only. The test-only
+metric classifies dependencies with "go mod why -m".
+
+Use --threshold metric=warn:fail to color a badge yellow once the value
+reaches warn and red once it reaches fail. Use --all to write one badge per
+metric into --output-dir.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("badge does not take any arguments"))
+		}
+		thresholds, err := parseBadgeThresholds(badgeThresholds)
+		if err != nil {
+			return err
+		}
+		if badgeColor != "" {
+			if _, ok := badgeColors[badgeColor]; !ok && !strings.HasPrefix(badgeColor, "#") {
+				return withExitCode(ExitUsage, fmt.Errorf("unknown --color %q; use a named color or a #hex value", badgeColor))
+			}
+		}
+
+		metrics := []string{badgeMetric}
+		if badgeAll {
+			if badgeLabel != "" || badgeOutputFile != "" {
+				return withExitCode(ExitUsage, fmt.Errorf("--label and --output cannot be used with --all"))
+			}
+			metrics = nil
+			for _, m := range badgeMetrics {
+				metrics = append(metrics, m.name)
+			}
+		} else if defaultBadgeLabel(badgeMetric) == "" {
+			return withExitCode(ExitUsage, fmt.Errorf("unknown --metric %q; supported: %s", badgeMetric, strings.Join(badgeMetricNames(), ", ")))
+		}
+
+		needsSplit := false
+		for _, m := range metrics {
+			if m == "test-only" {
+				needsSplit = true
+			}
+		}
+		snapshot, err := computeStatsSnapshot(mainModules, excludeModules, needsSplit)
+		if err != nil {
+			return err
+		}
+
+		if !badgeAll {
+			label := badgeLabel
+			if label == "" {
+				label = defaultBadgeLabel(badgeMetric)
+			}
+			svg := renderBadgeForMetric(badgeMetric, label, snapshot, thresholds)
+			if badgeOutputFile == "" {
+				fmt.Print(svg)
+				return nil
+			}
+			return os.WriteFile(badgeOutputFile, []byte(svg), 0644)
+		}
+
+		if err := os.MkdirAll(badgeOutputDir, 0755); err != nil {
+			return fmt.Errorf("creating output directory: %w", err)
+		}
+		for _, m := range metrics {
+			svg := renderBadgeForMetric(m, defaultBadgeLabel(m), snapshot, thresholds)
+			path := filepath.Join(badgeOutputDir, "depstat-"+m+".svg")
+			if err := os.WriteFile(path, []byte(svg), 0644); err != nil {
+				return err
+			}
+			fmt.Println(path)
+		}
+		return nil
+	},
+}
+
+func badgeMetricNames() []string {
+	var names []string
+	for _, m := range badgeMetrics {
+		names = append(names, m.name)
+	}
+	return names
+}
+
+func defaultBadgeLabel(metric string) string {
+	for _, m := range badgeMetrics {
+		if m.name == metric {
+			return m.label
+		}
+	}
+	return ""
+}
+
+// badgeValue returns the value of metric from a stats snapshot.
+func badgeValue(metric string, s *StatsSnapshot) int {
+	switch metric {
+	case "direct":
+		return s.DirectDeps
+	case "transitive":
+		return s.TransDeps
+	case "depth":
+		return s.MaxDepth
+	case "test-only":
+		if s.TestOnlyDeps != nil {
+			return *s.TestOnlyDeps
+		}
+		return 0
+	default:
+		return s.TotalDeps
+	}
+}
+
+// parseBadgeThresholds parses values of the form metric=warn:fail.
+func parseBadgeThresholds(values []string) (map[string]badgeThreshold, error) {
+	thresholds := map[string]badgeThreshold{}
+	for _, v := range values {
+		metric, spec, ok := strings.Cut(v, "=")
+		if !ok || defaultBadgeLabel(metric) == "" {
+			return nil, fmt.Errorf("invalid --threshold %q; expected metric=warn:fail with metric one of: %s", v, strings.Join(badgeMetricNames(), ", "))
+		}
+		warnStr, failStr, ok := strings.Cut(spec, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid --threshold %q; expected metric=warn:fail", v)
+		}
+		warn, err := strconv.Atoi(warnStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --threshold %q: %w", v, err)
+		}
+		fail, err := strconv.Atoi(failStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --threshold %q: %w", v, err)
+		}
+		if fail < warn {
+			return nil, fmt.Errorf("invalid --threshold %q: fail must be >= warn", v)
+		}
+		thresholds[metric] = badgeThreshold{warn: warn, fail: fail}
+	}
+	return thresholds, nil
+}
+
+func renderBadgeForMetric(metric, label string, s *StatsSnapshot, thresholds map[string]badgeThreshold) string {
+	value := badgeValue(metric, s)
+	color := badgeColor
+	if color == "" {
+		color = "blue"
+		if t, ok := thresholds[metric]; ok {
+			switch {
+			case value >= t.fail:
+				color = "red"
+			case value >= t.warn:
+				color = "yellow"
+			default:
+				color = "brightgreen"
+			}
+		}
+	}
+	return renderBadge(label, strconv.Itoa(value), color)
+}
+
+// renderBadge draws a flat two-part badge. Text widths are estimated from
+// character counts since no font metrics are available.
+func renderBadge(label, value, color string) string {
+	if hex, ok := badgeColors[color]; ok {
+		color = hex
+	}
+	labelWidth := len(label)*7 + 10
+	valueWidth := len(value)*7 + 10
+	width := labelWidth + valueWidth
+	label = xmlEscape(label)
+	value = xmlEscape(value)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`+"\n", width, label, value)
+	fmt.Fprintf(&b, "<title>%s: %s</title>\n", label, value)
+	b.WriteString(`<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>` + "\n")
+	fmt.Fprintf(&b, `<clipPath id="r"><rect width="%d" height="20" rx="3" fill="#fff"/></clipPath>`+"\n", width)
+	fmt.Fprintf(&b, `<g clip-path="url(#r)"><rect width="%d" height="20" fill="#555"/><rect x="%d" width="%d" height="20" fill="%s"/><rect width="%d" height="20" fill="url(#s)"/></g>`+"\n",
+		labelWidth, labelWidth, valueWidth, xmlEscape(color), width)
+	b.WriteString(`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">` + "\n")
+	for _, part := range []struct {
+		x    int
+		text string
+	}{{labelWidth / 2, label}, {labelWidth + valueWidth/2, value}} {
+		fmt.Fprintf(&b, `<text x="%d" y="15" fill="#010101" fill-opacity=".3">%s</text><text x="%d" y="14">%s</text>`+"\n", part.x, part.text, part.x, part.text)
+	}
+	b.WriteString("</g>\n</svg>\n")
+	return b.String()
+}
+
+func init() {
+	rootCmd.AddCommand(badgeCmd)
+	badgeCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
+	badgeCmd.Flags().StringVar(&badgeMetric, "metric", "deps", "Metric to render: deps, direct, transitive, depth, test-only")
+	badgeCmd.Flags().StringVar(&badgeLabel, "label", "", "Override the badge label")
+	badgeCmd.Flags().StringVar(&badgeColor, "color", "", "Fixed badge color (named shields.io color or #hex); overrides --threshold")
+	badgeCmd.Flags().StringArrayVar(&badgeThresholds, "threshold", []string{}, "Color thresholds as metric=warn:fail (repeatable)")
+	badgeCmd.Flags().BoolVar(&badgeAll, "all", false, "Write one badge per metric into --output-dir")
+	badgeCmd.Flags().StringVarP(&badgeOutputFile, "output", "o", "", "Write the badge to this file instead of stdout")
+	badgeCmd.Flags().StringVar(&badgeOutputDir, "output-dir", ".", "Directory for badges written with --all")
+	badgeCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
+	badgeCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Specify main modules")
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseBadgeThresholds(t *testing.T) {
+	got, err := parseBadgeThresholds([]string{"deps=100:200", "depth=10:15"})
+	if err != nil {
+		t.Fatalf("parseBadgeThresholds: %v", err)
+	}
+	if got["deps"] != (badgeThreshold{warn: 100, fail: 200}) || got["depth"] != (badgeThreshold{warn: 10, fail: 15}) {
+		t.Fatalf("unexpected thresholds %+v", got)
+	}
+	for _, bad := range []string{"deps", "deps=100", "unknown=1:2", "deps=5:1", "deps=a:2"} {
+		if _, err := parseBadgeThresholds([]string{bad}); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestRenderBadgeForMetric(t *testing.T) {
+	testOnly := 38
+	snapshot := &StatsSnapshot{TotalDeps: 213, MaxDepth: 14, TestOnlyDeps: &testOnly}
+	thresholds := map[string]badgeThreshold{"deps": {warn: 100, fail: 200}, "depth": {warn: 20, fail: 30}}
+
+	svg := renderBadgeForMetric("deps", "deps", snapshot, thresholds)
+	if !strings.Contains(svg, `aria-label="deps: 213"`) || !strings.Contains(svg, `fill="#e05d44"`) {
+		t.Errorf("expected red deps badge, got:\n%s", svg)
+	}
+	svg = renderBadgeForMetric("depth", "max depth", snapshot, thresholds)
+	if !strings.Contains(svg, `aria-label="max depth: 14"`) || !strings.Contains(svg, `fill="#4c1"`) {
+		t.Errorf("expected green depth badge, got:\n%s", svg)
+	}
+	svg = renderBadgeForMetric("test-only", "test-only", snapshot, thresholds)
+	if !strings.Contains(svg, `aria-label="test-only: 38"`) || !strings.Contains(svg, `fill="#007ec6"`) {
+		t.Errorf("expected blue test-only badge, got:\n%s", svg)
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// BlameResult attributes every transitive dependency to the direct
+// dependencies it is reachable through.
+type BlameResult struct {
+	MainModules        []string     `json:"mainModules"`
+	DirectDependencies []string     `json:"directDependencies"`
+	Modules            []BlameEntry `json:"modules"`
+}
+
+// BlameEntry is one row of the blame matrix.
+type BlameEntry struct {
+	Module string `json:"module"`
+	// Via lists the direct dependencies through which the module is
+	// reachable.
+	Via []string `json:"via"`
+	// Owner is the direct dependency dominating the module, if any:
+	// removing it would remove the module.
+	Owner string `json:"owner,omitempty"`
+}
+
+var blameCmd = &cobra.Command{
+	Use:   "blame",
+	Short: "Attribute every transitive dependency to the direct dependencies pulling it in",
+	Long: `For every transitive dependency, lists the direct dependencies through which
+it is reachable, and the owning direct dependency when a single one dominates
+it (see depstat dominators).
+
+--csv prints the result as a matrix with one column per direct dependency,
+for spreadsheets; --json prints one entry per transitive dependency.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("blame does not take any arguments"))
+		}
+		if jsonOutput && csvOutput {
+			return withExitCode(ExitUsage, fmt.Errorf("--json and --csv are mutually exclusive"))
+		}
+		depGraph := getDepInfo(mainModules)
+		if len(depGraph.MainModules) == 0 {
+			return errNoMainModules
+		}
+		result := computeBlame(depGraph)
+		switch {
+		case jsonOutput:
+			return writeJSON(os.Stdout, result)
+		case csvOutput:
+			return writeBlameCSV(os.Stdout, result)
+		}
+		for _, e := range result.Modules {
+			owner := ""
+			if e.Owner != "" {
+				owner = " (owned by " + e.Owner + ")"
+			}
+			fmt.Printf("%s%s\n  via %d: %s\n", e.Module, owner, len(e.Via), strings.Join(e.Via, ", "))
+		}
+		return nil
+	},
+}
+
+// computeBlame walks the graph from every direct dependency, without
+// passing through main modules, and records which transitive modules each
+// one reaches.
+func computeBlame(depGraph *DependencyOverview) BlameResult {
+	isMain := make(map[string]bool)
+	for _, m := range depGraph.MainModules {
+		isMain[m] = true
+	}
+	directs := sortedCopy(depGraph.DirectDepList)
+	isDirect := make(map[string]bool)
+	for _, d := range directs {
+		isDirect[d] = true
+	}
+
+	via := make(map[string][]string)
+	for _, direct := range directs {
+		seen := map[string]bool{direct: true}
+		queue := []string{direct}
+		for len(queue) > 0 {
+			node := queue[0]
+			queue = queue[1:]
+			for _, next := range depGraph.Graph[node] {
+				if !seen[next] && !isMain[next] {
+					seen[next] = true
+					queue = append(queue, next)
+					if !isDirect[next] {
+						via[next] = append(via[next], direct)
+					}
+				}
+			}
+		}
+	}
+
+	idom := computeDominators(depGraph.MainModules, depGraph.Graph)
+	result := BlameResult{MainModules: depGraph.MainModules, DirectDependencies: directs, Modules: []BlameEntry{}}
+	for mod, through := range via {
+		result.Modules = append(result.Modules, BlameEntry{
+			Module: mod,
+			Via:    through,
+			Owner:  lookupDominator(mod, idom, depGraph.DirectDepList).Owner,
+		})
+	}
+	sort.Slice(result.Modules, func(i, j int) bool { return result.Modules[i].Module < result.Modules[j].Module })
+	return result
+}
+
+// writeBlameCSV writes the blame matrix: a row per transitive module, a
+// column per direct dependency, and 1 where the module is reachable through
+// that dependency.
+func writeBlameCSV(out io.Writer, result BlameResult) error {
+	w := csv.NewWriter(out)
+	header := append([]string{"Module", "Owner", "Via"}, result.DirectDependencies...)
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	for _, e := range result.Modules {
+		reached := make(map[string]bool)
+		for _, d := range e.Via {
+			reached[d] = true
+		}
+		row := []string{e.Module, e.Owner, fmt.Sprint(len(e.Via))}
+		for _, d := range result.DirectDependencies {
+			cell := ""
+			if reached[d] {
+				cell = "1"
+			}
+			row = append(row, cell)
+		}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func init() {
+	rootCmd.AddCommand(blameCmd)
+	blameCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
+	blameCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Get the output in JSON format")
+	blameCmd.Flags().BoolVarP(&csvOutput, "csv", "c", false, "Get the output as a CSV matrix")
+	blameCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
+	blameCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Specify main modules")
+}
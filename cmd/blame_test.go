@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestComputeBlame(t *testing.T) {
+	// main -> A -> C -> E
+	// main -> B -> C
+	// A -> D
+	depGraph := &DependencyOverview{
+		MainModules:   []string{"main"},
+		DirectDepList: []string{"B", "A"},
+		TransDepList:  []string{"C", "D", "E"},
+		Graph: map[string][]string{
+			"main": {"A", "B"},
+			"A":    {"C", "D"},
+			"B":    {"C"},
+			"C":    {"E"},
+		},
+	}
+	result := computeBlame(depGraph)
+	want := []BlameEntry{
+		{Module: "C", Via: []string{"A", "B"}},
+		{Module: "D", Via: []string{"A"}, Owner: "A"},
+		{Module: "E", Via: []string{"A", "B"}},
+	}
+	if !reflect.DeepEqual(result.Modules, want) {
+		t.Fatalf("computeBlame =\n%+v\nwant\n%+v", result.Modules, want)
+	}
+
+	var b bytes.Buffer
+	if err := writeBlameCSV(&b, result); err != nil {
+		t.Fatal(err)
+	}
+	wantCSV := "Module,Owner,Via,A,B\nC,,2,1,1\nD,A,1,1,\nE,,2,1,1\n"
+	if b.String() != wantCSV {
+		t.Errorf("CSV =\n%s\nwant\n%s", b.String(), wantCSV)
+	}
+}
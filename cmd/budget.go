@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// parseBudgets parses module=N budget assignments, as given to --budget and
+// //depstat:budget.
+func parseBudgets(values []string) (map[string]int, error) {
+	budgets := make(map[string]int)
+	for _, value := range values {
+		mod, n, ok := strings.Cut(value, "=")
+		limit, err := strconv.Atoi(strings.TrimSpace(n))
+		if !ok || strings.TrimSpace(mod) == "" || err != nil || limit < 0 {
+			return nil, fmt.Errorf("invalid budget %q: want module=N with N >= 0", value)
+		}
+		budgets[strings.TrimSpace(mod)] = limit
+	}
+	return budgets, nil
+}
+
+// setBudget sets the budget of a module pattern, overriding an earlier one.
+func (p *Policy) setBudget(pattern string, limit int) {
+	if p.Budgets == nil {
+		p.Budgets = make(map[string]int)
+	}
+	p.Budgets[pattern] = limit
+}
+
+// budgetViolations checks the subtree of every direct dependency matching a
+// budget pattern: the modules reachable from it, other than itself and the
+// main modules, may not exceed the budget.
+func budgetViolations(budgets map[string]int, depGraph *DependencyOverview) []PolicyViolation {
+	patterns := make([]string, 0, len(budgets))
+	for pattern := range budgets {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	var violations []PolicyViolation
+	for _, pattern := range patterns {
+		matched := false
+		for _, dep := range depGraph.DirectDepList {
+			if !matchModulePattern(dep, pattern) {
+				continue
+			}
+			matched = true
+			size := len(subtreeModules(dep, depGraph.Graph, depGraph.MainModules))
+			if size <= budgets[pattern] {
+				continue
+			}
+			violations = append(violations, PolicyViolation{
+				Rule:    "budget",
+				Module:  dep,
+				Message: fmt.Sprintf("%s subtree has %d modules, over its budget of %d (+%d)", dep, size, budgets[pattern], size-budgets[pattern]),
+				Path:    shortestPath(depGraph.MainModules, dep, depGraph.Graph),
+			})
+		}
+		if !matched {
+			warnf("budget for %s matches no direct dependency\n", pattern)
+		}
+	}
+	return violations
+}
+
+// subtreeModules returns the modules reachable from mod, excluding mod and
+// the main modules.
+func subtreeModules(mod string, graph map[string][]string, mainModules []string) []string {
+	seen := map[string]bool{mod: true}
+	queue := []string{mod}
+	var subtree []string
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range graph[current] {
+			if seen[next] || contains(mainModules, next) {
+				continue
+			}
+			seen[next] = true
+			subtree = append(subtree, next)
+			queue = append(queue, next)
+		}
+	}
+	sort.Strings(subtree)
+	return subtree
+}
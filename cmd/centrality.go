@@ -0,0 +1,231 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var centralityAlgorithm string
+var centralityTopN int
+
+// CentralityScore is the centrality of a single module.
+type CentralityScore struct {
+	Module string  `json:"module"`
+	Score  float64 `json:"score"`
+}
+
+// CentralityResult holds the ranked centrality scores.
+type CentralityResult struct {
+	Algorithm   string            `json:"algorithm"`
+	MainModules []string          `json:"mainModules"`
+	Scores      []CentralityScore `json:"scores"`
+}
+
+var centralityCmd = &cobra.Command{
+	Use:   "centrality",
+	Short: "Rank modules by graph centrality",
+	Long: `Ranks modules by how central they are to the dependency graph, to spot
+modules that deserve extra scrutiny for supply-chain risk.
+
+Algorithms:
+  betweenness  number of shortest dependency paths between other modules
+               that pass through the module (Brandes' algorithm)
+  pagerank     PageRank over requirement edges, so modules required by
+               other well-required modules rank highest`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("centrality does not take any arguments"))
+		}
+		if centralityTopN <= 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("-n must be > 0"))
+		}
+		var scoreFn func(map[string][]string) map[string]float64
+		switch centralityAlgorithm {
+		case "betweenness":
+			scoreFn = betweennessCentrality
+		case "pagerank":
+			scoreFn = pageRank
+		default:
+			return withExitCode(ExitUsage, fmt.Errorf("--algorithm must be one of: betweenness, pagerank"))
+		}
+
+		depGraph := getDepInfo(mainModules)
+		if len(depGraph.MainModules) == 0 {
+			return errNoMainModules
+		}
+		result := CentralityResult{
+			Algorithm:   centralityAlgorithm,
+			MainModules: depGraph.MainModules,
+			Scores:      rankCentrality(scoreFn(depGraph.Graph), depGraph.MainModules, centralityTopN),
+		}
+
+		if jsonOutput {
+			out, err := json.MarshalIndent(result, "", "\t")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "RANK\tMODULE\t%s\n", centralityAlgorithm)
+		for i, s := range result.Scores {
+			fmt.Fprintf(w, "%d\t%s\t%.4f\n", i+1, s.Module, s.Score)
+		}
+		return w.Flush()
+	},
+}
+
+// graphNodes returns every module that appears in graph, sorted.
+func graphNodes(graph map[string][]string) []string {
+	seen := map[string]bool{}
+	for from, tos := range graph {
+		seen[from] = true
+		for _, to := range tos {
+			seen[to] = true
+		}
+	}
+	nodes := make([]string, 0, len(seen))
+	for n := range seen {
+		nodes = append(nodes, n)
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// betweennessCentrality computes unnormalized betweenness centrality for a
+// directed, unweighted graph using Brandes' algorithm.
+func betweennessCentrality(graph map[string][]string) map[string]float64 {
+	nodes := graphNodes(graph)
+	cb := make(map[string]float64, len(nodes))
+	for _, n := range nodes {
+		cb[n] = 0
+	}
+	for _, s := range nodes {
+		var stack []string
+		preds := map[string][]string{}
+		sigma := map[string]float64{s: 1}
+		dist := map[string]int{s: 0}
+		queue := []string{s}
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			stack = append(stack, v)
+			for _, w := range graph[v] {
+				if _, ok := dist[w]; !ok {
+					dist[w] = dist[v] + 1
+					queue = append(queue, w)
+				}
+				if dist[w] == dist[v]+1 {
+					sigma[w] += sigma[v]
+					preds[w] = append(preds[w], v)
+				}
+			}
+		}
+		delta := map[string]float64{}
+		for i := len(stack) - 1; i >= 0; i-- {
+			w := stack[i]
+			for _, v := range preds[w] {
+				delta[v] += sigma[v] / sigma[w] * (1 + delta[w])
+			}
+			if w != s {
+				cb[w] += delta[w]
+			}
+		}
+	}
+	return cb
+}
+
+// pageRank computes PageRank with a damping factor of 0.85. Rank flows along
+// requirement edges; modules without requirements spread their rank evenly.
+func pageRank(graph map[string][]string) map[string]float64 {
+	const damping = 0.85
+	nodes := graphNodes(graph)
+	n := float64(len(nodes))
+	rank := make(map[string]float64, len(nodes))
+	for _, node := range nodes {
+		rank[node] = 1 / n
+	}
+	for iter := 0; iter < 100; iter++ {
+		dangling := 0.0
+		for _, node := range nodes {
+			if len(graph[node]) == 0 {
+				dangling += rank[node]
+			}
+		}
+		next := make(map[string]float64, len(nodes))
+		for _, node := range nodes {
+			next[node] = (1-damping)/n + damping*dangling/n
+		}
+		for _, node := range nodes {
+			if out := graph[node]; len(out) > 0 {
+				share := damping * rank[node] / float64(len(out))
+				for _, to := range out {
+					next[to] += share
+				}
+			}
+		}
+		diff := 0.0
+		for _, node := range nodes {
+			diff += math.Abs(next[node] - rank[node])
+		}
+		rank = next
+		if diff < 1e-9 {
+			break
+		}
+	}
+	return rank
+}
+
+// rankCentrality sorts scores in descending order, dropping main modules.
+func rankCentrality(scores map[string]float64, mainModules []string, topN int) []CentralityScore {
+	ranked := []CentralityScore{}
+	for mod, score := range scores {
+		if contains(mainModules, mod) {
+			continue
+		}
+		ranked = append(ranked, CentralityScore{Module: mod, Score: score})
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Score == ranked[j].Score {
+			return ranked[i].Module < ranked[j].Module
+		}
+		return ranked[i].Score > ranked[j].Score
+	})
+	if len(ranked) > topN {
+		ranked = ranked[:topN]
+	}
+	return ranked
+}
+
+func init() {
+	rootCmd.AddCommand(centralityCmd)
+	centralityCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
+	centralityCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Get the output in JSON format")
+	centralityCmd.Flags().StringVar(&centralityAlgorithm, "algorithm", "betweenness", "Centrality algorithm: betweenness or pagerank")
+	centralityCmd.Flags().IntVarP(&centralityTopN, "top", "n", 20, "Number of modules to show")
+	centralityCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
+	centralityCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Specify main modules")
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBetweennessCentrality(t *testing.T) {
+	// B sits on every shortest path from main to D, E and F; the two
+	// shortest paths to E split their credit between D and F.
+	graph := map[string][]string{
+		"main": {"B", "C"},
+		"B":    {"D", "F"},
+		"D":    {"E"},
+		"F":    {"E"},
+	}
+	cb := betweennessCentrality(graph)
+	want := map[string]float64{"main": 0, "B": 3, "C": 0, "D": 1, "E": 0, "F": 1}
+	for mod, score := range want {
+		if cb[mod] != score {
+			t.Errorf("betweenness(%s) = %v, want %v", mod, cb[mod], score)
+		}
+	}
+
+	ranked := rankCentrality(cb, []string{"main"}, 2)
+	if len(ranked) != 2 || ranked[0].Module != "B" || ranked[1].Module != "D" || ranked[0].Score != 3 {
+		t.Fatalf("unexpected ranking %+v", ranked)
+	}
+}
+
+func TestPageRank(t *testing.T) {
+	graph := map[string][]string{
+		"main": {"A", "B"},
+		"A":    {"C"},
+		"B":    {"C"},
+	}
+	pr := pageRank(graph)
+	total := 0.0
+	for _, score := range pr {
+		total += score
+	}
+	if math.Abs(total-1) > 1e-6 {
+		t.Errorf("expected ranks to sum to 1, got %v", total)
+	}
+	if !(pr["C"] > pr["A"] && pr["A"] > pr["main"]) {
+		t.Errorf("expected C > A > main, got %v", pr)
+	}
+}
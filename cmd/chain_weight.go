@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// statsChainWeight selects the module size used for the heaviest chain:
+// "packages" or "loc".
+var statsChainWeight string
+
+// WeightedChain is the chain from a main module with the largest total
+// module size.
+type WeightedChain struct {
+	// Weight is the size metric: packages or loc.
+	Weight  string         `json:"weight"`
+	Total   int            `json:"total"`
+	Modules []WeightedNode `json:"modules"`
+	// Unmeasured counts modules in the graph whose source was not in the
+	// module cache; they weigh 0.
+	Unmeasured int `json:"unmeasured,omitempty"`
+}
+
+// WeightedNode is a module on a weighted chain with its own size.
+type WeightedNode struct {
+	Module string `json:"module"`
+	Weight int    `json:"weight"`
+}
+
+// computeHeaviestChain measures every module of the graph and returns the
+// chain from the first main module with the largest total size. Main
+// modules weigh 0, since their size is not a dependency cost.
+func computeHeaviestChain(depGraph *DependencyOverview, metric string) (*WeightedChain, error) {
+	dirs := make(map[string]string)
+	if depGraph.Modules != nil {
+		for mod, info := range depGraph.Modules {
+			dirs[mod] = info.Dir
+		}
+	} else {
+		modules, err := listAllModules(nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range modules {
+			dirs[m.Path] = m.Dir
+		}
+	}
+	weights := make(map[string]int)
+	result := &WeightedChain{Weight: metric, Modules: []WeightedNode{}}
+	for _, mod := range getAllDeps(depGraph.DirectDepList, depGraph.TransDepList) {
+		if contains(depGraph.MainModules, mod) || mod == "go" || mod == "toolchain" {
+			// the go and toolchain requirements are not modules with source
+			continue
+		}
+		if dirs[mod] == "" {
+			result.Unmeasured++
+			continue
+		}
+		weights[mod] = moduleSize(dirs[mod], metric)
+	}
+	if len(depGraph.MainModules) == 0 {
+		return result, nil
+	}
+	// the heaviest chain from any main module, the earliest on ties
+	var chain Chain
+	total := -1
+	memo := map[string]weightedMemo{}
+	for _, mod := range depGraph.MainModules {
+		if c, t := getHeaviestChain(mod, depGraph.Graph, weights, nil, memo); t > total {
+			chain, total = c, t
+		}
+	}
+	result.Total = total
+	for _, mod := range chain {
+		result.Modules = append(result.Modules, WeightedNode{Module: mod, Weight: weights[mod]})
+	}
+	return result, nil
+}
+
+type weightedMemo struct {
+	chain Chain
+	total int
+}
+
+// getHeaviestChain is getLongestChain with node weights: it returns the
+// chain starting at currentDep with the largest sum of weights, avoiding
+// cycles the same way.
+func getHeaviestChain(currentDep string, graph map[string][]string, weights map[string]int, currentChain Chain, memo map[string]weightedMemo) (Chain, int) {
+	if m, ok := memo[currentDep]; ok {
+		return m.chain, m.total
+	}
+	if contains(currentChain, currentDep) {
+		return nil, 0
+	}
+	currentChain = append(currentChain, currentDep)
+	var heaviest Chain
+	heaviestTotal := -1
+	for _, dep := range graph[currentDep] {
+		chain, total := getHeaviestChain(dep, graph, weights, currentChain, memo)
+		if chain != nil && total > heaviestTotal {
+			heaviest, heaviestTotal = chain, total
+		}
+	}
+	m := weightedMemo{chain: append(Chain{currentDep}, heaviest...), total: weights[currentDep] + max(heaviestTotal, 0)}
+	memo[currentDep] = m
+	return m.chain, m.total
+}
+
+// moduleSize measures the module in dir: the number of packages with
+// non-test Go files, or their non-test lines of Go code. Nested modules,
+// testdata and vendor directories are skipped.
+func moduleSize(dir, metric string) int {
+	size := 0
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != dir {
+				if name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+					return filepath.SkipDir
+				}
+				if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+					return filepath.SkipDir
+				}
+			}
+			if metric == "packages" && hasNonTestGoFile(path) {
+				size++
+			}
+			return nil
+		}
+		if metric == "loc" && isNonTestGoFile(d.Name()) {
+			if data, err := os.ReadFile(path); err == nil {
+				size += bytes.Count(data, []byte("\n"))
+			}
+		}
+		return nil
+	})
+	return size
+}
+
+func isNonTestGoFile(name string) bool {
+	return strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go")
+}
+
+func hasNonTestGoFile(dir string) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, e := range entries {
+		if !e.IsDir() && isNonTestGoFile(e.Name()) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGetHeaviestChain(t *testing.T) {
+	// main -> A -> B -> C is the longest chain, main -> D -> E the heaviest
+	graph := map[string][]string{
+		"main": {"A", "D"},
+		"A":    {"B"},
+		"B":    {"C", "A"},
+		"D":    {"E"},
+	}
+	weights := map[string]int{"A": 1, "B": 1, "C": 1, "D": 2, "E": 5}
+	chain, total := getHeaviestChain("main", graph, weights, nil, map[string]weightedMemo{})
+	if want := (Chain{"main", "D", "E"}); !reflect.DeepEqual(chain, want) || total != 7 {
+		t.Errorf("getHeaviestChain = %v (%d), want %v (7)", chain, total, want)
+	}
+}
+
+func TestModuleSize(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"a.go":                 "package a\n\nfunc A() {}\n",
+		"a_test.go":            "package a\n\nfunc TestA() {}\n",
+		"sub/b.go":             "package sub\n",
+		"only/c_test.go":       "package only\n",
+		"testdata/d.go":        "package d\n",
+		"nested/go.mod":        "module nested\n",
+		"nested/e.go":          "package nested\n",
+		"internal/x/f.go":      "package x\n\nvar F = 1\n",
+		"internal/x/README.md": "docs\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := moduleSize(root, "packages"); got != 3 {
+		t.Errorf("packages = %d, want 3", got)
+	}
+	if got := moduleSize(root, "loc"); got != 7 {
+		t.Errorf("loc = %d, want 7", got)
+	}
+}
@@ -0,0 +1,503 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var checkPolicyFile string
+var checkRegoPolicy string
+var checkRegoQuery string
+var checkAllowedHosts []string
+var checkTestOnly []string
+var checkBudgets []string
+var checkVet bool
+
+// Policy is the set of built-in rules enforced by check. It is read from the
+// JSON file given with --policy and extended by rule flags.
+type Policy struct {
+	// AllowedHosts lists module path prefixes dependencies must come from,
+	// such as "k8s.io" or "github.com/kubernetes*".
+	AllowedHosts []string `json:"allowedHosts,omitempty"`
+	// TestOnly lists module patterns that must only be reachable from test
+	// code, such as "github.com/stretchr/testify".
+	TestOnly []string `json:"testOnly,omitempty"`
+	// Budgets caps the number of modules in the subtree of a direct
+	// dependency, such as {"k8s.io/apimachinery": 40}. Keys may use *.
+	Budgets map[string]int `json:"budgets,omitempty"`
+}
+
+// PolicyViolation is a single failed policy rule.
+type PolicyViolation struct {
+	Rule    string   `json:"rule"`
+	Module  string   `json:"module,omitempty"`
+	Message string   `json:"message"`
+	Path    []string `json:"path,omitempty"`
+}
+
+// CheckResult holds the violations found by check.
+type CheckResult struct {
+	Violations  []PolicyViolation `json:"violations"`
+	MainModules []string          `json:"mainModules"`
+
+	// Warnings are analyzer findings below error severity; they do not
+	// fail the check.
+	Warnings []PolicyViolation `json:"warnings,omitempty"`
+}
+
+// policyNode is a module as seen by Rego policies.
+type policyNode struct {
+	graphNode
+	Version  string `json:"version,omitempty"`
+	TestOnly bool   `json:"testOnly"`
+}
+
+// policyInput is the document passed to Rego policies as `input`.
+type policyInput struct {
+	MainModules []string     `json:"mainModules"`
+	Nodes       []policyNode `json:"nodes"`
+	Edges       []graphEdge  `json:"edges"`
+}
+
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Evaluate dependency policies and report violations",
+	Long: `Evaluates policies against the dependency graph and exits with an error
+when any of them is violated.
+
+Built-in rules are read from a JSON policy file (--policy) or set with flags.
+Without either, they are read from //depstat: comments in go.mod, so a
+project tracking depstat as a tool (go get -tool) can run "go tool depstat
+check" with no arguments:
+
+  tool github.com/kubernetes-sigs/depstat
+
+  //depstat:policy hack/depstat-policy.json
+  //depstat:allowed-hosts k8s.io,golang.org
+  //depstat:test-only github.com/stretchr/testify
+  //depstat:budget k8s.io/apimachinery=40
+
+A policy file looks like:
+
+  {
+    "allowedHosts": ["k8s.io", "golang.org", "github.com/kubernetes*"],
+    "testOnly": ["github.com/stretchr/testify", "go.uber.org/mock"],
+    "budgets": {"k8s.io/apimachinery": 40}
+  }
+
+allowedHosts (--allowed-hosts) rejects dependencies whose module path does not
+start with one of the listed prefixes; * matches within a path element.
+
+testOnly (--test-only) rejects listed modules that are imported by non-test
+packages, reporting the import chain from "go mod why -m".
+
+budgets (--budget module=N) caps the subtree of a direct dependency: the
+modules reachable from it may not exceed N. The violation reports the
+current size, so it shows which subtree regressed when totals grow.
+
+With --rego, a user-supplied Rego policy is evaluated by the opa binary. The
+policy receives the graph as input:
+
+  input.mainModules  list of main modules
+  input.nodes        module, version, inDegree, outDegree, depth,
+                     isMainModule, testOnly and (with --enrich) enrichment
+  input.edges        {from, to} pairs
+
+Every element of the queried set (default data.depstat.deny) is reported as a
+violation. Elements may be plain strings or objects with msg, module and path
+fields, for example:
+
+  package depstat
+  deny contains msg if {
+    some n in input.nodes
+    n.enrichment.archived
+    msg := sprintf("%s is archived", [n.module])
+  }
+
+With --analyzer, external executables check org-specific rules. Each one
+reads the graph as JSON on stdin (schemaVersion, mainModules, nodes with
+their version, edges, and the raw go mod graph output) and writes
+
+  {"schemaVersion": 1, "findings": [{"rule": "...", "severity": "error",
+   "module": "...", "message": "...", "path": ["..."]}]}
+
+to stdout. Findings of error severity (the default) are violations, reported
+under the rule <analyzer>/<rule>; warning and info findings are listed
+without failing the check.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("check does not take any arguments"))
+		}
+		policy, err := loadPolicy(checkPolicyFile)
+		if err != nil {
+			return err
+		}
+		policy.AllowedHosts = append(policy.AllowedHosts, checkAllowedHosts...)
+		policy.TestOnly = append(policy.TestOnly, checkTestOnly...)
+		budgets, err := parseBudgets(checkBudgets)
+		if err != nil {
+			return withExitCode(ExitUsage, err)
+		}
+		for mod, limit := range budgets {
+			policy.setBudget(mod, limit)
+		}
+		if policy.empty() && checkRegoPolicy == "" && len(analyzerCommands) == 0 {
+			modPolicy, found, err := goModPolicy()
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			if !found {
+				return withExitCode(ExitUsage, fmt.Errorf("no policies configured; pass --policy, --rego, --analyzer or a rule flag such as --allowed-hosts or --budget, or add %spolicy to go.mod", goModDirectivePrefix))
+			}
+			policy = modPolicy
+		}
+		if err := validateEnrichSources(enrichSources); err != nil {
+			return err
+		}
+		depGraph := getDepInfo(mainModules)
+		if len(depGraph.MainModules) == 0 {
+			return errNoMainModules
+		}
+
+		violations, err := evaluatePolicy(policy, depGraph)
+		if err != nil {
+			return err
+		}
+		result := CheckResult{Violations: violations, MainModules: depGraph.MainModules}
+		if checkRegoPolicy != "" {
+			input, err := buildPolicyInput(depGraph)
+			if err != nil {
+				return err
+			}
+			policyFile, cleanup, err := regoPolicyFile(checkRegoPolicy)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+			violations, err := evaluateRegoPolicy(policyFile, checkRegoQuery, input)
+			if err != nil {
+				return err
+			}
+			result.Violations = append(result.Violations, violations...)
+		}
+		findings, err := runAnalyzers(analyzerCommands, depGraph)
+		if err != nil {
+			return err
+		}
+		for _, f := range findings {
+			if f.Severity == "error" {
+				result.Violations = append(result.Violations, analyzerViolation(f))
+			} else {
+				result.Warnings = append(result.Warnings, analyzerViolation(f))
+			}
+		}
+
+		if checkVet {
+			if err := writeVetDiagnostics(os.Stdout, result.Violations); err != nil {
+				return err
+			}
+		} else if jsonOutput {
+			out, err := json.MarshalIndent(result, "", "\t")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+		} else {
+			printCheckResult(result)
+		}
+		if len(result.Violations) > 0 {
+			cmd.SilenceUsage = true
+			return withExitCode(ExitViolation, fmt.Errorf("check failed: %d policy violation(s)", len(result.Violations)))
+		}
+		return nil
+	},
+}
+
+// loadPolicy reads a JSON policy file. An empty path yields an empty policy.
+func loadPolicy(path string) (Policy, error) {
+	var policy Policy
+	if path == "" {
+		return policy, nil
+	}
+	raw, err := readInputFile("--policy", path)
+	if err != nil {
+		return policy, fmt.Errorf("failed to read policy file: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&policy); err != nil {
+		return policy, fmt.Errorf("failed to parse policy file %s: %w", path, err)
+	}
+	return policy, nil
+}
+
+func (p Policy) empty() bool {
+	return len(p.AllowedHosts) == 0 && len(p.TestOnly) == 0 && len(p.Budgets) == 0
+}
+
+// evaluatePolicy applies the built-in rules to the dependency graph.
+func evaluatePolicy(policy Policy, depGraph *DependencyOverview) ([]PolicyViolation, error) {
+	violations := []PolicyViolation{}
+	if len(policy.AllowedHosts) > 0 {
+		for _, dep := range getAllDeps(depGraph.DirectDepList, depGraph.TransDepList) {
+			if contains(depGraph.MainModules, dep) || hostAllowed(dep, policy.AllowedHosts) {
+				continue
+			}
+			violations = append(violations, PolicyViolation{
+				Rule:    "allowed-hosts",
+				Module:  dep,
+				Message: fmt.Sprintf("%s is not from an allowed host", dep),
+				Path:    shortestPath(depGraph.MainModules, dep, depGraph.Graph),
+			})
+		}
+	}
+	if len(policy.TestOnly) > 0 {
+		var guarded []string
+		for _, dep := range getAllDeps(depGraph.DirectDepList, depGraph.TransDepList) {
+			if moduleExcluded(dep, policy.TestOnly) {
+				guarded = append(guarded, dep)
+			}
+		}
+		if len(guarded) > 0 {
+			output, err := runModWhy(guarded)
+			if err != nil {
+				return nil, err
+			}
+			violations = append(violations, testOnlyViolations(guarded, parseModWhyPaths(output))...)
+		}
+	}
+	if len(policy.Budgets) > 0 {
+		violations = append(violations, budgetViolations(policy.Budgets, depGraph)...)
+	}
+	return violations, nil
+}
+
+// testOnlyViolations reports guarded modules whose import chain, as
+// reported by `go mod why -m`, does not go through a test package.
+func testOnlyViolations(guarded []string, whyPaths map[string][]string) []PolicyViolation {
+	var violations []PolicyViolation
+	for _, mod := range guarded {
+		chain, ok := whyPaths[mod]
+		if !ok {
+			continue // not imported by any package
+		}
+		testOnly := false
+		for _, pkg := range chain {
+			if strings.HasSuffix(pkg, ".test") {
+				testOnly = true
+				break
+			}
+		}
+		if testOnly {
+			continue
+		}
+		violations = append(violations, PolicyViolation{
+			Rule:    "test-only",
+			Module:  mod,
+			Message: fmt.Sprintf("%s must be test-only but is imported by non-test code", mod),
+			Path:    chain,
+		})
+	}
+	return violations
+}
+
+// hostAllowed reports whether the module path starts with one of the
+// allowed prefixes. Each prefix is matched element by element, so
+// "github.com/kubernetes*" allows github.com/kubernetes-sigs/yaml but not
+// github.com/kube/x.
+func hostAllowed(modPath string, allowed []string) bool {
+	elems := strings.Split(modPath, "/")
+	for _, pattern := range allowed {
+		n := strings.Count(pattern, "/") + 1
+		if n > len(elems) {
+			continue
+		}
+		if matchModulePattern(strings.Join(elems[:n], "/"), pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildPolicyInput assembles the graph document handed to policies,
+// including test-only classification and, when requested, enrichment data.
+func buildPolicyInput(depGraph *DependencyOverview) (policyInput, error) {
+	nodes, edges := buildGraphTopology(depGraph)
+	if len(enrichSources) > 0 {
+		attachNodeEnrichment(nodes, depGraph.Versions, enrichSources)
+	}
+	testOnlySet, err := classifyTestDeps(getAllDeps(depGraph.DirectDepList, depGraph.TransDepList))
+	if err != nil {
+		return policyInput{}, fmt.Errorf("failed to classify dependencies: %w", err)
+	}
+	input := policyInput{MainModules: depGraph.MainModules, Nodes: make([]policyNode, 0, len(nodes)), Edges: edges}
+	for _, n := range nodes {
+		input.Nodes = append(input.Nodes, policyNode{
+			graphNode: n,
+			Version:   depGraph.Versions[n.Module],
+			TestOnly:  testOnlySet[n.Module],
+		})
+	}
+	if input.Edges == nil {
+		input.Edges = []graphEdge{}
+	}
+	return input, nil
+}
+
+// regoPolicyFile returns the path of the --rego policy for opa, which only
+// loads files: a policy read from stdin with "-" is spooled to a temporary
+// .rego file, removed by the returned function.
+func regoPolicyFile(name string) (string, func(), error) {
+	if name != "-" {
+		return name, func() {}, nil
+	}
+	data, err := readInputFile("--rego", name)
+	if err != nil {
+		return "", nil, err
+	}
+	f, err := os.CreateTemp("", "depstat-policy-*.rego")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() { os.Remove(f.Name()) }
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		cleanup()
+		return "", nil, err
+	}
+	if err := f.Close(); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return f.Name(), cleanup, nil
+}
+
+// evaluateRegoPolicy runs `opa eval` with the policy file and returns the
+// elements of the query result as violations.
+func evaluateRegoPolicy(policyFile, query string, input policyInput) ([]PolicyViolation, error) {
+	raw, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command("opa", "eval", "--format", "json", "--stdin-input", "--data", policyFile, query)
+	cmd.Stdin = bytes.NewReader(raw)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate Rego policy via 'opa eval': %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return parseRegoDenials(out, query)
+}
+
+// parseRegoDenials extracts violations from `opa eval --format json`
+// output. An undefined query yields no violations.
+func parseRegoDenials(out []byte, rule string) ([]PolicyViolation, error) {
+	var evalResult struct {
+		Result []struct {
+			Expressions []struct {
+				Value json.RawMessage `json:"value"`
+			} `json:"expressions"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(out, &evalResult); err != nil {
+		return nil, fmt.Errorf("failed to parse opa output: %w", err)
+	}
+	var violations []PolicyViolation
+	for _, r := range evalResult.Result {
+		for _, expr := range r.Expressions {
+			var items []json.RawMessage
+			if err := json.Unmarshal(expr.Value, &items); err != nil {
+				return nil, fmt.Errorf("%s must evaluate to a set or array, got %s", rule, string(expr.Value))
+			}
+			for _, item := range items {
+				v := PolicyViolation{Rule: rule}
+				var msg string
+				if err := json.Unmarshal(item, &msg); err == nil {
+					v.Message = msg
+					violations = append(violations, v)
+					continue
+				}
+				var obj struct {
+					Msg     string   `json:"msg"`
+					Message string   `json:"message"`
+					Module  string   `json:"module"`
+					Path    []string `json:"path"`
+				}
+				if err := json.Unmarshal(item, &obj); err != nil {
+					return nil, fmt.Errorf("unsupported %s element: %s", rule, string(item))
+				}
+				v.Message, v.Module, v.Path = obj.Msg, obj.Module, obj.Path
+				if v.Message == "" {
+					v.Message = obj.Message
+				}
+				violations = append(violations, v)
+			}
+		}
+	}
+	sort.SliceStable(violations, func(i, j int) bool { return violations[i].Message < violations[j].Message })
+	return violations, nil
+}
+
+func printCheckResult(result CheckResult) {
+	if len(result.Violations) == 0 {
+		fmt.Println("No policy violations found.")
+	} else {
+		fmt.Printf("POLICY VIOLATIONS (%d):\n", len(result.Violations))
+		printPolicyViolations(result.Violations)
+	}
+	if len(result.Warnings) > 0 {
+		fmt.Printf("WARNINGS (%d):\n", len(result.Warnings))
+		printPolicyViolations(result.Warnings)
+	}
+}
+
+func printPolicyViolations(violations []PolicyViolation) {
+	for _, v := range violations {
+		fmt.Printf("  [%s] %s\n", v.Rule, v.Message)
+		if len(v.Path) > 0 {
+			fmt.Printf("    path: %s\n", strings.Join(v.Path, " -> "))
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+	checkCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
+	checkCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Get the output in JSON format")
+	checkCmd.Flags().StringVar(&checkPolicyFile, "policy", "", "JSON policy file with built-in rules; - reads stdin")
+	checkCmd.Flags().StringSliceVar(&checkAllowedHosts, "allowed-hosts", []string{}, "Fail on dependencies whose module path is not under one of these prefixes (supports * wildcard)")
+	checkCmd.Flags().StringSliceVar(&checkTestOnly, "test-only", []string{}, "Fail if any of these modules is imported by non-test packages (supports * wildcard)")
+	checkCmd.Flags().StringSliceVar(&checkBudgets, "budget", []string{}, "Fail if the subtree of a direct dependency exceeds N modules, as module=N (repeatable, supports * wildcard)")
+	checkCmd.Flags().BoolVar(&checkVet, "vet", false, "Print violations as go vet-style diagnostics positioned at the go.mod require directive")
+	checkCmd.Flags().StringVar(&checkRegoPolicy, "rego", "", "Rego policy file evaluated with the opa binary; - reads stdin")
+	checkCmd.Flags().StringVar(&checkRegoQuery, "rego-query", "data.depstat.deny", "Rego query whose elements are reported as violations")
+	checkCmd.Flags().StringArrayVar(&analyzerCommands, "analyzer", nil, "External analyzer command reading the graph as JSON on stdin and writing findings to stdout; error findings are violations. Repeatable")
+	checkCmd.Flags().StringSliceVar(&enrichSources, "enrich", []string{}, "Include external metadata in the policy input (supported: depsdev, github, osv, proxy)")
+	checkCmd.Flags().StringVar(&enrichCacheDir, "enrich-cache-dir", "", "Directory for cached enrichment data. Defaults to the user cache directory.")
+	checkCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
+	checkCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Specify main modules")
+}
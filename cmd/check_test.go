@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRegoDenials(t *testing.T) {
+	out := []byte(`{"result":[{"expressions":[{"value":[
+		"b is archived",
+		{"msg":"a is not allowed","module":"example.com/a","path":["main","example.com/a"]}
+	],"text":"data.depstat.deny"}]}]}`)
+	got, err := parseRegoDenials(out, "data.depstat.deny")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []PolicyViolation{
+		{Rule: "data.depstat.deny", Module: "example.com/a", Message: "a is not allowed", Path: []string{"main", "example.com/a"}},
+		{Rule: "data.depstat.deny", Message: "b is archived"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseRegoDenials() = %+v, want %+v", got, want)
+	}
+
+	// undefined query
+	got, err = parseRegoDenials([]byte(`{}`), "data.depstat.deny")
+	if err != nil || len(got) != 0 {
+		t.Errorf("undefined query: got %v, %v", got, err)
+	}
+
+	if _, err := parseRegoDenials([]byte(`{"result":[{"expressions":[{"value":true}]}]}`), "data.depstat.allow"); err == nil {
+		t.Error("expected error for non-collection result")
+	}
+}
+
+func TestPolicyInputJSON(t *testing.T) {
+	input := policyInput{
+		MainModules: []string{"main"},
+		Nodes: []policyNode{{
+			graphNode: graphNode{Module: "example.com/a", Depth: 1},
+			Version:   "v1.0.0",
+			TestOnly:  true,
+		}},
+		Edges: []graphEdge{{From: "main", To: "example.com/a"}},
+	}
+	raw, err := json.Marshal(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"module":"example.com/a"`, `"version":"v1.0.0"`, `"testOnly":true`, `"depth":1`, `"from":"main"`} {
+		if !strings.Contains(string(raw), want) {
+			t.Errorf("policy input %s missing %s", raw, want)
+		}
+	}
+}
+
+func TestHostAllowed(t *testing.T) {
+	allowed := []string{"k8s.io", "golang.org", "github.com/kubernetes*"}
+	tests := []struct {
+		mod  string
+		want bool
+	}{
+		{"k8s.io/apimachinery", true},
+		{"golang.org/x/net", true},
+		{"github.com/kubernetes-sigs/yaml", true},
+		{"github.com/kubernetes/kube-openapi", true},
+		{"github.com/kube/x", false},
+		{"github.com", false},
+		{"gopkg.in/yaml.v3", false},
+		{"sigs.k8s.io/yaml", false},
+	}
+	for _, tt := range tests {
+		if got := hostAllowed(tt.mod, allowed); got != tt.want {
+			t.Errorf("hostAllowed(%q) = %v, want %v", tt.mod, got, tt.want)
+		}
+	}
+}
+
+func TestEvaluatePolicyAllowedHosts(t *testing.T) {
+	depGraph := generateGraph(strings.Join([]string{
+		"main k8s.io/api@v0.30.0",
+		"k8s.io/api@v0.30.0 gopkg.in/yaml.v3@v3.0.1",
+		"main golang.org/x/net@v0.20.0",
+	}, "\n"), []string{"main"})
+	violations, err := evaluatePolicy(Policy{AllowedHosts: []string{"k8s.io", "golang.org"}}, &depGraph)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []PolicyViolation{{
+		Rule:    "allowed-hosts",
+		Module:  "gopkg.in/yaml.v3",
+		Message: "gopkg.in/yaml.v3 is not from an allowed host",
+		Path:    []string{"main", "k8s.io/api", "gopkg.in/yaml.v3"},
+	}}
+	if !reflect.DeepEqual(violations, want) {
+		t.Errorf("evaluatePolicy() = %+v, want %+v", violations, want)
+	}
+}
+
+func TestEvaluatePolicyBudgets(t *testing.T) {
+	depGraph := generateGraph(strings.Join([]string{
+		"main k8s.io/apimachinery@v0.30.0",
+		"main golang.org/x/net@v0.20.0",
+		"k8s.io/apimachinery@v0.30.0 gopkg.in/yaml.v3@v3.0.1",
+		"k8s.io/apimachinery@v0.30.0 golang.org/x/net@v0.20.0",
+		"golang.org/x/net@v0.20.0 golang.org/x/text@v0.14.0",
+	}, "\n"), []string{"main"})
+	budgets, err := parseBudgets([]string{"k8s.io/*=2", "golang.org/x/net=1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	violations, err := evaluatePolicy(Policy{Budgets: budgets}, &depGraph)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []PolicyViolation{{
+		Rule:    "budget",
+		Module:  "k8s.io/apimachinery",
+		Message: "k8s.io/apimachinery subtree has 3 modules, over its budget of 2 (+1)",
+		Path:    []string{"main", "k8s.io/apimachinery"},
+	}}
+	if !reflect.DeepEqual(violations, want) {
+		t.Errorf("evaluatePolicy() = %+v, want %+v", violations, want)
+	}
+
+	for _, bad := range []string{"k8s.io/api", "k8s.io/api=x", "=3", "k8s.io/api=-1"} {
+		if _, err := parseBudgets([]string{bad}); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestTestOnlyViolations(t *testing.T) {
+	whyOutput := `# github.com/stretchr/testify
+example.com/main/pkg
+example.com/main/pkg.test
+github.com/stretchr/testify/assert
+
+# go.uber.org/mock
+example.com/main/pkg
+example.com/main/internal/fake
+go.uber.org/mock/gomock
+
+# github.com/onsi/gomega
+(main module does not need module github.com/onsi/gomega)
+`
+	guarded := []string{"github.com/onsi/gomega", "github.com/stretchr/testify", "go.uber.org/mock"}
+	got := testOnlyViolations(guarded, parseModWhyPaths(whyOutput))
+	want := []PolicyViolation{{
+		Rule:    "test-only",
+		Module:  "go.uber.org/mock",
+		Message: "go.uber.org/mock must be test-only but is imported by non-test code",
+		Path:    []string{"example.com/main/pkg", "example.com/main/internal/fake", "go.uber.org/mock/gomock"},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("testOnlyViolations() = %+v, want %+v", got, want)
+	}
+}
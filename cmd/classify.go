@@ -0,0 +1,374 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var classifyExplain string
+
+// ClassifyDisagreement is a module the two classifiers disagree on.
+type ClassifyDisagreement struct {
+	Module           string `json:"module"`
+	ModWhyTestOnly   bool   `json:"modwhyTestOnly"`
+	PackagesTestOnly bool   `json:"packagesTestOnly"`
+}
+
+// ClassifyExplanation describes why a module is or is not test-only.
+type ClassifyExplanation struct {
+	Module           string `json:"module"`
+	ModWhyTestOnly   bool   `json:"modwhyTestOnly"`
+	PackagesTestOnly bool   `json:"packagesTestOnly"`
+	// ModWhy is the go mod why -m output for the module.
+	ModWhy []string `json:"modWhy"`
+	// Importers are the packages built for the main modules that import a
+	// package of the module.
+	Importers []ClassifyImporter `json:"importers"`
+	// NonTestPath is the shortest import chain from a non-test package of a
+	// main module to the module, empty when only tests need it.
+	NonTestPath []string `json:"nonTestPath,omitempty"`
+	// TestPath is the shortest import chain through tests, set when there
+	// is no non-test path.
+	TestPath []string `json:"testPath,omitempty"`
+}
+
+// ClassifyImporter is a package importing the explained module.
+type ClassifyImporter struct {
+	Package string `json:"package"`
+	// Test is set when the importer is only built for tests.
+	Test bool `json:"test"`
+	// Files lists the importing source files, for main module packages.
+	Files []string `json:"files,omitempty"`
+}
+
+var classifyCmd = &cobra.Command{
+	Use:   "classify",
+	Short: "Compare test-only classifiers and explain a module's classification",
+	Long: `Classifies every dependency with both test-only classifiers (modwhy and
+packages, see --classifier) and lists the modules they disagree on.
+
+With --explain <module>, reports how each classifier sees the module, the
+go mod why -m output, which packages (and, in the main modules, which files)
+import it and whether they are only built for tests, and the shortest import
+chain from non-test code if there is one.
+
+Examples:
+  # Modules the classifiers disagree on
+  depstat classify
+
+  # Why is testify considered test-only?
+  depstat classify --explain github.com/stretchr/testify`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("classify does not take any arguments"))
+		}
+		depGraph := getDepInfo(mainModules)
+		if len(depGraph.MainModules) == 0 {
+			return errNoMainModules
+		}
+		if classifyExplain != "" {
+			if !contains(graphNodes(depGraph.Graph), classifyExplain) {
+				return withExitCode(ExitNotFound, fmt.Errorf("module %q not found in the dependency graph", classifyExplain))
+			}
+			explanation, err := explainClassification(classifyExplain)
+			if err != nil {
+				return err
+			}
+			if jsonOutput {
+				return writeJSON(cmd.OutOrStdout(), explanation)
+			}
+			printClassifyExplanation(explanation)
+			return nil
+		}
+
+		deps := getAllDeps(depGraph.DirectDepList, depGraph.TransDepList)
+		byModWhy, err := classifyTestDepsWith("modwhy", deps)
+		if err != nil {
+			return fmt.Errorf("failed to classify dependencies with go mod why: %w", err)
+		}
+		byPackages, err := classifyTestDepsWith("packages", deps)
+		if err != nil {
+			return fmt.Errorf("failed to classify dependencies with go list: %w", err)
+		}
+		disagreements := compareClassifications(deps, byModWhy, byPackages)
+		if jsonOutput {
+			return writeJSON(cmd.OutOrStdout(), disagreements)
+		}
+		if len(disagreements) == 0 {
+			fmt.Printf("Both classifiers agree on all %d dependencies.\n", len(deps))
+			return nil
+		}
+		fmt.Printf("Classifiers disagree on %d of %d dependencies:\n", len(disagreements), len(deps))
+		for _, d := range disagreements {
+			fmt.Printf("  %s: modwhy=%s packages=%s\n", d.Module, testOnlyLabel(d.ModWhyTestOnly), testOnlyLabel(d.PackagesTestOnly))
+		}
+		fmt.Println("\nRun depstat classify --explain <module> for details.")
+		return nil
+	},
+}
+
+func compareClassifications(deps []string, byModWhy, byPackages map[string]bool) []ClassifyDisagreement {
+	disagreements := []ClassifyDisagreement{}
+	for _, dep := range deps {
+		if byModWhy[dep] != byPackages[dep] {
+			disagreements = append(disagreements, ClassifyDisagreement{Module: dep, ModWhyTestOnly: byModWhy[dep], PackagesTestOnly: byPackages[dep]})
+		}
+	}
+	sort.Slice(disagreements, func(i, j int) bool { return disagreements[i].Module < disagreements[j].Module })
+	return disagreements
+}
+
+func testOnlyLabel(testOnly bool) string {
+	if testOnly {
+		return "test-only"
+	}
+	return "non-test"
+}
+
+// goPackage is the subset of go list -json package fields used to explain
+// classifications.
+type goPackage struct {
+	ImportPath   string
+	ForTest      string
+	Dir          string
+	Module       *goModule
+	Imports      []string
+	GoFiles      []string
+	XTestGoFiles []string
+}
+
+// packageGraph is the package graph of ./... including test variants, as
+// printed by go list -deps -test.
+type packageGraph struct {
+	packages map[string]*goPackage
+	order    []string
+}
+
+func loadPackageGraph() (*packageGraph, error) {
+	c := goCommand([]string{"list", "-deps", "-test", "-json=ImportPath,ForTest,Dir,Module,Imports,GoFiles,XTestGoFiles", "./..."})
+	out, err := c.Output()
+	if err != nil {
+		return nil, goCommandError(c, err)
+	}
+	g := &packageGraph{packages: make(map[string]*goPackage)}
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for dec.More() {
+		var p goPackage
+		if err := dec.Decode(&p); err != nil {
+			return nil, fmt.Errorf("parsing go list output: %w", err)
+		}
+		g.packages[p.ImportPath] = &p
+		g.order = append(g.order, p.ImportPath)
+	}
+	return g, nil
+}
+
+func (g *packageGraph) modulePath(id string) string {
+	if p := g.packages[id]; p != nil && p.Module != nil {
+		return p.Module.Path
+	}
+	return ""
+}
+
+// isTestPackage reports whether p is a test variant, an external test
+// package or a generated test main.
+func isTestPackage(p *goPackage) bool {
+	return p.ForTest != "" || strings.HasSuffix(p.ImportPath, ".test")
+}
+
+// roots returns the main module packages, without test packages unless
+// withTests is set.
+func (g *packageGraph) roots(withTests bool) []string {
+	var roots []string
+	for _, id := range g.order {
+		p := g.packages[id]
+		if p.Module != nil && p.Module.Main && (withTests || !isTestPackage(p)) {
+			roots = append(roots, id)
+		}
+	}
+	return roots
+}
+
+// shortestPath returns the shortest import chain from roots to a package of
+// module, with its reachable set.
+func (g *packageGraph) shortestPath(roots []string, module string) ([]string, map[string]bool) {
+	parent := make(map[string]string)
+	seen := make(map[string]bool)
+	queue := append([]string{}, roots...)
+	for _, r := range roots {
+		seen[r] = true
+	}
+	var found string
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if found == "" && g.modulePath(id) == module {
+			found = id
+		}
+		p := g.packages[id]
+		if p == nil {
+			continue
+		}
+		for _, imp := range p.Imports {
+			if !seen[imp] {
+				seen[imp] = true
+				parent[imp] = id
+				queue = append(queue, imp)
+			}
+		}
+	}
+	if found == "" {
+		return nil, seen
+	}
+	path := []string{found}
+	for id := found; parent[id] != ""; id = parent[id] {
+		path = append([]string{parent[id]}, path...)
+	}
+	return path, seen
+}
+
+// explainPackages fills the package-graph part of an explanation.
+func explainPackages(g *packageGraph, module string) ClassifyExplanation {
+	e := ClassifyExplanation{Module: module, Importers: []ClassifyImporter{}}
+	nonTestPath, nonTestReach := g.shortestPath(g.roots(false), module)
+	testPath, testReach := g.shortestPath(g.roots(true), module)
+	e.NonTestPath = nonTestPath
+	if nonTestPath == nil {
+		e.TestPath = testPath
+	}
+	e.PackagesTestOnly = nonTestPath == nil && testPath != nil
+
+	for _, id := range g.order {
+		p := g.packages[id]
+		if !testReach[id] || g.modulePath(id) == module {
+			continue
+		}
+		targets := make(map[string]bool)
+		for _, imp := range p.Imports {
+			if g.modulePath(imp) == module {
+				targets[stripTestVariant(imp)] = true
+			}
+		}
+		if len(targets) == 0 {
+			continue
+		}
+		importer := ClassifyImporter{Package: id, Test: !nonTestReach[id]}
+		if p.Module != nil && p.Module.Main {
+			importer.Files = importingFiles(p, targets)
+		}
+		e.Importers = append(e.Importers, importer)
+	}
+	return e
+}
+
+// stripTestVariant turns "pkg [pkg.test]" into "pkg".
+func stripTestVariant(id string) string {
+	if i := strings.Index(id, " ["); i >= 0 {
+		return id[:i]
+	}
+	return id
+}
+
+// importingFiles returns the files of p that import one of targets.
+func importingFiles(p *goPackage, targets map[string]bool) []string {
+	var files []string
+	fset := token.NewFileSet()
+	for _, name := range append(append([]string{}, p.GoFiles...), p.XTestGoFiles...) {
+		path := name
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(p.Dir, name)
+		}
+		f, err := parser.ParseFile(fset, path, nil, parser.ImportsOnly)
+		if err != nil {
+			continue
+		}
+		for _, imp := range f.Imports {
+			if importPath, err := strconv.Unquote(imp.Path.Value); err == nil && targets[importPath] {
+				files = append(files, path)
+				break
+			}
+		}
+	}
+	return files
+}
+
+func explainClassification(module string) (*ClassifyExplanation, error) {
+	g, err := loadPackageGraph()
+	if err != nil {
+		return nil, err
+	}
+	e := explainPackages(g, module)
+	output, err := runModWhy([]string{module})
+	if err != nil {
+		return nil, err
+	}
+	e.ModWhyTestOnly = parseModWhyOutput(output)[module]
+	e.ModWhy = strings.Split(strings.TrimSpace(output), "\n")
+	return &e, nil
+}
+
+func printClassifyExplanation(e *ClassifyExplanation) {
+	fmt.Printf("%s\n", e.Module)
+	fmt.Printf("  modwhy:   %s\n", testOnlyLabel(e.ModWhyTestOnly))
+	fmt.Printf("  packages: %s\n", testOnlyLabel(e.PackagesTestOnly))
+	if e.ModWhyTestOnly != e.PackagesTestOnly {
+		fmt.Println("  The classifiers disagree: modwhy considers every platform and build tag, packages only the current ones.")
+	}
+	fmt.Println("\ngo mod why -m:")
+	for _, line := range e.ModWhy {
+		fmt.Printf("  %s\n", line)
+	}
+	fmt.Printf("\nImported by (%d):\n", len(e.Importers))
+	for _, imp := range e.Importers {
+		kind := "non-test"
+		if imp.Test {
+			kind = "test"
+		}
+		fmt.Printf("  %s (%s)\n", imp.Package, kind)
+		for _, f := range imp.Files {
+			fmt.Printf("    %s\n", f)
+		}
+	}
+	if len(e.NonTestPath) > 0 {
+		fmt.Printf("\nNon-test path:\n  %s\n", strings.Join(e.NonTestPath, " -> "))
+	} else {
+		fmt.Println("\nNon-test path: none")
+		if len(e.TestPath) > 0 {
+			fmt.Printf("Test path:\n  %s\n", strings.Join(e.TestPath, " -> "))
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(classifyCmd)
+	classifyCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
+	classifyCmd.Flags().StringVar(&classifyExplain, "explain", "", "Explain the classification of this module")
+	classifyCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Output in JSON format")
+	classifyCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
+	classifyCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Specify main modules")
+}
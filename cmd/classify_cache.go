@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// noCache disables the test-only classification cache.
+var noCache bool
+
+// classifyCacheDir overrides the location of the classification cache.
+var classifyCacheDir string
+
+// classifyCacheFiles are the files whose content decides how go mod why
+// classifies modules.
+var classifyCacheFiles = []string{"go.mod", "go.sum", "go.work", "go.work.sum"}
+
+// classifyCacheEntry maps every classified module to whether it is
+// test-only.
+type classifyCacheEntry struct {
+	Modules map[string]bool `json:"modules"`
+}
+
+// classifyCacheKey hashes the module files in --dir, the classifier and the
+// go settings that change package selection (build tags, platform,
+// workspace). It returns "" when no go.mod is found, so nothing is cached.
+func classifyCacheKey(classifier string) string {
+	h := sha256.New()
+	found := false
+	for _, name := range classifyCacheFiles {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		found = found || name == "go.mod"
+		sum := sha256.Sum256(data)
+		h.Write([]byte(name + "\x00" + hex.EncodeToString(sum[:]) + "\n"))
+	}
+	if !found {
+		return ""
+	}
+	h.Write([]byte("classifier=" + classifier + "\n"))
+	settings := goEnvSettings()
+	for _, key := range []string{"GOFLAGS", "GOOS", "GOARCH", "GOWORK"} {
+		settings = append([]string{key + "=" + os.Getenv(key)}, settings...)
+	}
+	for _, kv := range lastSettings(settings) {
+		h.Write([]byte(kv + "\n"))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func classifyCachePath(key string) string {
+	base := classifyCacheDir
+	if base == "" {
+		userCache, err := os.UserCacheDir()
+		if err != nil {
+			return ""
+		}
+		base = filepath.Join(userCache, "depstat", "classify")
+	}
+	return filepath.Join(base, key+".json")
+}
+
+func loadClassifyCache(key string) map[string]bool {
+	p := classifyCachePath(key)
+	if p == "" {
+		return nil
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return nil
+	}
+	var entry classifyCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		debugf("ignoring unreadable classification cache %s: %v\n", p, err)
+		return nil
+	}
+	return entry.Modules
+}
+
+func storeClassifyCache(key string, modules map[string]bool) {
+	p := classifyCachePath(key)
+	if p == "" {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return
+	}
+	data, err := json.Marshal(classifyCacheEntry{Modules: modules})
+	if err != nil {
+		return
+	}
+	// write through a temporary file so concurrent runs never read a
+	// partial entry
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".classify-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), p); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestClassifyCache(t *testing.T) {
+	oldDir, oldCacheDir := dir, classifyCacheDir
+	dir, classifyCacheDir = t.TempDir(), t.TempDir()
+	defer func() { dir, classifyCacheDir, goFlagsOverride = oldDir, oldCacheDir, "" }()
+
+	if key := classifyCacheKey("modwhy"); key != "" {
+		t.Fatalf("expected no key without go.mod, got %q", key)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/a\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	key := classifyCacheKey("modwhy")
+	if key == "" {
+		t.Fatal("expected a key with go.mod")
+	}
+
+	want := map[string]bool{"example.com/b": true, "example.com/c": false}
+	storeClassifyCache(key, want)
+	if got := loadClassifyCache(key); !reflect.DeepEqual(got, want) {
+		t.Errorf("loadClassifyCache = %v, want %v", got, want)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "go.sum"), []byte("example.com/b v1.0.0 h1:x=\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if classifyCacheKey("modwhy") == key {
+		t.Error("expected go.sum changes to change the key")
+	}
+	sumKey := classifyCacheKey("modwhy")
+	goFlagsOverride = "-tags=e2e"
+	if classifyCacheKey("modwhy") == sumKey {
+		t.Error("expected --goflags to change the key")
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import "strings"
+
+// testClassifier selects how test-only dependencies are classified: "modwhy"
+// runs go mod why -m, "packages" compares the package graphs of go list -deps
+// with and without tests.
+var testClassifier string
+
+// classifyByPackages lists the modules providing the packages built by the
+// non-test packages in --dir, and those additionally needed by their tests.
+// A module only needed by tests is test-only. Modules not providing any
+// package are not test-only, as with go mod why.
+//
+// Unlike go mod why, go list only considers the current GOOS, GOARCH and
+// build tags, which makes the result describe what is actually built.
+func classifyByPackages(deps []string) (map[string]bool, error) {
+	nonTest, err := listPackageModules("-deps")
+	if err != nil {
+		return nil, err
+	}
+	withTests, err := listPackageModules("-deps", "-test")
+	if err != nil {
+		return nil, err
+	}
+	return testOnlyFromPackageModules(deps, nonTest, withTests), nil
+}
+
+// listPackageModules returns the modules of the packages go list reports
+// for ./... with the given flags.
+func listPackageModules(flags ...string) (map[string]bool, error) {
+	return listPackageModulesOf([]string{"./..."}, flags...)
+}
+
+// listPackageModulesOf is listPackageModules for other package patterns,
+// such as "tool".
+func listPackageModulesOf(patterns []string, flags ...string) (map[string]bool, error) {
+	args := append([]string{"list"}, flags...)
+	args = append(args, "-f", "{{with .Module}}{{.Path}}{{end}}")
+	args = append(args, patterns...)
+	c := goCommand(args)
+	out, err := c.Output()
+	if err != nil {
+		return nil, goCommandError(c, err)
+	}
+	modules := make(map[string]bool)
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			modules[line] = true
+		}
+	}
+	return modules, nil
+}
+
+func testOnlyFromPackageModules(deps []string, nonTest, withTests map[string]bool) map[string]bool {
+	testOnly := make(map[string]bool)
+	for _, dep := range deps {
+		if withTests[dep] && !nonTest[dep] {
+			testOnly[dep] = true
+		}
+	}
+	return testOnly
+}
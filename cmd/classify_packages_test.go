@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTestOnlyFromPackageModules(t *testing.T) {
+	deps := []string{"example.com/shipped", "example.com/testonly", "example.com/unused"}
+	nonTest := map[string]bool{"example.com/main": true, "example.com/shipped": true}
+	withTests := map[string]bool{"example.com/main": true, "example.com/shipped": true, "example.com/testonly": true}
+
+	got := testOnlyFromPackageModules(deps, nonTest, withTests)
+	want := map[string]bool{"example.com/testonly": true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("testOnlyFromPackageModules = %v, want %v", got, want)
+	}
+}
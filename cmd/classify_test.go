@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExplainPackages(t *testing.T) {
+	mainMod := &goModule{Path: "example.com/main", Main: true}
+	g := &packageGraph{packages: map[string]*goPackage{}}
+	for _, p := range []*goPackage{
+		{ImportPath: "example.com/main/pkg", Module: mainMod, Imports: []string{"example.com/lib"}},
+		{ImportPath: "example.com/main/pkg [example.com/main/pkg.test]", ForTest: "example.com/main/pkg", Module: mainMod, Imports: []string{"example.com/lib", "example.com/assert"}},
+		{ImportPath: "example.com/main/pkg.test", Module: mainMod, Imports: []string{"example.com/main/pkg [example.com/main/pkg.test]"}},
+		{ImportPath: "example.com/lib", Module: &goModule{Path: "example.com/lib"}},
+		{ImportPath: "example.com/assert", Module: &goModule{Path: "example.com/assert"}, Imports: []string{"example.com/yaml"}},
+		{ImportPath: "example.com/yaml", Module: &goModule{Path: "example.com/yaml"}},
+	} {
+		g.packages[p.ImportPath] = p
+		g.order = append(g.order, p.ImportPath)
+	}
+
+	yaml := explainPackages(g, "example.com/yaml")
+	if !yaml.PackagesTestOnly || yaml.NonTestPath != nil {
+		t.Errorf("expected yaml to be test-only, got %+v", yaml)
+	}
+	wantPath := []string{"example.com/main/pkg [example.com/main/pkg.test]", "example.com/assert", "example.com/yaml"}
+	if !reflect.DeepEqual(yaml.TestPath, wantPath) {
+		t.Errorf("test path = %v, want %v", yaml.TestPath, wantPath)
+	}
+	wantImporters := []ClassifyImporter{{Package: "example.com/assert", Test: true}}
+	if !reflect.DeepEqual(yaml.Importers, wantImporters) {
+		t.Errorf("importers = %+v, want %+v", yaml.Importers, wantImporters)
+	}
+
+	lib := explainPackages(g, "example.com/lib")
+	if lib.PackagesTestOnly {
+		t.Errorf("expected lib to be non-test")
+	}
+	if want := []string{"example.com/main/pkg", "example.com/lib"}; !reflect.DeepEqual(lib.NonTestPath, want) {
+		t.Errorf("non-test path = %v, want %v", lib.NonTestPath, want)
+	}
+	if len(lib.Importers) != 2 || lib.Importers[0].Test || !lib.Importers[1].Test {
+		t.Errorf("expected a non-test and a test importer, got %+v", lib.Importers)
+	}
+}
+
+func TestCompareClassifications(t *testing.T) {
+	got := compareClassifications(
+		[]string{"b", "a", "c"},
+		map[string]bool{"a": true, "b": true},
+		map[string]bool{"b": true, "c": true},
+	)
+	want := []ClassifyDisagreement{
+		{Module: "a", ModWhyTestOnly: true},
+		{Module: "c", PackagesTestOnly: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("compareClassifications = %+v, want %+v", got, want)
+	}
+}
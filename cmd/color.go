@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"strings"
+)
+
+const (
+	ansiReset  = "\x1b[0m"
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+)
+
+var noColor bool
+
+// colorOutput enables ANSI colors in text output. It is resolved once per
+// run by detectColor.
+var colorOutput bool
+
+// detectColor reports whether text written to f should be colored: only for
+// terminals, and never with --no-color, NO_COLOR (https://no-color.org) or
+// TERM=dumb.
+func detectColor(f *os.File) bool {
+	if noColor || os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func colorize(color, s string) string {
+	if !colorOutput || s == "" {
+		return s
+	}
+	return color + s + ansiReset
+}
+
+// colorModule highlights main modules in green and the target in yellow.
+func colorModule(mod string, mainModules []string, target string) string {
+	switch {
+	case mod == target:
+		return colorize(ansiYellow, mod)
+	case contains(mainModules, mod):
+		return colorize(ansiGreen, mod)
+	}
+	return mod
+}
+
+// colorPath joins a dependency path with " -> ", coloring its modules with
+// colorModule.
+func colorPath(path []string, mainModules []string, target string) string {
+	colored := make([]string, len(path))
+	for i, mod := range path {
+		colored[i] = colorModule(mod, mainModules, target)
+	}
+	return strings.Join(colored, " -> ")
+}
+
+// colorDelta colors a formatted change in a dependency metric: growth red,
+// shrinkage green.
+func colorDelta(s string, delta int) string {
+	switch {
+	case delta > 0:
+		return colorize(ansiRed, s)
+	case delta < 0:
+		return colorize(ansiGreen, s)
+	}
+	return s
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import "testing"
+
+func TestColorPath(t *testing.T) {
+	defer func() { colorOutput = false }()
+	path := []string{"main", "A", "B"}
+	colorOutput = false
+	if got := colorPath(path, []string{"main"}, "B"); got != "main -> A -> B" {
+		t.Errorf("uncolored path = %q", got)
+	}
+	colorOutput = true
+	want := ansiGreen + "main" + ansiReset + " -> A -> " + ansiYellow + "B" + ansiReset
+	if got := colorPath(path, []string{"main"}, "B"); got != want {
+		t.Errorf("colored path = %q, want %q", got, want)
+	}
+	if got := colorDelta("+3", 3); got != ansiRed+"+3"+ansiReset {
+		t.Errorf("growth delta = %q", got)
+	}
+	if got := colorDelta("+0", 0); got != "+0" {
+		t.Errorf("zero delta = %q", got)
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+)
+
+// concentrationTopN is the number of direct dependencies whose combined
+// share of the transitive modules is reported.
+const concentrationTopN = 3
+
+// Concentration describes how unevenly the transitive modules are spread
+// over the direct dependencies that pull them in.
+type Concentration struct {
+	// Gini is the Gini coefficient of the subtree sizes of the direct
+	// dependencies: 0 when every one pulls in as many modules, close to 1
+	// when a single one pulls in nearly all of them.
+	Gini float64 `json:"gini"`
+	// Top lists the direct dependencies with the largest subtrees.
+	Top []string `json:"top"`
+	// TopShare is the fraction of the transitive modules reachable from
+	// the Top dependencies.
+	TopShare float64 `json:"topShare"`
+	// TransitiveModules is the number of modules reachable from any
+	// direct dependency.
+	TransitiveModules int `json:"transitiveModules"`
+}
+
+// computeConcentration measures how much of the graph hangs off the largest
+// direct dependencies. Subtrees overlap, so TopShare counts every module
+// reachable from the top dependencies once. It returns nil without direct
+// dependencies.
+func computeConcentration(depGraph *DependencyOverview, topN int) *Concentration {
+	if len(depGraph.DirectDepList) == 0 {
+		return nil
+	}
+	subtrees := make(map[string][]string)
+	all := make(map[string]bool)
+	directs := uniqueStrings(depGraph.DirectDepList)
+	for _, direct := range directs {
+		subtrees[direct] = subtreeModules(direct, depGraph.Graph, depGraph.MainModules)
+		for _, mod := range subtrees[direct] {
+			all[mod] = true
+		}
+	}
+	sort.Slice(directs, func(i, j int) bool {
+		if len(subtrees[directs[i]]) == len(subtrees[directs[j]]) {
+			return directs[i] < directs[j]
+		}
+		return len(subtrees[directs[i]]) > len(subtrees[directs[j]])
+	})
+
+	c := &Concentration{TransitiveModules: len(all)}
+	if topN > len(directs) {
+		topN = len(directs)
+	}
+	covered := make(map[string]bool)
+	for _, direct := range directs[:topN] {
+		c.Top = append(c.Top, direct)
+		for _, mod := range subtrees[direct] {
+			covered[mod] = true
+		}
+	}
+	if len(all) > 0 {
+		c.TopShare = roundRatio(float64(len(covered)) / float64(len(all)))
+	}
+	sizes := make([]int, len(directs))
+	for i, direct := range directs {
+		sizes[i] = len(subtrees[direct])
+	}
+	c.Gini = roundRatio(gini(sizes))
+	return c
+}
+
+// gini returns the Gini coefficient of the values, 0 when they are all zero.
+func gini(values []int) float64 {
+	sorted := append([]int{}, values...)
+	sort.Ints(sorted)
+	var sum, weighted float64
+	for i, v := range sorted {
+		sum += float64(v)
+		weighted += float64(i+1) * float64(v)
+	}
+	if sum == 0 {
+		return 0
+	}
+	n := float64(len(sorted))
+	return 2*weighted/(n*sum) - (n+1)/n
+}
+
+// String summarizes the concentration in one line.
+func (c *Concentration) String() string {
+	return fmt.Sprintf("top %d direct deps account for %.0f%% of %d transitive modules (Gini %.2f)", len(c.Top), c.TopShare*100, c.TransitiveModules, c.Gini)
+}
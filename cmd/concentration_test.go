@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComputeConcentration(t *testing.T) {
+	depGraph := &DependencyOverview{
+		MainModules:   []string{"main"},
+		DirectDepList: []string{"A", "B", "C", "D"},
+		TransDepList:  []string{"E", "F", "G", "H", "I"},
+		Graph: map[string][]string{
+			"main": {"A", "B", "C", "D"},
+			"A":    {"E", "F", "G"},
+			"B":    {"E", "H"},
+			"C":    {"H", "I"},
+		},
+	}
+	got := computeConcentration(depGraph, 2)
+	want := &Concentration{Gini: 0.3214, Top: []string{"A", "B"}, TopShare: 0.8, TransitiveModules: 5}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("computeConcentration = %+v, want %+v", got, want)
+	}
+	if s := got.String(); s != "top 2 direct deps account for 80% of 5 transitive modules (Gini 0.32)" {
+		t.Errorf("unexpected summary %q", s)
+	}
+	if c := computeConcentration(&DependencyOverview{MainModules: []string{"main"}}, 3); c != nil {
+		t.Errorf("expected nil without direct dependencies, got %+v", c)
+	}
+}
+
+func TestGini(t *testing.T) {
+	tests := []struct {
+		values []int
+		want   float64
+	}{
+		{nil, 0},
+		{[]int{0, 0}, 0},
+		{[]int{5, 5, 5}, 0},
+		{[]int{0, 0, 0, 10}, 0.75},
+	}
+	for _, tt := range tests {
+		if got := roundRatio(gini(tt.values)); got != tt.want {
+			t.Errorf("gini(%v) = %v, want %v", tt.values, got, tt.want)
+		}
+	}
+}
@@ -17,8 +17,8 @@ limitations under the License.
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
+	"os"
 	"sort"
 	"strconv"
 
@@ -66,18 +66,18 @@ var cyclesCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 
 		if len(args) != 0 {
-			return fmt.Errorf("cycles does not take any arguments")
+			return withExitCode(ExitUsage, fmt.Errorf("cycles does not take any arguments"))
 		}
 
 		overview := getDepInfo(mainModules)
 		if maxCycleLength != 0 && maxCycleLength < 2 {
-			return fmt.Errorf("--max-length must be >= 2 (minimum cycle length is 2)")
+			return withExitCode(ExitUsage, fmt.Errorf("--max-length must be >= 2 (minimum cycle length is 2)"))
 		}
 		if summaryOutputCycles && cyclesTopN <= 0 {
-			return fmt.Errorf("-n must be > 0")
+			return withExitCode(ExitUsage, fmt.Errorf("-n must be > 0"))
 		}
 		if len(overview.MainModules) == 0 {
-			return fmt.Errorf("no main modules remain after exclusions; adjust --exclude-modules or --mainModules")
+			return errNoMainModules
 		}
 
 		cycles := findAllCyclesWithMaxLength(overview.Graph, maxCycleLength)
@@ -93,11 +93,9 @@ var cyclesCmd = &cobra.Command{
 					"nonTestOnly": buildCycleOutput(nonTestCycles, summaryOutputCycles, cyclesTopN),
 					"testOnly":    buildCycleOutput(testOnlyCycles, summaryOutputCycles, cyclesTopN),
 				}
-				outputRaw, err := json.MarshalIndent(outputObj, "", "\t")
-				if err != nil {
+				if err := writeJSON(os.Stdout, outputObj); err != nil {
 					return err
 				}
-				fmt.Print(string(outputRaw))
 				return nil
 			}
 			fmt.Println("Non-test cycles:")
@@ -131,7 +129,7 @@ var cyclesCmd = &cobra.Command{
 			}
 		}
 		if cyclesSVGOutput {
-			return fmt.Errorf("--svg is not supported for cycles")
+			return withExitCode(ExitUsage, fmt.Errorf("--svg is not supported for cycles"))
 		}
 
 		if jsonOutputCycles {
@@ -143,11 +141,9 @@ var cyclesCmd = &cobra.Command{
 				outputObj["summary"] = summary
 			}
 
-			outputRaw, err := json.MarshalIndent(outputObj, "", "\t")
-			if err != nil {
+			if err := writeJSON(os.Stdout, outputObj); err != nil {
 				return err
 			}
-			fmt.Print(string(outputRaw))
 		}
 		return nil
 	},
@@ -487,7 +483,7 @@ func init() {
 	cyclesCmd.Flags().BoolVar(&cyclesSplitTestOnly, "split-test-only", false, "Split cycles into test-only and non-test sections (uses go mod why -m)")
 	cyclesCmd.Flags().BoolVarP(&cyclesSVGOutput, "svg", "s", false, "(unsupported) placeholder for svg output")
 	cyclesCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
-	cyclesCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Enter modules whose dependencies should be considered direct dependencies; defaults to the first module encountered in `go mod graph` output")
+	cyclesCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Enter modules whose dependencies should be considered direct dependencies; defaults to the modules found by --main")
 	cyclesCmd.Flags().BoolVarP(&cyclesVerbose, "verbose", "v", false, "Include raw cycles with summary output")
 }
 
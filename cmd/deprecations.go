@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// ModuleDeprecation describes a dependency that is deprecated or pinned to
+// a retracted version.
+type ModuleDeprecation struct {
+	Module     string   `json:"module"`
+	Version    string   `json:"version"`
+	Deprecated string   `json:"deprecated,omitempty"`
+	Retracted  []string `json:"retracted,omitempty"`
+	Path       []string `json:"path,omitempty"`
+}
+
+// DeprecationsResult holds the result of the deprecations check.
+type DeprecationsResult struct {
+	Deprecated  []ModuleDeprecation `json:"deprecated"`
+	Retracted   []ModuleDeprecation `json:"retracted"`
+	MainModules []string            `json:"mainModules"`
+}
+
+var deprecationsCmd = &cobra.Command{
+	Use:   "deprecations",
+	Short: "Report deprecated modules and retracted versions in the dependency graph",
+	Long: `Queries module metadata via "go list -m -u -retracted -json all" and reports
+every dependency whose module is marked "Deprecated:" in its go.mod, or whose
+selected version has been retracted by its author.
+
+Each finding includes the shortest path from a main module to the dependency.
+This requires access to the module proxy.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("deprecations does not take any arguments"))
+		}
+		if err := requireNetwork("deprecations"); err != nil {
+			return err
+		}
+		depGraph := getDepInfo(mainModules)
+		if len(depGraph.MainModules) == 0 {
+			return errNoMainModules
+		}
+		modules, err := listAllModulesWithFlags(nil, "-u", "-retracted")
+		if err != nil {
+			return fmt.Errorf("listing modules: %w", err)
+		}
+		result := findDeprecations(modules, depGraph)
+		if jsonOutput {
+			out, err := json.MarshalIndent(result, "", "\t")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+			return nil
+		}
+		printDeprecations(result)
+		return nil
+	},
+}
+
+// findDeprecations matches `go list` metadata against the modules present
+// in the dependency graph.
+func findDeprecations(modules []goModule, depGraph *DependencyOverview) DeprecationsResult {
+	inGraph := make(map[string]bool)
+	for _, dep := range getAllDeps(depGraph.DirectDepList, depGraph.TransDepList) {
+		inGraph[dep] = true
+	}
+	result := DeprecationsResult{
+		Deprecated:  []ModuleDeprecation{},
+		Retracted:   []ModuleDeprecation{},
+		MainModules: depGraph.MainModules,
+	}
+	for _, mod := range modules {
+		if mod.Main || !inGraph[mod.Path] {
+			continue
+		}
+		if mod.Deprecated == "" && len(mod.Retracted) == 0 {
+			continue
+		}
+		finding := ModuleDeprecation{
+			Module:     mod.Path,
+			Version:    mod.Version,
+			Deprecated: mod.Deprecated,
+			Retracted:  mod.Retracted,
+			Path:       shortestPath(depGraph.MainModules, mod.Path, depGraph.Graph),
+		}
+		if mod.Deprecated != "" {
+			result.Deprecated = append(result.Deprecated, finding)
+		}
+		if len(mod.Retracted) > 0 {
+			result.Retracted = append(result.Retracted, finding)
+		}
+	}
+	sort.Slice(result.Deprecated, func(i, j int) bool { return result.Deprecated[i].Module < result.Deprecated[j].Module })
+	sort.Slice(result.Retracted, func(i, j int) bool { return result.Retracted[i].Module < result.Retracted[j].Module })
+	return result
+}
+
+func printDeprecations(result DeprecationsResult) {
+	if len(result.Deprecated) == 0 && len(result.Retracted) == 0 {
+		fmt.Println("No deprecated modules or retracted versions found.")
+		return
+	}
+	if len(result.Deprecated) > 0 {
+		fmt.Printf("DEPRECATED MODULES (%d):\n", len(result.Deprecated))
+		for _, d := range result.Deprecated {
+			fmt.Printf("  %s %s\n", d.Module, d.Version)
+			fmt.Printf("    deprecated: %s\n", d.Deprecated)
+			if len(d.Path) > 0 {
+				fmt.Printf("    via: %s\n", strings.Join(d.Path, " -> "))
+			}
+		}
+		fmt.Println()
+	}
+	if len(result.Retracted) > 0 {
+		fmt.Printf("RETRACTED VERSIONS (%d):\n", len(result.Retracted))
+		for _, d := range result.Retracted {
+			fmt.Printf("  %s %s\n", d.Module, d.Version)
+			fmt.Printf("    retracted: %s\n", strings.Join(d.Retracted, "; "))
+			if len(d.Path) > 0 {
+				fmt.Printf("    via: %s\n", strings.Join(d.Path, " -> "))
+			}
+		}
+		fmt.Println()
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(deprecationsCmd)
+	deprecationsCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
+	deprecationsCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Get the output in JSON format")
+	deprecationsCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
+	deprecationsCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Specify main modules")
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFindDeprecations(t *testing.T) {
+	depGraph := &DependencyOverview{
+		MainModules:   []string{"main"},
+		DirectDepList: []string{"A", "B"},
+		TransDepList:  []string{"C"},
+		Graph: map[string][]string{
+			"main": {"B", "A"},
+			"A":    {"C"},
+			"B":    {"C"},
+		},
+	}
+	modules, err := decodeGoModules(strings.NewReader(`
+{"Path": "main", "Main": true, "Deprecated": "ignored for main modules"}
+{"Path": "A", "Version": "v1.0.0"}
+{"Path": "C", "Version": "v0.3.0", "Deprecated": "use D instead", "Retracted": ["broken build"]}
+{"Path": "unreachable", "Version": "v1.0.0", "Deprecated": "not in graph"}
+`))
+	if err != nil {
+		t.Fatalf("decodeGoModules: %v", err)
+	}
+
+	result := findDeprecations(modules, depGraph)
+	if len(result.Deprecated) != 1 || result.Deprecated[0].Module != "C" {
+		t.Fatalf("expected only C to be deprecated, got %+v", result.Deprecated)
+	}
+	if len(result.Retracted) != 1 || result.Retracted[0].Retracted[0] != "broken build" {
+		t.Fatalf("expected C to be retracted, got %+v", result.Retracted)
+	}
+	// shortestPath visits neighbors in sorted order, so A wins over B.
+	if got := strings.Join(result.Deprecated[0].Path, " -> "); got != "main -> A -> C" {
+		t.Fatalf("unexpected path %q", got)
+	}
+}
+
+func TestShortestPathUnreachable(t *testing.T) {
+	if p := shortestPath([]string{"A"}, "Z", map[string][]string{"A": {"B"}}); p != nil {
+		t.Fatalf("expected nil path, got %v", p)
+	}
+}
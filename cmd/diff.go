@@ -23,6 +23,7 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"sort"
 	"strings"
 
@@ -97,6 +98,7 @@ type DiffSummary struct {
 	AddedCount          int `json:"addedCount"`
 	RemovedCount        int `json:"removedCount"`
 	VersionChangesCount int `json:"versionChangesCount"`
+	LicenseChangesCount int `json:"licenseChangesCount,omitempty"`
 }
 
 // DiffResult holds the complete diff analysis
@@ -117,6 +119,7 @@ type DiffResult struct {
 	EdgesAdded     []string          `json:"edgesAdded"`
 	EdgesRemoved   []string          `json:"edgesRemoved"`
 	VersionChanges []VersionChange   `json:"versionChanges,omitempty"`
+	LicenseChanges []LicenseChange   `json:"licenseChanges,omitempty"`
 	Vendor         *VendorDiffResult `json:"vendor,omitempty"`
 	Summary        DiffSummary       `json:"summary"`
 }
@@ -125,6 +128,9 @@ var diffCmd = &cobra.Command{
 	Use:   "diff <base-ref> [head-ref]",
 	Short: "Compare dependencies between two git refs",
 	Long: `Compare dependency changes between two git commits, branches, or tags.
+Both refs are checked out into temporary git worktrees next to the
+repository root, so the working tree, including uncommitted changes, is left
+alone and relative replace directives resolve as in the checkout.
 
 Examples:
   # Compare current HEAD with main branch
@@ -137,35 +143,41 @@ Examples:
   depstat diff main --json
 
   # Output as DOT format for visualization
-  depstat diff main --dot | dot -Tsvg -o diff.svg`,
+  depstat diff main --dot | dot -Tsvg -o diff.svg
+
+  # Flag version bumps that change the license
+  depstat diff main --enrich depsdev`,
 	Args: cobra.RangeArgs(1, 2),
 	RunE: runDiff,
 }
 
 func runDiff(cmd *cobra.Command, args []string) error {
 	if testOnly && nonTestOnly {
-		return fmt.Errorf("--test-only and --non-test-only are mutually exclusive")
+		return withExitCode(ExitUsage, fmt.Errorf("--test-only and --non-test-only are mutually exclusive"))
 	}
 	if vendorFilesFlag {
 		vendorFlag = true
 	}
 	if len(diffExcludeModules) > 0 && (vendorFlag || vendorFilesFlag) {
-		return fmt.Errorf("--exclude-modules cannot be combined with --vendor or --vendor-files")
+		return withExitCode(ExitUsage, fmt.Errorf("--exclude-modules cannot be combined with --vendor or --vendor-files"))
 	}
 	if len(diffExcludeModules) > 0 && diffSplitTestOnly {
-		return fmt.Errorf("--exclude-modules cannot be combined with --split-test-only")
+		return withExitCode(ExitUsage, fmt.Errorf("--exclude-modules cannot be combined with --split-test-only"))
 	}
 	if len(diffExcludeModules) > 0 && (testOnly || nonTestOnly) {
-		return fmt.Errorf("--exclude-modules cannot be combined with --test-only or --non-test-only")
+		return withExitCode(ExitUsage, fmt.Errorf("--exclude-modules cannot be combined with --test-only or --non-test-only"))
 	}
 	if diffSplitTestOnly && (testOnly || nonTestOnly) {
-		return fmt.Errorf("--split-test-only cannot be combined with --test-only or --non-test-only")
+		return withExitCode(ExitUsage, fmt.Errorf("--split-test-only cannot be combined with --test-only or --non-test-only"))
 	}
 	if dotOutput && svgOutput {
-		return fmt.Errorf("--dot and --svg are mutually exclusive")
+		return withExitCode(ExitUsage, fmt.Errorf("--dot and --svg are mutually exclusive"))
 	}
 	if diffStatsOnly && (dotOutput || svgOutput) {
-		return fmt.Errorf("--stats cannot be combined with --dot or --svg")
+		return withExitCode(ExitUsage, fmt.Errorf("--stats cannot be combined with --dot or --svg"))
+	}
+	if err := validateEnrichSources(enrichSources); err != nil {
+		return err
 	}
 
 	baseRef := args[0]
@@ -181,29 +193,8 @@ func runDiff(cmd *cobra.Command, args []string) error {
 		vendorFilesFlag = false
 	}()
 
-	// Save current ref state to restore later.
-	originalRef, err := gitCurrentRefState()
-	if err != nil {
-		return fmt.Errorf("failed to get current git ref state: %w", err)
-	}
-	if dirty, err := gitWorkingTreeDirty(); err != nil {
-		return fmt.Errorf("failed to check working tree status: %w", err)
-	} else if dirty {
-		stashed, stashErr := gitStashPush()
-		if stashErr != nil {
-			return fmt.Errorf("working tree is dirty and automatic stash failed: %w", stashErr)
-		}
-		if stashed {
-			defer func() {
-				if popErr := gitStashPop(); popErr != nil {
-					fmt.Fprintf(os.Stderr, "warning: failed to restore stashed changes: %v\n", popErr)
-				}
-			}()
-		}
-	}
-
-	// Resolve symbolic refs (like HEAD, HEAD~1) to SHAs before any
-	// checkout, since checkout changes what HEAD points to.
+	// Resolve symbolic refs (like HEAD, HEAD~1) to SHAs, which the vendor
+	// diff reads files at.
 	baseSHA, err := gitResolveRef(baseRef)
 	if err != nil {
 		return fmt.Errorf("failed to resolve base ref: %w", err)
@@ -213,52 +204,58 @@ func runDiff(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to resolve head ref: %w", err)
 	}
 
-	// Ensure we restore the original state when done
-	defer func() {
-		if restoreErr := gitCheckout(originalRef); restoreErr != nil {
-			fmt.Fprintf(os.Stderr, "warning: failed to restore git ref %s: %v\n", originalRef, restoreErr)
-		}
-	}()
+	// Analyze both refs in temporary worktrees, as pr-check does, so the
+	// working tree and its uncommitted changes are never touched.
+	baseDir, cleanupBase, err := gitTempWorktree(baseSHA)
+	if err != nil {
+		return fmt.Errorf("failed to check out base ref %s: %w", baseRef, err)
+	}
+	defer cleanupBase()
+	headDir, cleanupHead, err := gitTempWorktree(headSHA)
+	if err != nil {
+		return fmt.Errorf("failed to check out head ref %s: %w", headRef, err)
+	}
+	defer cleanupHead()
+	if err := prefetchGraphs(graphSource{Dir: baseDir}, graphSource{Dir: headDir}); err != nil {
+		return err
+	}
 
 	// Analyze base ref
-	if err := gitCheckout(baseSHA); err != nil {
-		return fmt.Errorf("failed to checkout base ref %s: %w", baseRef, err)
-	}
 	excludeModules = diffExcludeModules
-	baseDepGraph := getDepInfo(mainModules)
+	baseDepGraph, err := graphSource{Dir: baseDir}.load(mainModules)
+	if err != nil {
+		return err
+	}
 	if len(baseDepGraph.MainModules) == 0 {
-		return fmt.Errorf("no main modules remain after exclusions; adjust --exclude-modules or --mainModules")
+		return errNoMainModules
 	}
 	baseStats := computeStats(baseDepGraph)
 	baseDeps := getAllDeps(baseDepGraph.DirectDepList, baseDepGraph.TransDepList)
 	baseEdges := getEdges(baseDepGraph.Graph)
 
-	// Classify test-only deps at base ref (while still checked out)
 	var baseTestOnly map[string]bool
 	if needClassification {
-		baseTestOnly, err = classifyTestDeps(baseDeps)
+		baseTestOnly, err = classifyTestDepsIn(baseDir, baseDeps)
 		if err != nil {
 			return fmt.Errorf("failed to classify base dependencies as test-only/non-test: %w", err)
 		}
 	}
 
 	// Analyze head ref
-	if err := gitCheckout(headSHA); err != nil {
-		return fmt.Errorf("failed to checkout head ref %s: %w", headRef, err)
+	headDepGraph, err := graphSource{Dir: headDir}.load(mainModules)
+	if err != nil {
+		return err
 	}
-	excludeModules = diffExcludeModules
-	headDepGraph := getDepInfo(mainModules)
 	if len(headDepGraph.MainModules) == 0 {
-		return fmt.Errorf("no main modules remain after exclusions; adjust --exclude-modules or --mainModules")
+		return errNoMainModules
 	}
 	headStats := computeStats(headDepGraph)
 	headDeps := getAllDeps(headDepGraph.DirectDepList, headDepGraph.TransDepList)
 	headEdges := getEdges(headDepGraph.Graph)
 
-	// Classify test-only deps at head ref (while still checked out)
 	var headTestOnly map[string]bool
 	if needClassification {
-		headTestOnly, err = classifyTestDeps(headDeps)
+		headTestOnly, err = classifyTestDepsIn(headDir, headDeps)
 		if err != nil {
 			return fmt.Errorf("failed to classify head dependencies as test-only/non-test: %w", err)
 		}
@@ -313,10 +310,17 @@ func runDiff(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if len(enrichSources) > 0 {
+		var warnings []string
+		result.LicenseChanges, warnings = findLicenseChanges(result.VersionChanges, enrichSources)
+		printEnrichWarnings(warnings)
+	}
+
 	result.Summary = DiffSummary{
 		AddedCount:          len(result.Added),
 		RemovedCount:        len(result.Removed),
 		VersionChangesCount: len(result.VersionChanges),
+		LicenseChangesCount: len(result.LicenseChanges),
 	}
 
 	// Vendor diff
@@ -324,7 +328,7 @@ func runDiff(cmd *cobra.Command, args []string) error {
 	if includeVendor {
 		vendor, vendorErr := computeVendorDiff(baseSHA, headSHA, vendorFilesFlag)
 		if vendorErr != nil {
-			fmt.Fprintf(os.Stderr, "Warning: vendor diff skipped: %v\n", vendorErr)
+			warnf("vendor diff skipped: %v\n", vendorErr)
 		} else {
 			vendor.VendorOnlyRemovals = computeVendorOnlyRemovals(vendor.Removed, result.Removed)
 			result.Vendor = vendor
@@ -415,17 +419,11 @@ func buildSplitResult(result DiffResult, beforeGraph, afterGraph *DependencyOver
 }
 
 func computeStats(depGraph *DependencyOverview) DiffStats {
-	maxDepth := 0
-	if len(depGraph.MainModules) > 0 {
-		var temp Chain
-		longestChain := getLongestChain(depGraph.MainModules[0], depGraph.Graph, temp, map[string]Chain{})
-		maxDepth = len(longestChain)
-	}
 	return DiffStats{
 		DirectDeps: len(depGraph.DirectDepList),
 		TransDeps:  len(depGraph.TransDepList),
 		TotalDeps:  len(getAllDeps(depGraph.DirectDepList, depGraph.TransDepList)),
-		MaxDepth:   maxDepth,
+		MaxDepth:   len(longestChainFromMains(depGraph)),
 	}
 }
 
@@ -461,6 +459,7 @@ func gitResolveRef(ref string) (string, error) {
 	if dir != "" {
 		cmd.Dir = dir
 	}
+	logCommand(cmd)
 	out, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("git rev-parse %s: %w", ref, err)
@@ -468,17 +467,76 @@ func gitResolveRef(ref string) (string, error) {
 	return strings.TrimSpace(string(out)), nil
 }
 
+// gitTempWorktree checks out ref into a temporary detached worktree and
+// returns the directory inside it that corresponds to --dir, along with a
+// function that removes the worktree. The worktree is created next to the
+// repository root, so replace directives pointing at relative directories
+// outside the repository resolve as they do in the checkout.
+func gitTempWorktree(ref string) (string, func(), error) {
+	commit, err := gitResolveRef(ref)
+	if err != nil {
+		return "", nil, err
+	}
+	locateCmd := exec.Command("git", "rev-parse", "--show-toplevel", "--show-prefix")
+	if dir != "" {
+		locateCmd.Dir = dir
+	}
+	logCommand(locateCmd)
+	out, err := locateCmd.Output()
+	if err != nil {
+		return "", nil, fmt.Errorf("git rev-parse --show-toplevel --show-prefix: %w", err)
+	}
+	lines := strings.SplitN(string(out), "\n", 3)
+	toplevel, prefix := lines[0], ""
+	if len(lines) > 1 {
+		prefix = lines[1]
+	}
+	tmp, err := os.MkdirTemp(filepath.Dir(toplevel), ".depstat-worktree-")
+	if err != nil {
+		warnf("cannot create a worktree next to %s (%v); relative replace directives leaving the repository will not resolve\n", toplevel, err)
+		if tmp, err = os.MkdirTemp("", "depstat-worktree-"); err != nil {
+			return "", nil, err
+		}
+	}
+	add := exec.Command("git", "worktree", "add", "--detach", tmp, commit)
+	if dir != "" {
+		add.Dir = dir
+	}
+	logCommand(add)
+	add.Stdout = io.Discard
+	var stderr bytes.Buffer
+	add.Stderr = &stderr
+	if err := add.Run(); err != nil {
+		os.RemoveAll(tmp)
+		return "", nil, fmt.Errorf("git worktree add %s: %w: %s", ref, err, strings.TrimSpace(stderr.String()))
+	}
+	cleanup := func() {
+		remove := exec.Command("git", "worktree", "remove", "--force", tmp)
+		if dir != "" {
+			remove.Dir = dir
+		}
+		logCommand(remove)
+		if err := remove.Run(); err != nil {
+			warnf("failed to remove worktree %s: %v\n", tmp, err)
+		}
+		os.RemoveAll(tmp)
+	}
+	return filepath.Join(tmp, prefix), cleanup, nil
+}
+
 func gitCurrentRef() (string, error) {
 	cmd := exec.Command("git", "symbolic-ref", "-q", "HEAD")
 	if dir != "" {
 		cmd.Dir = dir
 	}
+	logCommand(cmd)
 	out, err := cmd.Output()
 	if err != nil {
 		detached := exec.Command("git", "rev-parse", "HEAD")
 		if dir != "" {
 			detached.Dir = dir
 		}
+		logCommand(detached)
 		out, err = detached.Output()
 		if err != nil {
 			return "", err
@@ -487,22 +545,12 @@ func gitCurrentRef() (string, error) {
 	return strings.TrimSpace(string(out)), nil
 }
 
-func gitCurrentRefState() (string, error) {
-	ref, err := gitCurrentRef()
-	if err != nil {
-		return "", err
-	}
-	if strings.HasPrefix(ref, "refs/heads/") {
-		return strings.TrimPrefix(ref, "refs/heads/"), nil
-	}
-	return ref, nil
-}
-
 func gitWorkingTreeDirty() (bool, error) {
 	cmd := exec.Command("git", "status", "--porcelain", "--untracked-files=no")
 	if dir != "" {
 		cmd.Dir = dir
 	}
+	logCommand(cmd)
 	out, err := cmd.Output()
 	if err != nil {
 		return false, err
@@ -510,52 +558,6 @@ func gitWorkingTreeDirty() (bool, error) {
 	return strings.TrimSpace(string(out)) != "", nil
 }
 
-func gitStashRef() string {
-	cmd := exec.Command("git", "rev-parse", "-q", "--verify", "refs/stash")
-	if dir != "" {
-		cmd.Dir = dir
-	}
-	out, err := cmd.Output()
-	if err != nil {
-		return ""
-	}
-	return strings.TrimSpace(string(out))
-}
-
-func gitStashPush() (bool, error) {
-	before := gitStashRef()
-	cmd := exec.Command("git", "stash", "push", "-m", "depstat diff temporary stash")
-	if dir != "" {
-		cmd.Dir = dir
-	}
-	cmd.Stdout = io.Discard
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		return false, err
-	}
-	after := gitStashRef()
-	return before != after && after != "", nil
-}
-
-func gitStashPop() error {
-	cmd := exec.Command("git", "stash", "pop", "-q")
-	if dir != "" {
-		cmd.Dir = dir
-	}
-	cmd.Stdout = io.Discard
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
-}
-
-func gitCheckout(ref string) error {
-	cmd := exec.Command("git", "checkout", "-q", ref)
-	if dir != "" {
-		cmd.Dir = dir
-	}
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
-}
-
 func outputJSON(result DiffResult) error {
 	out, err := json.MarshalIndent(result, "", "\t")
 	if err != nil {
@@ -578,10 +580,10 @@ func outputStatsText(result DiffResult) error {
 	fmt.Println("┌────────────────────┬──────────┬──────────┬─────────┐")
 	fmt.Println("│ Metric             │  Before  │  After   │  Delta  │")
 	fmt.Println("├────────────────────┼──────────┼──────────┼─────────┤")
-	fmt.Printf("│ Direct Deps        │ %8d │ %8d │ %+7d │\n", result.Before.DirectDeps, result.After.DirectDeps, result.Delta.DirectDeps)
-	fmt.Printf("│ Transitive Deps    │ %8d │ %8d │ %+7d │\n", result.Before.TransDeps, result.After.TransDeps, result.Delta.TransDeps)
-	fmt.Printf("│ Total Deps         │ %8d │ %8d │ %+7d │\n", result.Before.TotalDeps, result.After.TotalDeps, result.Delta.TotalDeps)
-	fmt.Printf("│ Max Depth          │ %8d │ %8d │ %+7d │\n", result.Before.MaxDepth, result.After.MaxDepth, result.Delta.MaxDepth)
+	fmt.Printf("│ Direct Deps        │ %8d │ %8d │ %s │\n", result.Before.DirectDeps, result.After.DirectDeps, colorDelta(fmt.Sprintf("%+7d", result.Delta.DirectDeps), result.Delta.DirectDeps))
+	fmt.Printf("│ Transitive Deps    │ %8d │ %8d │ %s │\n", result.Before.TransDeps, result.After.TransDeps, colorDelta(fmt.Sprintf("%+7d", result.Delta.TransDeps), result.Delta.TransDeps))
+	fmt.Printf("│ Total Deps         │ %8d │ %8d │ %s │\n", result.Before.TotalDeps, result.After.TotalDeps, colorDelta(fmt.Sprintf("%+7d", result.Delta.TotalDeps), result.Delta.TotalDeps))
+	fmt.Printf("│ Max Depth          │ %8d │ %8d │ %s │\n", result.Before.MaxDepth, result.After.MaxDepth, colorDelta(fmt.Sprintf("%+7d", result.Delta.MaxDepth), result.Delta.MaxDepth))
 	fmt.Println("└────────────────────┴──────────┴──────────┴─────────┘")
 	fmt.Println()
 
@@ -590,9 +592,9 @@ func outputStatsText(result DiffResult) error {
 		fmt.Println("┌────────────────────┬──────────┬──────────┬─────────┐")
 		fmt.Println("│ Metric             │  Before  │  After   │  Delta  │")
 		fmt.Println("├────────────────────┼──────────┼──────────┼─────────┤")
-		fmt.Printf("│ Direct Deps        │ %8d │ %8d │ %+7d │\n", result.FilteredBefore.DirectDeps, result.FilteredAfter.DirectDeps, result.FilteredDelta.DirectDeps)
-		fmt.Printf("│ Transitive Deps    │ %8d │ %8d │ %+7d │\n", result.FilteredBefore.TransDeps, result.FilteredAfter.TransDeps, result.FilteredDelta.TransDeps)
-		fmt.Printf("│ Total Deps         │ %8d │ %8d │ %+7d │\n", result.FilteredBefore.TotalDeps, result.FilteredAfter.TotalDeps, result.FilteredDelta.TotalDeps)
+		fmt.Printf("│ Direct Deps        │ %8d │ %8d │ %s │\n", result.FilteredBefore.DirectDeps, result.FilteredAfter.DirectDeps, colorDelta(fmt.Sprintf("%+7d", result.FilteredDelta.DirectDeps), result.FilteredDelta.DirectDeps))
+		fmt.Printf("│ Transitive Deps    │ %8d │ %8d │ %s │\n", result.FilteredBefore.TransDeps, result.FilteredAfter.TransDeps, colorDelta(fmt.Sprintf("%+7d", result.FilteredDelta.TransDeps), result.FilteredDelta.TransDeps))
+		fmt.Printf("│ Total Deps         │ %8d │ %8d │ %s │\n", result.FilteredBefore.TotalDeps, result.FilteredAfter.TotalDeps, colorDelta(fmt.Sprintf("%+7d", result.FilteredDelta.TotalDeps), result.FilteredDelta.TotalDeps))
 		fmt.Println("└────────────────────┴──────────┴──────────┴─────────┘")
 		fmt.Println()
 	}
@@ -625,11 +627,9 @@ func outputStatsJSON(result DiffResult) error {
 		FilteredDelta:  result.FilteredDelta,
 		Split:          result.Split,
 	}
-	outputRaw, err := json.MarshalIndent(outputObj, "", "\t")
-	if err != nil {
+	if err := writeJSON(os.Stdout, outputObj); err != nil {
 		return err
 	}
-	fmt.Print(string(outputRaw))
 	return nil
 }
 
@@ -649,10 +649,10 @@ func outputText(result DiffResult) error {
 	fmt.Println("┌────────────────────┬──────────┬──────────┬─────────┐")
 	fmt.Println("│ Metric             │  Before  │  After   │  Delta  │")
 	fmt.Println("├────────────────────┼──────────┼──────────┼─────────┤")
-	fmt.Printf("│ Direct Deps        │ %8d │ %8d │ %+7d │\n", result.Before.DirectDeps, result.After.DirectDeps, result.Delta.DirectDeps)
-	fmt.Printf("│ Transitive Deps    │ %8d │ %8d │ %+7d │\n", result.Before.TransDeps, result.After.TransDeps, result.Delta.TransDeps)
-	fmt.Printf("│ Total Deps         │ %8d │ %8d │ %+7d │\n", result.Before.TotalDeps, result.After.TotalDeps, result.Delta.TotalDeps)
-	fmt.Printf("│ Max Depth          │ %8d │ %8d │ %+7d │\n", result.Before.MaxDepth, result.After.MaxDepth, result.Delta.MaxDepth)
+	fmt.Printf("│ Direct Deps        │ %8d │ %8d │ %s │\n", result.Before.DirectDeps, result.After.DirectDeps, colorDelta(fmt.Sprintf("%+7d", result.Delta.DirectDeps), result.Delta.DirectDeps))
+	fmt.Printf("│ Transitive Deps    │ %8d │ %8d │ %s │\n", result.Before.TransDeps, result.After.TransDeps, colorDelta(fmt.Sprintf("%+7d", result.Delta.TransDeps), result.Delta.TransDeps))
+	fmt.Printf("│ Total Deps         │ %8d │ %8d │ %s │\n", result.Before.TotalDeps, result.After.TotalDeps, colorDelta(fmt.Sprintf("%+7d", result.Delta.TotalDeps), result.Delta.TotalDeps))
+	fmt.Printf("│ Max Depth          │ %8d │ %8d │ %s │\n", result.Before.MaxDepth, result.After.MaxDepth, colorDelta(fmt.Sprintf("%+7d", result.Delta.MaxDepth), result.Delta.MaxDepth))
 	fmt.Println("└────────────────────┴──────────┴──────────┴─────────┘")
 	fmt.Println()
 
@@ -661,9 +661,9 @@ func outputText(result DiffResult) error {
 		fmt.Println("┌────────────────────┬──────────┬──────────┬─────────┐")
 		fmt.Println("│ Metric             │  Before  │  After   │  Delta  │")
 		fmt.Println("├────────────────────┼──────────┼──────────┼─────────┤")
-		fmt.Printf("│ Direct Deps        │ %8d │ %8d │ %+7d │\n", result.FilteredBefore.DirectDeps, result.FilteredAfter.DirectDeps, result.FilteredDelta.DirectDeps)
-		fmt.Printf("│ Transitive Deps    │ %8d │ %8d │ %+7d │\n", result.FilteredBefore.TransDeps, result.FilteredAfter.TransDeps, result.FilteredDelta.TransDeps)
-		fmt.Printf("│ Total Deps         │ %8d │ %8d │ %+7d │\n", result.FilteredBefore.TotalDeps, result.FilteredAfter.TotalDeps, result.FilteredDelta.TotalDeps)
+		fmt.Printf("│ Direct Deps        │ %8d │ %8d │ %s │\n", result.FilteredBefore.DirectDeps, result.FilteredAfter.DirectDeps, colorDelta(fmt.Sprintf("%+7d", result.FilteredDelta.DirectDeps), result.FilteredDelta.DirectDeps))
+		fmt.Printf("│ Transitive Deps    │ %8d │ %8d │ %s │\n", result.FilteredBefore.TransDeps, result.FilteredAfter.TransDeps, colorDelta(fmt.Sprintf("%+7d", result.FilteredDelta.TransDeps), result.FilteredDelta.TransDeps))
+		fmt.Printf("│ Total Deps         │ %8d │ %8d │ %s │\n", result.FilteredBefore.TotalDeps, result.FilteredAfter.TotalDeps, colorDelta(fmt.Sprintf("%+7d", result.FilteredDelta.TotalDeps), result.FilteredDelta.TotalDeps))
 		fmt.Println("└────────────────────┴──────────┴──────────┴─────────┘")
 		fmt.Println()
 	}
@@ -681,7 +681,7 @@ func outputText(result DiffResult) error {
 		fmt.Println("  (none)")
 	} else {
 		for _, dep := range result.Added {
-			fmt.Printf("  + %s\n", dep)
+			fmt.Printf("  %s\n", colorize(ansiGreen, "+ "+dep))
 		}
 	}
 	fmt.Println()
@@ -692,7 +692,7 @@ func outputText(result DiffResult) error {
 		fmt.Println("  (none)")
 	} else {
 		for _, dep := range result.Removed {
-			fmt.Printf("  - %s\n", dep)
+			fmt.Printf("  %s\n", colorize(ansiRed, "- "+dep))
 		}
 	}
 	fmt.Println()
@@ -705,18 +705,19 @@ func outputText(result DiffResult) error {
 		}
 		fmt.Println()
 	}
+	printLicenseChanges(result.LicenseChanges)
 
 	// Edge changes (verbose only)
 	if verbose {
 		fmt.Printf("Edges Added (%d):\n", len(result.EdgesAdded))
 		for _, edge := range result.EdgesAdded {
-			fmt.Printf("  + %s\n", edge)
+			fmt.Printf("  %s\n", colorize(ansiGreen, "+ "+edge))
 		}
 		fmt.Println()
 
 		fmt.Printf("Edges Removed (%d):\n", len(result.EdgesRemoved))
 		for _, edge := range result.EdgesRemoved {
-			fmt.Printf("  - %s\n", edge)
+			fmt.Printf("  %s\n", colorize(ansiRed, "- "+edge))
 		}
 		fmt.Println()
 	}
@@ -728,7 +729,7 @@ func outputText(result DiffResult) error {
 		fmt.Println("┌────────────────────┬──────────┬──────────┬─────────┐")
 		fmt.Println("│ Metric             │  Before  │  After   │  Delta  │")
 		fmt.Println("├────────────────────┼──────────┼──────────┼─────────┤")
-		fmt.Printf("│ Vendored Modules   │ %8d │ %8d │ %+7d │\n", v.BeforeCount, v.AfterCount, v.DeltaCount)
+		fmt.Printf("│ Vendored Modules   │ %8d │ %8d │ %s │\n", v.BeforeCount, v.AfterCount, colorDelta(fmt.Sprintf("%+7d", v.DeltaCount), v.DeltaCount))
 		fmt.Println("└────────────────────┴──────────┴──────────┴─────────┘")
 		fmt.Println()
 
@@ -793,9 +794,9 @@ func printSplitSection(title string, sec DiffFilteredSection) {
 	fmt.Println("┌────────────────────┬──────────┬──────────┬─────────┐")
 	fmt.Println("│ Metric             │  Before  │  After   │  Delta  │")
 	fmt.Println("├────────────────────┼──────────┼──────────┼─────────┤")
-	fmt.Printf("│ Direct Deps        │ %8d │ %8d │ %+7d │\n", sec.Before.DirectDeps, sec.After.DirectDeps, sec.Delta.DirectDeps)
-	fmt.Printf("│ Transitive Deps    │ %8d │ %8d │ %+7d │\n", sec.Before.TransDeps, sec.After.TransDeps, sec.Delta.TransDeps)
-	fmt.Printf("│ Total Deps         │ %8d │ %8d │ %+7d │\n", sec.Before.TotalDeps, sec.After.TotalDeps, sec.Delta.TotalDeps)
+	fmt.Printf("│ Direct Deps        │ %8d │ %8d │ %s │\n", sec.Before.DirectDeps, sec.After.DirectDeps, colorDelta(fmt.Sprintf("%+7d", sec.Delta.DirectDeps), sec.Delta.DirectDeps))
+	fmt.Printf("│ Transitive Deps    │ %8d │ %8d │ %s │\n", sec.Before.TransDeps, sec.After.TransDeps, colorDelta(fmt.Sprintf("%+7d", sec.Delta.TransDeps), sec.Delta.TransDeps))
+	fmt.Printf("│ Total Deps         │ %8d │ %8d │ %s │\n", sec.Before.TotalDeps, sec.After.TotalDeps, colorDelta(fmt.Sprintf("%+7d", sec.Delta.TotalDeps), sec.Delta.TotalDeps))
 	fmt.Println("└────────────────────┴──────────┴──────────┴─────────┘")
 	fmt.Printf("Added (%d)\n", len(sec.Added))
 	fmt.Printf("Removed (%d)\n", len(sec.Removed))
@@ -811,6 +812,13 @@ func outputDOT(result DiffResult, baseGraph, headGraph *DependencyOverview) erro
 	fmt.Println("node [shape=box, style=filled, fillcolor=white, fontsize=11];")
 	fmt.Println("edge [fontsize=9];")
 	fmt.Println()
+	fmt.Println("subgraph cluster_legend {")
+	fmt.Println("label=\"Legend\"; fontsize=10; style=dashed;")
+	fmt.Println("\"legend: added\" [label=\"added\", fillcolor=\"#ccffcc\"];")
+	fmt.Println("\"legend: removed\" [label=\"removed\", fillcolor=\"#ffcccc\", style=\"filled,dashed\"];")
+	fmt.Println("\"legend: changed\" [label=\"version changed\", fillcolor=\"#ffd591\"];")
+	fmt.Println("}")
+	fmt.Println()
 
 	// Build version change lookup
 	versionChangeMap := make(map[string]VersionChange)
@@ -949,6 +957,25 @@ func outputDOT(result DiffResult, baseGraph, headGraph *DependencyOverview) erro
 	}
 	mainModuleEdges = dedupedMainEdges
 
+	// Connect version-changed modules to their visible or main-module
+	// parents in the head graph.
+	var changedEdges []string
+	for _, vc := range result.VersionChanges {
+		for _, parent := range sortedCopy(graphParents(headGraph.Graph, vc.Path)) {
+			edge := parent + " -> " + vc.Path
+			if reducedEdgeSet[edge] {
+				continue
+			}
+			if changedNodes[parent] == "" {
+				if !isMainModule[parent] {
+					continue
+				}
+				changedNodes[parent] = "main"
+			}
+			changedEdges = append(changedEdges, edge)
+		}
+	}
+
 	// Output nodes with colors
 	fmt.Println("// Nodes")
 	var nodeNames []string
@@ -969,7 +996,7 @@ func outputDOT(result DiffResult, baseGraph, headGraph *DependencyOverview) erro
 			color = "#ffcccc" // red
 			style = "filled,dashed"
 		case "changed":
-			color = "#ffffcc" // yellow
+			color = "#ffd591" // amber
 			if vc, ok := versionChangeMap[node]; ok {
 				label = fmt.Sprintf("%s\\n%s → %s", node, vc.Before, vc.After)
 			}
@@ -1004,6 +1031,15 @@ func outputDOT(result DiffResult, baseGraph, headGraph *DependencyOverview) erro
 		fmt.Println()
 	}
 
+	if len(changedEdges) > 0 {
+		fmt.Println("// Version-changed edges")
+		for _, edge := range changedEdges {
+			parts := strings.Split(edge, " -> ")
+			fmt.Printf("\"%s\" -> \"%s\" [color=\"#f0a020\"];\n", parts[0], parts[1])
+		}
+		fmt.Println()
+	}
+
 	if len(edgesAdded) > 0 {
 		fmt.Println("// Added edges")
 		for _, edge := range edgesAdded {
@@ -1182,6 +1218,35 @@ func computeVersionChanges(base, head *DependencyOverview) []VersionChange {
 	return changes
 }
 
+// graphParents returns the modules requiring mod.
+func graphParents(graph map[string][]string, mod string) []string {
+	var parents []string
+	for from, tos := range graph {
+		if contains(tos, mod) {
+			parents = append(parents, from)
+		}
+	}
+	return parents
+}
+
+// graphDiffResult returns the module and edge changes between two loaded
+// graphs, as rendered by outputDOT.
+func graphDiffResult(base, head *DependencyOverview, baseLabel, headLabel string) DiffResult {
+	baseDeps := getAllDeps(base.DirectDepList, base.TransDepList)
+	headDeps := getAllDeps(head.DirectDepList, head.TransDepList)
+	baseEdges := getEdges(base.Graph)
+	headEdges := getEdges(head.Graph)
+	return DiffResult{
+		BaseRef:        baseLabel,
+		HeadRef:        headLabel,
+		Added:          diffSlices(baseDeps, headDeps),
+		Removed:        diffSlices(headDeps, baseDeps),
+		EdgesAdded:     diffSlices(baseEdges, headEdges),
+		EdgesRemoved:   diffSlices(headEdges, baseEdges),
+		VersionChanges: computeVersionChanges(base, head),
+	}
+}
+
 // filterVersionChangesByTestStatus filters version changes by test-only status.
 func filterVersionChangesByTestStatus(changes []VersionChange, testOnlySet map[string]bool, wantTestOnly bool) []VersionChange {
 	var filtered []VersionChange
@@ -1331,5 +1396,7 @@ func init() {
 	_ = diffCmd.Flags().MarkDeprecated("non-test-only", "use --split-test-only and read split.nonTestOnly")
 	diffCmd.Flags().BoolVar(&vendorFlag, "vendor", false, "Include vendor-level diff using vendor/modules.txt")
 	diffCmd.Flags().BoolVar(&vendorFilesFlag, "vendor-files", false, "Report added/deleted Go files in vendor/ (implies --vendor)")
+	diffCmd.Flags().StringSliceVar(&enrichSources, "enrich", []string{}, "Detect license changes of modules selected at another version (needs depsdev; supported: depsdev, github, osv, proxy)")
+	diffCmd.Flags().StringVar(&enrichCacheDir, "enrich-cache-dir", "", "Directory for cached enrichment data. Defaults to the user cache directory.")
 	diffCmd.Flags().StringSliceVar(&diffExcludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
 }
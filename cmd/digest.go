@@ -0,0 +1,207 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// digestOutput and inTotoFile are set by --digest and --in-toto.
+var digestOutput bool
+var inTotoFile string
+
+const inTotoPredicateType = "https://github.com/kubernetes-sigs/depstat/result/v1"
+
+// digestCapture tees stdout into a buffer while a command runs, so the
+// digest covers exactly the bytes the command printed.
+type digestCapture struct {
+	cmd    *cobra.Command
+	stdout *os.File
+	w      *os.File
+	buf    bytes.Buffer
+	done   chan struct{}
+}
+
+var activeDigestCapture *digestCapture
+
+// inTotoStatement is an in-toto v1 statement about a depstat JSON result.
+type inTotoStatement struct {
+	Type          string          `json:"_type"`
+	Subject       []inTotoSubject `json:"subject"`
+	PredicateType string          `json:"predicateType"`
+	Predicate     inTotoPredicate `json:"predicate"`
+}
+
+type inTotoSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+type inTotoPredicate struct {
+	Command        []string       `json:"command"`
+	DepstatVersion string         `json:"depstatVersion"`
+	CreatedAt      time.Time      `json:"createdAt"`
+	GoEnv          *GoEnvironment `json:"goEnv,omitempty"`
+	Git            *GitMetadata   `json:"git,omitempty"`
+}
+
+var digestCmd = &cobra.Command{
+	Use:   "digest <file.json>",
+	Short: "Print the canonical SHA-256 digest of a stored JSON result",
+	Long: `Recomputes the digest printed by --digest for a stored JSON result, so a
+report can be checked against the digest or in-toto statement recorded when it
+was produced. The JSON is canonicalized first: object keys sorted, no
+insignificant whitespace, numbers kept as written. Use - to read stdin.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return withExitCode(ExitUsage, fmt.Errorf("digest takes exactly one file argument"))
+		}
+		var data []byte
+		var err error
+		if args[0] == "-" {
+			data, err = io.ReadAll(cmd.InOrStdin())
+		} else {
+			data, err = os.ReadFile(args[0])
+		}
+		if err != nil {
+			return err
+		}
+		sum, err := canonicalJSONDigest(data)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "sha256:%s\n", sum)
+		return nil
+	},
+}
+
+// startDigestCapture redirects stdout through a pipe for --digest and
+// --in-toto. It is a no-op when neither is set.
+func startDigestCapture(cmd *cobra.Command) error {
+	if !digestOutput && inTotoFile == "" {
+		return nil
+	}
+	if !jsonFlagSet(cmd) {
+		return withExitCode(ExitUsage, fmt.Errorf("--digest and --in-toto need JSON output; pass --json"))
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	c := &digestCapture{cmd: cmd, stdout: os.Stdout, w: w, done: make(chan struct{})}
+	go func() {
+		_, _ = io.Copy(io.MultiWriter(c.stdout, &c.buf), r)
+		r.Close()
+		close(c.done)
+	}()
+	os.Stdout = w
+	activeDigestCapture = c
+	return nil
+}
+
+// finishDigestCapture restores stdout, then prints the digest of what the
+// command wrote and writes the in-toto statement.
+func finishDigestCapture() error {
+	c := activeDigestCapture
+	if c == nil {
+		return nil
+	}
+	activeDigestCapture = nil
+	c.w.Close()
+	<-c.done
+	os.Stdout = c.stdout
+
+	if len(bytes.TrimSpace(c.buf.Bytes())) == 0 {
+		return fmt.Errorf("--digest: nothing was written to stdout; the digest covers stdout only, so drop --output")
+	}
+	sum, err := canonicalJSONDigest(c.buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("--digest: %w", err)
+	}
+	if digestOutput {
+		fmt.Fprintf(os.Stderr, "digest: sha256:%s\n", sum)
+	}
+	if inTotoFile == "" {
+		return nil
+	}
+	statement := inTotoStatement{
+		Type:          "https://in-toto.io/Statement/v1",
+		Subject:       []inTotoSubject{{Name: "depstat-" + c.cmd.Name() + ".json", Digest: map[string]string{"sha256": sum}}},
+		PredicateType: inTotoPredicateType,
+		Predicate: inTotoPredicate{
+			Command:        os.Args,
+			DepstatVersion: rootCmd.Version,
+			CreatedAt:      time.Now().UTC(),
+			GoEnv:          goEnvironmentForOutput(),
+			Git:            gitMetadataForOutput(),
+		},
+	}
+	f, err := os.Create(inTotoFile)
+	if err != nil {
+		return err
+	}
+	if err := writeJSON(f, statement); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// canonicalJSONDigest returns the hex SHA-256 of the canonical form of a
+// single JSON document.
+func canonicalJSONDigest(data []byte) (string, error) {
+	canonical, err := canonicalJSON(data)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// canonicalJSON re-encodes a JSON document with sorted object keys, no
+// insignificant whitespace and no HTML escaping. Numbers keep their text.
+func canonicalJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("output is not a JSON document: %w", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("output is not a single JSON document")
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func init() {
+	rootCmd.AddCommand(digestCmd)
+}
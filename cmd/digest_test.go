@@ -0,0 +1,32 @@
+package cmd
+
+import "testing"
+
+func TestCanonicalJSON(t *testing.T) {
+	got, err := canonicalJSON([]byte("{\n\t\"b\": [1.50, \"<x>\"],\n\t\"a\": {\"d\": null, \"c\": true}\n}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"a":{"c":true,"d":null},"b":[1.50,"<x>"]}`
+	if string(got) != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	a, err := canonicalJSONDigest([]byte(`{"x": 1, "y": 2}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := canonicalJSONDigest([]byte("{\"y\":2,\n\"x\":1}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != b {
+		t.Errorf("digests of equivalent documents differ: %s != %s", a, b)
+	}
+
+	for _, bad := range []string{"", "{", "{} {}"} {
+		if _, err := canonicalJSON([]byte(bad)); err == nil {
+			t.Errorf("canonicalJSON(%q) succeeded, want error", bad)
+		}
+	}
+}
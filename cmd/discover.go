@@ -0,0 +1,259 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// statsDiscover is set by stats --discover.
+var statsDiscover bool
+
+// DiscoveredModule is a module found by --discover and its own stats.
+type DiscoveredModule struct {
+	Module string         `json:"module"`
+	Dir    string         `json:"dir"`
+	Stats  *StatsSnapshot `json:"stats"`
+}
+
+// DiscoverResult holds per-module stats for every discovered module and the
+// stats of their combined dependency set.
+type DiscoverResult struct {
+	Modules  []DiscoveredModule `json:"modules"`
+	Combined StatsSnapshot      `json:"combined"`
+	GoEnv    *GoEnvironment     `json:"goEnv,omitempty"`
+	Git      *GitMetadata       `json:"git,omitempty"`
+}
+
+// discoverModuleDirs returns the directories below baseDir containing a
+// go.mod file, skipping hidden, vendor and testdata directories. Inside a
+// git checkout the files known to git, plus untracked files not ignored by
+// .gitignore, are used; otherwise the tree is walked.
+func discoverModuleDirs(baseDir string) ([]string, error) {
+	c := exec.Command("git", "ls-files", "-z", "--cached", "--others", "--exclude-standard", "--", "go.mod", "**/go.mod")
+	c.Dir = baseDir
+	logCommand(c)
+	if out, err := c.Output(); err == nil {
+		var dirs []string
+		for _, f := range bytes.Split(out, []byte{0}) {
+			if len(f) == 0 || filepath.Base(string(f)) != "go.mod" {
+				continue
+			}
+			if skippedModulePath(path.Dir(string(f))) {
+				continue
+			}
+			dirs = append(dirs, filepath.Join(baseDir, filepath.Dir(filepath.FromSlash(string(f)))))
+		}
+		return uniqueStrings(dirs), nil
+	}
+	debugf("%s is not a git checkout, walking the directory tree\n", baseDir)
+
+	var dirs []string
+	err := filepath.WalkDir(baseDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != baseDir && skippedModuleDir(d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() == "go.mod" {
+			dirs = append(dirs, filepath.Dir(path))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(dirs)
+	return dirs, nil
+}
+
+// skippedModuleDir reports whether discovery skips the directory name.
+func skippedModuleDir(name string) bool {
+	return strings.HasPrefix(name, ".") || name == "vendor" || name == "testdata"
+}
+
+// skippedModulePath reports whether the slash-separated path, relative to
+// the discovery root, is inside a directory discovery skips.
+func skippedModulePath(rel string) bool {
+	if rel == "." {
+		return false
+	}
+	for _, name := range strings.Split(rel, "/") {
+		if skippedModuleDir(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// computeDiscoverStats loads the graph of every module found below the
+// --dir directory, with that module as its only main module.
+func computeDiscoverStats(excludes []string) (*DiscoverResult, error) {
+	baseDir := dir
+	if baseDir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		baseDir = wd
+	}
+	dirs, err := discoverModuleDirs(baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("discovering modules: %w", err)
+	}
+
+	oldExcludes := excludeModules
+	excludeModules = excludes
+	defer func() {
+		excludeModules = oldExcludes
+	}()
+	result := &DiscoverResult{}
+	graphs := []*DependencyOverview{}
+	for _, modDir := range dirs {
+		modPath, err := modulePathFromDir(modDir)
+		if err != nil {
+			return nil, err
+		}
+		if moduleExcluded(modPath, excludes) {
+			continue
+		}
+		depGraph, err := graphSource{Dir: modDir}.load([]string{modPath})
+		if err != nil {
+			return nil, err
+		}
+		rel, err := filepath.Rel(baseDir, modDir)
+		if err != nil {
+			rel = modDir
+		}
+		result.Modules = append(result.Modules, DiscoveredModule{
+			Module: modPath,
+			Dir:    filepath.ToSlash(rel),
+			Stats:  snapshotFromGraph(depGraph),
+		})
+		graphs = append(graphs, depGraph)
+	}
+	if len(result.Modules) == 0 {
+		return nil, withExitCode(ExitNotFound, fmt.Errorf("no go.mod files found under %s", baseDir))
+	}
+	result.Combined = combineSnapshots(result.Modules, graphs)
+	return result, nil
+}
+
+// combineSnapshots counts the union of the discovered modules' dependencies
+// as snapshotFromGraph counts those of one module: a module direct for any
+// of them is direct, transitive counts every module reached, direct ones
+// included, and total the union of both. Discovered modules depending on
+// each other are not counted.
+func combineSnapshots(modules []DiscoveredModule, graphs []*DependencyOverview) StatsSnapshot {
+	isMain := make(map[string]bool)
+	var mains []string
+	for _, m := range modules {
+		isMain[m.Module] = true
+		mains = append(mains, m.Module)
+	}
+	direct := make(map[string]bool)
+	trans := make(map[string]bool)
+	combined := StatsSnapshot{MainModules: mains}
+	for i, g := range graphs {
+		for _, d := range g.DirectDepList {
+			if !isMain[d] {
+				direct[d] = true
+			}
+		}
+		for _, d := range g.TransDepList {
+			if !isMain[d] {
+				trans[d] = true
+			}
+		}
+		if modules[i].Stats.MaxDepth > combined.MaxDepth {
+			combined.MaxDepth = modules[i].Stats.MaxDepth
+		}
+	}
+	total := make(map[string]bool, len(trans))
+	for d := range direct {
+		total[d] = true
+	}
+	for d := range trans {
+		total[d] = true
+	}
+	combined.DirectDeps = len(direct)
+	combined.TransDeps = len(trans)
+	combined.TotalDeps = len(total)
+	return combined
+}
+
+// writeDiscoverCSV writes a row per discovered module and a last
+// "(combined)" row.
+func writeDiscoverCSV(out io.Writer, result *DiscoverResult) error {
+	w := csv.NewWriter(out)
+	if err := w.Write([]string{"Module", "Dir", "Direct", "Transitive", "Total", "MaxDepth"}); err != nil {
+		return err
+	}
+	row := func(module, dir string, s StatsSnapshot) []string {
+		return []string{module, dir, strconv.Itoa(s.DirectDeps), strconv.Itoa(s.TransDeps), strconv.Itoa(s.TotalDeps), strconv.Itoa(s.MaxDepth)}
+	}
+	for _, m := range result.Modules {
+		if err := w.Write(row(m.Module, m.Dir, *m.Stats)); err != nil {
+			return err
+		}
+	}
+	if err := w.Write(row("(combined)", "", result.Combined)); err != nil {
+		return err
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func renderDiscoverStats(result *DiscoverResult) error {
+	if jsonOutput {
+		result.GoEnv = goEnvironmentForOutput()
+		result.Git = gitMetadataForOutput()
+		return writeJSON(os.Stdout, result)
+	}
+	if csvOutput {
+		return writeDiscoverCSV(os.Stdout, result)
+	}
+	fmt.Printf("Discovered %d modules:\n", len(result.Modules))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  Module\tDir\tDirect\tTransitive\tTotal\tMax Depth")
+	for _, m := range result.Modules {
+		fmt.Fprintf(w, "  %s\t%s\t%d\t%d\t%d\t%d\n", m.Module, m.Dir, m.Stats.DirectDeps, m.Stats.TransDeps, m.Stats.TotalDeps, m.Stats.MaxDepth)
+	}
+	_ = w.Flush()
+	c := result.Combined
+	fmt.Println("Combined:")
+	fmt.Printf("Direct Dependencies: %d \n", c.DirectDeps)
+	fmt.Printf("Transitive Dependencies: %d \n", c.TransDeps)
+	fmt.Printf("Total Dependencies: %d \n", c.TotalDeps)
+	fmt.Printf("Max Depth Of Dependencies: %d \n", c.MaxDepth)
+	return nil
+}
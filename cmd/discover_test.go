@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDiscoverModuleDirsWalk(t *testing.T) {
+	base := t.TempDir()
+	for _, d := range []string{".", "a", "a/b", "vendor/x", ".hidden", "c/testdata/m"} {
+		if err := os.MkdirAll(filepath.Join(base, d), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(base, d, "go.mod"), []byte("module example.com/"+d+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := discoverModuleDirs(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{base, filepath.Join(base, "a"), filepath.Join(base, "a/b")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDiscoverModuleDirsGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	base := t.TempDir()
+	for _, d := range []string{".", "a", "vendor/x", ".hidden", "c/testdata/m"} {
+		if err := os.MkdirAll(filepath.Join(base, d), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(base, d, "go.mod"), []byte("module example.com/"+d+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if out, err := exec.Command("git", "init", "-q", base).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	got, err := discoverModuleDirs(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{base, filepath.Join(base, "a")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestWriteDiscoverCSV(t *testing.T) {
+	result := &DiscoverResult{
+		Modules:  []DiscoveredModule{{Module: "example.com/a", Dir: "a,b", Stats: &StatsSnapshot{DirectDeps: 1, TransDeps: 2, TotalDeps: 3, MaxDepth: 2}}},
+		Combined: StatsSnapshot{DirectDeps: 1, TransDeps: 2, TotalDeps: 3, MaxDepth: 2},
+	}
+	var buf bytes.Buffer
+	if err := writeDiscoverCSV(&buf, result); err != nil {
+		t.Fatal(err)
+	}
+	want := "Module,Dir,Direct,Transitive,Total,MaxDepth\nexample.com/a,\"a,b\",1,2,3,2\n(combined),,1,2,3,2\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestCombineSnapshots(t *testing.T) {
+	modules := []DiscoveredModule{
+		{Module: "example.com/a", Stats: &StatsSnapshot{MaxDepth: 2}},
+		{Module: "example.com/b", Stats: &StatsSnapshot{MaxDepth: 4}},
+	}
+	graphs := []*DependencyOverview{
+		{DirectDepList: []string{"example.com/b", "x"}, TransDepList: []string{"y", "z"}},
+		{DirectDepList: []string{"y"}, TransDepList: []string{"z", "w"}},
+	}
+	got := combineSnapshots(modules, graphs)
+	if got.DirectDeps != 2 || got.TransDeps != 3 || got.TotalDeps != 4 || got.MaxDepth != 4 {
+		t.Errorf("got %+v", got)
+	}
+}
+
+func TestCombineSnapshotsSingleModule(t *testing.T) {
+	depGraph := generateGraph(`example.com/a example.com/x@v1.0.0
+example.com/a example.com/y@v1.0.0
+example.com/x@v1.0.0 example.com/z@v1.0.0
+example.com/y@v1.0.0 example.com/x@v1.0.0`, []string{"example.com/a"})
+	snapshot := snapshotFromGraph(&depGraph)
+	got := combineSnapshots([]DiscoveredModule{{Module: "example.com/a", Stats: snapshot}}, []*DependencyOverview{&depGraph})
+	if got.DirectDeps != snapshot.DirectDeps || got.TransDeps != snapshot.TransDeps || got.TotalDeps != snapshot.TotalDeps || got.MaxDepth != snapshot.MaxDepth {
+		t.Errorf("combined %+v, want the counts of the module row %+v", got, *snapshot)
+	}
+}
@@ -0,0 +1,319 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Doctor check statuses.
+const (
+	doctorOK      = "ok"
+	doctorWarn    = "warn"
+	doctorFail    = "fail"
+	doctorSkipped = "skipped"
+)
+
+// doctorExamples is the number of offending modules or edges named in a
+// check's detail.
+const doctorExamples = 3
+
+// DoctorCheck is the outcome of one doctor check, with a suggested fix when
+// it did not pass.
+type DoctorCheck struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Detail     string `json:"detail"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// DoctorResult holds the checks run by doctor, in order.
+type DoctorResult struct {
+	Checks []DoctorCheck  `json:"checks"`
+	GoEnv  *GoEnvironment `json:"goEnv,omitempty"`
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check that the Go environment and module graph can be analyzed",
+	Long: `Checks the environment and the module graph of --dir and suggests a fix
+for every problem found:
+
+  go-version    the go binary runs and is not older than the go directive
+  goflags       GOFLAGS does not change how the module graph is loaded
+  mod-graph     "go mod graph" succeeds
+  go-sum        go.sum (or go.work.sum) has an entry for every module
+                version whose go.mod the graph was read from (the left
+                side of an edge), or for its replacement, unless it is
+                replaced by a directory
+  orphan-edges  every module the graph points at is in "go list -m all"
+  exclusions    --exclude-modules and --exclude-edges leave dependencies
+
+Checks that depend on a failed one are skipped. Exits with code 3 when a
+check fails; warnings do not change the exit code.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("doctor does not take any arguments"))
+		}
+		result := runDoctor()
+		if jsonOutput {
+			if err := writeJSON(os.Stdout, result); err != nil {
+				return err
+			}
+		} else {
+			printDoctorResult(result)
+		}
+		failed := 0
+		for _, c := range result.Checks {
+			if c.Status == doctorFail {
+				failed++
+			}
+		}
+		if failed > 0 {
+			cmd.SilenceUsage = true
+			return withExitCode(ExitViolation, fmt.Errorf("doctor found %d problem(s)", failed))
+		}
+		return nil
+	},
+}
+
+// runDoctor runs every check, skipping those whose input an earlier check
+// could not provide.
+func runDoctor() DoctorResult {
+	var result DoctorResult
+	add := func(c DoctorCheck) { result.Checks = append(result.Checks, c) }
+	skip := func(names ...string) {
+		for _, name := range names {
+			add(DoctorCheck{Name: name, Status: doctorSkipped, Detail: "an earlier check failed"})
+		}
+	}
+
+	env, err := effectiveGoEnvironment()
+	if err != nil {
+		add(DoctorCheck{Name: "go-version", Status: doctorFail, Detail: err.Error(),
+			Suggestion: "install Go from https://go.dev/dl and make sure go is on PATH"})
+		skip("goflags", "mod-graph", "go-sum", "orphan-edges", "exclusions")
+		return result
+	}
+	result.GoEnv = env
+	gomod, gomodErr := readGoModFile()
+	add(doctorGoVersion(env.GOVERSION, gomod))
+	add(doctorGoFlags(env.GOFLAGS))
+
+	c := goCommand([]string{"mod", "graph"})
+	out, err := c.Output()
+	if err != nil {
+		add(doctorModGraph(goCommandError(c, err)))
+		skip("go-sum", "orphan-edges", "exclusions")
+		return result
+	}
+	add(doctorModGraph(nil))
+
+	if gomodErr != nil {
+		add(DoctorCheck{Name: "go-sum", Status: doctorSkipped, Detail: gomodErr.Error()})
+	} else {
+		sums, err := readGoSums(filepath.Dir(goModPath()), mainModuleBaseDir())
+		if err != nil {
+			add(DoctorCheck{Name: "go-sum", Status: doctorFail, Detail: err.Error()})
+		} else {
+			add(doctorGoSum(string(out), gomod, sums))
+		}
+	}
+
+	mains := mainModules
+	if len(mains) == 0 {
+		if mains, err = autoDetectMainModules(); err != nil {
+			warnf("%v\n", err)
+		}
+	}
+	depGraph := generateGraph(string(out), mains)
+	c = goCommand([]string{"list", "-m", "all"})
+	listed, err := c.Output()
+	if err != nil {
+		add(DoctorCheck{Name: "orphan-edges", Status: doctorFail, Detail: goCommandError(c, err).Error(),
+			Suggestion: `run "go mod tidy" in --dir so that go list -m all can compute the build list`})
+	} else {
+		add(doctorOrphanEdges(&depGraph, buildListModules(string(listed))))
+	}
+	add(doctorExclusions(depGraph, excludeModules, edgeExclusions))
+	return result
+}
+
+// doctorGoVersion checks the go binary against the go directive of go.mod.
+func doctorGoVersion(goVersion string, gomod *goModFile) DoctorCheck {
+	check := DoctorCheck{Name: "go-version", Status: doctorOK, Detail: goVersion}
+	if gomod == nil || gomod.Go == "" || !strings.HasPrefix(goVersion, "go") {
+		return check
+	}
+	if compareGoVersions(goVersion, gomod.Go) < 0 {
+		check.Status = doctorWarn
+		check.Detail = fmt.Sprintf("%s is older than the go %s directive of go.mod", goVersion, gomod.Go)
+		check.Suggestion = fmt.Sprintf("install Go %s or later, or leave GOTOOLCHAIN=auto so go can download it", gomod.Go)
+	}
+	return check
+}
+
+// doctorGoFlags warns about GOFLAGS that change the graph depstat loads.
+func doctorGoFlags(goFlags string) DoctorCheck {
+	check := DoctorCheck{Name: "goflags", Status: doctorOK, Detail: "GOFLAGS=" + goFlags}
+	for _, f := range strings.Fields(goFlags) {
+		switch {
+		case f == "-mod=vendor":
+			check.Status = doctorWarn
+			check.Detail = "GOFLAGS=" + goFlags + ": go list -m all cannot compute the build list from vendor/"
+			check.Suggestion = "pass --goflags=-mod=mod"
+		case strings.HasPrefix(f, "-modfile="):
+			check.Status = doctorWarn
+			check.Detail = "GOFLAGS=" + goFlags + ": the graph is read from " + strings.TrimPrefix(f, "-modfile=") + " instead of go.mod"
+			check.Suggestion = "pass --goflags without -modfile to analyze go.mod"
+		}
+	}
+	return check
+}
+
+// doctorModGraph reports whether go mod graph succeeded, with a suggestion
+// matching the most common causes of failure.
+func doctorModGraph(err error) DoctorCheck {
+	if err == nil {
+		return DoctorCheck{Name: "mod-graph", Status: doctorOK, Detail: "go mod graph succeeded"}
+	}
+	check := DoctorCheck{Name: "mod-graph", Status: doctorFail, Detail: err.Error()}
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "go.sum"):
+		check.Suggestion = `run "go mod download" or "go mod tidy" in --dir, or pass --goflags=-mod=mod`
+	case strings.Contains(msg, "terminal prompts disabled") || strings.Contains(msg, "410 Gone") || strings.Contains(msg, "404 Not Found"):
+		check.Suggestion = "set GOPRIVATE for private modules and configure git credentials for their hosts"
+	case strings.Contains(msg, "GOPROXY=off") || offline:
+		check.Suggestion = `run "go mod download" with network access to fill the module cache`
+	default:
+		check.Suggestion = `run "go mod tidy" in --dir and fix the errors it reports`
+	}
+	return check
+}
+
+// doctorGoSum reports module versions of the go mod graph output that need
+// a go.sum entry, as crossCheckGoSum finds them, and have none.
+func doctorGoSum(graphOutput string, gomod *goModFile, sums map[string]bool) DoctorCheck {
+	keys := graphSumKeys(graphOutput, gomod, true)
+	var missing []string
+	for node, key := range keys {
+		if !sums[key] {
+			missing = append(missing, node)
+		}
+	}
+	if len(missing) == 0 {
+		return DoctorCheck{Name: "go-sum", Status: doctorOK, Detail: fmt.Sprintf("%d module versions have go.sum entries", len(keys))}
+	}
+	sort.Strings(missing)
+	return DoctorCheck{
+		Name:       "go-sum",
+		Status:     doctorFail,
+		Detail:     fmt.Sprintf("%d of %d module versions have no go.sum entry: %s", len(missing), len(keys), doctorExampleList(missing)),
+		Suggestion: `run "go mod tidy" in --dir and commit go.sum`,
+	}
+}
+
+// buildListModules returns the module paths printed by go list -m all.
+func buildListModules(output string) map[string]bool {
+	modules := make(map[string]bool)
+	for _, line := range strings.Split(output, "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 {
+			modules[fields[0]] = true
+		}
+	}
+	return modules
+}
+
+// doctorOrphanEdges reports graph edges pointing at modules outside the
+// build list, which usually come from stale requirements.
+func doctorOrphanEdges(depGraph *DependencyOverview, buildList map[string]bool) DoctorCheck {
+	var orphans []string
+	for from, tos := range depGraph.Graph {
+		for _, to := range tos {
+			if to == "go" || to == "toolchain" || buildList[to] {
+				continue
+			}
+			orphans = append(orphans, from+" -> "+to)
+		}
+	}
+	if len(orphans) == 0 {
+		return DoctorCheck{Name: "orphan-edges", Status: doctorOK, Detail: "every edge points at a module in go list -m all"}
+	}
+	sort.Strings(orphans)
+	return DoctorCheck{
+		Name:       "orphan-edges",
+		Status:     doctorWarn,
+		Detail:     fmt.Sprintf("%d edges point at modules outside go list -m all: %s", len(orphans), doctorExampleList(orphans)),
+		Suggestion: `run "go mod tidy", or analyze with --selected-only or drop the edges with --exclude-edges`,
+	}
+}
+
+// doctorExclusions reports whether the exclusions leave any dependency.
+func doctorExclusions(depGraph DependencyOverview, patterns []string, rules []EdgeExclusion) DoctorCheck {
+	if len(patterns) == 0 && len(rules) == 0 {
+		return DoctorCheck{Name: "exclusions", Status: doctorOK, Detail: "no exclusions"}
+	}
+	before := len(getAllDeps(depGraph.DirectDepList, depGraph.TransDepList))
+	excluded := excludeEdgesFrom(excludeModulesFrom(depGraph, patterns), rules)
+	after := len(getAllDeps(excluded.DirectDepList, excluded.TransDepList))
+	if after == 0 && before > 0 {
+		return DoctorCheck{
+			Name:       "exclusions",
+			Status:     doctorFail,
+			Detail:     fmt.Sprintf("the exclusions remove all %d dependencies", before),
+			Suggestion: "narrow the patterns; --explain-exclusions shows what each one removes",
+		}
+	}
+	return DoctorCheck{Name: "exclusions", Status: doctorOK, Detail: fmt.Sprintf("%d of %d dependencies remain", after, before)}
+}
+
+// doctorExampleList joins the first doctorExamples items, noting how many are left.
+func doctorExampleList(items []string) string {
+	if len(items) <= doctorExamples {
+		return strings.Join(items, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(items[:doctorExamples], ", "), len(items)-doctorExamples)
+}
+
+func printDoctorResult(result DoctorResult) {
+	colors := map[string]string{doctorOK: ansiGreen, doctorWarn: ansiYellow, doctorFail: ansiRed}
+	for _, c := range result.Checks {
+		status := fmt.Sprintf("%-7s", c.Status)
+		if color, ok := colors[c.Status]; ok {
+			status = colorize(color, status)
+		}
+		fmt.Printf("%s %-13s %s\n", status, c.Name, c.Detail)
+		if c.Suggestion != "" {
+			fmt.Printf("%-21s -> %s\n", "", c.Suggestion)
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+	doctorCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
+	doctorCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Get the output in JSON format")
+	doctorCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Specify main modules")
+	doctorCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
+}
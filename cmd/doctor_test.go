@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDoctorGoVersion(t *testing.T) {
+	gomod := &goModFile{Go: "1.22"}
+	if got := doctorGoVersion("go1.22.3", gomod); got.Status != doctorOK {
+		t.Errorf("go1.22.3 with go 1.22: status %q, want ok", got.Status)
+	}
+	got := doctorGoVersion("go1.21.9", gomod)
+	if got.Status != doctorWarn || got.Suggestion == "" {
+		t.Errorf("go1.21.9 with go 1.22: got %+v, want a warning with a suggestion", got)
+	}
+}
+
+func TestDoctorGoSum(t *testing.T) {
+	graph := "example.com/app example.com/a@v1.0.0\n" +
+		"example.com/app example.com/local@v0.0.0\n" +
+		"example.com/app example.com/forked@v1.0.0\n" +
+		"example.com/a@v1.0.0 example.com/b@v1.2.0\n" +
+		"example.com/b@v1.2.0 example.com/c@v1.0.0\n" +
+		"example.com/local@v0.0.0 example.com/c@v1.0.0\n" +
+		"example.com/forked@v1.0.0 example.com/c@v1.0.0\n" +
+		"example.com/a@v1.0.0 go@1.22\n"
+	gomod := &goModFile{Replace: []goModReplace{
+		{Old: goModVersion{Path: "example.com/local"}, New: goModVersion{Path: "../local"}},
+		{Old: goModVersion{Path: "example.com/forked"}, New: goModVersion{Path: "example.com/fork", Version: "v1.0.1"}},
+	}}
+	sums := map[string]bool{"example.com/a v1.0.0": true, "example.com/forked v1.0.0": true}
+
+	got := doctorGoSum(graph, gomod, sums)
+	if got.Status != doctorFail || !strings.Contains(got.Detail, "example.com/b@v1.2.0") || !strings.Contains(got.Detail, "example.com/forked@v1.0.0") ||
+		strings.Contains(got.Detail, "local") || strings.Contains(got.Detail, "example.com/c") {
+		t.Errorf("got %+v, want a failure naming only example.com/b@v1.2.0 and example.com/forked@v1.0.0", got)
+	}
+	sums["example.com/b v1.2.0"] = true
+	sums["example.com/fork v1.0.1"] = true
+	if got := doctorGoSum(graph, gomod, sums); got.Status != doctorOK {
+		t.Errorf("complete go.sum: got %+v, want ok", got)
+	}
+}
+
+func TestDoctorGoSumPrunedGraph(t *testing.T) {
+	// go mod tidy keeps no go.sum entry for example.com/b, whose go.mod
+	// module graph pruning never reads: it is only on the right side
+	graph := "example.com/app example.com/a@v1.0.0\n" +
+		"example.com/a@v1.0.0 example.com/b@v1.2.0\n" +
+		"example.com/a@v1.0.0 example.com/b@v1.1.0\n"
+	sums := map[string]bool{"example.com/a v1.0.0": true}
+	if got := doctorGoSum(graph, &goModFile{}, sums); got.Status != doctorOK || !strings.Contains(got.Detail, "1 module versions") {
+		t.Errorf("pruned graph: got %+v, want ok for the one loaded module version", got)
+	}
+}
+
+func TestDoctorOrphanEdges(t *testing.T) {
+	depGraph := &DependencyOverview{Graph: map[string][]string{
+		"example.com/app": {"example.com/a", "go"},
+		"example.com/a":   {"example.com/stale"},
+	}}
+	buildList := buildListModules("example.com/app\nexample.com/a v1.0.0\n")
+	got := doctorOrphanEdges(depGraph, buildList)
+	if got.Status != doctorWarn || !strings.Contains(got.Detail, "example.com/a -> example.com/stale") {
+		t.Errorf("got %+v, want a warning naming example.com/a -> example.com/stale", got)
+	}
+}
+
+func TestDoctorExclusions(t *testing.T) {
+	depGraph := DependencyOverview{
+		MainModules:   []string{"main"},
+		DirectDepList: []string{"A"},
+		TransDepList:  []string{"B"},
+		Graph:         map[string][]string{"main": {"A"}, "A": {"B"}},
+	}
+	if got := doctorExclusions(depGraph, []string{"A"}, nil); got.Status != doctorFail {
+		t.Errorf("excluding A: got %+v, want fail", got)
+	}
+	if got := doctorExclusions(depGraph, []string{"B"}, nil); got.Status != doctorOK {
+		t.Errorf("excluding B: got %+v, want ok", got)
+	}
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// DominatorsResult maps every dependency to the direct dependency that
+// dominates it, if any.
+type DominatorsResult struct {
+	MainModules []string `json:"mainModules"`
+	// Owners maps a direct dependency to the transitive dependencies that
+	// would disappear if it were removed.
+	Owners map[string][]string `json:"owners"`
+	// Shared lists transitive dependencies reachable through more than one
+	// direct dependency.
+	Shared []string `json:"shared"`
+	// ImmediateDominators maps every dependency to its immediate dominator;
+	// modules dominated only by the main modules map to "".
+	ImmediateDominators map[string]string `json:"immediateDominators"`
+}
+
+// DominatorLookup is the result for a single dependency.
+type DominatorLookup struct {
+	Target string   `json:"target"`
+	Owner  string   `json:"owner,omitempty"`
+	Chain  []string `json:"dominatorChain"`
+}
+
+var dominatorsCmd = &cobra.Command{
+	Use:   "dominators [dependency]",
+	Short: "Show which direct dependency is responsible for each transitive dependency",
+	Long: `Computes the dominator tree of the module graph rooted at the main module(s).
+
+A direct dependency "owns" a transitive dependency when every path from the
+main modules to it passes through that direct dependency, so removing the
+direct dependency would also remove the transitive one. Transitive
+dependencies reachable through several direct dependencies are reported as
+shared.
+
+With a dependency argument, prints the owning direct dependency and the chain
+of dominators leading to it.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		depGraph := getDepInfo(mainModules)
+		if len(depGraph.MainModules) == 0 {
+			return errNoMainModules
+		}
+		idom := computeDominators(depGraph.MainModules, depGraph.Graph)
+
+		if len(args) == 1 {
+			target := args[0]
+			if _, ok := idom[target]; !ok {
+				return withExitCode(ExitNotFound, fmt.Errorf("%s is not in the dependency graph", target))
+			}
+			lookup := lookupDominator(target, idom, depGraph.DirectDepList)
+			if jsonOutput {
+				out, err := json.MarshalIndent(lookup, "", "\t")
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(out))
+				return nil
+			}
+			if lookup.Owner == "" {
+				fmt.Printf("%s is not owned by a single direct dependency\n", target)
+			} else {
+				fmt.Printf("%s is owned by %s\n", target, lookup.Owner)
+			}
+			fmt.Printf("Dominator chain: %s\n", strings.Join(lookup.Chain, " -> "))
+			return nil
+		}
+
+		result := summarizeDominators(depGraph, idom)
+		if jsonOutput {
+			out, err := json.MarshalIndent(result, "", "\t")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+			return nil
+		}
+		printDominators(result)
+		return nil
+	},
+}
+
+// computeDominators returns the immediate dominator of every module reachable
+// from the main modules, using a virtual root above all main modules. Main
+// modules, and modules only dominated by the virtual root, map to "".
+// It uses the iterative algorithm by Cooper, Harvey and Kennedy.
+func computeDominators(mainModules []string, graph map[string][]string) map[string]string {
+	const root = ""
+
+	// number nodes in reverse postorder from the virtual root
+	var postorder []string
+	visited := map[string]bool{root: true}
+	var visit func(node string)
+	visit = func(node string) {
+		next := append([]string{}, graph[node]...)
+		sort.Strings(next)
+		for _, n := range next {
+			if !visited[n] {
+				visited[n] = true
+				visit(n)
+			}
+		}
+		postorder = append(postorder, node)
+	}
+	mains := append([]string{}, mainModules...)
+	sort.Strings(mains)
+	for _, m := range mains {
+		if !visited[m] {
+			visited[m] = true
+			visit(m)
+		}
+	}
+	postorder = append(postorder, root)
+
+	order := make(map[string]int, len(postorder))
+	for i, n := range postorder {
+		order[n] = i
+	}
+	preds := make(map[string][]string)
+	for _, m := range mains {
+		preds[m] = append(preds[m], root)
+	}
+	for from, tos := range graph {
+		if !visited[from] {
+			continue
+		}
+		for _, to := range tos {
+			preds[to] = append(preds[to], from)
+		}
+	}
+
+	idom := map[string]string{root: root}
+	intersect := func(a, b string) string {
+		for a != b {
+			for order[a] < order[b] {
+				a = idom[a]
+			}
+			for order[b] < order[a] {
+				b = idom[b]
+			}
+		}
+		return a
+	}
+	for changed := true; changed; {
+		changed = false
+		for i := len(postorder) - 2; i >= 0; i-- {
+			node := postorder[i]
+			newIdom, found := "", false
+			for _, p := range preds[node] {
+				if _, ok := idom[p]; !ok {
+					continue
+				}
+				if !found {
+					newIdom, found = p, true
+					continue
+				}
+				newIdom = intersect(p, newIdom)
+			}
+			if cur, ok := idom[node]; !ok || cur != newIdom {
+				idom[node] = newIdom
+				changed = true
+			}
+		}
+	}
+	delete(idom, root)
+	return idom
+}
+
+// lookupDominator walks the dominator chain of target up to the main modules
+// and picks out the direct dependency on it, if any.
+func lookupDominator(target string, idom map[string]string, directDeps []string) DominatorLookup {
+	direct := make(map[string]bool, len(directDeps))
+	for _, d := range directDeps {
+		direct[d] = true
+	}
+	lookup := DominatorLookup{Target: target, Chain: []string{target}}
+	for node := idom[target]; node != ""; node = idom[node] {
+		lookup.Chain = append([]string{node}, lookup.Chain...)
+	}
+	for _, node := range lookup.Chain {
+		if direct[node] && node != target {
+			lookup.Owner = node
+			break
+		}
+	}
+	return lookup
+}
+
+func summarizeDominators(depGraph *DependencyOverview, idom map[string]string) DominatorsResult {
+	result := DominatorsResult{
+		MainModules:         depGraph.MainModules,
+		Owners:              map[string][]string{},
+		Shared:              []string{},
+		ImmediateDominators: map[string]string{},
+	}
+	isMain := make(map[string]bool)
+	for _, m := range depGraph.MainModules {
+		isMain[m] = true
+	}
+	for _, dep := range depGraph.TransDepList {
+		if isMain[dep] || contains(depGraph.DirectDepList, dep) {
+			continue
+		}
+		if owner := lookupDominator(dep, idom, depGraph.DirectDepList).Owner; owner != "" {
+			result.Owners[owner] = append(result.Owners[owner], dep)
+		} else {
+			result.Shared = append(result.Shared, dep)
+		}
+	}
+	for mod, d := range idom {
+		if !isMain[mod] {
+			result.ImmediateDominators[mod] = d
+		}
+	}
+	for owner := range result.Owners {
+		sort.Strings(result.Owners[owner])
+	}
+	sort.Strings(result.Shared)
+	return result
+}
+
+func printDominators(result DominatorsResult) {
+	owners := make([]string, 0, len(result.Owners))
+	for owner := range result.Owners {
+		owners = append(owners, owner)
+	}
+	sort.Slice(owners, func(i, j int) bool {
+		a, b := len(result.Owners[owners[i]]), len(result.Owners[owners[j]])
+		if a == b {
+			return owners[i] < owners[j]
+		}
+		return a > b
+	})
+	if len(owners) == 0 {
+		fmt.Println("No transitive dependency is owned by a single direct dependency.")
+	} else {
+		fmt.Printf("OWNED TRANSITIVE DEPENDENCIES (%d direct dependencies):\n", len(owners))
+		for _, owner := range owners {
+			fmt.Printf("  %s (%d)\n", owner, len(result.Owners[owner]))
+			for _, dep := range result.Owners[owner] {
+				fmt.Printf("    - %s\n", dep)
+			}
+		}
+	}
+	if len(result.Shared) > 0 {
+		fmt.Printf("\nSHARED TRANSITIVE DEPENDENCIES (%d):\n", len(result.Shared))
+		for _, dep := range result.Shared {
+			fmt.Printf("  - %s\n", dep)
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(dominatorsCmd)
+	dominatorsCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
+	dominatorsCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Get the output in JSON format")
+	dominatorsCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
+	dominatorsCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Specify main modules")
+}
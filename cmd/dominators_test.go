@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestComputeDominators(t *testing.T) {
+	// main -> A -> C -> E
+	// main -> B -> C
+	// A -> D -> F
+	graph := map[string][]string{
+		"main": {"A", "B"},
+		"A":    {"C", "D"},
+		"B":    {"C"},
+		"C":    {"E"},
+		"D":    {"F"},
+		"F":    {"D"},
+	}
+	idom := computeDominators([]string{"main"}, graph)
+	want := map[string]string{
+		"main": "",
+		"A":    "main",
+		"B":    "main",
+		"C":    "main",
+		"D":    "A",
+		"E":    "C",
+		"F":    "D",
+	}
+	if !reflect.DeepEqual(idom, want) {
+		t.Fatalf("unexpected dominators\n got: %v\nwant: %v", idom, want)
+	}
+
+	depGraph := &DependencyOverview{
+		MainModules:   []string{"main"},
+		DirectDepList: []string{"A", "B"},
+		TransDepList:  []string{"B", "C", "D", "E", "F"},
+		Graph:         graph,
+	}
+	result := summarizeDominators(depGraph, idom)
+	if got := strings.Join(result.Owners["A"], ","); got != "D,F" {
+		t.Errorf("expected A to own D,F, got %q", got)
+	}
+	if got := strings.Join(result.Shared, ","); got != "C,E" {
+		t.Errorf("expected C,E to be shared, got %q", got)
+	}
+
+	lookup := lookupDominator("F", idom, depGraph.DirectDepList)
+	if lookup.Owner != "A" || strings.Join(lookup.Chain, " -> ") != "main -> A -> D -> F" {
+		t.Errorf("unexpected lookup %+v", lookup)
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+)
+
+var dryRun bool
+
+// goEnvKeys are the inherited variables shown by --dry-run, since they most
+// often explain a graph that differs from what the user expects.
+var goEnvKeys = []string{"GOFLAGS", "GOWORK", "GOPROXY", "GOPRIVATE", "GONOSUMDB", "GONOPROXY", "GOOS", "GOARCH", "GOTOOLCHAIN"}
+
+// dryRunState records the go commands built while --dry-run is set.
+var dryRunState struct {
+	sync.Mutex
+	name   string
+	cmds   []*exec.Cmd
+	stdout *os.File
+	stdin  *os.File
+}
+
+// startDryRun runs the command with its output discarded and its stdin
+// empty, so that --dry-run lists exactly the go commands it runs, in order,
+// with their real arguments. Those commands only query the module (the go
+// mod tidy of tidy-preview runs in a temporary copy), so running them is
+// safe; it is also what reproduces a failure to load the graph.
+func startDryRun(c *cobra.Command) error {
+	null, err := os.OpenFile(os.DevNull, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	dryRunState.Lock()
+	defer dryRunState.Unlock()
+	dryRunState.name = c.Name()
+	dryRunState.stdout, dryRunState.stdin = os.Stdout, os.Stdin
+	os.Stdout, os.Stdin = null, null
+	return nil
+}
+
+// recordDryRun records c when --dry-run is set.
+func recordDryRun(c *exec.Cmd) {
+	if !dryRun {
+		return
+	}
+	dryRunState.Lock()
+	defer dryRunState.Unlock()
+	dryRunState.cmds = append(dryRunState.cmds, c)
+}
+
+// finishDryRun restores stdout and stdin and prints the recorded commands.
+// It does nothing unless startDryRun ran.
+func finishDryRun() {
+	dryRunState.Lock()
+	defer dryRunState.Unlock()
+	if dryRunState.stdout == nil {
+		return
+	}
+	os.Stdout.Close()
+	os.Stdout, os.Stdin = dryRunState.stdout, dryRunState.stdin
+	dryRunState.stdout, dryRunState.stdin = nil, nil
+	writeDryRun(os.Stdout, dryRunState.name, dryRunState.cmds)
+}
+
+// writeDryRun prints the inherited go environment and every recorded
+// command as a line that can be pasted into a shell.
+func writeDryRun(w io.Writer, name string, cmds []*exec.Cmd) {
+	fmt.Fprintf(w, "# depstat %s ran:\n", name)
+	var inherited []string
+	for _, key := range goEnvKeys {
+		if value, ok := os.LookupEnv(key); ok {
+			inherited = append(inherited, key+"="+shellQuote(value))
+		}
+	}
+	if len(inherited) > 0 {
+		fmt.Fprintf(w, "# inherited: %s\n", strings.Join(inherited, " "))
+	} else {
+		fmt.Fprintln(w, "# inherited: no GO* variables set")
+	}
+	if len(cmds) == 0 {
+		fmt.Fprintln(w, "# no go commands")
+	}
+	for _, cmd := range cmds {
+		fmt.Fprintln(w, formatPlannedCommand(cmd))
+	}
+}
+
+// formatPlannedCommand renders cmd as "cd DIR && KEY=VALUE... go ARGS",
+// listing only the variables depstat sets on top of the caller's environment.
+func formatPlannedCommand(cmd *exec.Cmd) string {
+	workDir := cmd.Dir
+	if workDir == "" {
+		workDir = "."
+	}
+	if abs, err := filepath.Abs(workDir); err == nil {
+		workDir = abs
+	}
+	parts := []string{"cd", shellQuote(workDir), "&&"}
+	for _, kv := range lastSettings(cmd.Env[len(os.Environ()):]) {
+		key, value, _ := strings.Cut(kv, "=")
+		parts = append(parts, key+"="+shellQuote(value))
+	}
+	for _, arg := range cmd.Args {
+		parts = append(parts, shellQuote(arg))
+	}
+	return strings.Join(parts, " ")
+}
+
+// shellQuote single-quotes s for a POSIX shell unless it only contains
+// characters that need no quoting.
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./:=,@+") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestFormatPlannedCommand(t *testing.T) {
+	oldDir := dir
+	dir = "/src/my repo"
+	defer func() { dir, offline = oldDir, false }()
+	offline = true
+
+	got := formatPlannedCommand(newGoCommand([]string{"list", "-deps", "-f", "{{.ImportPath}}", "./..."}, "GOWORK=off"))
+	want := "cd '/src/my repo' && GOWORK=off GOFLAGS=-mod=mod GOPROXY=off go list -deps -f '{{.ImportPath}}' ./..."
+	if got != want {
+		t.Errorf("formatPlannedCommand =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := map[string]string{
+		"k8s.io/api@v0.30.0": "k8s.io/api@v0.30.0",
+		"-tags=a b":          "'-tags=a b'",
+		"it's":               `'it'\''s'`,
+		"":                   "''",
+	}
+	for in, want := range tests {
+		if got := shellQuote(in); got != want {
+			t.Errorf("shellQuote(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestDryRunRecordsGoCommands(t *testing.T) {
+	defer func() {
+		dryRun = false
+		dryRunState.cmds = nil
+	}()
+	newGoCommand([]string{"env", "GOVERSION"})
+	dryRun = true
+	newGoCommand([]string{"mod", "graph"})
+	goCommand([]string{"list", "-m", "-json", "all"})
+	dryRun = false
+
+	var args []string
+	for _, c := range dryRunState.cmds {
+		args = append(args, strings.Join(c.Args[1:], " "))
+	}
+	if got := strings.Join(args, "; "); got != "mod graph; list -m -json all" {
+		t.Errorf("recorded %q", got)
+	}
+
+	var buf bytes.Buffer
+	writeDryRun(&buf, "version", []*exec.Cmd{})
+	if !strings.Contains(buf.String(), "# depstat version ran:\n") || !strings.HasSuffix(buf.String(), "# no go commands\n") {
+		t.Errorf("writeDryRun =\n%s", buf.String())
+	}
+}
@@ -0,0 +1,520 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ModuleEnrichment holds external metadata attached to a dependency.
+// Fields are filled by the enrichment providers selected with --enrich.
+type ModuleEnrichment struct {
+	Licenses       []string `json:"licenses,omitempty"`
+	Scorecard      *float64 `json:"scorecard,omitempty"`
+	DependentCount *int     `json:"dependentCount,omitempty"`
+	SourceRepo     string   `json:"sourceRepo,omitempty"`
+
+	Archived   *bool      `json:"archived,omitempty"`
+	LastCommit *time.Time `json:"lastCommit,omitempty"`
+	Stars      *int       `json:"stars,omitempty"`
+	// Stale is derived from LastCommit and --stale-days, not cached.
+	Stale bool `json:"stale,omitempty"`
+
+	VersionTime   *time.Time `json:"versionTime,omitempty"`
+	LatestVersion string     `json:"latestVersion,omitempty"`
+	LatestTime    *time.Time `json:"latestTime,omitempty"`
+	// VersionAgeDays, DaysSinceLatest and Old are derived from VersionTime,
+	// LatestTime and --version-age-days, not cached.
+	VersionAgeDays  *int `json:"versionAgeDays,omitempty"`
+	DaysSinceLatest *int `json:"daysSinceLatestRelease,omitempty"`
+	Old             bool `json:"old,omitempty"`
+
+	// Vulnerabilities lists the IDs of the OSV advisories affecting the
+	// version.
+	Vulnerabilities []string `json:"vulnerabilities,omitempty"`
+}
+
+// enrichProvider fetches metadata for a single module version.
+type enrichProvider func(client *http.Client, modPath, version string) (*ModuleEnrichment, error)
+
+var enrichProviders = map[string]enrichProvider{
+	"depsdev": fetchDepsDev,
+	"github":  fetchGitHubHealth,
+	"osv":     fetchOSVVulnerabilities,
+	"proxy":   fetchProxyReleases,
+}
+
+const enrichCacheTTL = 24 * time.Hour
+
+var enrichSources []string
+var enrichCacheDir string
+var enrichStaleDays int
+
+var depsDevBaseURL = "https://api.deps.dev"
+
+// validateEnrichSources rejects unknown provider names early, before any
+// graph work is done.
+func validateEnrichSources(sources []string) error {
+	for _, s := range sources {
+		if _, ok := enrichProviders[s]; !ok {
+			known := make([]string, 0, len(enrichProviders))
+			for k := range enrichProviders {
+				known = append(known, k)
+			}
+			sort.Strings(known)
+			return withExitCode(ExitUsage, fmt.Errorf("unknown --enrich source %q (known: %s)", s, strings.Join(known, ", ")))
+		}
+	}
+	return nil
+}
+
+// enrichModules queries every selected provider for each module and merges
+// the results. Fresh cache entries are used as-is; on network failure a stale
+// cache entry is used instead, so enrichment keeps working offline once the
+// cache has been populated.
+func enrichModules(mods []string, versions map[string]string, sources []string) (map[string]*ModuleEnrichment, []string) {
+	out := make(map[string]*ModuleEnrichment, len(mods))
+	if len(sources) == 0 {
+		return out, nil
+	}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var warnings []string
+	skipped := make(map[string]bool)
+	sem := make(chan struct{}, 20)
+	client := &http.Client{Timeout: 15 * time.Second}
+
+	for _, mod := range mods {
+		wg.Add(1)
+		go func(m string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			merged := &ModuleEnrichment{}
+			var modWarnings, modSkips []string
+			for _, source := range sources {
+				e, err := enrichOne(client, source, m, versions[m])
+				var skip *enrichSkipped
+				if errors.As(err, &skip) {
+					modSkips = append(modSkips, source+": "+skip.reason)
+					continue
+				}
+				if err != nil {
+					modWarnings = append(modWarnings, fmt.Sprintf("%s: %s: %v", source, m, err))
+					continue
+				}
+				mergeEnrichment(merged, e)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			out[m] = merged
+			warnings = append(warnings, modWarnings...)
+			for _, reason := range modSkips {
+				skipped[reason] = true
+			}
+		}(mod)
+	}
+	wg.Wait()
+	for reason := range skipped {
+		warnings = append(warnings, reason)
+	}
+	markStale(out, enrichStaleDays, time.Now())
+	markVersionAge(out, enrichMaxVersionAgeDays, time.Now())
+	sort.Strings(warnings)
+	return out, warnings
+}
+
+// enrichSkipped is returned by a provider that deliberately does not look
+// a module up. enrichModules reports each distinct reason once instead of
+// one warning per module.
+type enrichSkipped struct {
+	reason string
+}
+
+func (e *enrichSkipped) Error() string { return e.reason }
+
+func enrichOne(client *http.Client, source, modPath, version string) (*ModuleEnrichment, error) {
+	cached, fetchedAt, ok := loadEnrichCache(source, modPath, version)
+	if ok && (offline || time.Since(fetchedAt) < enrichCacheTTL) {
+		return cached, nil
+	}
+	if offline {
+		return nil, fmt.Errorf("not in the enrichment cache and --offline disables network lookups")
+	}
+	fresh, err := enrichProviders[source](client, modPath, version)
+	if err != nil {
+		if ok {
+			// offline fallback: a stale entry beats no data
+			return cached, nil
+		}
+		return nil, err
+	}
+	storeEnrichCache(source, modPath, version, fresh)
+	return fresh, nil
+}
+
+func mergeEnrichment(dst, src *ModuleEnrichment) {
+	if src == nil {
+		return
+	}
+	if len(src.Licenses) > 0 {
+		dst.Licenses = src.Licenses
+	}
+	if src.Scorecard != nil {
+		dst.Scorecard = src.Scorecard
+	}
+	if src.DependentCount != nil {
+		dst.DependentCount = src.DependentCount
+	}
+	if src.SourceRepo != "" {
+		dst.SourceRepo = src.SourceRepo
+	}
+	if src.Archived != nil {
+		dst.Archived = src.Archived
+	}
+	if src.LastCommit != nil {
+		dst.LastCommit = src.LastCommit
+	}
+	if src.Stars != nil {
+		dst.Stars = src.Stars
+	}
+	if src.VersionTime != nil {
+		dst.VersionTime = src.VersionTime
+	}
+	if src.LatestVersion != "" {
+		dst.LatestVersion = src.LatestVersion
+	}
+	if src.LatestTime != nil {
+		dst.LatestTime = src.LatestTime
+	}
+	if src.Vulnerabilities != nil {
+		dst.Vulnerabilities = src.Vulnerabilities
+	}
+}
+
+// markStale flags modules whose upstream has not seen a commit within
+// staleDays. A non-positive staleDays disables the check.
+func markStale(enrichment map[string]*ModuleEnrichment, staleDays int, now time.Time) {
+	if staleDays <= 0 {
+		return
+	}
+	cutoff := now.Add(-time.Duration(staleDays) * 24 * time.Hour)
+	for _, e := range enrichment {
+		if e != nil && e.LastCommit != nil && e.LastCommit.Before(cutoff) {
+			e.Stale = true
+		}
+	}
+}
+
+type enrichCacheEntry struct {
+	FetchedAt time.Time         `json:"fetchedAt"`
+	Data      *ModuleEnrichment `json:"data"`
+}
+
+func resolveEnrichCacheDir() string {
+	if enrichCacheDir != "" {
+		return enrichCacheDir
+	}
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(base, "depstat", "enrich")
+}
+
+func enrichCachePath(source, modPath, version string) string {
+	base := resolveEnrichCacheDir()
+	if base == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(modPath + "@" + version))
+	return filepath.Join(base, source, hex.EncodeToString(sum[:])+".json")
+}
+
+func loadEnrichCache(source, modPath, version string) (*ModuleEnrichment, time.Time, bool) {
+	p := enrichCachePath(source, modPath, version)
+	if p == "" {
+		return nil, time.Time{}, false
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return nil, time.Time{}, false
+	}
+	var entry enrichCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Data == nil {
+		return nil, time.Time{}, false
+	}
+	return entry.Data, entry.FetchedAt, true
+}
+
+func storeEnrichCache(source, modPath, version string, e *ModuleEnrichment) {
+	p := enrichCachePath(source, modPath, version)
+	if p == "" {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return
+	}
+	data, err := json.Marshal(enrichCacheEntry{FetchedAt: time.Now().UTC(), Data: e})
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(p, data, 0644)
+}
+
+// deps.dev v3 API response fragments.
+type depsDevVersion struct {
+	Licenses        []string `json:"licenses"`
+	RelatedProjects []struct {
+		ProjectKey struct {
+			ID string `json:"id"`
+		} `json:"projectKey"`
+		RelationType string `json:"relationType"`
+	} `json:"relatedProjects"`
+}
+
+type depsDevProject struct {
+	Scorecard *struct {
+		OverallScore float64 `json:"overallScore"`
+	} `json:"scorecard"`
+}
+
+type depsDevDependents struct {
+	DependentCount int `json:"dependentCount"`
+}
+
+// fetchDepsDev queries deps.dev for license, source project scorecard and
+// dependent counts of a module version. Only the version lookup is required;
+// project and dependents lookups are best effort.
+func fetchDepsDev(client *http.Client, modPath, version string) (*ModuleEnrichment, error) {
+	if version == "" {
+		return nil, fmt.Errorf("no version known")
+	}
+	versionURL := fmt.Sprintf("%s/v3/systems/go/packages/%s/versions/%s", depsDevBaseURL, url.PathEscape(modPath), url.PathEscape(version))
+	body, err := httpGetBody(client, versionURL)
+	if err != nil {
+		return nil, err
+	}
+	var v depsDevVersion
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil, fmt.Errorf("decoding deps.dev version response: %w", err)
+	}
+	e := &ModuleEnrichment{Licenses: v.Licenses}
+	for _, rp := range v.RelatedProjects {
+		if rp.RelationType == "SOURCE_REPO" {
+			e.SourceRepo = rp.ProjectKey.ID
+			break
+		}
+	}
+
+	if e.SourceRepo != "" {
+		projectURL := fmt.Sprintf("%s/v3/projects/%s", depsDevBaseURL, url.PathEscape(e.SourceRepo))
+		if body, err := httpGetBody(client, projectURL); err == nil {
+			var p depsDevProject
+			if json.Unmarshal(body, &p) == nil && p.Scorecard != nil {
+				score := p.Scorecard.OverallScore
+				e.Scorecard = &score
+			}
+		}
+	}
+
+	dependentsURL := fmt.Sprintf("%s/v3alpha/systems/go/packages/%s/versions/%s:dependents", depsDevBaseURL, url.PathEscape(modPath), url.PathEscape(version))
+	if body, err := httpGetBody(client, dependentsURL); err == nil {
+		var d depsDevDependents
+		if json.Unmarshal(body, &d) == nil {
+			count := d.DependentCount
+			e.DependentCount = &count
+		}
+	}
+	return e, nil
+}
+
+var githubAPIBaseURL = "https://api.github.com"
+
+type githubRepoREST struct {
+	Archived        bool   `json:"archived"`
+	DefaultBranch   string `json:"default_branch"`
+	StargazersCount int    `json:"stargazers_count"`
+}
+
+// githubCommitREST is an entry of the GitHub commits API response.
+type githubCommitREST struct {
+	Commit struct {
+		Committer struct {
+			Date time.Time `json:"date"`
+		} `json:"committer"`
+	} `json:"commit"`
+}
+
+// fetchGitHubHealth maps a module to its GitHub repository (following
+// go-import meta tags for vanity paths) and reads archived status, star
+// count and the date of the latest commit on the default branch from the
+// REST API. The repository's pushed_at is not used: pushes to any branch or
+// tag update it. A token is used when available but is not required.
+func fetchGitHubHealth(client *http.Client, modPath, version string) (*ModuleEnrichment, error) {
+	repo := ""
+	if strings.HasPrefix(modPath, "github.com/") {
+		repo = extractGitHubRepo(modPath)
+	} else {
+		repo = resolveOneVanityURL(client, modPath)
+	}
+	if repo == "" {
+		return nil, fmt.Errorf("could not map module to a GitHub repository")
+	}
+	headers := map[string]string{"Accept": "application/vnd.github+json"}
+	if token, err := resolveGitHubToken(); err == nil {
+		headers["Authorization"] = "bearer " + token
+	}
+	body, err := httpGetBodyWithHeaders(client, githubAPIBaseURL+"/repos/"+repo, headers)
+	if err != nil {
+		return nil, err
+	}
+	var r githubRepoREST
+	if err := json.Unmarshal(body, &r); err != nil {
+		return nil, fmt.Errorf("decoding GitHub response: %w", err)
+	}
+	archived := r.Archived
+	stars := r.StargazersCount
+	e := &ModuleEnrichment{
+		SourceRepo: "github.com/" + repo,
+		Archived:   &archived,
+		Stars:      &stars,
+	}
+	commitsURL := githubAPIBaseURL + "/repos/" + repo + "/commits?per_page=1"
+	if r.DefaultBranch != "" {
+		commitsURL += "&sha=" + url.QueryEscape(r.DefaultBranch)
+	}
+	// an empty repository has no commits and answers 409
+	if body, err := httpGetBodyWithHeaders(client, commitsURL, headers); err == nil {
+		var commits []githubCommitREST
+		if json.Unmarshal(body, &commits) == nil && len(commits) > 0 && !commits[0].Commit.Committer.Date.IsZero() {
+			date := commits[0].Commit.Committer.Date
+			e.LastCommit = &date
+		}
+	}
+	return e, nil
+}
+
+func httpGetBody(client *http.Client, rawURL string) ([]byte, error) {
+	return httpGetBodyWithHeaders(client, rawURL, nil)
+}
+
+func httpGetBodyWithHeaders(client *http.Client, rawURL string, headers map[string]string) ([]byte, error) {
+	req, err := http.NewRequest("GET", rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("HTTP %d from %s", resp.StatusCode, rawURL)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+}
+
+// formatEnrichment renders enrichment as a compact annotation for text output.
+func formatEnrichment(e *ModuleEnrichment) string {
+	if e == nil {
+		return ""
+	}
+	var parts []string
+	if e.Archived != nil && *e.Archived {
+		parts = append(parts, "ARCHIVED")
+	}
+	if e.Stale {
+		parts = append(parts, "STALE")
+	}
+	if e.Old {
+		parts = append(parts, "OLD")
+	}
+	if len(e.Vulnerabilities) > 0 {
+		parts = append(parts, "vulns="+strings.Join(e.Vulnerabilities, "|"))
+	}
+	if e.Scorecard != nil {
+		parts = append(parts, fmt.Sprintf("scorecard=%.1f", *e.Scorecard))
+	}
+	if len(e.Licenses) > 0 {
+		parts = append(parts, "license="+strings.Join(e.Licenses, "|"))
+	}
+	if e.DependentCount != nil {
+		parts = append(parts, fmt.Sprintf("dependents=%d", *e.DependentCount))
+	}
+	if e.Stars != nil {
+		parts = append(parts, fmt.Sprintf("stars=%d", *e.Stars))
+	}
+	if e.LastCommit != nil {
+		parts = append(parts, "last-commit="+e.LastCommit.Format("2006-01-02"))
+	}
+	if e.VersionAgeDays != nil {
+		parts = append(parts, fmt.Sprintf("version-age=%dd", *e.VersionAgeDays))
+	}
+	if e.LatestVersion != "" {
+		latest := "latest=" + e.LatestVersion
+		if e.DaysSinceLatest != nil {
+			latest += fmt.Sprintf(" (%dd ago)", *e.DaysSinceLatest)
+		}
+		parts = append(parts, latest)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+// printEnrichedDeps is printDeps with per-module enrichment annotations.
+func printEnrichedDeps(deps []string, enrichment map[string]*ModuleEnrichment) {
+	fmt.Println()
+	sort.Strings(deps)
+	for _, dep := range deps {
+		if note := formatEnrichment(enrichment[dep]); note != "" {
+			fmt.Printf("%s %s\n", dep, note)
+		} else {
+			fmt.Println(dep)
+		}
+	}
+	fmt.Println()
+}
+
+func printEnrichWarnings(warnings []string) {
+	if len(warnings) == 0 {
+		return
+	}
+	warnf("enrichment incomplete for %d lookups\n", len(warnings))
+	for _, w := range warnings {
+		logf(levelWarn, "  - %s\n", w)
+	}
+}
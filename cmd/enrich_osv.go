@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+var osvAPIBaseURL = "https://api.osv.dev"
+
+// osvQuery is the request body of the OSV v1 query endpoint.
+type osvQuery struct {
+	Version string `json:"version"`
+	Package struct {
+		Name      string `json:"name"`
+		Ecosystem string `json:"ecosystem"`
+	} `json:"package"`
+}
+
+// osvQueryResponse is the fragment of the OSV query response depstat reads.
+type osvQueryResponse struct {
+	Vulns []struct {
+		ID      string   `json:"id"`
+		Aliases []string `json:"aliases"`
+	} `json:"vulns"`
+}
+
+// fetchOSVVulnerabilities lists the advisories of the OSV database (which
+// includes the Go vulnerability database) affecting a module version. The
+// lookup is by module version, not by reachable symbol as in govulncheck,
+// so it may list advisories for code the project never calls.
+func fetchOSVVulnerabilities(client *http.Client, modPath, version string) (*ModuleEnrichment, error) {
+	if version == "" {
+		return nil, fmt.Errorf("no version known")
+	}
+	var q osvQuery
+	// OSV records Go versions without the "v" prefix
+	q.Version = strings.TrimPrefix(version, "v")
+	q.Package.Name = modPath
+	q.Package.Ecosystem = "Go"
+	payload, err := json.Marshal(q)
+	if err != nil {
+		return nil, err
+	}
+	rawURL := osvAPIBaseURL + "/v1/query"
+	resp, err := client.Post(rawURL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("HTTP %d from %s", resp.StatusCode, rawURL)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return nil, err
+	}
+	var r osvQueryResponse
+	if err := json.Unmarshal(body, &r); err != nil {
+		return nil, fmt.Errorf("decoding OSV response: %w", err)
+	}
+	e := &ModuleEnrichment{}
+	for _, v := range r.Vulns {
+		e.Vulnerabilities = append(e.Vulnerabilities, osvDisplayID(v.ID, v.Aliases))
+	}
+	sort.Strings(e.Vulnerabilities)
+	return e, nil
+}
+
+// osvDisplayID prefers the Go vulnerability database ID of an advisory,
+// which is what govulncheck and pkg.go.dev show.
+func osvDisplayID(id string, aliases []string) string {
+	if strings.HasPrefix(id, "GO-") {
+		return id
+	}
+	for _, a := range aliases {
+		if strings.HasPrefix(a, "GO-") {
+			return a
+		}
+	}
+	return id
+}
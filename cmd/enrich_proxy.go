@@ -0,0 +1,229 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// enrichMaxVersionAgeDays is set by --version-age-days.
+var enrichMaxVersionAgeDays int
+
+// moduleProxyURL overrides the proxy read by the "proxy" enrichment;
+// tests point it at a local server.
+var moduleProxyURL string
+
+// proxyInfo is the response of the module proxy's .info and @latest
+// endpoints.
+type proxyInfo struct {
+	Version string
+	Time    time.Time
+}
+
+// moduleProxyEnv holds the GOPROXY and GONOPROXY settings of the go
+// environment, read once per run.
+var moduleProxyEnv struct {
+	once     sync.Once
+	proxy    string
+	noProxy  string
+	envError error
+}
+
+// resolveModuleProxy returns the proxy URL the go command would fetch
+// modPath from: the first entry of the effective GOPROXY, as reported by
+// "go env". Modules matched by GONOPROXY (which defaults to GOPRIVATE), and
+// every module when GOPROXY starts with off or direct, are not looked up.
+func resolveModuleProxy(modPath string) (string, error) {
+	if moduleProxyURL != "" {
+		return moduleProxyURL, nil
+	}
+	moduleProxyEnv.once.Do(func() {
+		c := goCommand([]string{"env", "-json", "GOPROXY", "GONOPROXY"})
+		out, err := c.Output()
+		if err != nil {
+			moduleProxyEnv.envError = goCommandError(c, err)
+			return
+		}
+		var env struct{ GOPROXY, GONOPROXY string }
+		if err := json.Unmarshal(out, &env); err != nil {
+			moduleProxyEnv.envError = fmt.Errorf("parsing go env output: %w", err)
+			return
+		}
+		moduleProxyEnv.proxy, moduleProxyEnv.noProxy = env.GOPROXY, env.GONOPROXY
+	})
+	if moduleProxyEnv.envError != nil {
+		return "", moduleProxyEnv.envError
+	}
+	return moduleProxyFor(moduleProxyEnv.proxy, moduleProxyEnv.noProxy, modPath)
+}
+
+// moduleProxyFor picks the proxy for modPath from GOPROXY and GONOPROXY
+// values.
+func moduleProxyFor(goproxy, noProxy, modPath string) (string, error) {
+	if matchGlobPrefixes(noProxy, modPath) {
+		return "", &enrichSkipped{reason: "skipped modules matching GONOPROXY/GOPRIVATE " + noProxy}
+	}
+	first, _, _ := strings.Cut(goproxy, ",")
+	first, _, _ = strings.Cut(first, "|")
+	switch first = strings.TrimSpace(first); first {
+	case "", "off", "direct":
+		return "", &enrichSkipped{reason: "GOPROXY=" + goproxy + " does not start with a module proxy; release data is not looked up"}
+	}
+	return strings.TrimSuffix(first, "/"), nil
+}
+
+// matchGlobPrefixes reports whether a leading sequence of path elements of
+// target matches one of the comma-separated glob patterns, as the go command
+// matches GOPRIVATE and GONOPROXY.
+func matchGlobPrefixes(globs, target string) bool {
+	for _, glob := range strings.Split(globs, ",") {
+		glob = strings.TrimSuffix(strings.TrimSpace(glob), "/")
+		if glob == "" {
+			continue
+		}
+		n := strings.Count(glob, "/") + 1
+		prefix := target
+		for i := 0; i < len(target); i++ {
+			if target[i] == '/' {
+				n--
+				if n == 0 {
+					prefix = target[:i]
+					break
+				}
+			}
+		}
+		if n > 1 {
+			continue // target has fewer elements than glob
+		}
+		if ok, _ := path.Match(glob, prefix); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// escapeModulePath applies the module proxy case encoding, which replaces
+// every upper-case letter with an exclamation mark and its lower-case form.
+func escapeModulePath(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsUpper(r) {
+			b.WriteByte('!')
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// fetchProxyReleases reads the release time of the pinned version and the
+// latest release of the module from the module proxy (@v/list and .info).
+func fetchProxyReleases(client *http.Client, modPath, version string) (*ModuleEnrichment, error) {
+	if version == "" {
+		return nil, fmt.Errorf("no version known")
+	}
+	proxy, err := resolveModuleProxy(modPath)
+	if err != nil {
+		return nil, err
+	}
+	base := proxy + "/" + escapeModulePath(modPath)
+	pinned, err := fetchProxyInfo(client, base+"/@v/"+escapeModulePath(version)+".info")
+	if err != nil {
+		return nil, err
+	}
+	e := &ModuleEnrichment{}
+	if !pinned.Time.IsZero() {
+		released := pinned.Time
+		e.VersionTime = &released
+	}
+
+	body, err := httpGetBody(client, base+"/@v/list")
+	if err != nil {
+		return e, nil // the pinned release time is still useful
+	}
+	// prefer the highest release over pre-releases
+	latest, latestPre := "", ""
+	for _, v := range strings.Fields(string(body)) {
+		if strings.Contains(v, "-") {
+			if versionGreater(v, latestPre) {
+				latestPre = v
+			}
+		} else if versionGreater(v, latest) {
+			latest = v
+		}
+	}
+	if latest == "" {
+		latest = latestPre
+	}
+	var info proxyInfo
+	switch {
+	case latest == "":
+		info, err = fetchProxyInfo(client, base+"/@latest")
+	case latest == version:
+		info = pinned
+	default:
+		info, err = fetchProxyInfo(client, base+"/@v/"+escapeModulePath(latest)+".info")
+	}
+	if err != nil {
+		return e, nil
+	}
+	e.LatestVersion = info.Version
+	if !info.Time.IsZero() {
+		t := info.Time
+		e.LatestTime = &t
+	}
+	return e, nil
+}
+
+func fetchProxyInfo(client *http.Client, rawURL string) (proxyInfo, error) {
+	var info proxyInfo
+	body, err := httpGetBody(client, rawURL)
+	if err != nil {
+		return info, err
+	}
+	if err := json.Unmarshal(body, &info); err != nil {
+		return info, fmt.Errorf("decoding module proxy response: %w", err)
+	}
+	return info, nil
+}
+
+// markVersionAge derives the age of the pinned version and the time since
+// the latest release, and flags versions older than maxAgeDays. A
+// non-positive maxAgeDays disables the flag.
+func markVersionAge(enrichment map[string]*ModuleEnrichment, maxAgeDays int, now time.Time) {
+	for _, e := range enrichment {
+		if e == nil {
+			continue
+		}
+		if e.VersionTime != nil {
+			age := int(now.Sub(*e.VersionTime).Hours() / 24)
+			e.VersionAgeDays = &age
+			e.Old = maxAgeDays > 0 && age > maxAgeDays
+		}
+		if e.LatestTime != nil {
+			since := int(now.Sub(*e.LatestTime).Hours() / 24)
+			e.DaysSinceLatest = &since
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFetchDepsDev(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, ":dependents"):
+			_, _ = w.Write([]byte(`{"dependentCount": 42}`))
+		case strings.HasPrefix(r.URL.Path, "/v3/projects/"):
+			_, _ = w.Write([]byte(`{"scorecard": {"overallScore": 7.5}}`))
+		default:
+			_, _ = w.Write([]byte(`{"licenses": ["Apache-2.0"], "relatedProjects": [
+				{"projectKey": {"id": "github.com/acme/issues"}, "relationType": "ISSUE_TRACKER"},
+				{"projectKey": {"id": "github.com/acme/lib"}, "relationType": "SOURCE_REPO"}]}`))
+		}
+	}))
+	defer srv.Close()
+	oldBase := depsDevBaseURL
+	depsDevBaseURL = srv.URL
+	defer func() { depsDevBaseURL = oldBase }()
+
+	e, err := fetchDepsDev(srv.Client(), "example.com/lib", "v1.0.0")
+	if err != nil {
+		t.Fatalf("fetchDepsDev: %v", err)
+	}
+	if e.SourceRepo != "github.com/acme/lib" {
+		t.Errorf("expected source repo github.com/acme/lib, got %q", e.SourceRepo)
+	}
+	if e.Scorecard == nil || *e.Scorecard != 7.5 {
+		t.Errorf("expected scorecard 7.5, got %v", e.Scorecard)
+	}
+	if e.DependentCount == nil || *e.DependentCount != 42 {
+		t.Errorf("expected 42 dependents, got %v", e.DependentCount)
+	}
+	if got := formatEnrichment(e); got != "[scorecard=7.5 license=Apache-2.0 dependents=42]" {
+		t.Errorf("unexpected annotation %q", got)
+	}
+}
+
+func TestEnrichOneFallsBackToStaleCache(t *testing.T) {
+	oldDir := enrichCacheDir
+	enrichCacheDir = t.TempDir()
+	defer func() { enrichCacheDir = oldDir }()
+
+	enrichProviders["failing"] = func(*http.Client, string, string) (*ModuleEnrichment, error) {
+		return nil, errors.New("network unreachable")
+	}
+	defer delete(enrichProviders, "failing")
+
+	if _, err := enrichOne(nil, "failing", "example.com/a", "v1.0.0"); err == nil {
+		t.Fatalf("expected error without a cache entry")
+	}
+
+	// write an entry older than the TTL so a refresh is attempted
+	p := enrichCachePath("failing", "example.com/a", "v1.0.0")
+	data, _ := json.Marshal(enrichCacheEntry{
+		FetchedAt: time.Now().Add(-2 * enrichCacheTTL),
+		Data:      &ModuleEnrichment{Licenses: []string{"MIT"}},
+	})
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := enrichOne(nil, "failing", "example.com/a", "v1.0.0")
+	if err != nil {
+		t.Fatalf("expected stale cache fallback, got %v", err)
+	}
+	if len(e.Licenses) != 1 || e.Licenses[0] != "MIT" {
+		t.Fatalf("unexpected cached data: %+v", e)
+	}
+}
+
+func TestEnrichOneOffline(t *testing.T) {
+	oldDir := enrichCacheDir
+	enrichCacheDir = t.TempDir()
+	offline = true
+	defer func() { enrichCacheDir, offline = oldDir, false }()
+
+	calls := 0
+	enrichProviders["counting"] = func(*http.Client, string, string) (*ModuleEnrichment, error) {
+		calls++
+		return &ModuleEnrichment{}, nil
+	}
+	defer delete(enrichProviders, "counting")
+
+	if _, err := enrichOne(nil, "counting", "example.com/a", "v1.0.0"); err == nil {
+		t.Errorf("expected error without a cache entry")
+	}
+	storeEnrichCache("counting", "example.com/a", "v1.0.0", &ModuleEnrichment{Licenses: []string{"MIT"}})
+	e, err := enrichOne(nil, "counting", "example.com/a", "v1.0.0")
+	if err != nil || len(e.Licenses) != 1 {
+		t.Errorf("expected cached entry, got %+v, %v", e, err)
+	}
+	if calls != 0 {
+		t.Errorf("expected no provider calls offline, got %d", calls)
+	}
+}
+
+func TestFetchGitHubHealthMarksStale(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/repos/acme/old":
+			_, _ = w.Write([]byte(`{"archived": true, "default_branch": "trunk", "pushed_at": "2021-05-01T00:00:00Z", "stargazers_count": 12}`))
+		case r.URL.Path == "/repos/acme/old/commits" && r.URL.Query().Get("sha") == "trunk":
+			_, _ = w.Write([]byte(`[{"commit": {"committer": {"date": "2020-01-02T03:04:05Z"}}}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	oldBase := githubAPIBaseURL
+	githubAPIBaseURL = srv.URL
+	defer func() { githubAPIBaseURL = oldBase }()
+	t.Setenv("GITHUB_TOKEN", "")
+
+	e, err := fetchGitHubHealth(srv.Client(), "github.com/acme/old/v2", "v2.0.0")
+	if err != nil {
+		t.Fatalf("fetchGitHubHealth: %v", err)
+	}
+	if e.Archived == nil || !*e.Archived || e.Stars == nil || *e.Stars != 12 {
+		t.Fatalf("unexpected health data: %+v", e)
+	}
+
+	enrichment := map[string]*ModuleEnrichment{"github.com/acme/old/v2": e}
+	markStale(enrichment, 365, time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC))
+	if !e.Stale {
+		t.Fatalf("expected module to be stale")
+	}
+	if got := formatEnrichment(e); !strings.HasPrefix(got, "[ARCHIVED STALE stars=12 last-commit=2020-01-02") {
+		t.Fatalf("unexpected annotation %q", got)
+	}
+}
+
+func TestFetchProxyReleases(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/github.com/!acme/lib/@v/v1.0.0.info":
+			_, _ = w.Write([]byte(`{"Version": "v1.0.0", "Time": "2020-01-01T00:00:00Z"}`))
+		case "/github.com/!acme/lib/@v/list":
+			_, _ = w.Write([]byte("v1.0.0\nv1.2.0\nv1.10.0\nv2.0.0-rc.1\n"))
+		case "/github.com/!acme/lib/@v/v1.10.0.info":
+			_, _ = w.Write([]byte(`{"Version": "v1.10.0", "Time": "2021-03-01T00:00:00Z"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	moduleProxyURL = srv.URL
+	defer func() { moduleProxyURL = "" }()
+
+	e, err := fetchProxyReleases(srv.Client(), "github.com/Acme/lib", "v1.0.0")
+	if err != nil {
+		t.Fatalf("fetchProxyReleases: %v", err)
+	}
+	if e.LatestVersion != "v1.10.0" || e.VersionTime == nil || e.LatestTime == nil {
+		t.Fatalf("unexpected release data: %+v", e)
+	}
+
+	enrichment := map[string]*ModuleEnrichment{"github.com/Acme/lib": e}
+	markVersionAge(enrichment, 365, time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC))
+	if !e.Old || *e.VersionAgeDays != 517 || *e.DaysSinceLatest != 92 {
+		t.Fatalf("unexpected age: old=%v age=%v since=%v", e.Old, *e.VersionAgeDays, *e.DaysSinceLatest)
+	}
+	if got := formatEnrichment(e); got != "[OLD version-age=517d latest=v1.10.0 (92d ago)]" {
+		t.Fatalf("unexpected annotation %q", got)
+	}
+}
+
+func TestFetchOSVVulnerabilities(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var q osvQuery
+		if r.URL.Path != "/v1/query" || json.NewDecoder(r.Body).Decode(&q) != nil {
+			http.NotFound(w, r)
+			return
+		}
+		if q.Package.Name != "golang.org/x/net" || q.Package.Ecosystem != "Go" || q.Version != "0.1.0" {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"vulns": [{"id": "GHSA-xxxx", "aliases": ["CVE-2023-1", "GO-2023-0002"]}, {"id": "GO-2023-0001"}]}`))
+	}))
+	defer srv.Close()
+	oldBase := osvAPIBaseURL
+	osvAPIBaseURL = srv.URL
+	defer func() { osvAPIBaseURL = oldBase }()
+
+	e, err := fetchOSVVulnerabilities(srv.Client(), "golang.org/x/net", "v0.1.0")
+	if err != nil {
+		t.Fatalf("fetchOSVVulnerabilities: %v", err)
+	}
+	if got := strings.Join(e.Vulnerabilities, ","); got != "GO-2023-0001,GO-2023-0002" {
+		t.Fatalf("vulnerabilities = %q", got)
+	}
+	if got := formatEnrichment(e); got != "[vulns=GO-2023-0001|GO-2023-0002]" {
+		t.Fatalf("unexpected annotation %q", got)
+	}
+
+	e, err = fetchOSVVulnerabilities(srv.Client(), "golang.org/x/net", "v0.2.0")
+	if err != nil || len(e.Vulnerabilities) != 0 {
+		t.Fatalf("clean version = %+v, %v", e, err)
+	}
+}
+
+func TestModuleProxyFor(t *testing.T) {
+	tests := []struct {
+		goproxy, noProxy, mod string
+		want                  string
+		skipped               bool
+	}{
+		{"https://proxy.golang.org,direct", "", "github.com/acme/lib", "https://proxy.golang.org", false},
+		{"https://goproxy.example.com/|https://proxy.golang.org", "", "github.com/acme/lib", "https://goproxy.example.com", false},
+		{"direct", "", "github.com/acme/lib", "", true},
+		{"off", "", "github.com/acme/lib", "", true},
+		{"https://proxy.golang.org", "github.com/acme,*.corp.example.com", "github.com/acme/lib", "", true},
+		{"https://proxy.golang.org", "github.com/acme,*.corp.example.com", "git.corp.example.com/team/svc", "", true},
+		{"https://proxy.golang.org", "github.com/acme/lib/v2", "github.com/acme/lib", "https://proxy.golang.org", false},
+		{"https://proxy.golang.org", "github.com/acme", "github.com/acmeco/lib", "https://proxy.golang.org", false},
+	}
+	for _, tt := range tests {
+		got, err := moduleProxyFor(tt.goproxy, tt.noProxy, tt.mod)
+		var skip *enrichSkipped
+		if got != tt.want || errors.As(err, &skip) != tt.skipped {
+			t.Errorf("moduleProxyFor(%q, %q, %q) = %q, %v", tt.goproxy, tt.noProxy, tt.mod, got, err)
+		}
+	}
+}
+
+func TestEnrichModulesSkipped(t *testing.T) {
+	enrichCacheDir = t.TempDir()
+	defer func() { enrichCacheDir = "" }()
+	enrichProviders["skip-test"] = func(*http.Client, string, string) (*ModuleEnrichment, error) {
+		return nil, &enrichSkipped{reason: "not looked up"}
+	}
+	defer delete(enrichProviders, "skip-test")
+
+	_, warnings := enrichModules([]string{"a", "b", "c"}, map[string]string{"a": "v1.0.0", "b": "v1.0.0", "c": "v1.0.0"}, []string{"skip-test"})
+	if want := []string{"skip-test: not looked up"}; !reflect.DeepEqual(warnings, want) {
+		t.Errorf("warnings = %v, want %v", warnings, want)
+	}
+}
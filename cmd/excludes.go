@@ -0,0 +1,381 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var excludesFailOnUnused bool
+
+// ExcludeEffect is an exclude directive of go.mod with the requirements it
+// removed from the module graph.
+type ExcludeEffect struct {
+	Module  string `json:"module"`
+	Version string `json:"version"`
+	// Selected is the version of the module MVS selects instead, empty
+	// when the module is not in the graph.
+	Selected string `json:"selected,omitempty"`
+	// Requests are the requirements naming the excluded version, which the
+	// go command dropped, from module@version. An exclude without requests
+	// has no effect.
+	Requests  []Requirement `json:"requests"`
+	Effective bool          `json:"effective"`
+	// Reason explains why an exclude has no effect.
+	Reason string `json:"reason,omitempty"`
+}
+
+// ExcludesResult lists the exclude directives of go.mod.
+type ExcludesResult struct {
+	Excludes []ExcludeEffect `json:"excludes"`
+	// Unused is the number of excludes without effect.
+	Unused int `json:"unused"`
+	// Unresolved lists the module versions whose go.mod could not be read,
+	// whose requirements are missing from Requests.
+	Unresolved []string `json:"unresolved,omitempty"`
+}
+
+var excludesCmd = &cobra.Command{
+	Use:   "excludes",
+	Short: "Show what the exclude directives of go.mod remove from the graph",
+	Long: `Lists the exclude directives of the go.mod in --dir with the requirements
+each one removes from the module graph.
+
+"go mod graph" never shows a requirement naming an excluded version: the go
+command drops it, so the modules asking for that version silently get the
+selected one instead. excludes reads the go.mod of every module version the
+graph was read from, whether selected or not (with "go list -m -json", from
+the module cache when possible), to find those requirements again.
+
+An exclude no module of the graph requests has no effect and can be deleted;
+with --fail-on-unused such excludes make the command exit with code 3.
+"depstat lint" reports the same excludes as its unused-exclude check.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("excludes does not take any arguments"))
+		}
+		gomod, err := readGoModFile()
+		if err != nil {
+			return err
+		}
+		depGraph := getDepInfo(mainModules)
+		unresolved, err := attachExcludedRequirements(depGraph, gomod)
+		if err != nil {
+			return err
+		}
+		result := findExcludeEffects(depGraph, gomod.Exclude)
+		result.Unresolved = unresolved
+		if jsonOutput {
+			if err := writeJSON(os.Stdout, result); err != nil {
+				return err
+			}
+		} else {
+			printExcludes(result)
+		}
+		if excludesFailOnUnused && result.Unused > 0 {
+			cmd.SilenceUsage = true
+			return withExitCode(ExitViolation, fmt.Errorf("%d exclude directive(s) have no effect", result.Unused))
+		}
+		return nil
+	},
+}
+
+// attachExcludedRequirements records in depGraph.ExcludedRequirements the
+// requirements of the main module and of every module version the graph was
+// read from that name a version excluded by gomod; From is the module@version
+// requiring it, or the main module path. It returns the module versions
+// whose go.mod could not be read.
+func attachExcludedRequirements(depGraph *DependencyOverview, gomod *goModFile) ([]string, error) {
+	depGraph.ExcludedRequirements = make(map[string][]Requirement)
+	if len(gomod.Exclude) == 0 {
+		return nil, nil
+	}
+	goMods := map[string][]goModVersion{gomod.Module.Path: requireVersions(gomod.Require)}
+	loaded, unresolved, err := readGraphGoMods(depGraph, gomod)
+	if err != nil {
+		return nil, err
+	}
+	for node, reqs := range loaded {
+		goMods[node] = reqs
+	}
+	excluded := make(map[goModVersion]bool)
+	for _, ex := range gomod.Exclude {
+		excluded[ex] = true
+	}
+	for from, reqs := range goMods {
+		for _, r := range reqs {
+			if excluded[r] {
+				depGraph.ExcludedRequirements[r.Path] = append(depGraph.ExcludedRequirements[r.Path], Requirement{From: from, Version: r.Version})
+			}
+		}
+	}
+	for _, reqs := range depGraph.ExcludedRequirements {
+		sort.Slice(reqs, func(i, j int) bool {
+			if reqs[i].Version != reqs[j].Version {
+				return reqs[i].Version < reqs[j].Version
+			}
+			return reqs[i].From < reqs[j].From
+		})
+	}
+	return unresolved, nil
+}
+
+func requireVersions(reqs []goModRequirement) []goModVersion {
+	versions := make([]goModVersion, 0, len(reqs))
+	for _, r := range reqs {
+		versions = append(versions, goModVersion{Path: r.Path, Version: r.Version})
+	}
+	return versions
+}
+
+// readGraphGoMods returns the requirements in the go.mod of every module
+// version on the left side of an edge of the "go mod graph" output, by
+// module@version: every version the graph was read from, not only the
+// selected one. Modules replaced by a directory are read from it; the
+// others are located with a single "go list -m -e -json", whose failures
+// are returned as unresolved.
+func readGraphGoMods(depGraph *DependencyOverview, gomod *goModFile) (map[string][]goModVersion, []string, error) {
+	goMods := make(map[string][]goModVersion)
+	modDir := filepath.Dir(goModPath())
+	var queries []string
+	owners := make(map[string][]string) // queried module@version to graph nodes
+	var unresolved []string
+	for _, node := range graphLoadedNodes(depGraph.rawGraph) {
+		mod := parseModule(node)
+		target := goModVersion{Path: mod.name, Version: mod.version}
+		if rep, ok := findReplacement(gomod.Replace, target); ok {
+			if rep.Version == "" {
+				path := rep.Path
+				if !filepath.IsAbs(path) {
+					path = filepath.Join(modDir, path)
+				}
+				data, err := os.ReadFile(filepath.Join(path, "go.mod"))
+				if err != nil {
+					unresolved = append(unresolved, node)
+					continue
+				}
+				goMods[node] = parseGoModRequires(data)
+				continue
+			}
+			target = rep
+		}
+		query := target.Path + "@" + target.Version
+		if owners[query] == nil {
+			queries = append(queries, query)
+		}
+		owners[query] = append(owners[query], node)
+	}
+	if len(queries) > 0 {
+		c := goCommand(append([]string{"list", "-m", "-e", "-json"}, queries...))
+		out, err := c.Output()
+		if err != nil {
+			return nil, nil, goCommandError(c, err)
+		}
+		results, err := decodeGoModInfos(bytes.NewReader(out))
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, r := range results {
+			query := r.Path + "@" + r.Version
+			nodes, ok := owners[query]
+			if !ok {
+				continue
+			}
+			delete(owners, query)
+			data, err := os.ReadFile(r.GoMod)
+			if r.Error != nil || r.GoMod == "" || err != nil {
+				unresolved = append(unresolved, nodes...)
+				continue
+			}
+			for _, node := range nodes {
+				goMods[node] = parseGoModRequires(data)
+			}
+		}
+		for _, nodes := range owners {
+			unresolved = append(unresolved, nodes...)
+		}
+	}
+	sort.Strings(unresolved)
+	if len(unresolved) > 0 {
+		warnf("could not read the go.mod of %d module version(s): %s\n", len(unresolved), strings.Join(unresolved, ", "))
+	}
+	return goMods, unresolved, nil
+}
+
+// graphLoadedNodes returns the sorted module@version nodes on the left side
+// of the edges of "go mod graph" output, without the versionless main
+// modules and the go and toolchain pseudo-modules.
+func graphLoadedNodes(graphOutput string) []string {
+	seen := make(map[string]bool)
+	var nodes []string
+	for _, line := range strings.Split(graphOutput, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || seen[fields[0]] {
+			continue
+		}
+		seen[fields[0]] = true
+		mod := parseModule(fields[0])
+		if mod.version == "" || mod.name == "go" || mod.name == "toolchain" {
+			continue
+		}
+		nodes = append(nodes, fields[0])
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// findReplacement returns the replacement of mod, preferring a replace
+// directive for its exact version over one for all versions.
+func findReplacement(replaces []goModReplace, mod goModVersion) (goModVersion, bool) {
+	var found goModVersion
+	ok := false
+	for _, rep := range replaces {
+		if rep.Old.Path != mod.Path {
+			continue
+		}
+		if rep.Old.Version == mod.Version {
+			return rep.New, true
+		}
+		if rep.Old.Version == "" {
+			found, ok = rep.New, true
+		}
+	}
+	return found, ok
+}
+
+// goModInfo locates the go.mod of a module version, as printed by
+// go list -m -e -json module@version.
+type goModInfo struct {
+	Path    string
+	Version string
+	GoMod   string
+	Error   *struct{ Err string }
+}
+
+func decodeGoModInfos(r io.Reader) ([]goModInfo, error) {
+	var infos []goModInfo
+	dec := json.NewDecoder(r)
+	for {
+		var info goModInfo
+		if err := dec.Decode(&info); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("parsing go list output: %v", err)
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// parseGoModRequires returns the require directives of a go.mod file.
+func parseGoModRequires(data []byte) []goModVersion {
+	var reqs []goModVersion
+	inBlock := false
+	for _, line := range strings.Split(string(data), "\n") {
+		if j := strings.Index(line, "//"); j >= 0 {
+			line = line[:j]
+		}
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+		case inBlock && fields[0] == ")":
+			inBlock = false
+		case inBlock && len(fields) >= 2:
+			reqs = append(reqs, goModVersion{Path: strings.Trim(fields[0], `"`), Version: fields[1]})
+		case fields[0] == "require" && len(fields) == 2 && fields[1] == "(":
+			inBlock = true
+		case fields[0] == "require" && len(fields) >= 3:
+			reqs = append(reqs, goModVersion{Path: strings.Trim(fields[1], `"`), Version: fields[2]})
+		}
+	}
+	return reqs
+}
+
+// findExcludeEffects pairs every exclude directive with the requirements
+// it removed, as recorded by attachExcludedRequirements.
+func findExcludeEffects(depGraph *DependencyOverview, excludes []goModVersion) ExcludesResult {
+	result := ExcludesResult{Excludes: []ExcludeEffect{}}
+	for _, ex := range excludes {
+		effect := ExcludeEffect{Module: ex.Path, Version: ex.Version, Selected: mvsSelectedVersion(depGraph, ex.Path), Requests: []Requirement{}}
+		for _, r := range depGraph.ExcludedRequirements[ex.Path] {
+			if r.Version == ex.Version {
+				effect.Requests = append(effect.Requests, r)
+			}
+		}
+		effect.Effective = len(effect.Requests) > 0
+		switch {
+		case effect.Effective:
+		case effect.Selected == "":
+			effect.Reason = fmt.Sprintf("%s is not in the module graph", ex.Path)
+		default:
+			effect.Reason = fmt.Sprintf("no module in the graph requires %s (%s is selected)", ex.Version, effect.Selected)
+		}
+		if !effect.Effective {
+			result.Unused++
+		}
+		result.Excludes = append(result.Excludes, effect)
+	}
+	return result
+}
+
+func printExcludes(result ExcludesResult) {
+	if len(result.Excludes) == 0 {
+		fmt.Println("No exclude directives in go.mod.")
+		return
+	}
+	var unused []ExcludeEffect
+	for _, e := range result.Excludes {
+		if !e.Effective {
+			unused = append(unused, e)
+			continue
+		}
+		fmt.Printf("exclude %s %s: %d requirement(s) dropped", e.Module, e.Version, len(e.Requests))
+		if e.Selected != "" {
+			fmt.Printf(", %s used instead", e.Selected)
+		}
+		fmt.Println()
+		for _, r := range e.Requests {
+			fmt.Printf("  required by %s\n", r.From)
+		}
+	}
+	if len(unused) > 0 {
+		fmt.Printf("Excludes with no effect (%d):\n", len(unused))
+		for _, e := range unused {
+			fmt.Printf("  exclude %s %s: %s\n", e.Module, e.Version, e.Reason)
+		}
+	}
+	if len(result.Unresolved) > 0 {
+		fmt.Printf("Could not read the go.mod of: %s\n", strings.Join(result.Unresolved, ", "))
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(excludesCmd)
+	excludesCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
+	excludesCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Get the output in JSON format")
+	excludesCmd.Flags().BoolVar(&excludesFailOnUnused, "fail-on-unused", false, "Exit with code 3 when an exclude directive has no effect")
+	excludesCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Specify main modules")
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseGoModRequires(t *testing.T) {
+	data := []byte(`module example.com/m
+
+go 1.22
+
+require example.com/a v1.0.0 // indirect
+
+require (
+	// comment
+	example.com/b v1.2.0
+	"example.com/c" v0.1.0
+)
+
+exclude example.com/d v1.0.0
+`)
+	want := []goModVersion{{"example.com/a", "v1.0.0"}, {"example.com/b", "v1.2.0"}, {"example.com/c", "v0.1.0"}}
+	if got := parseGoModRequires(data); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseGoModRequires = %v, want %v", got, want)
+	}
+}
+
+func TestFindReplacement(t *testing.T) {
+	replaces := []goModReplace{
+		{Old: goModVersion{Path: "example.com/a"}, New: goModVersion{Path: "../a"}},
+		{Old: goModVersion{Path: "example.com/a", Version: "v1.0.0"}, New: goModVersion{Path: "example.com/fork", Version: "v1.0.1"}},
+	}
+	if rep, ok := findReplacement(replaces, goModVersion{"example.com/a", "v1.0.0"}); !ok || rep.Path != "example.com/fork" {
+		t.Errorf("exact version replacement = %v, %v", rep, ok)
+	}
+	if rep, ok := findReplacement(replaces, goModVersion{"example.com/a", "v2.0.0"}); !ok || rep.Path != "../a" {
+		t.Errorf("all versions replacement = %v, %v", rep, ok)
+	}
+	if _, ok := findReplacement(replaces, goModVersion{"example.com/b", "v1.0.0"}); ok {
+		t.Error("expected no replacement for example.com/b")
+	}
+}
+
+func TestExcludeEffects(t *testing.T) {
+	tmp := t.TempDir()
+	writeFile := func(path, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(filepath.Join(tmp, "app", "go.mod"), "module example.com/app\n")
+	writeFile(filepath.Join(tmp, "y", "go.mod"), "module example.com/y\n\nrequire example.com/x v1.0.0\n")
+	writeFile(filepath.Join(tmp, "y09", "go.mod"), "module example.com/y\n\nrequire example.com/x v0.9.0\n")
+	oldDir := dir
+	dir = filepath.Join(tmp, "app")
+	defer func() { dir = oldDir }()
+
+	// y@v0.9.0 is not selected, but its go.mod was read and its request for
+	// the excluded x@v0.9.0 dropped all the same.
+	graph := `example.com/app example.com/y@v1.0.0
+example.com/app example.com/x@v1.1.0
+example.com/y@v1.0.0 example.com/x@v1.1.0
+example.com/x@v1.1.0 example.com/y@v0.9.0
+example.com/y@v0.9.0 example.com/x@v1.1.0`
+	depGraph := generateGraph(graph, []string{"example.com/app"})
+	depGraph.rawGraph = graph
+	gomod := &goModFile{
+		Module:  goModVersion{Path: "example.com/app"},
+		Require: []goModRequirement{{Path: "example.com/y", Version: "v1.0.0"}, {Path: "example.com/x", Version: "v1.1.0"}},
+		Exclude: []goModVersion{{"example.com/x", "v1.0.0"}, {"example.com/x", "v0.9.0"}, {"example.com/x", "v1.2.0"}, {"example.com/z", "v1.0.0"}},
+		Replace: []goModReplace{
+			{Old: goModVersion{Path: "example.com/x"}, New: goModVersion{Path: "../x"}},
+			{Old: goModVersion{Path: "example.com/y", Version: "v0.9.0"}, New: goModVersion{Path: "../y09"}},
+			{Old: goModVersion{Path: "example.com/y"}, New: goModVersion{Path: "../y"}},
+		},
+	}
+	unresolved, err := attachExcludedRequirements(&depGraph, gomod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// ../x has no go.mod
+	if want := []string{"example.com/x@v1.1.0"}; !reflect.DeepEqual(unresolved, want) {
+		t.Errorf("unresolved = %v, want %v", unresolved, want)
+	}
+
+	result := findExcludeEffects(&depGraph, gomod.Exclude)
+	if result.Unused != 2 {
+		t.Errorf("unused = %d, want 2", result.Unused)
+	}
+	want := []ExcludeEffect{{
+		Module:    "example.com/x",
+		Version:   "v1.0.0",
+		Selected:  "v1.1.0",
+		Requests:  []Requirement{{From: "example.com/y@v1.0.0", Version: "v1.0.0"}},
+		Effective: true,
+	}, {
+		Module:    "example.com/x",
+		Version:   "v0.9.0",
+		Selected:  "v1.1.0",
+		Requests:  []Requirement{{From: "example.com/y@v0.9.0", Version: "v0.9.0"}},
+		Effective: true,
+	}}
+	if !reflect.DeepEqual(result.Excludes[:2], want) {
+		t.Errorf("effects = %+v, want %+v", result.Excludes[:2], want)
+	}
+	if r := result.Excludes[2].Reason; r != "no module in the graph requires v1.2.0 (v1.1.0 is selected)" {
+		t.Errorf("reason = %q", r)
+	}
+	if r := result.Excludes[3].Reason; r != "example.com/z is not in the module graph" {
+		t.Errorf("reason = %q", r)
+	}
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// explainExclusions is set by --explain-exclusions.
+var explainExclusions bool
+
+// excludeEdges holds the raw --exclude-edges values and edgeExclusions the
+// rules parsed from them by the root command.
+var excludeEdges []string
+var edgeExclusions []EdgeExclusion
+
+// exclusionExamples is the number of example modules and edges listed per
+// pattern.
+const exclusionExamples = 3
+
+// ExclusionEffect is what a single --exclude-modules pattern removed from
+// the graph when applied on its own.
+type ExclusionEffect struct {
+	Pattern string
+	// Matched lists the modules whose path matches the pattern.
+	Matched []string
+	// Removed lists the modules no longer reachable, matched or not.
+	Removed []string
+	// RemovedEdges lists the edges dropped, as "from -> to".
+	RemovedEdges []string
+}
+
+// excludeModulesFrom applies the exclusion patterns to the graph and, with
+// --explain-exclusions, reports the effect of every pattern on stderr.
+func excludeModulesFrom(depGraph DependencyOverview, patterns []string) DependencyOverview {
+	excluded := applyModuleExclusions(depGraph, patterns)
+	if explainExclusions && len(patterns) > 0 {
+		writeExclusionEffects(logOutput, computeExclusionEffects(depGraph, patterns))
+		if len(patterns) > 1 {
+			before := len(uniqueStrings(getAllDeps(depGraph.DirectDepList, depGraph.TransDepList)))
+			after := len(uniqueStrings(getAllDeps(excluded.DirectDepList, excluded.TransDepList)))
+			fmt.Fprintf(logOutput, "  together: removed %d of %d dependencies and %d edges\n", before-after, before, len(getEdges(depGraph.Graph))-len(getEdges(excluded.Graph)))
+		}
+	}
+	return excluded
+}
+
+// computeExclusionEffects applies every pattern separately, so a pattern
+// whose modules are also removed by another one is still credited.
+func computeExclusionEffects(depGraph DependencyOverview, patterns []string) []ExclusionEffect {
+	nodes := append(append([]string{}, depGraph.MainModules...), getAllDeps(depGraph.DirectDepList, depGraph.TransDepList)...)
+	edges := getEdges(depGraph.Graph)
+	var effects []ExclusionEffect
+	for _, pattern := range patterns {
+		effect := ExclusionEffect{Pattern: pattern}
+		for _, n := range uniqueStrings(nodes) {
+			if moduleExcluded(n, []string{pattern}) {
+				effect.Matched = append(effect.Matched, n)
+			}
+		}
+		if len(effect.Matched) > 0 {
+			after := applyModuleExclusions(depGraph, []string{pattern})
+			afterNodes := append(append([]string{}, after.MainModules...), getAllDeps(after.DirectDepList, after.TransDepList)...)
+			effect.Removed = diffSlices(afterNodes, uniqueStrings(nodes))
+			effect.RemovedEdges = diffSlices(getEdges(after.Graph), edges)
+		}
+		effects = append(effects, effect)
+	}
+	return effects
+}
+
+func writeExclusionEffects(w io.Writer, effects []ExclusionEffect) {
+	fmt.Fprintln(w, "Exclusions:")
+	for _, e := range effects {
+		if len(e.Matched) == 0 {
+			fmt.Fprintf(w, "  %q matched no module and removed nothing\n", e.Pattern)
+			continue
+		}
+		fmt.Fprintf(w, "  %q matched %d modules (%s); removed %d modules and %d edges\n",
+			e.Pattern, len(e.Matched), exclusionExampleList(e.Matched), len(e.Removed), len(e.RemovedEdges))
+		if unmatched := diffSlices(e.Matched, e.Removed); len(unmatched) > 0 {
+			fmt.Fprintf(w, "    only reachable through them: %s\n", exclusionExampleList(unmatched))
+		}
+		if len(e.RemovedEdges) > 0 {
+			fmt.Fprintf(w, "    edges: %s\n", exclusionExampleList(e.RemovedEdges))
+		}
+	}
+}
+
+// exclusionExampleList joins the first few items, noting how many are left.
+func exclusionExampleList(items []string) string {
+	if len(items) <= exclusionExamples {
+		return strings.Join(items, ", ")
+	}
+	return fmt.Sprintf("%s, and %d more", strings.Join(items[:exclusionExamples], ", "), len(items)-exclusionExamples)
+}
+
+// EdgeExclusion drops the requirement edges from modules matching From to
+// modules matching To. An empty pattern matches every module.
+type EdgeExclusion struct {
+	From string
+	To   string
+}
+
+func (e EdgeExclusion) String() string {
+	var parts []string
+	if e.From != "" {
+		parts = append(parts, "from="+e.From)
+	}
+	if e.To != "" {
+		parts = append(parts, "to="+e.To)
+	}
+	return strings.Join(parts, ",")
+}
+
+func (e EdgeExclusion) matches(from, to string) bool {
+	return (e.From == "" || matchModulePattern(from, e.From)) && (e.To == "" || matchModulePattern(to, e.To))
+}
+
+// parseEdgeExclusions parses --exclude-edges values of the form
+// from=pattern,to=pattern, where either side may be left out.
+func parseEdgeExclusions(values []string) ([]EdgeExclusion, error) {
+	var rules []EdgeExclusion
+	for _, value := range values {
+		var rule EdgeExclusion
+		for _, part := range strings.Split(value, ",") {
+			key, pattern, ok := strings.Cut(strings.TrimSpace(part), "=")
+			if !ok || pattern == "" {
+				return nil, fmt.Errorf("--exclude-edges %q: want from=pattern,to=pattern", value)
+			}
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("--exclude-edges %q: bad pattern %q: %w", value, pattern, err)
+			}
+			switch key {
+			case "from":
+				rule.From = pattern
+			case "to":
+				rule.To = pattern
+			default:
+				return nil, fmt.Errorf("--exclude-edges %q: unknown key %q (want from or to)", value, key)
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// edgeExcluded reports whether any rule drops the edge from -> to.
+func edgeExcluded(from, to string, rules []EdgeExclusion) bool {
+	for _, rule := range rules {
+		if rule.matches(from, to) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyEdgeExclusions drops the edges matched by the rules. Modules stay in
+// the graph as long as another path still reaches them.
+func applyEdgeExclusions(depGraph DependencyOverview, rules []EdgeExclusion) DependencyOverview {
+	if len(rules) == 0 {
+		return depGraph
+	}
+	return filterReachable(depGraph, depGraph.MainModules, func(from, to string) bool {
+		return !edgeExcluded(from, to, rules)
+	})
+}
+
+// excludeEdgesFrom applies the edge exclusion rules to the graph and, with
+// --explain-exclusions, reports what every rule removed on stderr.
+func excludeEdgesFrom(depGraph DependencyOverview, rules []EdgeExclusion) DependencyOverview {
+	excluded := applyEdgeExclusions(depGraph, rules)
+	if explainExclusions && len(rules) > 0 {
+		writeEdgeExclusionEffects(logOutput, depGraph, rules)
+	}
+	return excluded
+}
+
+func writeEdgeExclusionEffects(w io.Writer, depGraph DependencyOverview, rules []EdgeExclusion) {
+	nodes := uniqueStrings(getAllDeps(depGraph.DirectDepList, depGraph.TransDepList))
+	fmt.Fprintln(w, "Edge exclusions:")
+	for _, rule := range rules {
+		var matched []string
+		for _, edge := range getEdges(depGraph.Graph) {
+			from, to, _ := strings.Cut(edge, " -> ")
+			if rule.matches(from, to) {
+				matched = append(matched, edge)
+			}
+		}
+		if len(matched) == 0 {
+			fmt.Fprintf(w, "  %q matched no edge and removed nothing\n", rule.String())
+			continue
+		}
+		after := applyEdgeExclusions(depGraph, []EdgeExclusion{rule})
+		removed := diffSlices(getAllDeps(after.DirectDepList, after.TransDepList), nodes)
+		fmt.Fprintf(w, "  %q matched %d edges (%s); removed %d modules\n", rule.String(), len(matched), exclusionExampleList(matched), len(removed))
+		if len(removed) > 0 {
+			fmt.Fprintf(w, "    no longer reachable: %s\n", exclusionExampleList(removed))
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestComputeExclusionEffects(t *testing.T) {
+	depGraph := generateGraph(`main A@v1.0.0
+main B@v1.0.0
+A@v1.0.0 C@v1.0.0
+B@v1.0.0 C@v1.0.0
+A@v1.0.0 D@v1.0.0`, []string{"main"})
+	effects := computeExclusionEffects(depGraph, []string{"A", "x.io/*"})
+	if len(effects) != 2 {
+		t.Fatalf("expected one effect per pattern, got %+v", effects)
+	}
+	a := effects[0]
+	if !reflect.DeepEqual(a.Matched, []string{"A"}) || !reflect.DeepEqual(a.Removed, []string{"A", "D"}) {
+		t.Errorf("unexpected effect of A: %+v", a)
+	}
+	if !reflect.DeepEqual(a.RemovedEdges, []string{"A -> C", "A -> D", "main -> A"}) {
+		t.Errorf("unexpected removed edges %v", a.RemovedEdges)
+	}
+	if len(effects[1].Matched) != 0 || len(effects[1].Removed) != 0 {
+		t.Errorf("expected x.io/* to match nothing, got %+v", effects[1])
+	}
+
+	var buf bytes.Buffer
+	writeExclusionEffects(&buf, effects)
+	for _, want := range []string{
+		`"A" matched 1 modules (A); removed 2 modules and 3 edges`,
+		`only reachable through them: D`,
+		`"x.io/*" matched no module and removed nothing`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestApplyEdgeExclusions(t *testing.T) {
+	depGraph := generateGraph(`main A@v1.0.0
+main B@v1.0.0
+A@v1.0.0 C@v1.0.0
+B@v1.0.0 C@v1.0.0
+A@v1.0.0 D@v1.0.0`, []string{"main"})
+	rules, err := parseEdgeExclusions([]string{"from=A,to=*", "to=B"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	after := applyEdgeExclusions(depGraph, rules)
+	if got := getEdges(after.Graph); !reflect.DeepEqual(got, []string{"main -> A"}) {
+		t.Errorf("unexpected edges %v", got)
+	}
+	if got := getAllDeps(after.DirectDepList, after.TransDepList); !reflect.DeepEqual(got, []string{"A"}) {
+		t.Errorf("unexpected dependencies %v", got)
+	}
+
+	// C is still reached through B, so only D goes away with A's edges.
+	rules, _ = parseEdgeExclusions([]string{"from=A"})
+	after = applyEdgeExclusions(depGraph, rules)
+	if got := uniqueStrings(getAllDeps(after.DirectDepList, after.TransDepList)); !reflect.DeepEqual(got, []string{"A", "B", "C"}) {
+		t.Errorf("unexpected dependencies %v", got)
+	}
+	if len(after.Requirements["C"]) != 1 || after.Requirements["C"][0].From != "B" {
+		t.Errorf("expected only B's requirement of C, got %+v", after.Requirements["C"])
+	}
+
+	var buf bytes.Buffer
+	writeEdgeExclusionEffects(&buf, depGraph, append(rules, EdgeExclusion{From: "x.io/*"}))
+	for _, want := range []string{
+		`"from=A" matched 2 edges (A -> C, A -> D); removed 1 modules`,
+		`no longer reachable: D`,
+		`"from=x.io/*" matched no edge and removed nothing`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, buf.String())
+		}
+	}
+
+	for _, bad := range []string{"A", "from=", "via=A", "from=[a"} {
+		if _, err := parseEdgeExclusions([]string{bad}); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Process exit codes of depstat. Scripts can rely on these values.
+const (
+	// ExitOK means the command succeeded.
+	ExitOK = 0
+	// ExitError means the analysis failed, e.g. go or git returned an error.
+	ExitError = 1
+	// ExitNotFound means the requested module is not in the dependency
+	// graph (path, focus, dominators, and why with --fail-if-not-found).
+	ExitNotFound = 2
+	// ExitViolation means the analysis succeeded but a policy or threshold
+	// was violated (check, hygiene --fail-on).
+	ExitViolation = 3
+	// ExitUsage means invalid flags or arguments.
+	ExitUsage = 4
+)
+
+// exitError attaches an exit code to an error returned by a command.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+
+func (e *exitError) Unwrap() error { return e.err }
+
+func withExitCode(code int, err error) error {
+	return &exitError{code: code, err: err}
+}
+
+// exitCode returns the process exit code for an error returned by a command.
+func exitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var e *exitError
+	if errors.As(err, &e) {
+		return e.code
+	}
+	return ExitError
+}
+
+// errNoMainModules is returned when the exclusions remove every main module.
+var errNoMainModules = errors.New("no main modules remain after exclusions; adjust --exclude-modules or --mainModules")
+
+// Error codes of the JSON error object printed by commands run with --json.
+const (
+	ErrorCodeUsage         = "usage"
+	ErrorCodeNotFound      = "not_found"
+	ErrorCodeViolation     = "violation"
+	ErrorCodeNoMainModules = "no_main_modules"
+	ErrorCodeGoCommand     = "go_command_failed"
+	ErrorCodeError         = "error"
+)
+
+// JSONError is printed on stdout instead of the plain-text message when a
+// command run with --json fails.
+type JSONError struct {
+	Error JSONErrorDetail `json:"error"`
+}
+
+// JSONErrorDetail describes a failure for automation: Code is one of the
+// ErrorCode constants and ExitCode the process exit code.
+type JSONErrorDetail struct {
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	ExitCode int    `json:"exitCode"`
+}
+
+// jsonErrors is set when the running command was given --json.
+var jsonErrors bool
+
+// jsonResultWritten is set once writeJSON wrote a document to stdout, so a
+// later error does not follow the result with a second JSON object.
+var jsonResultWritten bool
+
+// errorCode classifies an error returned by a command for JSON output.
+func errorCode(err error) string {
+	var goErr *goCommandFailure
+	switch {
+	case errors.Is(err, errNoMainModules):
+		return ErrorCodeNoMainModules
+	case errors.As(err, &goErr):
+		return ErrorCodeGoCommand
+	}
+	switch exitCode(err) {
+	case ExitUsage:
+		return ErrorCodeUsage
+	case ExitNotFound:
+		return ErrorCodeNotFound
+	case ExitViolation:
+		return ErrorCodeViolation
+	}
+	return ErrorCodeError
+}
+
+func newJSONError(err error) JSONError {
+	return JSONError{Error: JSONErrorDetail{Code: errorCode(err), Message: err.Error(), ExitCode: exitCode(err)}}
+}
+
+// reportError prints the error of a failed command: as a JSON error object
+// on stdout with --json, and as plain text on stderr otherwise. Violations,
+// and errors returned after the command wrote its JSON result (such as why
+// --fail-if-not-found), keep the plain text, since stdout already holds
+// the result.
+func reportError(err error) {
+	if jsonErrors && exitCode(err) != ExitViolation && !jsonResultWritten {
+		if writeJSON(os.Stdout, newJSONError(err)) == nil {
+			return
+		}
+	}
+	fmt.Fprintln(os.Stderr, err)
+}
+
+// fatal reports err and exits, for code paths that cannot return an error
+// to the command, such as getDepInfo.
+func fatal(err error) {
+	finishDryRun()
+	_ = finishDigestCapture() // restore stdout; a failed run has no digest
+	if profileErr := stopProfiling(); profileErr != nil {
+		fmt.Fprintln(os.Stderr, profileErr)
+	}
+	reportError(err)
+	os.Exit(exitCode(err))
+}
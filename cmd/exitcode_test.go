@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, ExitOK},
+		{errors.New("boom"), ExitError},
+		{withExitCode(ExitNotFound, errors.New("missing")), ExitNotFound},
+		{fmt.Errorf("wrapped: %w", withExitCode(ExitViolation, errors.New("violation"))), ExitViolation},
+	}
+	for _, tt := range tests {
+		if got := exitCode(tt.err); got != tt.want {
+			t.Errorf("exitCode(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestUsageArgs(t *testing.T) {
+	root := &cobra.Command{Use: "root"}
+	sub := &cobra.Command{Use: "sub", Args: cobra.ExactArgs(1), RunE: func(*cobra.Command, []string) error { return nil }}
+	root.AddCommand(sub)
+	usageArgs(root)
+	if err := sub.Args(sub, nil); exitCode(err) != ExitUsage {
+		t.Errorf("exit code of %v = %d, want %d", err, exitCode(err), ExitUsage)
+	}
+	if err := sub.Args(sub, []string{"a"}); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestWhyNotFound(t *testing.T) {
+	defer func() { whyFailIfNotFound = false }()
+	whyFailIfNotFound = false
+	if err := whyNotFound(nil, "example.com/x"); err != nil {
+		t.Errorf("expected nil without --fail-if-not-found, got %v", err)
+	}
+	whyFailIfNotFound = true
+	if got := exitCode(whyNotFound(nil, "example.com/x")); got != ExitNotFound {
+		t.Errorf("exit code = %d, want %d", got, ExitNotFound)
+	}
+	writeErr := errors.New("write failed")
+	if err := whyNotFound(writeErr, "example.com/x"); err != writeErr {
+		t.Errorf("expected output error to pass through, got %v", err)
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{errors.New("boom"), ErrorCodeError},
+		{withExitCode(ExitUsage, errors.New("unknown flag")), ErrorCodeUsage},
+		{withExitCode(ExitNotFound, errors.New("missing")), ErrorCodeNotFound},
+		{withExitCode(ExitViolation, errors.New("violation")), ErrorCodeViolation},
+		{errNoMainModules, ErrorCodeNoMainModules},
+		{fmt.Errorf("set A: %w", errNoMainModules), ErrorCodeNoMainModules},
+		{&goCommandFailure{msg: "go mod graph failed"}, ErrorCodeGoCommand},
+		{fmt.Errorf("go list -m -json all: %w", &goCommandFailure{msg: "exit status 1"}), ErrorCodeGoCommand},
+	}
+	for _, tt := range tests {
+		if got := errorCode(tt.err); got != tt.want {
+			t.Errorf("errorCode(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+	got := newJSONError(withExitCode(ExitNotFound, errors.New("missing")))
+	want := JSONErrorDetail{Code: ErrorCodeNotFound, Message: "missing", ExitCode: ExitNotFound}
+	if got.Error != want {
+		t.Errorf("newJSONError = %+v, want %+v", got.Error, want)
+	}
+}
+
+func TestReportErrorAfterJSONResult(t *testing.T) {
+	tmp := t.TempDir()
+	stdout, err := os.Create(filepath.Join(tmp, "stdout"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr, err := os.Create(filepath.Join(tmp, "stderr"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldStdout, oldStderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = stdout, stderr
+	defer func() {
+		os.Stdout, os.Stderr = oldStdout, oldStderr
+		jsonErrors, jsonResultWritten = false, false
+	}()
+	jsonErrors, jsonResultWritten = true, false
+
+	notFound := withExitCode(ExitNotFound, errors.New("no dependency paths found for x"))
+	reportError(notFound)
+	if !jsonResultWritten {
+		t.Error("the JSON error object should count as the result")
+	}
+	jsonResultWritten = false
+	if err := writeJSON(os.Stdout, WhyResult{Target: "x"}); err != nil {
+		t.Fatal(err)
+	}
+	reportError(notFound)
+
+	out, _ := os.ReadFile(stdout.Name())
+	if n := strings.Count(string(out), `"error"`); n != 1 {
+		t.Errorf("stdout has %d error objects, want 1:\n%s", n, out)
+	}
+	if errOut, _ := os.ReadFile(stderr.Name()); string(errOut) != "no dependency paths found for x\n" {
+		t.Errorf("stderr = %q", errOut)
+	}
+}
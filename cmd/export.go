@@ -0,0 +1,357 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var exportSQLite string
+var exportSQL string
+var exportCSVDir string
+
+// exportTable is one relational table of the export. Cell values are nil
+// (NULL), string, int, float64 or bool.
+type exportTable struct {
+	Name    string
+	Columns []exportColumn
+	Rows    [][]any
+}
+
+type exportColumn struct {
+	Name string
+	Type string
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the dependency graph as relational tables (SQLite, SQL or CSV)",
+	Long: `Writes the dependency graph as relational tables so it can be queried with
+SQL and joined with other inventory data:
+
+  nodes            module, version, is_main, is_direct, depth
+  edges            from_module, to_module, requested_version
+  classifications  module, test_only, classifier (with --split-test-only)
+  enrichment       module, licenses, scorecard, ... (with --enrich)
+
+--sqlite writes a SQLite database using the sqlite3 command found in PATH,
+--sql writes the equivalent SQL script ("-" for stdout) and --csv-dir writes
+one CSV file per table.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("export does not take any arguments"))
+		}
+		if exportSQLite == "" && exportSQL == "" && exportCSVDir == "" {
+			return withExitCode(ExitUsage, fmt.Errorf("export needs at least one of --sqlite, --sql or --csv-dir"))
+		}
+		if err := validateEnrichSources(enrichSources); err != nil {
+			return err
+		}
+		depGraph := getDepInfo(mainModules)
+		if len(depGraph.MainModules) == 0 {
+			return errNoMainModules
+		}
+		allDeps := getAllDeps(depGraph.DirectDepList, depGraph.TransDepList)
+
+		var testOnly map[string]bool
+		if splitTestOnly {
+			var err error
+			testOnly, err = classifyTestDeps(allDeps)
+			if err != nil {
+				return fmt.Errorf("failed to classify dependencies as test-only/non-test: %w", err)
+			}
+		}
+		var enrichment map[string]*ModuleEnrichment
+		if len(enrichSources) > 0 {
+			var warnings []string
+			enrichment, warnings = enrichModules(allDeps, depGraph.Versions, enrichSources)
+			for _, w := range warnings {
+				warnf("%s\n", w)
+			}
+		}
+		tables := exportTables(depGraph, testOnly, enrichment)
+
+		if exportSQL != "" {
+			var buf bytes.Buffer
+			writeExportSQL(&buf, tables)
+			if exportSQL == "-" {
+				if _, err := os.Stdout.Write(buf.Bytes()); err != nil {
+					return err
+				}
+			} else if err := os.WriteFile(exportSQL, buf.Bytes(), 0o644); err != nil {
+				return err
+			}
+		}
+		if exportSQLite != "" {
+			if err := writeExportSQLite(exportSQLite, tables); err != nil {
+				return err
+			}
+			infof("Wrote %s\n", exportSQLite)
+		}
+		if exportCSVDir != "" {
+			if err := writeExportCSV(exportCSVDir, tables); err != nil {
+				return err
+			}
+			infof("Wrote %d CSV files to %s\n", len(tables), exportCSVDir)
+		}
+		return nil
+	},
+}
+
+// exportTables builds the tables for a graph. The classifications and
+// enrichment tables are only included when that data was computed.
+func exportTables(depGraph *DependencyOverview, testOnly map[string]bool, enrichment map[string]*ModuleEnrichment) []exportTable {
+	isDirect := make(map[string]bool)
+	for _, d := range depGraph.DirectDepList {
+		isDirect[d] = true
+	}
+	depthOf := shortestDepthByModule(depGraph.MainModules, depGraph.Graph)
+	nodes := graphNodes(depGraph.Graph)
+
+	nodeTable := exportTable{
+		Name: "nodes",
+		Columns: []exportColumn{
+			{"module", "TEXT PRIMARY KEY"}, {"version", "TEXT"}, {"is_main", "INTEGER"},
+			{"is_direct", "INTEGER"}, {"depth", "INTEGER"},
+		},
+	}
+	for _, mod := range nodes {
+		var version, depth any
+		if v := depGraph.Versions[mod]; v != "" {
+			version = v
+		}
+		if d, ok := depthOf[mod]; ok {
+			depth = d
+		}
+		nodeTable.Rows = append(nodeTable.Rows, []any{mod, version, contains(depGraph.MainModules, mod), isDirect[mod], depth})
+	}
+
+	edgeTable := exportTable{
+		Name:    "edges",
+		Columns: []exportColumn{{"from_module", "TEXT"}, {"to_module", "TEXT"}, {"requested_version", "TEXT"}},
+	}
+	for _, from := range nodes {
+		for _, to := range sortedCopy(depGraph.Graph[from]) {
+			var requested any
+			for _, r := range depGraph.Requirements[to] {
+				if r.From == from {
+					requested = r.Version
+					break
+				}
+			}
+			edgeTable.Rows = append(edgeTable.Rows, []any{from, to, requested})
+		}
+	}
+	tables := []exportTable{nodeTable, edgeTable}
+
+	allDeps := getAllDeps(depGraph.DirectDepList, depGraph.TransDepList)
+	sort.Strings(allDeps)
+	if testOnly != nil {
+		t := exportTable{
+			Name:    "classifications",
+			Columns: []exportColumn{{"module", "TEXT PRIMARY KEY"}, {"test_only", "INTEGER"}, {"classifier", "TEXT"}},
+		}
+		for _, mod := range allDeps {
+			t.Rows = append(t.Rows, []any{mod, testOnly[mod], testClassifier})
+		}
+		tables = append(tables, t)
+	}
+	if enrichment != nil {
+		t := exportTable{
+			Name: "enrichment",
+			Columns: []exportColumn{
+				{"module", "TEXT PRIMARY KEY"}, {"licenses", "TEXT"}, {"scorecard", "REAL"},
+				{"dependent_count", "INTEGER"}, {"source_repo", "TEXT"}, {"archived", "INTEGER"},
+				{"last_commit", "TEXT"}, {"stars", "INTEGER"}, {"stale", "INTEGER"},
+				{"version_time", "TEXT"}, {"latest_version", "TEXT"}, {"latest_time", "TEXT"}, {"old", "INTEGER"},
+			},
+		}
+		for _, mod := range allDeps {
+			e := enrichment[mod]
+			if e == nil {
+				continue
+			}
+			row := []any{mod, nil, nil, nil, nil, nil, nil, nil, e.Stale, nil, nil, nil, e.Old}
+			if len(e.Licenses) > 0 {
+				row[1] = strings.Join(e.Licenses, ",")
+			}
+			if e.Scorecard != nil {
+				row[2] = *e.Scorecard
+			}
+			if e.DependentCount != nil {
+				row[3] = *e.DependentCount
+			}
+			if e.SourceRepo != "" {
+				row[4] = e.SourceRepo
+			}
+			if e.Archived != nil {
+				row[5] = *e.Archived
+			}
+			if e.LastCommit != nil {
+				row[6] = e.LastCommit.UTC().Format(time.RFC3339)
+			}
+			if e.Stars != nil {
+				row[7] = *e.Stars
+			}
+			if e.VersionTime != nil {
+				row[9] = e.VersionTime.UTC().Format(time.RFC3339)
+			}
+			if e.LatestVersion != "" {
+				row[10] = e.LatestVersion
+			}
+			if e.LatestTime != nil {
+				row[11] = e.LatestTime.UTC().Format(time.RFC3339)
+			}
+			t.Rows = append(t.Rows, row)
+		}
+		tables = append(tables, t)
+	}
+	return tables
+}
+
+// writeExportSQL writes a SQL script recreating the tables in a single
+// transaction.
+func writeExportSQL(w io.Writer, tables []exportTable) {
+	fmt.Fprintln(w, "BEGIN TRANSACTION;")
+	for _, t := range tables {
+		cols := make([]string, len(t.Columns))
+		for i, c := range t.Columns {
+			cols[i] = c.Name + " " + c.Type
+		}
+		fmt.Fprintf(w, "DROP TABLE IF EXISTS %s;\n", t.Name)
+		fmt.Fprintf(w, "CREATE TABLE %s (%s);\n", t.Name, strings.Join(cols, ", "))
+		for _, row := range t.Rows {
+			values := make([]string, len(row))
+			for i, v := range row {
+				values[i] = sqlLiteral(v)
+			}
+			fmt.Fprintf(w, "INSERT INTO %s VALUES (%s);\n", t.Name, strings.Join(values, ", "))
+		}
+	}
+	fmt.Fprintln(w, "CREATE INDEX IF NOT EXISTS edges_from ON edges (from_module);")
+	fmt.Fprintln(w, "CREATE INDEX IF NOT EXISTS edges_to ON edges (to_module);")
+	fmt.Fprintln(w, "COMMIT;")
+}
+
+func sqlLiteral(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	}
+	return csvCell(v)
+}
+
+func csvCell(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// writeExportSQLite pipes the SQL script into the sqlite3 command, which
+// creates the database file if needed.
+func writeExportSQLite(path string, tables []exportTable) error {
+	if _, err := exec.LookPath("sqlite3"); err != nil {
+		return fmt.Errorf("--sqlite needs the sqlite3 command in PATH; use --sql and load the script yourself instead")
+	}
+	var script bytes.Buffer
+	writeExportSQL(&script, tables)
+	c := exec.Command("sqlite3", "-bail", path)
+	c.Stdin = &script
+	var stderr bytes.Buffer
+	c.Stderr = &stderr
+	logCommand(c)
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("sqlite3 %s failed: %v\n%s", path, err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// writeExportCSV writes <table>.csv files with a header row into outDir.
+func writeExportCSV(outDir string, tables []exportTable) error {
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return err
+	}
+	for _, t := range tables {
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		header := make([]string, len(t.Columns))
+		for i, c := range t.Columns {
+			header[i] = c.Name
+		}
+		_ = w.Write(header)
+		for _, row := range t.Rows {
+			cells := make([]string, len(row))
+			for i, v := range row {
+				cells[i] = csvCell(v)
+			}
+			_ = w.Write(cells)
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(outDir, t.Name+".csv"), buf.Bytes(), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
+	exportCmd.Flags().StringVar(&exportSQLite, "sqlite", "", "Write the tables to this SQLite database (needs sqlite3 in PATH)")
+	exportCmd.Flags().StringVar(&exportSQL, "sql", "", "Write a SQL script creating the tables to this file (- for stdout)")
+	exportCmd.Flags().StringVar(&exportCSVDir, "csv-dir", "", "Write one CSV file per table into this directory")
+	exportCmd.Flags().BoolVar(&splitTestOnly, "split-test-only", false, "Add a classifications table of test-only dependencies")
+	exportCmd.Flags().StringSliceVar(&enrichSources, "enrich", []string{}, "Add an enrichment table with external metadata (supported: depsdev, github, osv, proxy)")
+	exportCmd.Flags().StringVar(&enrichCacheDir, "enrich-cache-dir", "", "Directory for cached enrichment data. Defaults to the user cache directory.")
+	exportCmd.Flags().IntVar(&enrichStaleDays, "stale-days", 365, "With --enrich github, flag dependencies whose upstream has no commits in this many days (0 disables)")
+	exportCmd.Flags().IntVar(&enrichMaxVersionAgeDays, "version-age-days", 730, "With --enrich proxy, flag dependencies whose pinned version was released more than this many days ago (0 disables)")
+	exportCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
+	exportCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Specify main modules")
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExportTables(t *testing.T) {
+	depGraph := &DependencyOverview{
+		MainModules:   []string{"m"},
+		Graph:         map[string][]string{"m": {"a"}, "a": {"b"}},
+		DirectDepList: []string{"a"},
+		TransDepList:  []string{"b"},
+		Versions:      map[string]string{"a": "v1.0.0", "b": "v0.2.0"},
+		Requirements: map[string][]Requirement{
+			"a": {{From: "m", Version: "v1.0.0"}},
+			"b": {{From: "a", Version: "v0.1.0"}},
+		},
+	}
+	tables := exportTables(depGraph, map[string]bool{"b": true}, nil)
+	if len(tables) != 3 {
+		t.Fatalf("got %d tables, want nodes, edges and classifications", len(tables))
+	}
+	wantNodes := [][]any{
+		{"a", "v1.0.0", false, true, 1},
+		{"b", "v0.2.0", false, false, 2},
+		{"m", nil, true, false, 0},
+	}
+	if !reflect.DeepEqual(tables[0].Rows, wantNodes) {
+		t.Errorf("nodes = %v, want %v", tables[0].Rows, wantNodes)
+	}
+	wantEdges := [][]any{{"a", "b", "v0.1.0"}, {"m", "a", "v1.0.0"}}
+	if !reflect.DeepEqual(tables[1].Rows, wantEdges) {
+		t.Errorf("edges = %v, want %v", tables[1].Rows, wantEdges)
+	}
+}
+
+func TestWriteExportSQL(t *testing.T) {
+	var buf bytes.Buffer
+	writeExportSQL(&buf, []exportTable{{
+		Name:    "nodes",
+		Columns: []exportColumn{{"module", "TEXT"}, {"depth", "INTEGER"}},
+		Rows:    [][]any{{"it's", nil}, {"x", 2}},
+	}})
+	for _, want := range []string{
+		"CREATE TABLE nodes (module TEXT, depth INTEGER);",
+		"INSERT INTO nodes VALUES ('it''s', NULL);",
+		"INSERT INTO nodes VALUES ('x', 2);",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("missing %q in:\n%s", want, buf.String())
+		}
+	}
+}
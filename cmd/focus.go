@@ -0,0 +1,228 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var focusHops int
+
+// FocusResult is the neighborhood of a module within a number of hops.
+type FocusResult struct {
+	Target string `json:"target"`
+	Hops   int    `json:"hops"`
+	// Dependencies maps modules reachable from the target to their distance.
+	Dependencies map[string]int `json:"dependencies"`
+	// Dependents maps modules that reach the target to their distance.
+	Dependents  map[string]int `json:"dependents"`
+	Edges       []graphEdge    `json:"edges"`
+	MainModules []string       `json:"mainModules"`
+}
+
+var focusCmd = &cobra.Command{
+	Use:   "focus <module>",
+	Short: "Show the dependency neighborhood around a module",
+	Long: `Renders only the part of the dependency graph within --hops edges of the
+given module, in both directions: what it depends on and what depends on it.
+
+Examples:
+  # Direct dependencies and dependents of a module
+  depstat focus github.com/google/btree
+
+  # Two hops in each direction, rendered with graphviz
+  depstat focus github.com/google/btree --hops 2 --svg > focus.svg`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target := args[0]
+		if focusHops <= 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("--hops must be > 0"))
+		}
+		if (dotOutput && jsonOutput) || (svgOutput && jsonOutput) || (dotOutput && svgOutput) {
+			return withExitCode(ExitUsage, fmt.Errorf("--dot, --svg, and --json are mutually exclusive"))
+		}
+		depGraph := getDepInfo(mainModules)
+		if len(depGraph.MainModules) == 0 {
+			return errNoMainModules
+		}
+		if !contains(graphNodes(depGraph.Graph), target) {
+			return withExitCode(ExitNotFound, fmt.Errorf("module %q not found in the dependency graph", target))
+		}
+
+		result := computeNeighborhood(target, focusHops, depGraph.Graph)
+		result.MainModules = depGraph.MainModules
+		switch {
+		case jsonOutput:
+			out, err := json.MarshalIndent(result, "", "\t")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+		case dotOutput:
+			fmt.Print(focusDOT(result))
+		case svgOutput:
+			return outputGraphSVG(focusDOT(result))
+		default:
+			printFocus(result)
+		}
+		return nil
+	},
+}
+
+// computeNeighborhood collects modules within hops edges of target in both
+// directions, and every graph edge between the collected modules.
+func computeNeighborhood(target string, hops int, graph map[string][]string) FocusResult {
+	reverse := map[string][]string{}
+	for from, tos := range graph {
+		for _, to := range tos {
+			reverse[to] = append(reverse[to], from)
+		}
+	}
+	result := FocusResult{
+		Target:       target,
+		Hops:         hops,
+		Dependencies: boundedBFS(target, hops, graph),
+		Dependents:   boundedBFS(target, hops, reverse),
+	}
+
+	inView := map[string]bool{target: true}
+	for m := range result.Dependencies {
+		inView[m] = true
+	}
+	for m := range result.Dependents {
+		inView[m] = true
+	}
+	result.Edges = []graphEdge{}
+	for from, tos := range graph {
+		if !inView[from] {
+			continue
+		}
+		for _, to := range tos {
+			if inView[to] {
+				result.Edges = append(result.Edges, graphEdge{From: from, To: to})
+			}
+		}
+	}
+	sort.Slice(result.Edges, func(i, j int) bool {
+		if result.Edges[i].From == result.Edges[j].From {
+			return result.Edges[i].To < result.Edges[j].To
+		}
+		return result.Edges[i].From < result.Edges[j].From
+	})
+	return result
+}
+
+// boundedBFS returns the distance to every node reachable from start within
+// maxHops edges, excluding start itself.
+func boundedBFS(start string, maxHops int, graph map[string][]string) map[string]int {
+	dist := map[string]int{start: 0}
+	queue := []string{start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if dist[current] == maxHops {
+			continue
+		}
+		for _, next := range graph[current] {
+			if _, seen := dist[next]; seen {
+				continue
+			}
+			dist[next] = dist[current] + 1
+			queue = append(queue, next)
+		}
+	}
+	delete(dist, start)
+	return dist
+}
+
+// sortByDistance orders modules by distance, then name.
+func sortByDistance(dist map[string]int) []string {
+	mods := make([]string, 0, len(dist))
+	for m := range dist {
+		mods = append(mods, m)
+	}
+	sort.Slice(mods, func(i, j int) bool {
+		if dist[mods[i]] == dist[mods[j]] {
+			return mods[i] < mods[j]
+		}
+		return dist[mods[i]] < dist[mods[j]]
+	})
+	return mods
+}
+
+func printFocus(result FocusResult) {
+	fmt.Printf("Neighborhood of %s (%d hops)\n", result.Target, result.Hops)
+	fmt.Println(strings.Repeat("=", 50))
+	fmt.Println()
+	fmt.Printf("Depends on (%d modules):\n", len(result.Dependencies))
+	for _, m := range sortByDistance(result.Dependencies) {
+		fmt.Printf("  [%d] %s\n", result.Dependencies[m], m)
+	}
+	fmt.Println()
+	fmt.Printf("Depended on by (%d modules):\n", len(result.Dependents))
+	for _, m := range sortByDistance(result.Dependents) {
+		marker := "  "
+		if contains(result.MainModules, m) {
+			marker = "* "
+		}
+		fmt.Printf("  [%d] %s%s\n", result.Dependents[m], marker, m)
+	}
+}
+
+func focusDOT(result FocusResult) string {
+	var b strings.Builder
+	b.WriteString("strict digraph {\n")
+	fmt.Fprintf(&b, "graph [overlap=false, label=\"Focus: %s (%d hops)\", labelloc=t];\n", result.Target, result.Hops)
+	b.WriteString("node [shape=box, style=filled, fillcolor=white];\n\n")
+
+	b.WriteString("// Nodes\n")
+	fmt.Fprintf(&b, "\"%s\" [fillcolor=\"#ffffcc\"];\n", result.Target)
+	for _, m := range sortByDistance(result.Dependents) {
+		color := "#e6f0ff" // blue for dependents
+		if contains(result.MainModules, m) {
+			color = "#ccffcc" // green for main modules
+		}
+		fmt.Fprintf(&b, "\"%s\" [fillcolor=\"%s\"];\n", m, color)
+	}
+	for _, m := range sortByDistance(result.Dependencies) {
+		if _, ok := result.Dependents[m]; !ok {
+			fmt.Fprintf(&b, "\"%s\";\n", m)
+		}
+	}
+	b.WriteString("\n// Edges\n")
+	for _, e := range result.Edges {
+		fmt.Fprintf(&b, "\"%s\" -> \"%s\";\n", e.From, e.To)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func init() {
+	rootCmd.AddCommand(focusCmd)
+	focusCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
+	focusCmd.Flags().IntVar(&focusHops, "hops", 1, "Number of edges to follow in each direction")
+	focusCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Output in JSON format")
+	focusCmd.Flags().BoolVar(&dotOutput, "dot", false, "Output in DOT format for Graphviz")
+	focusCmd.Flags().BoolVarP(&svgOutput, "svg", "s", false, "Render DOT output as SVG (requires graphviz 'dot')")
+	focusCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
+	focusCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Specify main modules")
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComputeNeighborhood(t *testing.T) {
+	graph := map[string][]string{
+		"main": {"A"},
+		"A":    {"T"},
+		"T":    {"B"},
+		"B":    {"C"},
+		"X":    {"C"},
+	}
+
+	result := computeNeighborhood("T", 1, graph)
+	if !reflect.DeepEqual(result.Dependencies, map[string]int{"B": 1}) {
+		t.Errorf("unexpected dependencies %v", result.Dependencies)
+	}
+	if !reflect.DeepEqual(result.Dependents, map[string]int{"A": 1}) {
+		t.Errorf("unexpected dependents %v", result.Dependents)
+	}
+	want := []graphEdge{{From: "A", To: "T"}, {From: "T", To: "B"}}
+	if !reflect.DeepEqual(result.Edges, want) {
+		t.Errorf("unexpected edges %v", result.Edges)
+	}
+
+	result = computeNeighborhood("T", 2, graph)
+	if !reflect.DeepEqual(result.Dependencies, map[string]int{"B": 1, "C": 2}) {
+		t.Errorf("unexpected dependencies %v", result.Dependencies)
+	}
+	if !reflect.DeepEqual(result.Dependents, map[string]int{"A": 1, "main": 2}) {
+		t.Errorf("unexpected dependents %v", result.Dependents)
+	}
+	// X -> C is not shown since X is outside the neighborhood
+	if len(result.Edges) != 4 {
+		t.Errorf("expected 4 edges, got %v", result.Edges)
+	}
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var genGraphNodes int
+var genGraphBranching float64
+var genGraphCycles float64
+var genGraphSeed int64
+var genGraphOutput string
+
+// syntheticMainModule is the main module of generated graphs.
+const syntheticMainModule = "example.com/synthetic/main"
+
+// syntheticGraphOptions shape a generated dependency graph.
+type syntheticGraphOptions struct {
+	// Nodes is the number of dependency modules, excluding the main module.
+	Nodes int
+	// Branching is the average number of requirements per module.
+	Branching float64
+	// Cycles is the fraction of dependency modules that also require a
+	// module that (indirectly) requires them, closing a cycle.
+	Cycles float64
+	Seed   int64
+}
+
+var genGraphCmd = &cobra.Command{
+	Use:   "gen-graph",
+	Short: "Generate a synthetic go mod graph for benchmarks and bug reports",
+	Long: `Generates "go mod graph" output for a synthetic module graph, so scaling
+problems can be reproduced and shared without a proprietary graph.
+
+Every dependency module is reachable from the main module
+example.com/synthetic/main. --branching sets the average number of
+requirements per module and --cycles the fraction of modules that close a
+cycle back to a module above them. The same --seed always generates the same
+graph. Feed the output to other commands with --graph-file, e.g.
+
+  depstat gen-graph --nodes 5000 --branching 4 --cycles 0.01 > graph.txt
+  depstat why example.com/synthetic/mod4999 --graph-file graph.txt -m example.com/synthetic/main`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("gen-graph does not take any arguments"))
+		}
+		opts := syntheticGraphOptions{
+			Nodes:     genGraphNodes,
+			Branching: genGraphBranching,
+			Cycles:    genGraphCycles,
+			Seed:      genGraphSeed,
+		}
+		if err := opts.validate(); err != nil {
+			return withExitCode(ExitUsage, err)
+		}
+		if genGraphOutput == "" {
+			return writeSyntheticGraph(os.Stdout, opts)
+		}
+		f, err := os.Create(genGraphOutput)
+		if err != nil {
+			return err
+		}
+		if err := writeSyntheticGraph(f, opts); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+		infof("Wrote a graph of %d modules to %s\n", opts.Nodes+1, genGraphOutput)
+		return nil
+	},
+}
+
+func (o syntheticGraphOptions) validate() error {
+	if o.Nodes < 1 {
+		return withExitCode(ExitUsage, fmt.Errorf("--nodes must be >= 1"))
+	}
+	if o.Branching < 1 {
+		return withExitCode(ExitUsage, fmt.Errorf("--branching must be >= 1, since every module needs a requirement reaching it"))
+	}
+	if o.Cycles < 0 || o.Cycles > 1 {
+		return withExitCode(ExitUsage, fmt.Errorf("--cycles must be between 0 and 1"))
+	}
+	return nil
+}
+
+// syntheticModule returns the path of the i-th generated module; 0 is the
+// main module.
+func syntheticModule(i int) string {
+	if i == 0 {
+		return syntheticMainModule
+	}
+	return fmt.Sprintf("example.com/synthetic/mod%d", i)
+}
+
+// generateSyntheticGraph returns the requirements of a random graph, by
+// module index. A random spanning tree keeps every module reachable from
+// the main module; further edges only point from lower to higher indexes,
+// so the graph stays acyclic until opts.Cycles adds edges pointing back.
+func generateSyntheticGraph(opts syntheticGraphOptions) map[int][]int {
+	rng := rand.New(rand.NewSource(opts.Seed))
+	n := opts.Nodes
+	edges := make(map[int]map[int]bool)
+	add := func(from, to int) bool {
+		if edges[from] == nil {
+			edges[from] = make(map[int]bool)
+		}
+		if from == to || edges[from][to] {
+			return false
+		}
+		edges[from][to] = true
+		return true
+	}
+	parent := make([]int, n+1)
+	for to := 1; to <= n; to++ {
+		parent[to] = rng.Intn(to)
+		add(parent[to], to)
+	}
+
+	// the main module can reach every other module, module i the ones
+	// after it: n*(n+1)/2 forward edges in total
+	maxForward := n * (n + 1) / 2
+	target := int(opts.Branching*float64(n+1) + 0.5)
+	if target > maxForward {
+		target = maxForward
+	}
+	for count := n; count < target; {
+		from := rng.Intn(n)
+		to := from + 1 + rng.Intn(n-from)
+		if add(from, to) {
+			count++
+		}
+	}
+
+	// a cycle is closed by requiring a spanning tree ancestor other than
+	// the main module, which no module may require
+	var candidates []int
+	for i := 1; i <= n; i++ {
+		if parent[i] != 0 {
+			candidates = append(candidates, i)
+		}
+	}
+	rng.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+	backEdges := int(opts.Cycles*float64(n) + 0.5)
+	if backEdges > len(candidates) {
+		backEdges = len(candidates)
+	}
+	for _, from := range candidates[:backEdges] {
+		var ancestors []int
+		for a := parent[from]; a != 0; a = parent[a] {
+			ancestors = append(ancestors, a)
+		}
+		add(from, ancestors[rng.Intn(len(ancestors))])
+	}
+
+	graph := make(map[int][]int, len(edges))
+	for from, tos := range edges {
+		for to := range tos {
+			graph[from] = append(graph[from], to)
+		}
+		sort.Ints(graph[from])
+	}
+	return graph
+}
+
+// writeSyntheticGraph writes the generated graph in "go mod graph" format,
+// with every dependency module at v1.0.0.
+func writeSyntheticGraph(w io.Writer, opts syntheticGraphOptions) error {
+	graph := generateSyntheticGraph(opts)
+	var b strings.Builder
+	for from := 0; from <= opts.Nodes; from++ {
+		fromNode := syntheticModule(from)
+		if from != 0 {
+			fromNode += "@v1.0.0"
+		}
+		for _, to := range graph[from] {
+			fmt.Fprintf(&b, "%s %s@v1.0.0\n", fromNode, syntheticModule(to))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func init() {
+	rootCmd.AddCommand(genGraphCmd)
+	genGraphCmd.Flags().IntVar(&genGraphNodes, "nodes", 100, "Number of dependency modules, not counting the main module")
+	genGraphCmd.Flags().Float64Var(&genGraphBranching, "branching", 3, "Average number of requirements per module (>= 1)")
+	genGraphCmd.Flags().Float64Var(&genGraphCycles, "cycles", 0, "Fraction of dependency modules (0-1) that also require a module above them, closing a cycle")
+	genGraphCmd.Flags().Int64Var(&genGraphSeed, "seed", 1, "Random seed; the same seed generates the same graph")
+	genGraphCmd.Flags().StringVarP(&genGraphOutput, "output", "o", "", "Write the graph to this file instead of stdout")
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func syntheticOverview(tb testing.TB, opts syntheticGraphOptions) DependencyOverview {
+	tb.Helper()
+	var b strings.Builder
+	if err := writeSyntheticGraph(&b, opts); err != nil {
+		tb.Fatal(err)
+	}
+	return generateGraph(b.String(), []string{syntheticMainModule})
+}
+
+func TestGenerateSyntheticGraph(t *testing.T) {
+	opts := syntheticGraphOptions{Nodes: 200, Branching: 3, Seed: 7}
+	var first, second strings.Builder
+	if err := writeSyntheticGraph(&first, opts); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeSyntheticGraph(&second, opts); err != nil {
+		t.Fatal(err)
+	}
+	if first.String() != second.String() {
+		t.Error("the same seed generated different graphs")
+	}
+
+	overview := generateGraph(first.String(), []string{syntheticMainModule})
+	if got := len(getAllDeps(overview.DirectDepList, overview.TransDepList)); got != opts.Nodes {
+		t.Errorf("%d modules reachable from the main module, want %d", got, opts.Nodes)
+	}
+	if edges := strings.Count(first.String(), "\n"); edges != 603 {
+		t.Errorf("%d edges, want 603 for branching 3 over 201 modules", edges)
+	}
+	if cycles := findAllCycles(overview.Graph); len(cycles) != 0 {
+		t.Errorf("graph without --cycles has cycles: %v", cycles)
+	}
+
+	opts.Cycles = 0.05
+	cyclic := syntheticOverview(t, opts)
+	if len(cyclicComponents(cyclic.Graph)) == 0 {
+		t.Error("graph with --cycles 0.05 has no cycle")
+	}
+	for from, tos := range cyclic.Graph {
+		for _, to := range tos {
+			if to == syntheticMainModule {
+				t.Errorf("%s requires the main module", from)
+			}
+		}
+	}
+}
+
+// cyclicComponents returns the components with more than one
+// module.
+func cyclicComponents(graph map[string][]string) [][]string {
+	var out [][]string
+	for _, scc := range stronglyConnectedComponents(graph) {
+		if len(scc) > 1 {
+			out = append(out, scc)
+		}
+	}
+	return out
+}
+
+func TestSyntheticGraphOptionsValidate(t *testing.T) {
+	for _, opts := range []syntheticGraphOptions{
+		{Nodes: 0, Branching: 2},
+		{Nodes: 10, Branching: 0.5},
+		{Nodes: 10, Branching: 2, Cycles: 1.5},
+	} {
+		if err := opts.validate(); err == nil {
+			t.Errorf("%+v: expected an error", opts)
+		}
+	}
+	if err := (syntheticGraphOptions{Nodes: 1, Branching: 1}).validate(); err != nil {
+		t.Error(err)
+	}
+}
+
+var benchmarkSizes = []int{1000, 10000}
+
+func BenchmarkGenerateGraph(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		var graph strings.Builder
+		if err := writeSyntheticGraph(&graph, syntheticGraphOptions{Nodes: n, Branching: 4, Cycles: 0.01, Seed: 1}); err != nil {
+			b.Fatal(err)
+		}
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				generateGraph(graph.String(), []string{syntheticMainModule})
+			}
+		})
+	}
+}
+
+func BenchmarkCountPaths(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		overview := syntheticOverview(b, syntheticGraphOptions{Nodes: n, Branching: 4, Cycles: 0.01, Seed: 1})
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				countPaths(overview.MainModules, syntheticModule(n), overview.Graph)
+			}
+		})
+	}
+}
+
+func BenchmarkFindAllPaths(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		overview := syntheticOverview(b, syntheticGraphOptions{Nodes: n, Branching: 4, Cycles: 0.01, Seed: 1})
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				var paths [][]string
+				findAllPaths(syntheticMainModule, syntheticModule(n), overview.Graph, nil, map[string]bool{}, &paths, 1000)
+			}
+		})
+	}
+}
+
+func BenchmarkLongestChain(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		overview := syntheticOverview(b, syntheticGraphOptions{Nodes: n, Branching: 4, Cycles: 0.01, Seed: 1})
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				longestChainFromMains(&overview)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import "strings"
+
+// noGitMetadata is set by --no-git-metadata.
+var noGitMetadata bool
+
+// GitMetadata is the source state of --dir, recorded in JSON output and
+// reports so results can be matched with the commit they describe.
+type GitMetadata struct {
+	Commit string `json:"commit"`
+	// Branch is empty for a detached HEAD.
+	Branch string `json:"branch,omitempty"`
+	// Dirty is set when tracked files have uncommitted changes.
+	Dirty bool `json:"dirty"`
+}
+
+// gitMetadataForOutput returns the git state of --dir, or nil outside a
+// git repository or with --no-git-metadata.
+func gitMetadataForOutput() *GitMetadata {
+	if noGitMetadata {
+		return nil
+	}
+	commit, err := gitResolveRef("HEAD")
+	if err != nil {
+		debugf("no git metadata: %v\n", err)
+		return nil
+	}
+	meta := &GitMetadata{Commit: commit}
+	if ref, err := gitCurrentRef(); err == nil && strings.HasPrefix(ref, "refs/heads/") {
+		meta.Branch = strings.TrimPrefix(ref, "refs/heads/")
+	}
+	if dirty, err := gitWorkingTreeDirty(); err != nil {
+		warnf("could not check for uncommitted changes: %v\n", err)
+	} else {
+		meta.Dirty = dirty
+	}
+	return meta
+}
+
+// describeGitMetadata formats the metadata as a phrase for reports, quoting
+// the commit and branch with quote.
+func describeGitMetadata(meta *GitMetadata, quote string) string {
+	s := "commit " + quote + meta.Commit + quote
+	if meta.Branch != "" {
+		s += " on branch " + quote + meta.Branch + quote
+	}
+	if meta.Dirty {
+		s += " with uncommitted changes"
+	}
+	return s
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestGitMetadataForOutput(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	repo := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		c := exec.Command("git", args...)
+		c.Dir = repo
+		c.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+		if out, err := c.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("init", "-q", "-b", "trunk")
+	if err := os.WriteFile(filepath.Join(repo, "go.mod"), []byte("module example.com/m\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", "go.mod")
+	git("commit", "-q", "-m", "init")
+
+	oldDir := dir
+	dir = repo
+	defer func() { dir = oldDir; noGitMetadata = false }()
+
+	meta := gitMetadataForOutput()
+	if meta == nil || len(meta.Commit) != 40 || meta.Branch != "trunk" || meta.Dirty {
+		t.Fatalf("clean repository: got %+v", meta)
+	}
+	if got, want := describeGitMetadata(meta, ""), "commit "+meta.Commit+" on branch trunk"; got != want {
+		t.Errorf("describeGitMetadata() = %q, want %q", got, want)
+	}
+
+	if err := os.WriteFile(filepath.Join(repo, "go.mod"), []byte("module example.com/m\n\ngo 1.22\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if meta := gitMetadataForOutput(); meta == nil || !meta.Dirty {
+		t.Errorf("modified go.mod: got %+v, want dirty", meta)
+	}
+
+	noGitMetadata = true
+	if meta := gitMetadataForOutput(); meta != nil {
+		t.Errorf("--no-git-metadata: got %+v, want nil", meta)
+	}
+	noGitMetadata = false
+	dir = t.TempDir()
+	if meta := gitMetadataForOutput(); meta != nil {
+		t.Errorf("outside a repository: got %+v, want nil", meta)
+	}
+}
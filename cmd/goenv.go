@@ -0,0 +1,186 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Overrides of the go environment set with --goflags, --goos, --goarch and
+// --gowork. Empty values inherit the caller's environment.
+var goFlagsOverride string
+var goOSOverride string
+var goArchOverride string
+var goWorkOverride string
+
+// offline is set by --offline: go commands may only use the module cache and
+// no network lookups are made.
+var offline bool
+
+// GoEnvironment is the go environment in effect for an analysis, recorded in
+// JSON output so results can be reproduced.
+type GoEnvironment struct {
+	GOVERSION string `json:"GOVERSION"`
+	GOFLAGS   string `json:"GOFLAGS"`
+	GOOS      string `json:"GOOS"`
+	GOARCH    string `json:"GOARCH"`
+	GOWORK    string `json:"GOWORK"`
+	GOPROXY   string `json:"GOPROXY"`
+	GOPRIVATE string `json:"GOPRIVATE"`
+	GONOSUMDB string `json:"GONOSUMDB"`
+}
+
+// goEnv returns the environment for go subprocesses: the caller's
+// environment followed by goEnvSettings.
+func goEnv(defaults ...string) []string {
+	return append(os.Environ(), goEnvSettings(defaults...)...)
+}
+
+// goEnvSettings returns the assignments depstat adds to the caller's
+// environment: the command's own defaults, then --offline, then the user's
+// overrides. Later assignments win.
+func goEnvSettings(defaults ...string) []string {
+	env := append([]string{}, defaults...)
+	if offline {
+		env = append(env, "GOFLAGS=-mod=mod", "GOPROXY=off")
+	}
+	for _, o := range []struct{ key, value string }{
+		{"GOFLAGS", goFlagsOverride},
+		{"GOOS", goOSOverride},
+		{"GOARCH", goArchOverride},
+		{"GOWORK", goWorkOverride},
+	} {
+		if o.value != "" {
+			env = append(env, o.key+"="+o.value)
+		}
+	}
+	return env
+}
+
+// goCommand returns a go subprocess running in --dir with the effective go
+// environment. defaults such as "GOWORK=off" apply unless overridden by the
+// user.
+func goCommand(args []string, defaults ...string) *exec.Cmd {
+	c := newGoCommand(args, defaults...)
+	logCommand(c)
+	return c
+}
+
+func newGoCommand(args []string, defaults ...string) *exec.Cmd {
+	c := exec.Command("go", args...)
+	if dir != "" {
+		c.Dir = dir
+	}
+	c.Env = goEnv(defaults...)
+	recordDryRun(c)
+	return c
+}
+
+// goCommandError describes a failed go subprocess with its stderr and the
+// environment settings that most often explain failures in private or
+// workspace-based repositories.
+func goCommandError(c *exec.Cmd, err error) error {
+	msg := fmt.Sprintf("%s failed: %v", strings.Join(c.Args, " "), err)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		msg += "\n" + strings.TrimSpace(string(exitErr.Stderr))
+	}
+	var settings []string
+	for _, kv := range c.Env {
+		key, _, _ := strings.Cut(kv, "=")
+		switch key {
+		case "GOFLAGS", "GOWORK", "GOPRIVATE", "GOPROXY", "GOOS", "GOARCH":
+			settings = append(settings, kv)
+		}
+	}
+	msg += "\ncheck GOFLAGS, GOPRIVATE and GOWORK or pass --goflags/--gowork"
+	if len(settings) > 0 {
+		msg += "; effective settings: " + strings.Join(lastSettings(settings), " ")
+	}
+	return &goCommandFailure{msg: msg + offlineHint()}
+}
+
+// goCommandFailure is the error returned by goCommandError, so JSON error
+// output can tell go tool failures apart.
+type goCommandFailure struct {
+	msg string
+}
+
+func (e *goCommandFailure) Error() string { return e.msg }
+
+// offlineHint explains failures caused by --offline, or is empty.
+func offlineHint() string {
+	if !offline {
+		return ""
+	}
+	return "\n--offline is set: the module cache is missing data this command needs; run \"go mod download\" with network access first"
+}
+
+// requireNetwork fails when --offline is set, for features that can only be
+// answered by a network service.
+func requireNetwork(feature string) error {
+	if offline {
+		return fmt.Errorf("%s needs network access and is disabled by --offline", feature)
+	}
+	return nil
+}
+
+// lastSettings keeps the last assignment of every key, as exec does.
+func lastSettings(kvs []string) []string {
+	index := make(map[string]int)
+	var out []string
+	for _, kv := range kvs {
+		key, _, _ := strings.Cut(kv, "=")
+		if i, ok := index[key]; ok {
+			out[i] = kv
+			continue
+		}
+		index[key] = len(out)
+		out = append(out, kv)
+	}
+	return out
+}
+
+// effectiveGoEnvironment asks go for the environment it runs with.
+func effectiveGoEnvironment() (*GoEnvironment, error) {
+	c := goCommand([]string{"env", "-json", "GOVERSION", "GOFLAGS", "GOOS", "GOARCH", "GOWORK", "GOPROXY", "GOPRIVATE", "GONOSUMDB"})
+	out, err := c.Output()
+	if err != nil {
+		return nil, goCommandError(c, err)
+	}
+	var env GoEnvironment
+	if err := json.Unmarshal(out, &env); err != nil {
+		return nil, fmt.Errorf("parsing go env output: %w", err)
+	}
+	return &env, nil
+}
+
+// goEnvironmentForOutput returns the effective go environment for JSON
+// output, or nil with a warning when go env cannot be run.
+func goEnvironmentForOutput() *GoEnvironment {
+	env, err := effectiveGoEnvironment()
+	if err != nil {
+		warnf("could not record go environment: %v\n", err)
+		return nil
+	}
+	return env
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGoEnvOverrides(t *testing.T) {
+	defer func() { goFlagsOverride, goWorkOverride, offline = "", "", false }()
+	t.Setenv("GOFLAGS", "-mod=vendor")
+	t.Setenv("GOWORK", "")
+	t.Setenv("GOPROXY", "https://proxy.example.com")
+
+	lookup := func(env []string, key string) string {
+		value := ""
+		for _, kv := range env {
+			if k, v, _ := strings.Cut(kv, "="); k == key {
+				value = v
+			}
+		}
+		return value
+	}
+
+	tests := []struct {
+		name        string
+		flags, work string
+		offline     bool
+		want        map[string]string
+	}{
+		{
+			name: "defaults win over environment",
+			want: map[string]string{"GOFLAGS": "-mod=mod", "GOWORK": "off", "GOPROXY": "https://proxy.example.com"},
+		},
+		{
+			name:  "overrides win over defaults",
+			flags: "-tags=e2e",
+			work:  "/tmp/go.work",
+			want:  map[string]string{"GOFLAGS": "-tags=e2e", "GOWORK": "/tmp/go.work"},
+		},
+		{
+			name:    "offline",
+			offline: true,
+			want:    map[string]string{"GOFLAGS": "-mod=mod", "GOPROXY": "off"},
+		},
+	}
+	for _, tt := range tests {
+		goFlagsOverride, goWorkOverride, offline = tt.flags, tt.work, tt.offline
+		env := goEnv("GOWORK=off", "GOFLAGS=-mod=mod")
+		for key, want := range tt.want {
+			if got := lookup(env, key); got != want {
+				t.Errorf("%s: %s = %q, want %q", tt.name, key, got, want)
+			}
+		}
+	}
+}
+
+func TestLastSettings(t *testing.T) {
+	got := lastSettings([]string{"A=1", "B=2", "A=3"})
+	want := []string{"A=3", "B=2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("lastSettings = %v, want %v", got, want)
+	}
+}
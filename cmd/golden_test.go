@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+// goldenCases run against testdata/golden/app, whose dependencies are local
+// replacements, so go mod graph works without network access. The file
+// extension selects the checks made on top of the golden comparison.
+var goldenCases = []struct {
+	file string
+	args []string
+}{
+	{"stats.txt", []string{"stats"}},
+	{"stats.json", []string{"stats", "--json"}},
+	{"stats.csv", []string{"stats", "--csv"}},
+	{"list.txt", []string{"list"}},
+	{"list.json", []string{"list", "--json"}},
+	{"list-depth.txt", []string{"list", "--depth"}},
+	{"graph.dot", []string{"graph", "--dot"}},
+	{"graph.json", []string{"graph", "--json"}},
+	{"why.txt", []string{"why", "example.com/c"}},
+	{"why.json", []string{"why", "example.com/c", "--json"}},
+	{"why.dot", []string{"why", "example.com/c", "--dot"}},
+	{"why.svg", []string{"why", "example.com/c", "--svg"}},
+	{"why-not-found.dot", []string{"why", "example.com/nope", "--dot"}},
+	{"why-not-found.json", []string{"why", "Example.com/C", "--json"}},
+	{"path.json", []string{"path", "example.com/app", "example.com/c", "--json"}},
+	{"path.dot", []string{"path", "example.com/app", "example.com/c", "--dot"}},
+	{"cycles.txt", []string{"cycles"}},
+	{"cycles.json", []string{"cycles", "--json"}},
+	{"centrality.json", []string{"centrality", "--json"}},
+	{"centrality.txt", []string{"centrality"}},
+	{"dominators.json", []string{"dominators", "--json"}},
+	{"dominators.txt", []string{"dominators"}},
+	{"blame.json", []string{"blame", "--json"}},
+	{"hygiene.json", []string{"hygiene", "--json"}},
+	{"skew.json", []string{"skew", "--json"}},
+	{"focus.json", []string{"focus", "example.com/a", "--json"}},
+	{"focus.dot", []string{"focus", "example.com/a", "--dot"}},
+	{"focus.txt", []string{"focus", "example.com/a"}},
+	{"search.txt", []string{"search", "example"}},
+	{"search.json", []string{"search", "example.com/*", "--json"}},
+	{"tidy-preview.txt", []string{"tidy-preview"}},
+	{"whatif.txt", []string{"whatif", "--remove", "example.com/b"}},
+	{"whatif.json", []string{"whatif", "--remove", "example.com/b", "--json"}},
+}
+
+// goVersionPattern matches the toolchain version recorded in goEnv.
+var goVersionPattern = regexp.MustCompile(`"GOVERSION": "[^"]*"`)
+
+func TestGoldenOutput(t *testing.T) {
+	for k, v := range map[string]string{
+		"GOFLAGS": "-mod=mod", "GOWORK": "off", "GOPROXY": "off", "GOTOOLCHAIN": "local",
+		"GOPRIVATE": "", "GONOSUMDB": "", "GOOS": "linux", "GOARCH": "amd64",
+	} {
+		t.Setenv(k, v)
+	}
+	moduleDir, err := filepath.Abs(filepath.Join("testdata", "golden", "app"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldLog := logOutput
+	defer func() { logOutput = oldLog }()
+
+	for _, tc := range goldenCases {
+		t.Run(tc.file, func(t *testing.T) {
+			defer resetCommandFlags(rootCmd)
+			var stderr strings.Builder
+			logOutput = &stderr
+			rootCmd.SetArgs(append(tc.args, "--dir", moduleDir, "--no-git-metadata"))
+			var runErr error
+			got := captureStdout(t, func() { _, runErr = rootCmd.ExecuteC() })
+			if runErr != nil {
+				t.Fatalf("depstat %s: %v\nstderr:\n%s", strings.Join(tc.args, " "), runErr, stderr.String())
+			}
+			got = goVersionPattern.ReplaceAllString(got, `"GOVERSION": "go"`)
+
+			if got != "" && !strings.HasSuffix(got, "\n") {
+				t.Errorf("stdout does not end with a newline:\n%s", got)
+			}
+			switch filepath.Ext(tc.file) {
+			case ".json":
+				if !json.Valid([]byte(got)) {
+					t.Errorf("stdout is not a single JSON document:\n%s", got)
+				}
+			case ".dot":
+				if got != "" && !strings.HasPrefix(got, "strict digraph") && !strings.HasPrefix(got, "digraph") {
+					t.Errorf("stdout is not a DOT graph:\n%s", got)
+				}
+			}
+
+			golden := filepath.Join("testdata", "golden", tc.file)
+			if *updateGolden {
+				if err := os.WriteFile(golden, []byte(got), 0644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("reading golden file (run go test ./cmd -run TestGoldenOutput -update): %v", err)
+			}
+			if got != string(want) {
+				t.Errorf("stdout differs from %s:\ngot:\n%s\nwant:\n%s", golden, got, want)
+			}
+		})
+	}
+}
+
+// resetCommandFlags restores the flags set by a test run to their defaults,
+// since cobra keeps parsed values in package variables between executions.
+func resetCommandFlags(c *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if !f.Changed {
+			return
+		}
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			var def []string
+			if trimmed := strings.Trim(f.DefValue, "[]"); trimmed != "" {
+				def = strings.Split(trimmed, ",")
+			}
+			_ = sv.Replace(def)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	c.Flags().VisitAll(reset)
+	c.PersistentFlags().VisitAll(reset)
+	for _, sub := range c.Commands() {
+		resetCommandFlags(sub)
+	}
+	jsonErrors = false
+	jsonResultWritten = false
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// goModDirectivePrefix marks depstat configuration comments in go.mod, so
+// projects tracking depstat with a Go 1.24 tool directive can keep its
+// policy next to it:
+//
+//	tool github.com/kubernetes-sigs/depstat
+//
+//	//depstat:policy hack/depstat-policy.json
+//	//depstat:allowed-hosts k8s.io,golang.org,github.com/kubernetes*
+//	//depstat:test-only github.com/stretchr/testify
+//	//depstat:budget k8s.io/apimachinery=40
+const goModDirectivePrefix = "//depstat:"
+
+// goModPath returns the go.mod of --dir.
+func goModPath() string {
+	return filepath.Join(dir, "go.mod")
+}
+
+// goModPolicy reads the policy declared by //depstat: comments in the
+// go.mod of --dir. A policy file is resolved relative to go.mod. found is
+// false when go.mod has no such comments.
+func goModPolicy() (policy Policy, found bool, err error) {
+	file := goModPath()
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return policy, false, err
+	}
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, goModDirectivePrefix) {
+			continue
+		}
+		key, value, _ := strings.Cut(strings.TrimPrefix(line, goModDirectivePrefix), " ")
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return policy, false, fmt.Errorf("%s:%d: %s%s has no value", file, i+1, goModDirectivePrefix, key)
+		}
+		switch key {
+		case "policy":
+			if !filepath.IsAbs(value) {
+				value = filepath.Join(filepath.Dir(file), value)
+			}
+			p, err := loadPolicy(value)
+			if err != nil {
+				return policy, false, fmt.Errorf("%s:%d: %w", file, i+1, err)
+			}
+			policy.AllowedHosts = append(policy.AllowedHosts, p.AllowedHosts...)
+			policy.TestOnly = append(policy.TestOnly, p.TestOnly...)
+			for mod, limit := range p.Budgets {
+				policy.setBudget(mod, limit)
+			}
+		case "allowed-hosts":
+			policy.AllowedHosts = append(policy.AllowedHosts, splitDirectiveList(value)...)
+		case "test-only":
+			policy.TestOnly = append(policy.TestOnly, splitDirectiveList(value)...)
+		case "budget":
+			budgets, err := parseBudgets(splitDirectiveList(value))
+			if err != nil {
+				return policy, false, fmt.Errorf("%s:%d: %w", file, i+1, err)
+			}
+			for mod, limit := range budgets {
+				policy.setBudget(mod, limit)
+			}
+		default:
+			return policy, false, fmt.Errorf("%s:%d: unknown directive %s%s (supported: policy, allowed-hosts, test-only, budget)", file, i+1, goModDirectivePrefix, key)
+		}
+		found = true
+	}
+	return policy, found, nil
+}
+
+// splitDirectiveList splits a comma- or space-separated directive value.
+func splitDirectiveList(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+}
+
+// goModRequireLines maps every module required by the go.mod in --dir to
+// the line of its require directive.
+func goModRequireLines() (map[string]int, error) {
+	data, err := os.ReadFile(goModPath())
+	if err != nil {
+		return nil, err
+	}
+	lines := make(map[string]int)
+	inBlock := false
+	for i, line := range strings.Split(string(data), "\n") {
+		if j := strings.Index(line, "//"); j >= 0 {
+			line = line[:j]
+		}
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+		case inBlock && fields[0] == ")":
+			inBlock = false
+		case inBlock:
+			lines[fields[0]] = i + 1
+		case fields[0] == "require" && len(fields) == 2 && fields[1] == "(":
+			inBlock = true
+		case fields[0] == "require" && len(fields) >= 3:
+			lines[fields[1]] = i + 1
+		}
+	}
+	return lines, nil
+}
+
+// writeVetDiagnostics prints violations in the file:line: message format of
+// go vet, positioned at the require directive of the offending module or of
+// the first required module on its path.
+func writeVetDiagnostics(w io.Writer, violations []PolicyViolation) error {
+	lines, err := goModRequireLines()
+	if err != nil {
+		return err
+	}
+	file := goModPath()
+	for _, v := range violations {
+		line := 1
+		for _, mod := range append([]string{v.Module}, v.Path...) {
+			if n, ok := lines[mod]; ok {
+				line = n
+				break
+			}
+		}
+		fmt.Fprintf(w, "%s:%d: %s (%s)\n", file, line, v.Message, v.Rule)
+	}
+	return nil
+}
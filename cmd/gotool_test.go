@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const toolGoMod = `module example.com/a
+
+go 1.24
+
+require example.com/b v1.0.0
+
+require (
+	example.com/c v1.2.0 // indirect
+	example.com/d v0.1.0
+)
+
+tool github.com/kubernetes-sigs/depstat
+
+//depstat:policy policy.json
+//depstat:allowed-hosts example.com, k8s.io
+//depstat:test-only github.com/stretchr/testify
+//depstat:budget example.com/b=5
+`
+
+func TestGoModPolicy(t *testing.T) {
+	oldDir := dir
+	dir = t.TempDir()
+	defer func() { dir = oldDir }()
+
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(toolGoMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "policy.json"), []byte(`{"allowedHosts": ["golang.org"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	policy, found, err := goModPolicy()
+	if err != nil || !found {
+		t.Fatalf("goModPolicy() = %v, %v", found, err)
+	}
+	want := Policy{
+		AllowedHosts: []string{"golang.org", "example.com", "k8s.io"},
+		TestOnly:     []string{"github.com/stretchr/testify"},
+		Budgets:      map[string]int{"example.com/b": 5},
+	}
+	if !reflect.DeepEqual(policy, want) {
+		t.Errorf("goModPolicy() = %+v, want %+v", policy, want)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/a\n//depstat:bogus x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := goModPolicy(); err == nil {
+		t.Error("expected an error for an unknown directive")
+	}
+}
+
+func TestWriteVetDiagnostics(t *testing.T) {
+	oldDir := dir
+	dir = t.TempDir()
+	defer func() { dir = oldDir }()
+
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(toolGoMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+	violations := []PolicyViolation{
+		{Rule: "allowed-hosts", Module: "evil.io/x", Message: "evil.io/x is not from an allowed host", Path: []string{"example.com/a", "example.com/d", "evil.io/x"}},
+		{Rule: "allowed-hosts", Module: "example.com/b", Message: "b"},
+		{Rule: "custom", Message: "no module"},
+	}
+	var buf bytes.Buffer
+	if err := writeVetDiagnostics(&buf, violations); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(dir, "go.mod")
+	want := file + ":9: evil.io/x is not from an allowed host (allowed-hosts)\n" +
+		file + ":5: b (allowed-hosts)\n" +
+		file + ":1: no module (custom)\n"
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
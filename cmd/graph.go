@@ -18,13 +18,14 @@ package cmd
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"sort"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -39,6 +40,7 @@ var graphTopN int
 var graphVerbose bool
 var graphSplitTestOnly bool
 var graphSVGOutput bool
+var graphCondense bool
 
 type graphNode struct {
 	Module       string `json:"module"`
@@ -46,6 +48,8 @@ type graphNode struct {
 	OutDegree    int    `json:"outDegree"`
 	Depth        int    `json:"depth"` // -1 means unreachable from any main module
 	IsMainModule bool   `json:"isMainModule"`
+
+	Enrichment *ModuleEnrichment `json:"enrichment,omitempty"`
 }
 
 type graphEdge struct {
@@ -71,136 +75,172 @@ var graphCmd = &cobra.Command{
 	- Direct edges (solid blue): from main module(s) to their direct dependencies
 	- Transitive edges (dashed gray): dependencies of dependencies`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if (graphDotOutput && graphJSONOutput) || (graphSVGOutput && graphJSONOutput) || (graphDotOutput && graphSVGOutput) {
-			return fmt.Errorf("--dot, --svg, and --json are mutually exclusive")
-		}
-		if graphTopMode != "" && graphDotOutput {
-			return fmt.Errorf("cannot use --top with --dot")
-		}
-		if graphTopMode != "" && graphTopMode != "in" && graphTopMode != "out" && graphTopMode != "both" {
-			return fmt.Errorf("--top must be one of: in, out, both")
-		}
-		if graphTopMode != "" && graphTopN <= 0 {
-			return fmt.Errorf("-n must be > 0")
-		}
-		if graphTopMode != "" && graphTopN <= 0 {
-			return fmt.Errorf("-n must be > 0")
-		}
-		overview := getDepInfo(mainModules)
-		if len(overview.MainModules) == 0 {
-			return fmt.Errorf("no main modules remain after exclusions; adjust --exclude-modules or --mainModules")
-		}
-		if graphSplitTestOnly {
-			allDeps := getAllDeps(overview.DirectDepList, overview.TransDepList)
-			testOnlySet, err := classifyTestDeps(allDeps)
-			if err != nil {
-				return fmt.Errorf("failed to classify dependencies: %w", err)
-			}
-			nonTestGraph, testOnlyGraph := splitGraphByTestStatus(overview, testOnlySet)
-			if graphJSONOutput {
-				outputObj := map[string]interface{}{
-					"nonTestOnly": buildGraphOutput(nonTestGraph),
-					"testOnly":    buildGraphOutput(testOnlyGraph),
-				}
-				out, err := json.MarshalIndent(outputObj, "", "\t")
-				if err != nil {
-					return err
-				}
-				fmt.Print(string(out))
-				return nil
-			}
-			fmt.Println("Non-test dependencies graph:")
-			fmt.Println(getFileContentsForAllDepsWithTypes(nonTestGraph, showEdgeTypes))
-			fmt.Println("Test-only dependencies graph:")
-			fmt.Println(getFileContentsForAllDepsWithTypes(testOnlyGraph, showEdgeTypes))
-			return nil
-		}
-		nodes, edgeObjects := buildGraphTopology(overview)
-
-		if graphTopMode != "" && !graphJSONOutput && !graphDotOutput {
-			printTopNodes(nodes, graphTopMode, graphTopN)
-			return nil
+		if watchMode {
+			return watchAndRun(func() error { return runGraph(cmd, args) })
 		}
-		// strict ensures that there is only one edge between two vertices
-		// overlap = false ensures the vertices don't overlap
-		fileContents := "strict digraph {\ngraph [overlap=false];\n"
+		return runGraph(cmd, args)
+	},
+}
 
-		// graph to be generated is based around input dep
-		if dep != "" {
-			var chains []Chain
-			var temp Chain
-			getAllChains(overview.MainModules[0], overview.Graph, temp, &chains)
-			fileContents += getFileContentsForSingleDep(chains, dep)
-		} else {
-			fileContents += getFileContentsForAllDepsWithTypes(overview, showEdgeTypes)
+// runGraph loads the graph and writes it in the selected format.
+func runGraph(cmd *cobra.Command, args []string) error {
+	if (graphDotOutput && graphJSONOutput) || (graphSVGOutput && graphJSONOutput) || (graphDotOutput && graphSVGOutput) {
+		return withExitCode(ExitUsage, fmt.Errorf("--dot, --svg, and --json are mutually exclusive"))
+	}
+	if graphTopMode != "" && graphDotOutput {
+		return withExitCode(ExitUsage, fmt.Errorf("cannot use --top with --dot"))
+	}
+	if graphTopMode != "" && graphTopMode != "in" && graphTopMode != "out" && graphTopMode != "both" {
+		return withExitCode(ExitUsage, fmt.Errorf("--top must be one of: in, out, both"))
+	}
+	if graphTopMode != "" && graphTopN <= 0 {
+		return withExitCode(ExitUsage, fmt.Errorf("-n must be > 0"))
+	}
+	if graphTopMode != "" && graphTopN <= 0 {
+		return withExitCode(ExitUsage, fmt.Errorf("-n must be > 0"))
+	}
+	if graphCondense && (dep != "" || graphSplitTestOnly || len(enrichSources) > 0) {
+		return withExitCode(ExitUsage, fmt.Errorf("--condense cannot be used with --dep, --split-test-only or --enrich"))
+	}
+	if graphDotStyle.DashedTestOnly && !graphSplitTestOnly {
+		return withExitCode(ExitUsage, fmt.Errorf("--dashed-test-only requires --split-test-only"))
+	}
+	if err := validateEnrichSources(enrichSources); err != nil {
+		return err
+	}
+	if err := graphDotStyle.validate(); err != nil {
+		return err
+	}
+	// an error rather than an exit, so --watch survives a broken go.mod
+	overview, err := loadDepInfo(mainModules)
+	if err != nil {
+		return err
+	}
+	if len(overview.MainModules) == 0 {
+		return errNoMainModules
+	}
+	var components map[string][]string
+	if graphCondense {
+		overview, components = condenseGraph(overview)
+	}
+	var testOnlySet map[string]bool
+	if graphSplitTestOnly {
+		allDeps := getAllDeps(overview.DirectDepList, overview.TransDepList)
+		var err error
+		testOnlySet, err = classifyTestDeps(allDeps)
+		if err != nil {
+			return fmt.Errorf("failed to classify dependencies: %w", err)
 		}
-		fileContents += "}"
+	}
+	// DOT and SVG output draw a single diagram with test-only nodes
+	// highlighted; other outputs keep the two separate sections.
+	if graphSplitTestOnly && !graphDotOutput && !graphSVGOutput {
+		nonTestGraph, testOnlyGraph := splitGraphByTestStatus(overview, testOnlySet)
 		if graphJSONOutput {
-			edges := getEdges(overview.Graph)
-			var rankings *graphRankings
-			if graphTopMode != "" {
-				rankings = buildRankings(nodes, graphTopMode, graphTopN)
-			}
-			outputObj := struct {
-				MainModules         []string            `json:"mainModules"`
-				DirectDependencies  []string            `json:"directDependencies"`
-				TransDependencies   []string            `json:"transitiveDependencies"`
-				Graph               map[string][]string `json:"graph"`
-				Edges               []string            `json:"edges"`
-				Nodes               []graphNode         `json:"nodes"`
-				EdgeObjects         []graphEdge         `json:"edgeObjects"`
-				Rankings            *graphRankings      `json:"rankings,omitempty"`
-				FocusedDependency   string              `json:"focusedDependency,omitempty"`
-				ShowEdgeTypes       bool                `json:"showEdgeTypes"`
-				DirectCount         int                 `json:"directDependencyCount"`
-				TransitiveCount     int                 `json:"transitiveDependencyCount"`
-				TotalDependencyEdge int                 `json:"edgeCount"`
-			}{
-				MainModules:         overview.MainModules,
-				DirectDependencies:  overview.DirectDepList,
-				TransDependencies:   overview.TransDepList,
-				Graph:               overview.Graph,
-				Edges:               edges,
-				Nodes:               nodes,
-				EdgeObjects:         edgeObjects,
-				Rankings:            rankings,
-				FocusedDependency:   dep,
-				ShowEdgeTypes:       showEdgeTypes,
-				DirectCount:         len(overview.DirectDepList),
-				TransitiveCount:     len(overview.TransDepList),
-				TotalDependencyEdge: len(edges),
+			outputObj := map[string]interface{}{
+				"nonTestOnly": buildGraphOutput(nonTestGraph),
+				"testOnly":    buildGraphOutput(testOnlyGraph),
 			}
-			out, err := json.MarshalIndent(outputObj, "", "\t")
-			if err != nil {
-				return err
-			}
-			fmt.Print(string(out))
-			return nil
+			return writeJSON(os.Stdout, outputObj)
 		}
-		if graphDotOutput {
-			fmt.Print(fileContents)
+		return writeBuffered(os.Stdout, func(w io.Writer) error {
+			fmt.Fprintln(w, "Non-test dependencies graph:")
+			writeDotForAllDeps(w, nonTestGraph, showEdgeTypes)
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, "Test-only dependencies graph:")
+			writeDotForAllDeps(w, testOnlyGraph, showEdgeTypes)
+			fmt.Fprintln(w)
 			return nil
+		})
+	}
+	nodes, edgeObjects := buildGraphTopology(overview)
+	if len(enrichSources) > 0 {
+		attachNodeEnrichment(nodes, overview.Versions, enrichSources)
+	}
+
+	if graphTopMode != "" && !graphJSONOutput && !graphDotOutput {
+		printTopNodes(nodes, graphTopMode, graphTopN)
+		return nil
+	}
+	writeDOT := func(w io.Writer) error {
+		writeGraphDOT(w, overview, testOnlySet)
+		return nil
+	}
+	if graphJSONOutput {
+		edges := getEdges(overview.Graph)
+		var rankings *graphRankings
+		if graphTopMode != "" {
+			rankings = buildRankings(nodes, graphTopMode, graphTopN)
 		}
-		if graphSVGOutput {
-			return outputGraphSVG(fileContents)
-		}
-		if graphVerbose {
-			fmt.Println("Main modules:")
-			printDeps(overview.MainModules)
-			fmt.Println("Direct dependencies:")
-			printDeps(overview.DirectDepList)
-			fmt.Println("Transitive dependencies:")
-			printDeps(overview.TransDepList)
+		outputObj := struct {
+			MainModules         []string            `json:"mainModules"`
+			DirectDependencies  []string            `json:"directDependencies"`
+			TransDependencies   []string            `json:"transitiveDependencies"`
+			Graph               map[string][]string `json:"graph"`
+			Edges               []string            `json:"edges"`
+			Nodes               []graphNode         `json:"nodes"`
+			EdgeObjects         []graphEdge         `json:"edgeObjects"`
+			Rankings            *graphRankings      `json:"rankings,omitempty"`
+			Components          map[string][]string `json:"components,omitempty"`
+			FocusedDependency   string              `json:"focusedDependency,omitempty"`
+			ShowEdgeTypes       bool                `json:"showEdgeTypes"`
+			DirectCount         int                 `json:"directDependencyCount"`
+			TransitiveCount     int                 `json:"transitiveDependencyCount"`
+			TotalDependencyEdge int                 `json:"edgeCount"`
+			View                string              `json:"view"`
+			MainStrategy        string              `json:"mainStrategy"`
+			GoEnv               *GoEnvironment      `json:"goEnv,omitempty"`
+			Git                 *GitMetadata        `json:"git,omitempty"`
+		}{
+			MainModules:         overview.MainModules,
+			DirectDependencies:  overview.DirectDepList,
+			TransDependencies:   overview.TransDepList,
+			Graph:               overview.Graph,
+			Edges:               edges,
+			Nodes:               nodes,
+			EdgeObjects:         edgeObjects,
+			Rankings:            rankings,
+			Components:          components,
+			FocusedDependency:   dep,
+			ShowEdgeTypes:       showEdgeTypes,
+			DirectCount:         len(overview.DirectDepList),
+			TransitiveCount:     len(overview.TransDepList),
+			TotalDependencyEdge: len(edges),
+			View:                graphView(),
+			MainStrategy:        mainModuleStrategy(),
+			GoEnv:               goEnvironmentForOutput(),
+			Git:                 gitMetadataForOutput(),
 		}
+		return writeJSON(os.Stdout, outputObj)
+	}
+	if graphDotOutput {
+		return writeBuffered(os.Stdout, writeDOT)
+	}
+	if graphSVGOutput {
+		return streamGraphSVG(writeDOT)
+	}
+	if graphVerbose {
+		fmt.Println("Main modules:")
+		printDeps(overview.MainModules)
+		fmt.Println("Direct dependencies:")
+		printDeps(overview.DirectDepList)
+		fmt.Println("Transitive dependencies:")
+		printDeps(overview.TransDepList)
+	}
 
-		fileContentsByte := []byte(fileContents)
-		err := os.WriteFile(graphOutputPath, fileContentsByte, 0644)
-		if err != nil {
-			return err
-		}
-		fmt.Printf("\nCreated %s file!\n", graphOutputPath)
-		return nil
-	},
+	f, err := os.Create(graphOutputPath)
+	if err != nil {
+		return err
+	}
+	if err := writeBuffered(f, writeDOT); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Printf("\nCreated %s file!\n", graphOutputPath)
+	return nil
 }
 
 // find all possible chains starting from currentDep
@@ -225,8 +265,15 @@ func getAllChains(currentDep string, graph map[string][]string, currentChain Cha
 // get the contents of the .dot file for the graph
 // when the --dep flag is set
 func getFileContentsForSingleDep(chains []Chain, dep string) string {
+	var b strings.Builder
+	writeDotForSingleDep(&b, chains, dep)
+	return b.String()
+}
+
+// writeDotForSingleDep writes the DOT body of getFileContentsForSingleDep to w.
+func writeDotForSingleDep(w io.Writer, chains []Chain, dep string) {
 	// to color the entered node as yellow
-	data := colorMainNode(dep)
+	io.WriteString(w, colorMainNode(dep))
 
 	// add all chains which have the input dep to the .dot file
 	for _, chain := range chains {
@@ -238,11 +285,10 @@ func getFileContentsForSingleDep(chains []Chain, dep string) string {
 					chain[i] = "\"" + chain[i] + "\""
 				}
 			}
-			data += strings.Join(chain, " -> ")
-			data += "\n"
+			io.WriteString(w, strings.Join(chain, " -> "))
+			io.WriteString(w, "\n")
 		}
 	}
-	return data
 }
 
 // get the contents of the .dot file for the graph
@@ -253,11 +299,19 @@ func getFileContentsForAllDeps(overview *DependencyOverview) string {
 
 // getFileContentsForAllDepsWithTypes generates DOT content with optional edge type annotations
 func getFileContentsForAllDepsWithTypes(overview *DependencyOverview, showTypes bool) string {
+	var b strings.Builder
+	writeDotForAllDeps(&b, overview, showTypes)
+	return b.String()
+}
+
+// writeDotForAllDeps writes the DOT body of getFileContentsForAllDepsWithTypes
+// to w, one edge at a time.
+func writeDotForAllDeps(w io.Writer, overview *DependencyOverview, showTypes bool) {
 	if len(overview.MainModules) == 0 {
-		return ""
+		return
 	}
 	// color the main module as yellow
-	data := colorMainNode(overview.MainModules[0])
+	io.WriteString(w, colorMainNode(overview.MainModules[0]))
 
 	// Create a set of main modules for quick lookup
 	mainModSet := make(map[string]bool)
@@ -295,13 +349,58 @@ func getFileContentsForAllDepsWithTypes(overview *DependencyOverview, showTypes
 
 			if mainModSet[dep] {
 				// for the main module use a colored node
-				data += fmt.Sprintf("\"MainNode\" -> \"%s\"%s\n", neighbour, edgeAttrs)
+				fmt.Fprintf(w, "\"MainNode\" -> \"%s\"%s\n", neighbour, edgeAttrs)
 			} else {
-				data += fmt.Sprintf("\"%s\" -> \"%s\"%s\n", dep, neighbour, edgeAttrs)
+				fmt.Fprintf(w, "\"%s\" -> \"%s\"%s\n", dep, neighbour, edgeAttrs)
 			}
 		}
 	}
-	return data
+}
+
+// writeGraphDOT writes the complete DOT document of the graph command, around
+// --dep when set, streaming it to w instead of building it in memory.
+func writeGraphDOT(w io.Writer, overview *DependencyOverview, testOnlySet map[string]bool) {
+	// strict ensures that there is only one edge between two vertices
+	// overlap = false ensures the vertices don't overlap
+	fmt.Fprintf(w, "strict digraph {\ngraph [%s];\n", graphDotStyle.graphAttributes())
+	var weights map[string]int
+	if graphDotStyle.WeightNodes {
+		weights = transitiveWeights(overview.Graph)
+	}
+
+	// graph to be generated is based around input dep
+	if dep != "" {
+		var chains []Chain
+		var temp Chain
+		getAllChains(overview.MainModules[0], overview.Graph, temp, &chains)
+		var chainNodes []string
+		for _, chain := range chains {
+			if chainContains(chain, dep) {
+				chainNodes = append(chainNodes, chain...)
+			}
+		}
+		writeDotForSingleDep(w, chains, dep)
+		chainID := func(m string) string {
+			if m == dep {
+				return "MainNode"
+			}
+			return m
+		}
+		io.WriteString(w, graphDotStyle.nodeStatements(uniqueStrings(chainNodes), overview.Versions, weights, chainID))
+		io.WriteString(w, graphDotStyle.testOnlyStatements(uniqueStrings(chainNodes), testOnlySet, chainID))
+	} else {
+		writeDotForAllDeps(w, overview, showEdgeTypes)
+		nodeModules := append(getAllDeps(overview.DirectDepList, overview.TransDepList), overview.MainModules...)
+		mainID := func(m string) string {
+			if m == overview.MainModules[0] {
+				return "MainNode"
+			}
+			return m
+		}
+		io.WriteString(w, graphDotStyle.nodeStatements(uniqueStrings(nodeModules), overview.Versions, weights, mainID))
+		io.WriteString(w, graphDotStyle.testOnlyStatements(uniqueStrings(nodeModules), testOnlySet, mainID))
+	}
+	io.WriteString(w, "}\n")
 }
 
 func chainContains(chain Chain, dep string) bool {
@@ -314,15 +413,32 @@ func chainContains(chain Chain, dep string) bool {
 }
 
 func outputGraphSVG(dot string) error {
+	return streamGraphSVG(func(w io.Writer) error {
+		_, err := io.WriteString(w, dot)
+		return err
+	})
+}
+
+// streamGraphSVG pipes the DOT written by write into graphviz's dot and
+// copies the rendered SVG to stdout.
+func streamGraphSVG(write func(io.Writer) error) error {
 	cmd := exec.Command("dot", "-Tsvg")
-	cmd.Stdin = strings.NewReader(dot)
 	cmd.Stdout = os.Stdout
 	var stderr bytes.Buffer
 	cmd.Stderr = &stderr
-	if err := cmd.Run(); err != nil {
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to render DOT as SVG via graphviz 'dot': %w", err)
+	}
+	writeErr := writeBuffered(stdin, write)
+	stdin.Close()
+	if err := cmd.Wait(); err != nil {
 		return fmt.Errorf("failed to render DOT as SVG via graphviz 'dot': %w: %s", err, strings.TrimSpace(stderr.String()))
 	}
-	return nil
+	return writeErr
 }
 
 func colorMainNode(mainNode string) string {
@@ -471,16 +587,42 @@ func printTopByMetric(nodes []graphNode, n int, metric string) {
 	}
 	fmt.Printf("%s (N=%d)\n", title, n)
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "RANK\tMODULE\tIN\tOUT\tDEPTH\tMAIN")
+	enriched := len(enrichSources) > 0
+	if enriched {
+		fmt.Fprintln(w, "RANK\tMODULE\tIN\tOUT\tDEPTH\tMAIN\tMETADATA")
+	} else {
+		fmt.Fprintln(w, "RANK\tMODULE\tIN\tOUT\tDEPTH\tMAIN")
+	}
 	for i := 0; i < n; i++ {
 		node := ranked[i]
+		if enriched {
+			fmt.Fprintf(w, "%d\t%s\t%d\t%d\t%d\t%t\t%s\n", i+1, node.Module, node.InDegree, node.OutDegree, node.Depth, node.IsMainModule, formatEnrichment(node.Enrichment))
+			continue
+		}
 		fmt.Fprintf(w, "%d\t%s\t%d\t%d\t%d\t%t\n", i+1, node.Module, node.InDegree, node.OutDegree, node.Depth, node.IsMainModule)
 	}
 	_ = w.Flush()
 }
 
+// attachNodeEnrichment looks up external metadata for every non-main node.
+func attachNodeEnrichment(nodes []graphNode, versions map[string]string, sources []string) {
+	var mods []string
+	for _, n := range nodes {
+		if !n.IsMainModule {
+			mods = append(mods, n.Module)
+		}
+	}
+	enrichment, warnings := enrichModules(mods, versions, sources)
+	printEnrichWarnings(warnings)
+	for i := range nodes {
+		nodes[i].Enrichment = enrichment[nodes[i].Module]
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(graphCmd)
+	graphCmd.Flags().BoolVar(&watchMode, "watch", false, "Re-run and re-print the graph whenever go.mod, go.sum, go.work or go.work.sum change")
+	graphCmd.Flags().DurationVar(&watchInterval, "watch-interval", time.Second, "How often --watch checks the module files for changes")
 	graphCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
 	graphCmd.Flags().StringVarP(&dep, "dep", "p", "", "Specify dependency to create a graph around")
 	graphCmd.Flags().BoolVar(&showEdgeTypes, "show-edge-types", false, "Distinguish direct vs transitive edges with colors/styles")
@@ -489,13 +631,124 @@ func init() {
 	graphCmd.Flags().BoolVarP(&graphSVGOutput, "svg", "s", false, "Render DOT output as SVG (requires graphviz 'dot')")
 	graphCmd.Flags().StringVar(&graphTopMode, "top", "", "Show top modules by degree: in, out, or both")
 	graphCmd.Flags().IntVarP(&graphTopN, "n", "n", 10, "Number of modules to show with --top")
-	graphCmd.Flags().BoolVar(&graphSplitTestOnly, "split-test-only", false, "Split graph into test-only and non-test sections (uses go mod why -m)")
+	graphCmd.Flags().StringVar(&graphDotStyle.RankDir, "rankdir", "", "DOT rank direction: LR (wide graphs) or TB; default keeps Graphviz's TB")
+	graphCmd.Flags().StringVar(&graphDotStyle.NodeLabel, "node-label", "full", "DOT node labels: full (module path), short (last path element) or version (module@version)")
+	graphCmd.Flags().IntVar(&graphDotStyle.MaxLabelLen, "max-label-len", 0, "Truncate DOT node labels to this many characters, keeping the end (0 = no limit)")
+	graphCmd.Flags().StringVar(&graphDotStyle.URLTemplate, "url-template", "", "Hyperlink DOT nodes; {module} and {version} are substituted, e.g. https://pkg.go.dev/{module}@{version}")
+	graphCmd.Flags().BoolVar(&graphDotStyle.WeightNodes, "weight-nodes", false, "Scale and shade DOT nodes by the number of transitive dependencies they pull in")
+	graphCmd.Flags().BoolVar(&graphCondense, "condense", false, "Collapse strongly connected components (cycles) into single nodes")
+	graphCmd.Flags().BoolVar(&graphSplitTestOnly, "split-test-only", false, "Split graph into test-only and non-test sections (uses go mod why -m); with --dot or --svg, highlight test-only nodes in a single graph")
+	graphCmd.Flags().BoolVar(&graphDotStyle.DashedTestOnly, "dashed-test-only", false, "With --split-test-only and --dot or --svg, also draw test-only nodes dashed")
 	graphCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
 	graphCmd.Flags().StringVar(&graphOutputPath, "output", "graph.dot", "Path to DOT output file when not using --dot or --json")
 	graphCmd.Flags().BoolVarP(&graphVerbose, "verbose", "v", false, "Include dependency lists in text output")
+	graphCmd.Flags().StringSliceVar(&enrichSources, "enrich", []string{}, "Attach external metadata to ranked/JSON nodes (supported: depsdev, github, osv, proxy)")
+	graphCmd.Flags().StringVar(&enrichCacheDir, "enrich-cache-dir", "", "Directory for cached enrichment data. Defaults to the user cache directory.")
+	graphCmd.Flags().IntVar(&enrichStaleDays, "stale-days", 365, "With --enrich github, flag dependencies whose upstream has no commits in this many days (0 disables)")
+	graphCmd.Flags().IntVar(&enrichMaxVersionAgeDays, "version-age-days", 730, "With --enrich proxy, flag dependencies whose pinned version was released more than this many days ago (0 disables)")
+	graphCmd.Flags().StringVar(&githubTokenPath, "github-token-path", "", "Path to a file containing the GitHub API token. If not set, uses GITHUB_TOKEN env var.")
 	graphCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Specify main modules")
 }
 
+// stronglyConnectedComponents returns the strongly connected components of
+// graph using Tarjan's algorithm. Members of each component are sorted.
+func stronglyConnectedComponents(graph map[string][]string) [][]string {
+	index := map[string]int{}
+	lowlink := map[string]int{}
+	onStack := map[string]bool{}
+	var stack []string
+	var components [][]string
+	var strongConnect func(v string)
+	strongConnect = func(v string) {
+		index[v] = len(index)
+		lowlink[v] = index[v]
+		stack = append(stack, v)
+		onStack[v] = true
+		for _, w := range graph[v] {
+			if _, visited := index[w]; !visited {
+				strongConnect(w)
+				lowlink[v] = min(lowlink[v], lowlink[w])
+			} else if onStack[w] {
+				lowlink[v] = min(lowlink[v], index[w])
+			}
+		}
+		if lowlink[v] == index[v] {
+			var component []string
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				component = append(component, w)
+				if w == v {
+					break
+				}
+			}
+			sort.Strings(component)
+			components = append(components, component)
+		}
+	}
+	for _, v := range graphNodes(graph) {
+		if _, visited := index[v]; !visited {
+			strongConnect(v)
+		}
+	}
+	return components
+}
+
+// condenseGraph collapses every strongly connected component with more than
+// one member into a single node named after its first member and labeled with
+// the member count, so the result is a DAG. It also returns the members of
+// each collapsed node.
+func condenseGraph(overview *DependencyOverview) (*DependencyOverview, map[string][]string) {
+	rename := map[string]string{}
+	components := map[string][]string{}
+	for _, component := range stronglyConnectedComponents(overview.Graph) {
+		if len(component) == 1 {
+			continue
+		}
+		name := fmt.Sprintf("%s (+%d in cycle)", component[0], len(component)-1)
+		components[name] = component
+		for _, m := range component {
+			rename[m] = name
+		}
+	}
+	nodeName := func(m string) string {
+		if name, ok := rename[m]; ok {
+			return name
+		}
+		return m
+	}
+
+	condensed := &DependencyOverview{
+		Graph:    map[string][]string{},
+		Versions: map[string]string{},
+	}
+	for _, m := range overview.MainModules {
+		if name := nodeName(m); !contains(condensed.MainModules, name) {
+			condensed.MainModules = append(condensed.MainModules, name)
+		}
+	}
+	for from, tos := range overview.Graph {
+		cf := nodeName(from)
+		for _, to := range tos {
+			ct := nodeName(to)
+			if cf != ct && !contains(condensed.Graph[cf], ct) {
+				condensed.Graph[cf] = append(condensed.Graph[cf], ct)
+			}
+		}
+	}
+	for from := range condensed.Graph {
+		sort.Strings(condensed.Graph[from])
+	}
+	for m, v := range overview.Versions {
+		if _, ok := rename[m]; !ok {
+			condensed.Versions[m] = v
+		}
+	}
+	condensed.DirectDepList, condensed.TransDepList = recomputeDepLists(condensed)
+	return condensed, components
+}
+
 func splitGraphByTestStatus(overview *DependencyOverview, testOnlySet map[string]bool) (*DependencyOverview, *DependencyOverview) {
 	clone := func() *DependencyOverview {
 		return &DependencyOverview{
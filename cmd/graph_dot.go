@@ -0,0 +1,199 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// dotStyle controls optional presentation settings of emitted DOT graphs.
+// The zero value keeps the historical output unchanged.
+type dotStyle struct {
+	// RankDir is the Graphviz rankdir (LR, TB, RL or BT).
+	RankDir string
+	// NodeLabel selects node labels: full (module path), short (last path
+	// element) or version (module@version).
+	NodeLabel string
+	// MaxLabelLen truncates labels longer than this many characters.
+	MaxLabelLen int
+	// URLTemplate links nodes; {module} and {version} are substituted.
+	URLTemplate string
+	// WeightNodes scales and shades nodes by their transitive weight.
+	WeightNodes bool
+	// DashedTestOnly draws test-only nodes with a dashed outline.
+	DashedTestOnly bool
+}
+
+var graphDotStyle dotStyle
+
+var majorVersionElemRE = regexp.MustCompile(`^v[0-9]+$`)
+
+func (s dotStyle) validate() error {
+	switch s.RankDir {
+	case "", "LR", "TB", "RL", "BT":
+	default:
+		return withExitCode(ExitUsage, fmt.Errorf("--rankdir must be one of: LR, TB, RL, BT"))
+	}
+	switch s.NodeLabel {
+	case "", "full", "short", "version":
+	default:
+		return withExitCode(ExitUsage, fmt.Errorf("--node-label must be one of: full, short, version"))
+	}
+	if s.MaxLabelLen < 0 {
+		return withExitCode(ExitUsage, fmt.Errorf("--max-label-len must be >= 0"))
+	}
+	return nil
+}
+
+// graphAttributes returns the attribute list of the DOT graph statement.
+func (s dotStyle) graphAttributes() string {
+	attrs := "overlap=false"
+	if s.RankDir != "" {
+		attrs += ", rankdir=" + s.RankDir
+	}
+	return attrs
+}
+
+// customizesNodes reports whether node attribute statements are needed.
+func (s dotStyle) customizesNodes() bool {
+	return (s.NodeLabel != "" && s.NodeLabel != "full") || s.MaxLabelLen > 0 || s.URLTemplate != "" || s.WeightNodes
+}
+
+// nodeLabel formats the label of a module node.
+func (s dotStyle) nodeLabel(module, version string) string {
+	label := module
+	switch s.NodeLabel {
+	case "short":
+		elems := strings.Split(module, "/")
+		label = elems[len(elems)-1]
+		if len(elems) > 1 && majorVersionElemRE.MatchString(label) {
+			label = elems[len(elems)-2] + "/" + label
+		}
+	case "version":
+		if version != "" {
+			label = module + "@" + version
+		}
+	}
+	if s.MaxLabelLen > 0 && len(label) > s.MaxLabelLen {
+		if s.MaxLabelLen <= 3 {
+			label = label[len(label)-s.MaxLabelLen:]
+		} else {
+			label = "..." + label[len(label)-(s.MaxLabelLen-3):]
+		}
+	}
+	return label
+}
+
+// nodeStatements emits one attribute statement per node carrying its label,
+// URL and weight styling. id maps a module to its DOT node ID, for the
+// renamed main node. weights is only used with WeightNodes.
+func (s dotStyle) nodeStatements(modules []string, versions map[string]string, weights map[string]int, id func(string) string) string {
+	if !s.customizesNodes() {
+		return ""
+	}
+	maxWeight := 0
+	for _, mod := range modules {
+		maxWeight = max(maxWeight, weights[mod])
+	}
+	var b strings.Builder
+	for _, mod := range modules {
+		label := s.nodeLabel(mod, versions[mod])
+		var attrs string
+		if s.WeightNodes {
+			t := weightFraction(weights[mod], maxWeight)
+			label = fmt.Sprintf("%s (%d)", label, weights[mod])
+			attrs = fmt.Sprintf(", fontsize=%.0f", 10+t*14)
+			if id(mod) != "MainNode" {
+				attrs += fmt.Sprintf(", style=filled, fillcolor=%q", weightColor(t))
+			}
+		}
+		attrs = fmt.Sprintf("label=%q", label) + attrs
+		if s.URLTemplate != "" {
+			url := strings.NewReplacer("{module}", mod, "{version}", versions[mod]).Replace(s.URLTemplate)
+			attrs += fmt.Sprintf(", URL=%q, tooltip=%q", url, mod)
+		}
+		fmt.Fprintf(&b, "\"%s\" [%s]\n", id(mod), attrs)
+	}
+	return b.String()
+}
+
+// testOnlyStatements colors the test-only modules among modules with the
+// test-only palette of the light SVG theme. They are emitted after
+// nodeStatements, so the test-only fill takes precedence over weight shading.
+func (s dotStyle) testOnlyStatements(modules []string, testOnly map[string]bool, id func(string) string) string {
+	if len(testOnly) == 0 {
+		return ""
+	}
+	color := svgThemes["light"].TestOnly
+	style := "filled"
+	if s.DashedTestOnly {
+		style = "filled,dashed"
+	}
+	var b strings.Builder
+	for _, mod := range modules {
+		if !testOnly[mod] {
+			continue
+		}
+		fmt.Fprintf(&b, "\"%s\" [style=%q, fillcolor=%q, color=%q, fontcolor=%q]\n", id(mod), style, color.Fill, color.Stroke, color.Text)
+	}
+	return b.String()
+}
+
+// transitiveWeights returns, for every node, the number of distinct modules
+// reachable from it.
+func transitiveWeights(graph map[string][]string) map[string]int {
+	weights := make(map[string]int)
+	for _, node := range graphNodes(graph) {
+		seen := map[string]bool{node: true}
+		queue := []string{node}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			for _, next := range graph[current] {
+				if !seen[next] {
+					seen[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+		weights[node] = len(seen) - 1
+	}
+	return weights
+}
+
+// weightFraction maps a weight onto [0, 1] on a logarithmic scale, so a few
+// very heavy nodes do not wash out the rest.
+func weightFraction(weight, maxWeight int) float64 {
+	if maxWeight <= 0 {
+		return 0
+	}
+	return math.Log1p(float64(weight)) / math.Log1p(float64(maxWeight))
+}
+
+// weightColor interpolates from a pale to a deep orange for t in [0, 1].
+func weightColor(t float64) string {
+	from := [3]float64{0xFF, 0xF7, 0xE6}
+	to := [3]float64{0xD8, 0x43, 0x15}
+	var c [3]int
+	for i := range c {
+		c[i] = int(math.Round(from[i] + (to[i]-from[i])*t))
+	}
+	return fmt.Sprintf("#%02X%02X%02X", c[0], c[1], c[2])
+}
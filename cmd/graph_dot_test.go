@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDotStyleNodeLabel(t *testing.T) {
+	tests := []struct {
+		style   dotStyle
+		module  string
+		version string
+		want    string
+	}{
+		{dotStyle{}, "github.com/foo/bar", "v1.0.0", "github.com/foo/bar"},
+		{dotStyle{NodeLabel: "short"}, "github.com/foo/bar", "v1.0.0", "bar"},
+		{dotStyle{NodeLabel: "short"}, "github.com/foo/bar/v2", "v2.1.0", "bar/v2"},
+		{dotStyle{NodeLabel: "version"}, "github.com/foo/bar", "v1.0.0", "github.com/foo/bar@v1.0.0"},
+		{dotStyle{NodeLabel: "version"}, "main", "", "main"},
+		{dotStyle{MaxLabelLen: 10}, "github.com/foo/bar", "", "...foo/bar"},
+	}
+	for _, tt := range tests {
+		if got := tt.style.nodeLabel(tt.module, tt.version); got != tt.want {
+			t.Errorf("%+v.nodeLabel(%q) = %q, want %q", tt.style, tt.module, got, tt.want)
+		}
+	}
+}
+
+func TestDotStyleNodeStatements(t *testing.T) {
+	if got := (dotStyle{RankDir: "LR"}).nodeStatements([]string{"a"}, nil, nil, func(m string) string { return m }); got != "" {
+		t.Errorf("default labels should not emit node statements, got %q", got)
+	}
+	style := dotStyle{NodeLabel: "short", URLTemplate: "https://pkg.go.dev/{module}@{version}"}
+	got := style.nodeStatements([]string{"example.com/main", "github.com/foo/bar"}, map[string]string{"github.com/foo/bar": "v1.2.0"}, nil, func(m string) string {
+		if m == "example.com/main" {
+			return "MainNode"
+		}
+		return m
+	})
+	for _, want := range []string{
+		`"MainNode" [label="main", URL="https://pkg.go.dev/example.com/main@", tooltip="example.com/main"]`,
+		`"github.com/foo/bar" [label="bar", URL="https://pkg.go.dev/github.com/foo/bar@v1.2.0", tooltip="github.com/foo/bar"]`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("node statements missing %s:\n%s", want, got)
+		}
+	}
+	if err := (dotStyle{RankDir: "XY"}).validate(); err == nil {
+		t.Error("expected invalid --rankdir error")
+	}
+}
+
+func TestTransitiveWeights(t *testing.T) {
+	graph := map[string][]string{
+		"main": {"A", "B"},
+		"A":    {"C", "D"},
+		"B":    {"D"},
+		"D":    {"E"},
+	}
+	want := map[string]int{"main": 5, "A": 3, "B": 2, "C": 0, "D": 1, "E": 0}
+	if got := transitiveWeights(graph); !reflect.DeepEqual(got, want) {
+		t.Errorf("transitiveWeights() = %v, want %v", got, want)
+	}
+
+	got := (dotStyle{WeightNodes: true}).nodeStatements([]string{"A", "C"}, nil, want, func(m string) string { return m })
+	for _, line := range []string{
+		`"A" [label="A (3)", fontsize=24, style=filled, fillcolor="#D84315"]`,
+		`"C" [label="C (0)", fontsize=10, style=filled, fillcolor="#FFF7E6"]`,
+	} {
+		if !strings.Contains(got, line) {
+			t.Errorf("weighted node statements missing %s:\n%s", line, got)
+		}
+	}
+}
+
+func TestDotStyleTestOnlyStatements(t *testing.T) {
+	id := func(m string) string { return m }
+	if got := (dotStyle{}).testOnlyStatements([]string{"a"}, nil, id); got != "" {
+		t.Errorf("expected no statements without test-only modules, got %q", got)
+	}
+	testOnly := map[string]bool{"b": true}
+	got := (dotStyle{}).testOnlyStatements([]string{"a", "b"}, testOnly, id)
+	want := `"b" [style="filled", fillcolor="#F5F5F5", color="#9E9E9E", fontcolor="#616161"]` + "\n"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	got = (dotStyle{DashedTestOnly: true}).testOnlyStatements([]string{"a", "b"}, testOnly, id)
+	if !strings.Contains(got, `style="filled,dashed"`) {
+		t.Errorf("expected dashed style, got %q", got)
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -169,3 +170,33 @@ func Test_buildGraphTopology_unreachableDepth(t *testing.T) {
 		}
 	}
 }
+
+func TestCondenseGraph(t *testing.T) {
+	overview := &DependencyOverview{
+		MainModules: []string{"main"},
+		Graph: map[string][]string{
+			"main": {"A", "D"},
+			"A":    {"B"},
+			"B":    {"C", "D"},
+			"C":    {"A"},
+		},
+		Versions: map[string]string{"A": "v1", "B": "v1", "C": "v1", "D": "v1"},
+	}
+	condensed, components := condenseGraph(overview)
+	scc := "A (+2 in cycle)"
+	if got := strings.Join(components[scc], ","); got != "A,B,C" {
+		t.Fatalf("unexpected component members %q (components: %v)", got, components)
+	}
+	if got := strings.Join(condensed.Graph["main"], ","); got != scc+",D" {
+		t.Errorf("unexpected main edges %q", got)
+	}
+	if got := strings.Join(condensed.Graph[scc], ","); got != "D" {
+		t.Errorf("expected cycle edges to collapse into a single edge to D, got %q", got)
+	}
+	if len(findAllCycles(condensed.Graph)) != 0 {
+		t.Errorf("expected condensed graph to be acyclic")
+	}
+	if _, ok := condensed.Versions["A"]; ok {
+		t.Errorf("expected collapsed members to be dropped from versions")
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+// selectedOnly is set by --selected-only.
+var selectedOnly bool
+
+// graphView names the view of the module graph being analyzed, for the
+// "view" field of JSON results:
+//
+//   - "requested": the default. A module's version is the one the main
+//     modules require or, failing that, the first one reached, and its
+//     edges are the requirements of that version.
+//   - "selected": every module is at the version MVS selects, the highest
+//     one required anywhere in the graph, and only the requirements of
+//     selected versions are edges.
+func graphView() string {
+	if selectedOnly {
+		return "selected"
+	}
+	return "requested"
+}
+
+// mvsSelectedVersions returns the version MVS selects for every module in
+// the "go mod graph" output reachable from roots: the highest version of
+// it reached through any requirement, whether or not the requiring version
+// is itself selected. The roots keep their own versions.
+func mvsSelectedVersions(versionedGraph map[module][]module, roots []module) map[string]string {
+	selected := make(map[string]string)
+	isRoot := make(map[string]bool)
+	for _, r := range roots {
+		selected[r.name] = r.version
+		isRoot[r.name] = true
+	}
+	visited := make(map[module]bool)
+	queue := append([]module{}, roots...)
+	for len(queue) > 0 {
+		m := queue[0]
+		queue = queue[1:]
+		if visited[m] {
+			continue
+		}
+		visited[m] = true
+		for _, next := range versionedGraph[m] {
+			if !isRoot[next.name] && versionGreater(next.version, selected[next.name]) {
+				selected[next.name] = next.version
+			}
+			queue = append(queue, next)
+		}
+	}
+	return selected
+}
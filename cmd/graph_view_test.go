@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGenerateGraphSelectedOnly(t *testing.T) {
+	graph := `main A@v1.0.0
+main B@v1.0.0
+A@v1.0.0 C@v1.0.0
+B@v1.0.0 A@v1.1.0
+A@v1.1.0 D@v1.0.0`
+	defer func() { selectedOnly = false }()
+
+	requested := generateGraph(graph, []string{"main"})
+	if got, want := requested.Versions["A"], "v1.0.0"; got != want {
+		t.Errorf("requested view: A at %s, want %s", got, want)
+	}
+	if got, want := sortedCopy(requested.TransDepList), []string{"A", "C"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("requested view: transitive = %v, want %v", got, want)
+	}
+
+	selectedOnly = true
+	selected := generateGraph(graph, []string{"main"})
+	if got, want := selected.Versions, map[string]string{"main": "", "A": "v1.1.0", "B": "v1.0.0", "D": "v1.0.0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("selected view: versions = %v, want %v", got, want)
+	}
+	if got, want := selected.Graph["A"], []string{"D"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("selected view: A requires %v, want %v", got, want)
+	}
+	if got, want := sortedCopy(selected.TransDepList), []string{"A", "D"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("selected view: transitive = %v, want %v", got, want)
+	}
+	if graphView() != "selected" {
+		t.Errorf("graphView() = %q, want selected", graphView())
+	}
+}
@@ -0,0 +1,313 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var hygieneFailOn []string
+
+// pseudoVersionRE matches Go pseudo-versions such as
+// v0.0.0-20230102150405-abcdef123456, following golang.org/x/mod/module.
+var pseudoVersionRE = regexp.MustCompile(`^v[0-9]+\.(0\.0-|\d+\.\d+-([^+]*\.)?0\.)\d{14}-[A-Za-z0-9]+(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+
+// majorSuffixRE matches the /vN (N >= 2) suffix of a module path, and
+// gopkgInSuffixRE the .vN suffix used by gopkg.in.
+var majorSuffixRE = regexp.MustCompile(`^(.+)/v([2-9]|[1-9][0-9]+)$`)
+var gopkgInSuffixRE = regexp.MustCompile(`^(gopkg\.in/.+)\.v([0-9]+)$`)
+
+// HygieneFinding is a dependency pinned to a pseudo-version or pre-release.
+type HygieneFinding struct {
+	Module     string   `json:"module"`
+	Version    string   `json:"version"`
+	RequiredBy []string `json:"requiredBy"`
+}
+
+// MajorVersionDuplicate is a module present in the graph under more than
+// one major version.
+type MajorVersionDuplicate struct {
+	Module string         `json:"module"`
+	Majors []MajorVersion `json:"majors"`
+}
+
+// MajorVersion is one major version of a duplicated module, with the
+// shortest path that pulls it in.
+type MajorVersion struct {
+	Module  string   `json:"module"`
+	Version string   `json:"version"`
+	Path    []string `json:"path"`
+}
+
+// HygieneResult holds the result of the hygiene check.
+type HygieneResult struct {
+	PseudoVersions  []HygieneFinding        `json:"pseudoVersions"`
+	PreReleases     []HygieneFinding        `json:"preReleases"`
+	DuplicateMajors []MajorVersionDuplicate `json:"duplicateMajors"`
+	// PromoteIndirect and UnusedDirect are only filled with --requirements.
+	PromoteIndirect []RequirementFinding `json:"promoteIndirect,omitempty"`
+	// UnusedDirect are warnings: imports are read for the current GOOS and
+	// GOARCH with the default build tags only, so files for other
+	// platforms or tags may still import these modules.
+	UnusedDirect []RequirementFinding `json:"unusedDirect,omitempty"`
+	MainModules  []string             `json:"mainModules"`
+}
+
+var hygieneCmd = &cobra.Command{
+	Use:   "hygiene",
+	Short: "Report pseudo-version, pre-release and duplicate major version dependencies",
+	Long: `Lists dependencies whose selected version is a pseudo-version
+(v0.0.0-20230102150405-abcdef123456) or a semver pre-release (v1.2.0-rc.1),
+together with the modules whose requirement selects that version.
+
+It also lists modules present under more than one major version, such as
+github.com/foo/bar and github.com/foo/bar/v2, with the shortest path pulling
+in each major. Both copies end up in the binary.
+
+With --requirements, it also compares the // indirect markers of go.mod with
+the imports of the main module's packages (tests included): indirect
+requirements imported directly should become direct requirements, and direct
+requirements no longer imported should be marked indirect or dropped. This
+loads the package graph with go list, for the current GOOS/GOARCH and the
+default build tags only, unlike go mod tidy, which reads the files of every
+platform and tag. Direct requirements only imported by files excluded there
+show up as not imported, so those findings are warnings: check them before
+acting on them, and --fail-on requirements does not count them.
+
+Use --fail-on pseudo,prerelease,duplicate-major,requirements to exit with an
+error when findings of those kinds are present, so the check can gate CI.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("hygiene does not take any arguments"))
+		}
+		for _, kind := range hygieneFailOn {
+			if kind != "pseudo" && kind != "prerelease" && kind != "duplicate-major" && kind != "requirements" {
+				return withExitCode(ExitUsage, fmt.Errorf("--fail-on must be one of: pseudo, prerelease, duplicate-major, requirements"))
+			}
+		}
+		if contains(hygieneFailOn, "requirements") && !hygieneRequirements {
+			return withExitCode(ExitUsage, fmt.Errorf("--fail-on requirements requires --requirements"))
+		}
+		depGraph := getDepInfo(mainModules)
+		if len(depGraph.MainModules) == 0 {
+			return errNoMainModules
+		}
+		result := findHygieneIssues(depGraph)
+		if hygieneRequirements {
+			var err error
+			result.PromoteIndirect, result.UnusedDirect, err = findRequirementIssues()
+			if err != nil {
+				return fmt.Errorf("checking requirements: %w", err)
+			}
+		}
+
+		if jsonOutput {
+			out, err := json.MarshalIndent(result, "", "\t")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+		} else {
+			printHygiene(result)
+		}
+
+		var failures []string
+		if contains(hygieneFailOn, "pseudo") && len(result.PseudoVersions) > 0 {
+			failures = append(failures, fmt.Sprintf("%d pseudo-version", len(result.PseudoVersions)))
+		}
+		if contains(hygieneFailOn, "prerelease") && len(result.PreReleases) > 0 {
+			failures = append(failures, fmt.Sprintf("%d pre-release", len(result.PreReleases)))
+		}
+		if contains(hygieneFailOn, "duplicate-major") && len(result.DuplicateMajors) > 0 {
+			failures = append(failures, fmt.Sprintf("%d duplicate major version", len(result.DuplicateMajors)))
+		}
+		// unused direct requirements are warnings, see UnusedDirect
+		if n := len(result.PromoteIndirect); contains(hygieneFailOn, "requirements") && n > 0 {
+			failures = append(failures, fmt.Sprintf("%d mismarked requirement", n))
+		}
+		if len(failures) > 0 {
+			cmd.SilenceUsage = true
+			return withExitCode(ExitViolation, fmt.Errorf("hygiene check failed: found %s dependencies", strings.Join(failures, " and ")))
+		}
+		return nil
+	},
+}
+
+func isPseudoVersion(v string) bool {
+	return pseudoVersionRE.MatchString(v)
+}
+
+// isPreRelease reports whether v is a semver pre-release that is not a
+// pseudo-version.
+func isPreRelease(v string) bool {
+	v = strings.SplitN(v, "+", 2)[0]
+	return strings.Contains(v, "-") && !isPseudoVersion(v)
+}
+
+func findHygieneIssues(depGraph *DependencyOverview) HygieneResult {
+	result := HygieneResult{
+		PseudoVersions: []HygieneFinding{},
+		PreReleases:    []HygieneFinding{},
+		MainModules:    depGraph.MainModules,
+	}
+	for _, dep := range getAllDeps(depGraph.DirectDepList, depGraph.TransDepList) {
+		version := depGraph.Versions[dep]
+		if version == "" || contains(depGraph.MainModules, dep) {
+			continue
+		}
+		pseudo := isPseudoVersion(version)
+		if !pseudo && !isPreRelease(version) {
+			continue
+		}
+		requiredBy := requestersOfVersion(depGraph.Requirements[dep], version)
+		if requiredBy == nil {
+			requiredBy = []string{}
+		}
+		finding := HygieneFinding{Module: dep, Version: version, RequiredBy: requiredBy}
+		if pseudo {
+			result.PseudoVersions = append(result.PseudoVersions, finding)
+		} else {
+			result.PreReleases = append(result.PreReleases, finding)
+		}
+	}
+	sort.Slice(result.PseudoVersions, func(i, j int) bool { return result.PseudoVersions[i].Module < result.PseudoVersions[j].Module })
+	sort.Slice(result.PreReleases, func(i, j int) bool { return result.PreReleases[i].Module < result.PreReleases[j].Module })
+	result.DuplicateMajors = findDuplicateMajors(depGraph)
+	return result
+}
+
+// majorVersionBase strips the major version suffix from a module path, so
+// that all majors of a module share the same base path.
+func majorVersionBase(modPath string) string {
+	if m := gopkgInSuffixRE.FindStringSubmatch(modPath); m != nil {
+		return m[1]
+	}
+	if m := majorSuffixRE.FindStringSubmatch(modPath); m != nil {
+		return m[1]
+	}
+	return modPath
+}
+
+// findDuplicateMajors groups dependencies by base path and returns the
+// groups with more than one major version.
+func findDuplicateMajors(depGraph *DependencyOverview) []MajorVersionDuplicate {
+	byBase := map[string][]string{}
+	for _, dep := range getAllDeps(depGraph.DirectDepList, depGraph.TransDepList) {
+		if contains(depGraph.MainModules, dep) {
+			continue
+		}
+		base := majorVersionBase(moduleKey(dep))
+		byBase[base] = append(byBase[base], dep)
+	}
+	duplicates := []MajorVersionDuplicate{}
+	for base, mods := range byBase {
+		if len(mods) < 2 {
+			continue
+		}
+		sort.Strings(mods)
+		dup := MajorVersionDuplicate{Module: base}
+		for _, mod := range mods {
+			dup.Majors = append(dup.Majors, MajorVersion{
+				Module:  mod,
+				Version: depGraph.Versions[mod],
+				Path:    shortestPath(depGraph.MainModules, mod, depGraph.Graph),
+			})
+		}
+		duplicates = append(duplicates, dup)
+	}
+	sort.Slice(duplicates, func(i, j int) bool { return duplicates[i].Module < duplicates[j].Module })
+	return duplicates
+}
+
+func printHygiene(result HygieneResult) {
+	if len(result.PseudoVersions) == 0 && len(result.PreReleases) == 0 && len(result.DuplicateMajors) == 0 &&
+		len(result.PromoteIndirect) == 0 && len(result.UnusedDirect) == 0 {
+		if result.PromoteIndirect != nil {
+			fmt.Println("No pseudo-version, pre-release, duplicate major version or mismarked requirement found.")
+			return
+		}
+		fmt.Println("No pseudo-version, pre-release or duplicate major version dependencies found.")
+		return
+	}
+	sections := []struct {
+		title    string
+		findings []HygieneFinding
+	}{
+		{"PSEUDO-VERSIONS", result.PseudoVersions},
+		{"PRE-RELEASES", result.PreReleases},
+	}
+	for _, section := range sections {
+		if len(section.findings) == 0 {
+			continue
+		}
+		fmt.Printf("%s (%d):\n", section.title, len(section.findings))
+		for _, f := range section.findings {
+			fmt.Printf("  %s %s\n", f.Module, f.Version)
+			if len(f.RequiredBy) > 0 {
+				fmt.Printf("    required by: %s\n", strings.Join(f.RequiredBy, ", "))
+			}
+		}
+		fmt.Println()
+	}
+	if len(result.DuplicateMajors) > 0 {
+		fmt.Printf("DUPLICATE MAJOR VERSIONS (%d):\n", len(result.DuplicateMajors))
+		printDuplicateMajors(result.DuplicateMajors)
+		fmt.Println()
+	}
+	if len(result.PromoteIndirect) > 0 {
+		fmt.Printf("INDIRECT REQUIREMENTS IMPORTED DIRECTLY (%d), drop the // indirect marker:\n", len(result.PromoteIndirect))
+		for _, f := range result.PromoteIndirect {
+			fmt.Printf("  %s %s\n    imported by: %s\n", f.Module, f.Version, strings.Join(f.ImportedBy, ", "))
+		}
+		fmt.Println()
+	}
+	if len(result.UnusedDirect) > 0 {
+		fmt.Printf("DIRECT REQUIREMENTS NOT IMPORTED (%d), warning: only the current GOOS/GOARCH and default build tags were checked; if no other platform or tag imports them, mark them // indirect or drop them:\n", len(result.UnusedDirect))
+		for _, f := range result.UnusedDirect {
+			fmt.Printf("  %s %s\n", f.Module, f.Version)
+		}
+		fmt.Println()
+	}
+}
+
+func printDuplicateMajors(duplicates []MajorVersionDuplicate) {
+	for _, d := range duplicates {
+		fmt.Printf("  %s\n", d.Module)
+		for _, m := range d.Majors {
+			fmt.Printf("    %s %s\n", m.Module, m.Version)
+			if len(m.Path) > 0 {
+				fmt.Printf("      path: %s\n", strings.Join(m.Path, " -> "))
+			}
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(hygieneCmd)
+	hygieneCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
+	hygieneCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Get the output in JSON format")
+	hygieneCmd.Flags().StringSliceVar(&hygieneFailOn, "fail-on", []string{}, "Exit with an error when findings of these kinds exist: pseudo, prerelease, duplicate-major, requirements")
+	hygieneCmd.Flags().BoolVar(&hygieneRequirements, "requirements", false, "Also flag indirect requirements imported directly and warn about direct requirements not imported for the current GOOS/GOARCH and default build tags")
+	hygieneCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
+	hygieneCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Specify main modules")
+}
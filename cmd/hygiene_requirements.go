@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+var hygieneRequirements bool
+
+// RequirementFinding is a go.mod requirement whose // indirect marker does
+// not match how the main module's packages use it.
+type RequirementFinding struct {
+	Module  string `json:"module"`
+	Version string `json:"version"`
+	// ImportedBy lists the main module packages importing the module.
+	ImportedBy []string `json:"importedBy,omitempty"`
+}
+
+// goModRequirement is a require directive as printed by go mod edit -json.
+type goModRequirement struct {
+	Path     string
+	Version  string
+	Indirect bool
+}
+
+// goModReplace is a replace directive as printed by go mod edit -json.
+type goModReplace struct {
+	Old goModVersion
+	New goModVersion
+}
+
+type goModVersion struct {
+	Path    string
+	Version string
+}
+
+// goModFile is the subset of go mod edit -json output depstat reads.
+type goModFile struct {
+	Module goModVersion
+	// Go is the version of the go directive.
+	Go      string
+	Require []goModRequirement
+	Exclude []goModVersion
+	Replace []goModReplace
+	// Tool lists the tool directives of Go 1.24 and later.
+	Tool []struct{ Path string }
+}
+
+// readGoModFile parses the go.mod in --dir with go mod edit -json.
+func readGoModFile() (*goModFile, error) {
+	c := goCommand([]string{"mod", "edit", "-json"})
+	out, err := c.Output()
+	if err != nil {
+		return nil, goCommandError(c, err)
+	}
+	var gomod goModFile
+	if err := json.Unmarshal(out, &gomod); err != nil {
+		return nil, fmt.Errorf("parsing go mod edit -json output: %w", err)
+	}
+	return &gomod, nil
+}
+
+// readGoModRequirements returns the require directives of the go.mod in
+// --dir.
+func readGoModRequirements() ([]goModRequirement, error) {
+	gomod, err := readGoModFile()
+	if err != nil {
+		return nil, err
+	}
+	return gomod.Require, nil
+}
+
+// directImports maps every module imported by a package of a main module,
+// tests included, to the importing packages.
+func directImports(g *packageGraph) map[string][]string {
+	imports := make(map[string][]string)
+	for _, id := range g.order {
+		p := g.packages[id]
+		if p.Module == nil || !p.Module.Main {
+			continue
+		}
+		importer := stripTestVariant(p.ImportPath)
+		for _, imp := range p.Imports {
+			mod := g.modulePath(imp)
+			importedMod := g.packages[imp]
+			if mod == "" || (importedMod != nil && importedMod.Module.Main) {
+				continue
+			}
+			if !contains(imports[mod], importer) {
+				imports[mod] = append(imports[mod], importer)
+			}
+		}
+	}
+	for mod := range imports {
+		sort.Strings(imports[mod])
+	}
+	return imports
+}
+
+// checkRequirements compares the // indirect markers of go.mod with the
+// modules the main module's packages import: indirect requirements that are
+// imported should be promoted to direct ones, and direct requirements that
+// are no longer imported should become indirect or be dropped. imports only
+// covers the build configuration go list ran with, so unused is a hint
+// rather than a verdict: go mod tidy reads the files of every platform and
+// build tag.
+func checkRequirements(requirements []goModRequirement, imports map[string][]string) (promote, unused []RequirementFinding) {
+	promote, unused = []RequirementFinding{}, []RequirementFinding{}
+	for _, r := range requirements {
+		importedBy := imports[r.Path]
+		switch {
+		case r.Indirect && len(importedBy) > 0:
+			promote = append(promote, RequirementFinding{Module: r.Path, Version: r.Version, ImportedBy: importedBy})
+		case !r.Indirect && len(importedBy) == 0:
+			unused = append(unused, RequirementFinding{Module: r.Path, Version: r.Version})
+		}
+	}
+	sort.Slice(promote, func(i, j int) bool { return promote[i].Module < promote[j].Module })
+	sort.Slice(unused, func(i, j int) bool { return unused[i].Module < unused[j].Module })
+	return promote, unused
+}
+
+// findRequirementIssues runs checkRequirements on the go.mod and packages of
+// --dir.
+func findRequirementIssues() (promote, unused []RequirementFinding, err error) {
+	requirements, err := readGoModRequirements()
+	if err != nil {
+		return nil, nil, err
+	}
+	g, err := loadPackageGraph()
+	if err != nil {
+		return nil, nil, err
+	}
+	promote, unused = checkRequirements(requirements, directImports(g))
+	return promote, unused, nil
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestVersionClassification(t *testing.T) {
+	tests := []struct {
+		version    string
+		pseudo     bool
+		prerelease bool
+	}{
+		{"v1.2.3", false, false},
+		{"v0.0.0-20230102150405-abcdef123456", true, false},
+		{"v1.2.4-0.20230102150405-abcdef123456", true, false},
+		{"v1.3.0-rc.1.0.20230102150405-abcdef123456", true, false},
+		{"v1.3.0-rc.1", false, true},
+		{"v2.0.0-beta.2+incompatible", false, true},
+		{"v2.0.0+incompatible", false, false},
+	}
+	for _, tt := range tests {
+		if got := isPseudoVersion(tt.version); got != tt.pseudo {
+			t.Errorf("isPseudoVersion(%q) = %v, want %v", tt.version, got, tt.pseudo)
+		}
+		if got := isPreRelease(tt.version); got != tt.prerelease {
+			t.Errorf("isPreRelease(%q) = %v, want %v", tt.version, got, tt.prerelease)
+		}
+	}
+}
+
+func TestFindHygieneIssues(t *testing.T) {
+	depGraph := generateGraph(`main A@v1.0.0
+main B@v0.0.0-20230102150405-abcdef123456
+A@v1.0.0 C@v2.0.0-rc.1
+A@v1.0.0 B@v0.0.0-20230102150405-abcdef123456`, nil)
+	result := findHygieneIssues(&depGraph)
+	if len(result.PseudoVersions) != 1 || result.PseudoVersions[0].Module != "B" {
+		t.Fatalf("unexpected pseudo-versions %+v", result.PseudoVersions)
+	}
+	if got := strings.Join(result.PseudoVersions[0].RequiredBy, ","); got != "A,main" {
+		t.Errorf("unexpected requiring modules %q", got)
+	}
+	if len(result.PreReleases) != 1 || result.PreReleases[0].Module != "C" || result.PreReleases[0].RequiredBy[0] != "A" {
+		t.Fatalf("unexpected pre-releases %+v", result.PreReleases)
+	}
+}
+
+func TestFindDuplicateMajors(t *testing.T) {
+	depGraph := generateGraph(`main github.com/foo/bar@v1.4.0
+main A@v1.0.0
+A@v1.0.0 github.com/foo/bar/v2@v2.1.0
+A@v1.0.0 gopkg.in/yaml.v2@v2.4.0
+main gopkg.in/yaml.v3@v3.0.1
+main github.com/foo/baz/v3@v3.0.0`, nil)
+	got := findDuplicateMajors(&depGraph)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 duplicates, got %+v", got)
+	}
+	if got[0].Module != "github.com/foo/bar" || got[0].Majors[1].Module != "github.com/foo/bar/v2" || got[0].Majors[1].Version != "v2.1.0" {
+		t.Errorf("unexpected first duplicate %+v", got[0])
+	}
+	if p := strings.Join(got[0].Majors[1].Path, " -> "); p != "main -> A -> github.com/foo/bar/v2" {
+		t.Errorf("unexpected path %q", p)
+	}
+	if got[1].Module != "gopkg.in/yaml" || len(got[1].Majors) != 2 {
+		t.Errorf("unexpected second duplicate %+v", got[1])
+	}
+}
+
+func TestCheckRequirements(t *testing.T) {
+	mainMod := &goModule{Path: "example.com/main", Main: true}
+	g := &packageGraph{packages: map[string]*goPackage{}}
+	for _, p := range []*goPackage{
+		{ImportPath: "example.com/main/a", Module: mainMod, Imports: []string{"example.com/main/b", "example.com/lib/x", "fmt"}},
+		{ImportPath: "example.com/main/b", Module: mainMod},
+		{ImportPath: "example.com/main/b [example.com/main/b.test]", ForTest: "example.com/main/b", Module: mainMod, Imports: []string{"example.com/assert"}},
+		{ImportPath: "example.com/lib/x", Module: &goModule{Path: "example.com/lib"}, Imports: []string{"example.com/deep"}},
+		{ImportPath: "example.com/assert", Module: &goModule{Path: "example.com/assert"}},
+		{ImportPath: "example.com/deep", Module: &goModule{Path: "example.com/deep"}},
+		{ImportPath: "fmt"},
+	} {
+		g.packages[p.ImportPath] = p
+		g.order = append(g.order, p.ImportPath)
+	}
+	imports := directImports(g)
+	wantImports := map[string][]string{
+		"example.com/lib":    {"example.com/main/a"},
+		"example.com/assert": {"example.com/main/b"},
+	}
+	if !reflect.DeepEqual(imports, wantImports) {
+		t.Fatalf("directImports = %v, want %v", imports, wantImports)
+	}
+
+	promote, unused := checkRequirements([]goModRequirement{
+		{Path: "example.com/lib", Version: "v1.0.0"},
+		{Path: "example.com/assert", Version: "v1.1.0", Indirect: true},
+		{Path: "example.com/deep", Version: "v0.1.0", Indirect: true},
+		{Path: "example.com/stale", Version: "v0.2.0"},
+	}, imports)
+	wantPromote := []RequirementFinding{{Module: "example.com/assert", Version: "v1.1.0", ImportedBy: []string{"example.com/main/b"}}}
+	wantUnused := []RequirementFinding{{Module: "example.com/stale", Version: "v0.2.0"}}
+	if !reflect.DeepEqual(promote, wantPromote) {
+		t.Errorf("promote = %+v, want %+v", promote, wantPromote)
+	}
+	if !reflect.DeepEqual(unused, wantUnused) {
+		t.Errorf("unused = %+v, want %+v", unused, wantUnused)
+	}
+}
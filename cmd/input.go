@@ -0,0 +1,58 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// stdinInput is read by input files named "-"; tests replace it.
+var stdinInput io.Reader = os.Stdin
+
+// stdinFlag is the flag that consumed stdin and stdinData what it read, so
+// the flag can be read again, e.g. for both sets of a comparison.
+var stdinFlag string
+var stdinData []byte
+
+// readInputFile reads the file given to flag, such as --graph-file or
+// --policy, or stdin when name is "-". Stdin is refused when it is a
+// terminal, where reading would silently wait for typed input, and when
+// another flag already read it.
+func readInputFile(flag, name string) ([]byte, error) {
+	if name != "-" {
+		return os.ReadFile(name)
+	}
+	if stdinFlag == flag {
+		return stdinData, nil
+	}
+	if stdinFlag != "" {
+		return nil, fmt.Errorf("%s -: stdin was already read by %s", flag, stdinFlag)
+	}
+	if f, ok := stdinInput.(*os.File); ok {
+		if info, err := f.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+			return nil, fmt.Errorf("%s -: stdin is a terminal; pipe the input in", flag)
+		}
+	}
+	data, err := io.ReadAll(stdinInput)
+	if err != nil {
+		return nil, err
+	}
+	stdinFlag, stdinData = flag, data
+	return data, nil
+}
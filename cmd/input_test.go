@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadInputFile(t *testing.T) {
+	oldInput := stdinInput
+	defer func() {
+		stdinInput = oldInput
+		stdinFlag, stdinData = "", nil
+	}()
+	stdinInput = strings.NewReader("main A@v1.0.0\n")
+
+	path := filepath.Join(t.TempDir(), "graph.txt")
+	if err := os.WriteFile(path, []byte("from file\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := readInputFile("--graph-file", path); err != nil || string(data) != "from file\n" {
+		t.Fatalf("reading a file: %q, %v", data, err)
+	}
+	for i := 0; i < 2; i++ {
+		data, err := readInputFile("--graph-file", "-")
+		if err != nil || string(data) != "main A@v1.0.0\n" {
+			t.Fatalf("read %d of stdin: %q, %v", i+1, data, err)
+		}
+	}
+	if _, err := readInputFile("--policy", "-"); err == nil || !strings.Contains(err.Error(), "already read by --graph-file") {
+		t.Errorf("expected a second flag reading stdin to fail, got %v", err)
+	}
+}
+
+func TestRegoPolicyFileFromStdin(t *testing.T) {
+	oldInput := stdinInput
+	defer func() {
+		stdinInput = oldInput
+		stdinFlag, stdinData = "", nil
+	}()
+	stdinInput = strings.NewReader("package depstat\n")
+
+	if path, _, err := regoPolicyFile("policy.rego"); err != nil || path != "policy.rego" {
+		t.Fatalf("a file name should pass through, got %q, %v", path, err)
+	}
+	path, cleanup, err := regoPolicyFile("-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != "package depstat\n" {
+		t.Errorf("spooled policy = %q, %v", data, err)
+	}
+	if filepath.Ext(path) != ".rego" {
+		t.Errorf("opa needs a .rego file, got %s", path)
+	}
+	cleanup()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected %s to be removed, got %v", path, err)
+	}
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var k8sRelease string
+var k8sGoModFile string
+var k8sGoModURL = "https://raw.githubusercontent.com/kubernetes/kubernetes/{release}/go.mod"
+var k8sFailOnMismatch bool
+
+// K8sCompatResult compares the analyzed graph against the versions pinned by
+// a kubernetes/kubernetes release.
+type K8sCompatResult struct {
+	Release     string        `json:"release"`
+	MainModules []string      `json:"mainModules"`
+	Shared      int           `json:"sharedModules"`
+	Mismatches  []K8sMismatch `json:"mismatches"`
+}
+
+// K8sMismatch is a shared module selected at a different version than the
+// one pinned by Kubernetes.
+type K8sMismatch struct {
+	Module     string `json:"module"`
+	Version    string `json:"version"`
+	K8sVersion string `json:"k8sVersion"`
+	// Direction is "newer" or "older" than the Kubernetes pin.
+	Direction string   `json:"direction"`
+	Path      []string `json:"path"`
+}
+
+var k8sCompatCmd = &cobra.Command{
+	Use:   "k8s-compat",
+	Short: "Compare shared dependencies with the pins of a Kubernetes release",
+	Long: `Fetches the go.mod of a kubernetes/kubernetes release (--release v1.31.2)
+and reports every module in the dependency graph that Kubernetes also pins,
+but at a different version, with the path pulling it in.
+
+The k8s.io staging modules published from the release (k8s.io/api,
+k8s.io/client-go, ...) are expected at the matching v0 version, e.g. v0.31.2
+for v1.31.2. Use --go-mod-file to compare against a local copy of the file.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("k8s-compat does not take any arguments"))
+		}
+		if k8sRelease == "" {
+			return withExitCode(ExitUsage, fmt.Errorf("--release is required, e.g. --release v1.31.2"))
+		}
+		data, err := readK8sGoMod(k8sRelease)
+		if err != nil {
+			return err
+		}
+		pins, err := parseK8sPins(data, k8sRelease)
+		if err != nil {
+			return err
+		}
+		depGraph := getDepInfo(mainModules)
+		if len(depGraph.MainModules) == 0 {
+			return errNoMainModules
+		}
+		result := compareK8sPins(depGraph, pins, k8sRelease)
+		if jsonOutput {
+			if err := writeJSON(os.Stdout, result); err != nil {
+				return err
+			}
+		} else {
+			printK8sCompat(result)
+		}
+		if k8sFailOnMismatch && len(result.Mismatches) > 0 {
+			cmd.SilenceUsage = true
+			return withExitCode(ExitViolation, fmt.Errorf("%d modules differ from the Kubernetes %s pins", len(result.Mismatches), k8sRelease))
+		}
+		return nil
+	},
+}
+
+// readK8sGoMod returns the go.mod of the release, from --go-mod-file or
+// downloaded from GitHub.
+func readK8sGoMod(release string) ([]byte, error) {
+	if k8sGoModFile != "" {
+		return readInputFile("--go-mod-file", k8sGoModFile)
+	}
+	if err := requireNetwork("k8s-compat"); err != nil {
+		return nil, fmt.Errorf("%w; pass --go-mod-file", err)
+	}
+	url := strings.ReplaceAll(k8sGoModURL, "{release}", release)
+	client := &http.Client{Timeout: 30 * time.Second}
+	debugf("GET %s\n", url)
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("fetching Kubernetes go.mod: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: %s (is %q a kubernetes/kubernetes tag?)", url, resp.Status, release)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// parseK8sPins parses the Kubernetes go.mod with go mod edit -json and
+// returns the pinned version of every required module. Modules replaced by
+// a ./staging directory are pinned to the v0 version of the release.
+func parseK8sPins(data []byte, release string) (map[string]string, error) {
+	tmp, err := os.MkdirTemp("", "depstat-k8s-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	file := filepath.Join(tmp, "go.mod")
+	if err := os.WriteFile(file, data, 0o644); err != nil {
+		return nil, err
+	}
+	c := goCommand([]string{"mod", "edit", "-json", file})
+	out, err := c.Output()
+	if err != nil {
+		return nil, goCommandError(c, err)
+	}
+	var gomod goModFile
+	if err := json.Unmarshal(out, &gomod); err != nil {
+		return nil, fmt.Errorf("parsing Kubernetes go.mod: %w", err)
+	}
+
+	staging := make(map[string]bool)
+	for _, rep := range gomod.Replace {
+		if strings.HasPrefix(rep.New.Path, "./staging/") {
+			staging[rep.Old.Path] = true
+		}
+	}
+	stagingVersion := ""
+	if strings.HasPrefix(release, "v1.") {
+		stagingVersion = "v0." + strings.TrimPrefix(release, "v1.")
+	}
+	pins := make(map[string]string)
+	for _, r := range gomod.Require {
+		if staging[r.Path] {
+			if stagingVersion != "" {
+				pins[r.Path] = stagingVersion
+			}
+			continue
+		}
+		pins[r.Path] = r.Version
+	}
+	return pins, nil
+}
+
+func compareK8sPins(depGraph *DependencyOverview, pins map[string]string, release string) K8sCompatResult {
+	result := K8sCompatResult{Release: release, MainModules: depGraph.MainModules, Mismatches: []K8sMismatch{}}
+	for _, mod := range getAllDeps(depGraph.DirectDepList, depGraph.TransDepList) {
+		pin, ok := pins[mod]
+		if !ok || contains(depGraph.MainModules, mod) {
+			continue
+		}
+		result.Shared++
+		version := depGraph.Versions[mod]
+		if version == "" || version == pin {
+			continue
+		}
+		direction := "older"
+		if versionGreater(version, pin) {
+			direction = "newer"
+		}
+		result.Mismatches = append(result.Mismatches, K8sMismatch{
+			Module:     mod,
+			Version:    version,
+			K8sVersion: pin,
+			Direction:  direction,
+			Path:       shortestPath(depGraph.MainModules, mod, depGraph.Graph),
+		})
+	}
+	sort.Slice(result.Mismatches, func(i, j int) bool { return result.Mismatches[i].Module < result.Mismatches[j].Module })
+	return result
+}
+
+func printK8sCompat(r K8sCompatResult) {
+	fmt.Printf("Kubernetes %s pins %d of the modules in the graph; %d differ:\n", r.Release, r.Shared, len(r.Mismatches))
+	for _, m := range r.Mismatches {
+		fmt.Printf("  %s %s (%s than Kubernetes %s)\n", m.Module, m.Version, m.Direction, m.K8sVersion)
+		if len(m.Path) > 0 {
+			fmt.Printf("    path: %s\n", colorPath(m.Path, r.MainModules, m.Module))
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(k8sCompatCmd)
+	k8sCompatCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
+	k8sCompatCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Get the output in JSON format")
+	k8sCompatCmd.Flags().StringVar(&k8sRelease, "release", "", "kubernetes/kubernetes release tag to compare against, e.g. v1.31.2")
+	k8sCompatCmd.Flags().StringVar(&k8sGoModFile, "go-mod-file", "", "Read the Kubernetes go.mod from this file instead of downloading it; - reads stdin")
+	k8sCompatCmd.Flags().BoolVar(&k8sFailOnMismatch, "fail-on-mismatch", false, "Exit with code 3 when any shared module differs from the Kubernetes pin")
+	k8sCompatCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
+	k8sCompatCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Specify main modules")
+}
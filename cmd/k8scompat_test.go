@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testK8sGoMod = `module k8s.io/kubernetes
+
+go 1.22.0
+
+require (
+	github.com/spf13/cobra v1.8.1
+	k8s.io/api v0.0.0
+	k8s.io/klog/v2 v2.130.1
+)
+
+replace k8s.io/api => ./staging/src/k8s.io/api
+`
+
+func TestParseK8sPins(t *testing.T) {
+	pins, err := parseK8sPins([]byte(testK8sGoMod), "v1.31.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"github.com/spf13/cobra": "v1.8.1",
+		"k8s.io/api":             "v0.31.2",
+		"k8s.io/klog/v2":         "v2.130.1",
+	}
+	if !reflect.DeepEqual(pins, want) {
+		t.Errorf("got %v, want %v", pins, want)
+	}
+}
+
+func TestCompareK8sPins(t *testing.T) {
+	depGraph := &DependencyOverview{
+		MainModules:   []string{"m"},
+		Graph:         map[string][]string{"m": {"k8s.io/api", "other"}, "k8s.io/api": {"k8s.io/klog/v2"}},
+		DirectDepList: []string{"k8s.io/api", "other"},
+		TransDepList:  []string{"k8s.io/klog/v2"},
+		Versions:      map[string]string{"k8s.io/api": "v0.31.2", "other": "v1.0.0", "k8s.io/klog/v2": "v2.90.0"},
+	}
+	pins := map[string]string{"k8s.io/api": "v0.31.2", "k8s.io/klog/v2": "v2.130.1"}
+	got := compareK8sPins(depGraph, pins, "v1.31.2")
+	want := []K8sMismatch{{
+		Module:     "k8s.io/klog/v2",
+		Version:    "v2.90.0",
+		K8sVersion: "v2.130.1",
+		Direction:  "older",
+		Path:       []string{"m", "k8s.io/api", "k8s.io/klog/v2"},
+	}}
+	if got.Shared != 2 || !reflect.DeepEqual(got.Mismatches, want) {
+		t.Errorf("got %+v", got)
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LicenseChange is a dependency whose detected license differs between the
+// versions compared, which needs legal review even for a routine bump.
+type LicenseChange struct {
+	Module         string   `json:"module"`
+	Before         string   `json:"before"`
+	After          string   `json:"after"`
+	BeforeLicenses []string `json:"beforeLicenses"`
+	AfterLicenses  []string `json:"afterLicenses"`
+}
+
+// findLicenseChanges enriches both versions of every version change and
+// reports those whose licenses differ. Licenses come from the depsdev
+// source; without it nothing is reported.
+func findLicenseChanges(changes []VersionChange, sources []string) ([]LicenseChange, []string) {
+	if !contains(sources, "depsdev") {
+		warnf("license changes are only detected with --enrich depsdev\n")
+		return nil, nil
+	}
+	mods := make([]string, 0, len(changes))
+	beforeVersions := make(map[string]string, len(changes))
+	afterVersions := make(map[string]string, len(changes))
+	for _, c := range changes {
+		mods = append(mods, c.Path)
+		beforeVersions[c.Path] = c.Before
+		afterVersions[c.Path] = c.After
+	}
+	before, warnings := enrichModules(mods, beforeVersions, []string{"depsdev"})
+	after, afterWarnings := enrichModules(mods, afterVersions, []string{"depsdev"})
+	return compareLicenses(changes, before, after), append(warnings, afterWarnings...)
+}
+
+// compareLicenses returns the version changes whose license sets differ.
+// Modules without a detected license on either side are skipped, since an
+// unknown license is not a change.
+func compareLicenses(changes []VersionChange, before, after map[string]*ModuleEnrichment) []LicenseChange {
+	var result []LicenseChange
+	for _, c := range changes {
+		b, a := before[c.Path], after[c.Path]
+		if b == nil || a == nil || len(b.Licenses) == 0 || len(a.Licenses) == 0 {
+			continue
+		}
+		bl, al := sortedCopy(b.Licenses), sortedCopy(a.Licenses)
+		if strings.Join(bl, ",") == strings.Join(al, ",") {
+			continue
+		}
+		result = append(result, LicenseChange{Module: c.Path, Before: c.Before, After: c.After, BeforeLicenses: bl, AfterLicenses: al})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Module < result[j].Module })
+	return result
+}
+
+func printLicenseChanges(changes []LicenseChange) {
+	if len(changes) == 0 {
+		return
+	}
+	fmt.Printf("License Changes (%d):\n", len(changes))
+	for _, c := range changes {
+		fmt.Printf("  ! %-50s %s → %s: %s\n", c.Module, c.Before, c.After,
+			colorize(ansiYellow, strings.Join(c.BeforeLicenses, "|")+" → "+strings.Join(c.AfterLicenses, "|")))
+	}
+	fmt.Println()
+}
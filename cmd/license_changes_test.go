@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompareLicenses(t *testing.T) {
+	changes := []VersionChange{
+		{Path: "example.com/relicensed", Before: "v1.0.0", After: "v2.0.0"},
+		{Path: "example.com/same", Before: "v1.0.0", After: "v1.1.0"},
+		{Path: "example.com/unknown", Before: "v1.0.0", After: "v1.1.0"},
+	}
+	before := map[string]*ModuleEnrichment{
+		"example.com/relicensed": {Licenses: []string{"MIT"}},
+		"example.com/same":       {Licenses: []string{"MIT", "Apache-2.0"}},
+		"example.com/unknown":    {Licenses: []string{"MIT"}},
+	}
+	after := map[string]*ModuleEnrichment{
+		"example.com/relicensed": {Licenses: []string{"BUSL-1.1"}},
+		"example.com/same":       {Licenses: []string{"Apache-2.0", "MIT"}},
+		"example.com/unknown":    {},
+	}
+	want := []LicenseChange{{
+		Module:         "example.com/relicensed",
+		Before:         "v1.0.0",
+		After:          "v2.0.0",
+		BeforeLicenses: []string{"MIT"},
+		AfterLicenses:  []string{"BUSL-1.1"},
+	}}
+	if got := compareLicenses(changes, before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("compareLicenses() = %+v, want %+v", got, want)
+	}
+}
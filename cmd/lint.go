@@ -0,0 +1,280 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// lintChecks lists the checks run by lint, in output order.
+var lintChecks = []string{"duplicate-require", "missing-replace", "unused-exclude", "unselected-require", "indirect-marker"}
+
+var lintEnabled []string
+
+// LintFinding is a single go.mod hygiene problem found by lint.
+type LintFinding struct {
+	Check   string `json:"check"`
+	Module  string `json:"module"`
+	Version string `json:"version,omitempty"`
+	Message string `json:"message"`
+}
+
+// LintResult holds the findings of lint and the checks that could not run.
+type LintResult struct {
+	Findings []LintFinding `json:"findings"`
+	Skipped  []string      `json:"skipped,omitempty"`
+}
+
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check go.mod for requirements, replaces and excludes that do nothing",
+	Long: `Checks the go.mod in --dir and exits with code 3 when it finds:
+
+  duplicate-require   a module required more than once
+  missing-replace     a replace directive pointing at a local directory
+                      that does not exist or has no go.mod
+  unused-exclude      an exclude directive no module of the graph requests,
+                      found as by "depstat excludes" (reads the go.mod of
+                      every module version the graph was read from)
+  unselected-require  a requirement MVS never selects because another
+                      module requires a higher version
+  indirect-marker     an // indirect requirement imported directly by the
+                      main module's packages (loads the package graph)
+
+The first two only read go.mod; the others need "go mod graph" and are
+reported as skipped when the graph cannot be loaded, e.g. because of the
+problems found by the first two. Use --checks to run a subset.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("lint does not take any arguments"))
+		}
+		for _, c := range lintEnabled {
+			if !contains(lintChecks, c) {
+				return withExitCode(ExitUsage, fmt.Errorf("--checks must be a subset of: %s", strings.Join(lintChecks, ", ")))
+			}
+		}
+		gomod, err := readGoModFile()
+		if err != nil {
+			return err
+		}
+		result := runLint(gomod, lintEnabled)
+		if jsonOutput {
+			if err := writeJSON(os.Stdout, result); err != nil {
+				return err
+			}
+		} else {
+			printLintResult(result)
+		}
+		if len(result.Findings) > 0 {
+			cmd.SilenceUsage = true
+			return withExitCode(ExitViolation, fmt.Errorf("lint failed: %d finding(s)", len(result.Findings)))
+		}
+		return nil
+	},
+}
+
+// runLint runs the enabled checks against the parsed go.mod, loading the
+// module and package graphs only when a check needs them.
+func runLint(gomod *goModFile, enabled []string) LintResult {
+	result := LintResult{Findings: []LintFinding{}}
+	if contains(enabled, "duplicate-require") {
+		result.Findings = append(result.Findings, lintDuplicateRequires(gomod)...)
+	}
+	if contains(enabled, "missing-replace") {
+		result.Findings = append(result.Findings, lintMissingReplaces(gomod, filepath.Dir(goModPath()))...)
+	}
+	if contains(enabled, "unused-exclude") || contains(enabled, "unselected-require") {
+		c := goCommand([]string{"mod", "graph"})
+		out, err := c.Output()
+		if err != nil {
+			warnf("skipping graph checks: %v\n", goCommandError(c, err))
+			for _, check := range []string{"unused-exclude", "unselected-require"} {
+				if contains(enabled, check) {
+					result.Skipped = append(result.Skipped, check)
+				}
+			}
+		} else {
+			depGraph := generateGraph(string(out), []string{gomod.Module.Path})
+			depGraph.rawGraph = string(out)
+			if contains(enabled, "unused-exclude") {
+				unresolved, err := attachExcludedRequirements(&depGraph, gomod)
+				if err != nil {
+					warnf("skipping unused-exclude: %v\n", err)
+					result.Skipped = append(result.Skipped, "unused-exclude")
+				} else {
+					if len(unresolved) > 0 {
+						warnf("unused-exclude: could not read the go.mod of %d module version(s); excludes only they request are reported as unused\n", len(unresolved))
+					}
+					result.Findings = append(result.Findings, lintUnusedExcludes(gomod, &depGraph)...)
+				}
+			}
+			if contains(enabled, "unselected-require") {
+				result.Findings = append(result.Findings, lintUnselectedRequires(gomod, &depGraph)...)
+			}
+		}
+	}
+	if contains(enabled, "indirect-marker") {
+		promote, _, err := findRequirementIssues()
+		if err != nil {
+			warnf("skipping indirect-marker: %v\n", err)
+			result.Skipped = append(result.Skipped, "indirect-marker")
+		}
+		for _, f := range promote {
+			result.Findings = append(result.Findings, LintFinding{
+				Check:   "indirect-marker",
+				Module:  f.Module,
+				Version: f.Version,
+				Message: fmt.Sprintf("%s is marked // indirect but imported by %s", f.Module, strings.Join(f.ImportedBy, ", ")),
+			})
+		}
+	}
+	return result
+}
+
+func lintDuplicateRequires(gomod *goModFile) []LintFinding {
+	versions := make(map[string][]string)
+	var order []string
+	for _, r := range gomod.Require {
+		if versions[r.Path] == nil {
+			order = append(order, r.Path)
+		}
+		versions[r.Path] = append(versions[r.Path], r.Version)
+	}
+	var findings []LintFinding
+	for _, mod := range order {
+		if len(versions[mod]) < 2 {
+			continue
+		}
+		findings = append(findings, LintFinding{
+			Check:   "duplicate-require",
+			Module:  mod,
+			Message: fmt.Sprintf("%s is required %d times (%s)", mod, len(versions[mod]), strings.Join(versions[mod], ", ")),
+		})
+	}
+	return findings
+}
+
+// lintMissingReplaces reports local replacements whose directory, resolved
+// relative to modDir, does not contain a go.mod.
+func lintMissingReplaces(gomod *goModFile, modDir string) []LintFinding {
+	var findings []LintFinding
+	for _, rep := range gomod.Replace {
+		if rep.New.Version != "" {
+			continue // module replacement, resolved through the proxy
+		}
+		target := rep.New.Path
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(modDir, target)
+		}
+		problem := ""
+		if info, err := os.Stat(target); err != nil || !info.IsDir() {
+			problem = "does not exist"
+		} else if _, err := os.Stat(filepath.Join(target, "go.mod")); err != nil {
+			problem = "has no go.mod"
+		}
+		if problem == "" {
+			continue
+		}
+		findings = append(findings, LintFinding{
+			Check:   "missing-replace",
+			Module:  rep.Old.Path,
+			Version: rep.Old.Version,
+			Message: fmt.Sprintf("%s is replaced by %s, which %s", rep.Old.Path, rep.New.Path, problem),
+		})
+	}
+	return findings
+}
+
+// lintUnusedExcludes reports exclude directives no requirement of the graph
+// names, using the requirements attachExcludedRequirements recorded in
+// depGraph.
+func lintUnusedExcludes(gomod *goModFile, depGraph *DependencyOverview) []LintFinding {
+	var findings []LintFinding
+	for _, e := range findExcludeEffects(depGraph, gomod.Exclude).Excludes {
+		if e.Effective {
+			continue
+		}
+		message := fmt.Sprintf("exclude %s %s has no effect: %s", e.Module, e.Version, e.Reason)
+		findings = append(findings, LintFinding{Check: "unused-exclude", Module: e.Module, Version: e.Version, Message: message})
+	}
+	return findings
+}
+
+// lintUnselectedRequires reports go.mod requirements below the version MVS
+// selects, with the modules requiring the selected version.
+func lintUnselectedRequires(gomod *goModFile, depGraph *DependencyOverview) []LintFinding {
+	var findings []LintFinding
+	for _, r := range gomod.Require {
+		selected := mvsSelectedVersion(depGraph, r.Path)
+		if selected == "" || !versionGreater(selected, r.Version) {
+			continue
+		}
+		var by []string
+		for _, req := range depGraph.Requirements[r.Path] {
+			if req.Version == selected {
+				by = append(by, req.From)
+			}
+		}
+		sort.Strings(by)
+		message := fmt.Sprintf("%s %s is never selected: MVS selects %s", r.Path, r.Version, selected)
+		if len(by) > 0 {
+			message += " (required by " + strings.Join(uniqueStrings(by), ", ") + ")"
+		}
+		findings = append(findings, LintFinding{Check: "unselected-require", Module: r.Path, Version: r.Version, Message: message})
+	}
+	return findings
+}
+
+// mvsSelectedVersion returns the highest version of mod required anywhere
+// in the graph. Versions alone is not enough, since it prefers the version
+// the main module requires, which an untidy go.mod may leave too low.
+func mvsSelectedVersion(depGraph *DependencyOverview, mod string) string {
+	selected := depGraph.Versions[mod]
+	for _, req := range depGraph.Requirements[mod] {
+		if versionGreater(req.Version, selected) {
+			selected = req.Version
+		}
+	}
+	return selected
+}
+
+func printLintResult(result LintResult) {
+	for _, check := range result.Skipped {
+		fmt.Printf("Skipped %s: the module or package graph could not be loaded.\n", check)
+	}
+	if len(result.Findings) == 0 {
+		fmt.Println("No go.mod lint findings.")
+		return
+	}
+	fmt.Printf("LINT FINDINGS (%d):\n", len(result.Findings))
+	for _, f := range result.Findings {
+		fmt.Printf("  [%s] %s\n", f.Check, f.Message)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(lintCmd)
+	lintCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
+	lintCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Get the output in JSON format")
+	lintCmd.Flags().StringSliceVar(&lintEnabled, "checks", lintChecks, "Checks to run: "+strings.Join(lintChecks, ", "))
+}
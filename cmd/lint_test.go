@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLintGoMod(t *testing.T) {
+	modDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(modDir, "ok"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(modDir, "ok", "go.mod"), []byte("module example.com/ok\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(modDir, "nomod"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	gomod := &goModFile{
+		Module: goModVersion{Path: "main"},
+		Require: []goModRequirement{
+			{Path: "A", Version: "v1.0.0"},
+			{Path: "B", Version: "v1.0.0"},
+			{Path: "A", Version: "v1.1.0"},
+		},
+		Exclude: []goModVersion{
+			{Path: "B", Version: "v2.0.0"},
+			{Path: "Z", Version: "v1.0.0"},
+			{Path: "B", Version: "v1.1.0"},
+			{Path: "B", Version: "v1.0.5"},
+		},
+		Replace: []goModReplace{
+			{Old: goModVersion{Path: "A"}, New: goModVersion{Path: "./ok"}},
+			{Old: goModVersion{Path: "B"}, New: goModVersion{Path: "./nomod"}},
+			{Old: goModVersion{Path: "C"}, New: goModVersion{Path: "../missing"}},
+			{Old: goModVersion{Path: "D"}, New: goModVersion{Path: "example.com/fork", Version: "v1.0.0"}},
+		},
+	}
+	depGraph := generateGraph(`main A@v1.1.0
+main B@v1.0.0
+A@v1.1.0 B@v1.2.0`, []string{"main"})
+	// as attachExcludedRequirements records a request of A@v1.1.0's go.mod
+	depGraph.ExcludedRequirements = map[string][]Requirement{"B": {{From: "A@v1.1.0", Version: "v1.1.0"}}}
+
+	checks := func(findings []LintFinding) []string {
+		var out []string
+		for _, f := range findings {
+			out = append(out, f.Module+" "+f.Version)
+		}
+		return out
+	}
+	if got := checks(lintDuplicateRequires(gomod)); !reflect.DeepEqual(got, []string{"A "}) {
+		t.Errorf("duplicate requires = %v", got)
+	}
+	if got := checks(lintMissingReplaces(gomod, modDir)); !reflect.DeepEqual(got, []string{"B ", "C "}) {
+		t.Errorf("missing replaces = %v", got)
+	}
+	unused := lintUnusedExcludes(gomod, &depGraph)
+	if got := checks(unused); !reflect.DeepEqual(got, []string{"B v2.0.0", "Z v1.0.0", "B v1.0.5"}) {
+		t.Errorf("unused excludes = %v", got)
+	}
+	if want := "exclude B v1.0.5 has no effect: no module in the graph requires v1.0.5 (v1.2.0 is selected)"; unused[2].Message != want {
+		t.Errorf("message = %q, want %q", unused[2].Message, want)
+	}
+	unselected := lintUnselectedRequires(gomod, &depGraph)
+	if got := checks(unselected); !reflect.DeepEqual(got, []string{"A v1.0.0", "B v1.0.0"}) {
+		t.Errorf("unselected requires = %v", got)
+	}
+	if want := "B v1.0.0 is never selected: MVS selects v1.2.0 (required by A)"; unselected[1].Message != want {
+		t.Errorf("message = %q, want %q", unselected[1].Message, want)
+	}
+}
@@ -17,9 +17,11 @@ limitations under the License.
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
+	"os"
 	"sort"
+	"strconv"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 )
@@ -27,6 +29,7 @@ import (
 var listSplitTestOnly bool
 var listJSONOutput bool
 var listVerbose bool
+var listDepth bool
 
 // analyzeDepsCmd represents the analyzeDeps command
 var listCmd = &cobra.Command{
@@ -37,15 +40,80 @@ var listCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 
 		if len(args) != 0 {
-			return fmt.Errorf("list does not take any arguments")
+			return withExitCode(ExitUsage, fmt.Errorf("list does not take any arguments"))
+		}
+		if err := validateEnrichSources(enrichSources); err != nil {
+			return err
+		}
+		if err := validateToolsScope(toolsScope); err != nil {
+			return err
+		}
+		if updatesOnly && !checkUpdates {
+			return withExitCode(ExitUsage, fmt.Errorf("--updates-only requires --check-updates"))
+		}
+		if listDepth && (checkUpdates || len(enrichSources) > 0) {
+			return withExitCode(ExitUsage, fmt.Errorf("--depth cannot be combined with --check-updates or --enrich"))
 		}
 
 		depGraph := getDepInfo(mainModules)
 		if len(depGraph.MainModules) == 0 {
-			return fmt.Errorf("no main modules remain after exclusions; adjust --exclude-modules or --mainModules")
+			return errNoMainModules
 		}
 		allDeps := getAllDeps(depGraph.DirectDepList, depGraph.TransDepList)
 		sort.Strings(allDeps)
+		depths := dependencyDepths(depGraph, allDeps)
+
+		var updates map[string]ModuleUpdate
+		if checkUpdates {
+			var err error
+			updates, err = findModuleUpdates(depGraph)
+			if err != nil {
+				return err
+			}
+			if updatesOnly {
+				var outdated []string
+				for _, dep := range allDeps {
+					if _, ok := updates[dep]; ok {
+						outdated = append(outdated, dep)
+					}
+				}
+				allDeps = outdated
+			}
+		}
+
+		var enrichment map[string]*ModuleEnrichment
+		if len(enrichSources) > 0 {
+			var warnings []string
+			enrichment, warnings = enrichModules(allDeps, depGraph.Versions, enrichSources)
+			printEnrichWarnings(warnings)
+		}
+		printList := func(deps []string) {
+			if listDepth {
+				printDepsWithDepth(deps, depths)
+				return
+			}
+			if enrichment != nil {
+				printEnrichedDeps(deps, enrichment)
+				return
+			}
+			if updates != nil {
+				rows := make([]ModuleUpdate, 0, len(deps))
+				for _, dep := range deps {
+					u, ok := updates[dep]
+					if !ok {
+						u = ModuleUpdate{Module: dep, Version: depGraph.Versions[dep], Latest: "-", Kind: "-"}
+					}
+					rows = append(rows, u)
+				}
+				printModuleUpdates(rows)
+				return
+			}
+			if depGraph.Modules != nil {
+				printModuleInfo(deps, depGraph.Modules)
+				return
+			}
+			printDeps(deps)
+		}
 
 		if listSplitTestOnly {
 			testOnlySet, err := classifyTestDeps(allDeps)
@@ -63,64 +131,133 @@ var listCmd = &cobra.Command{
 			}
 			if listJSONOutput {
 				outputObj := struct {
-					All       []string `json:"allDependencies"`
-					NonTest   []string `json:"nonTestDependencies"`
-					TestOnly  []string `json:"testOnlyDependencies"`
-					MainMods  []string `json:"mainModules"`
-					Total     int      `json:"totalDependencies"`
-					NonTestN  int      `json:"nonTestCount"`
-					TestOnlyN int      `json:"testOnlyCount"`
+					All        []string                     `json:"allDependencies"`
+					NonTest    []string                     `json:"nonTestDependencies"`
+					TestOnly   []string                     `json:"testOnlyDependencies"`
+					MainMods   []string                     `json:"mainModules"`
+					Total      int                          `json:"totalDependencies"`
+					NonTestN   int                          `json:"nonTestCount"`
+					TestOnlyN  int                          `json:"testOnlyCount"`
+					Enrichment map[string]*ModuleEnrichment `json:"enrichment,omitempty"`
+					Modules    map[string]ModuleInfo        `json:"modules,omitempty"`
+					Updates    map[string]ModuleUpdate      `json:"updates,omitempty"`
+					Depths     map[string]int               `json:"depths"`
+					View       string                       `json:"view"`
+					Strategy   string                       `json:"mainStrategy"`
+					GoEnv      *GoEnvironment               `json:"goEnv,omitempty"`
+					Git        *GitMetadata                 `json:"git,omitempty"`
 				}{
-					All:       allDeps,
-					NonTest:   nonTest,
-					TestOnly:  testOnly,
-					MainMods:  depGraph.MainModules,
-					Total:     len(allDeps),
-					NonTestN:  len(nonTest),
-					TestOnlyN: len(testOnly),
+					All:        allDeps,
+					NonTest:    nonTest,
+					TestOnly:   testOnly,
+					MainMods:   depGraph.MainModules,
+					Total:      len(allDeps),
+					NonTestN:   len(nonTest),
+					TestOnlyN:  len(testOnly),
+					Enrichment: enrichment,
+					Modules:    depGraph.Modules,
+					Updates:    updates,
+					Depths:     depths,
+					View:       graphView(),
+					Strategy:   mainModuleStrategy(),
+					GoEnv:      goEnvironmentForOutput(),
+					Git:        gitMetadataForOutput(),
 				}
-				outputRaw, err := json.MarshalIndent(outputObj, "", "\t")
-				if err != nil {
+				if err := writeJSON(os.Stdout, outputObj); err != nil {
 					return err
 				}
-				fmt.Print(string(outputRaw))
 				return nil
 			}
 			fmt.Printf("Non-test dependencies (%d):\n", len(nonTest))
-			printDeps(nonTest)
+			printList(nonTest)
 			fmt.Printf("\nTest-only dependencies (%d):\n", len(testOnly))
-			printDeps(testOnly)
+			printList(testOnly)
 		} else {
 			if listJSONOutput {
 				outputObj := struct {
-					All      []string `json:"allDependencies"`
-					MainMods []string `json:"mainModules"`
-					Total    int      `json:"totalDependencies"`
+					All        []string                     `json:"allDependencies"`
+					MainMods   []string                     `json:"mainModules"`
+					Total      int                          `json:"totalDependencies"`
+					Enrichment map[string]*ModuleEnrichment `json:"enrichment,omitempty"`
+					Modules    map[string]ModuleInfo        `json:"modules,omitempty"`
+					Updates    map[string]ModuleUpdate      `json:"updates,omitempty"`
+					Depths     map[string]int               `json:"depths"`
+					View       string                       `json:"view"`
+					Strategy   string                       `json:"mainStrategy"`
+					GoEnv      *GoEnvironment               `json:"goEnv,omitempty"`
+					Git        *GitMetadata                 `json:"git,omitempty"`
 				}{
-					All:      allDeps,
-					MainMods: depGraph.MainModules,
-					Total:    len(allDeps),
+					All:        allDeps,
+					MainMods:   depGraph.MainModules,
+					Total:      len(allDeps),
+					Enrichment: enrichment,
+					Modules:    depGraph.Modules,
+					Updates:    updates,
+					Depths:     depths,
+					View:       graphView(),
+					Strategy:   mainModuleStrategy(),
+					GoEnv:      goEnvironmentForOutput(),
+					Git:        gitMetadataForOutput(),
 				}
-				outputRaw, err := json.MarshalIndent(outputObj, "", "\t")
-				if err != nil {
+				if err := writeJSON(os.Stdout, outputObj); err != nil {
 					return err
 				}
-				fmt.Print(string(outputRaw))
 				return nil
 			}
 			fmt.Println("List of all dependencies:")
-			printDeps(allDeps)
+			printList(allDeps)
 		}
 		return nil
 	},
 }
 
+// dependencyDepths returns the number of hops from the nearest main module
+// to each of deps, found by a single breadth-first search. Dependencies
+// unreachable after exclusions are left out.
+func dependencyDepths(depGraph *DependencyOverview, deps []string) map[string]int {
+	depthOf := shortestDepthByModule(depGraph.MainModules, depGraph.Graph)
+	depths := make(map[string]int, len(deps))
+	for _, dep := range deps {
+		if d, ok := depthOf[dep]; ok {
+			depths[dep] = d
+		}
+	}
+	return depths
+}
+
+// printDepsWithDepth is printDeps with a depth column; "-" marks a
+// dependency no main module reaches.
+func printDepsWithDepth(deps []string, depths map[string]int) {
+	fmt.Println()
+	sort.Strings(deps)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DEPTH\tMODULE")
+	for _, dep := range deps {
+		depth := "-"
+		if d, ok := depths[dep]; ok {
+			depth = strconv.Itoa(d)
+		}
+		fmt.Fprintf(w, "%s\t%s\n", depth, dep)
+	}
+	_ = w.Flush()
+	fmt.Println()
+}
+
 func init() {
 	rootCmd.AddCommand(listCmd)
 	listCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
 	listCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Specify main modules")
+	addToolsFlag(listCmd)
 	listCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
 	listCmd.Flags().BoolVarP(&listJSONOutput, "json", "j", false, "Get the output in JSON format")
 	listCmd.Flags().BoolVar(&listSplitTestOnly, "split-test-only", false, "Split list into test-only and non-test sections (uses go mod why -m)")
 	listCmd.Flags().BoolVarP(&listVerbose, "verbose", "v", false, "Include full dependency list alongside split output")
+	listCmd.Flags().BoolVar(&listDepth, "depth", false, "Show the shortest-path depth of every dependency from the nearest main module (always included in JSON output)")
+	listCmd.Flags().BoolVar(&checkUpdates, "check-updates", false, "Show available updates and their kind (uses go list -m -u)")
+	listCmd.Flags().BoolVar(&updatesOnly, "updates-only", false, "With --check-updates, only list dependencies that have an update")
+	listCmd.Flags().StringSliceVar(&enrichSources, "enrich", []string{}, "Attach external metadata to each dependency (supported: depsdev, github, osv, proxy)")
+	listCmd.Flags().StringVar(&enrichCacheDir, "enrich-cache-dir", "", "Directory for cached enrichment data. Defaults to the user cache directory.")
+	listCmd.Flags().IntVar(&enrichStaleDays, "stale-days", 365, "With --enrich github, flag dependencies whose upstream has no commits in this many days (0 disables)")
+	listCmd.Flags().IntVar(&enrichMaxVersionAgeDays, "version-age-days", 730, "With --enrich proxy, flag dependencies whose pinned version was released more than this many days ago (0 disables)")
+	listCmd.Flags().StringVar(&githubTokenPath, "github-token-path", "", "Path to a file containing the GitHub API token. If not set, uses GITHUB_TOKEN env var.")
 }
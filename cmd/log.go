@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// logLevel orders diagnostic messages by importance. Diagnostics always go
+// to stderr so that stdout only carries command output.
+type logLevel int
+
+const (
+	levelError logLevel = iota
+	levelWarn
+	levelInfo
+	levelDebug
+	levelTrace
+)
+
+var logLevelNames = []string{"error", "warn", "info", "debug", "trace"}
+
+var quiet bool
+var logLevelFlag string
+
+// verbosity counts -V flags: each raises the level by one above info, so -V
+// is debug and -VV is trace.
+var verbosity int
+
+var currentLogLevel = levelInfo
+var logOutput io.Writer = os.Stderr
+
+// setLogLevel applies --quiet, --log-level and -v: the more verbose of
+// --log-level and the -v count applies, and --quiet wins and keeps only
+// errors.
+func setLogLevel(name string, verbosity int, quiet bool) error {
+	level := -1
+	for i, n := range logLevelNames {
+		if n == name {
+			level = i
+		}
+	}
+	if level < 0 {
+		return withExitCode(ExitUsage, fmt.Errorf("--log-level must be one of: %s", strings.Join(logLevelNames, ", ")))
+	}
+	currentLogLevel = logLevel(level)
+	if v := levelInfo + logLevel(verbosity); v > currentLogLevel {
+		currentLogLevel = min(v, levelTrace)
+	}
+	if quiet {
+		currentLogLevel = levelError
+	}
+	return nil
+}
+
+func logf(level logLevel, format string, args ...interface{}) {
+	if level > currentLogLevel {
+		return
+	}
+	fmt.Fprintf(logOutput, format, args...)
+}
+
+// warnf reports a recoverable problem; the analysis continues.
+func warnf(format string, args ...interface{}) {
+	logf(levelWarn, "warning: "+format, args...)
+}
+
+// infof reports progress and decisions made on the user's behalf, such as
+// auto-detected main modules.
+func infof(format string, args ...interface{}) {
+	logf(levelInfo, format, args...)
+}
+
+// debugf reports details useful when diagnosing depstat itself.
+func debugf(format string, args ...interface{}) {
+	logf(levelDebug, "debug: "+format, args...)
+}
+
+// logCommand logs an external command about to run at trace level.
+func logCommand(c *exec.Cmd) {
+	where := c.Dir
+	if where == "" {
+		where = "."
+	}
+	logf(levelTrace, "trace: running %s (in %s)\n", strings.Join(c.Args, " "), where)
+}
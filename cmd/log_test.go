@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"testing"
+)
+
+func TestLogLevels(t *testing.T) {
+	var buf bytes.Buffer
+	logOutput = &buf
+	defer func() {
+		logOutput = os.Stderr
+		currentLogLevel = levelInfo
+	}()
+
+	if err := setLogLevel("verbose", 0, false); err == nil {
+		t.Error("expected error for unknown log level")
+	}
+	if err := setLogLevel("info", 0, false); err != nil {
+		t.Fatal(err)
+	}
+	infof("progress\n")
+	debugf("details\n")
+	if got := buf.String(); got != "progress\n" {
+		t.Errorf("info level output = %q", got)
+	}
+
+	buf.Reset()
+	if err := setLogLevel("debug", 0, true); err != nil {
+		t.Fatal(err)
+	}
+	warnf("careful\n")
+	infof("progress\n")
+	if buf.Len() != 0 {
+		t.Errorf("--quiet should suppress warnings and info, got %q", buf.String())
+	}
+
+	if err := setLogLevel("debug", 0, false); err != nil {
+		t.Fatal(err)
+	}
+	warnf("careful\n")
+	debugf("details\n")
+	if got := buf.String(); got != "warning: careful\ndebug: details\n" {
+		t.Errorf("debug level output = %q", got)
+	}
+
+	buf.Reset()
+	logCommand(exec.Command("go", "mod", "graph"))
+	if buf.Len() != 0 {
+		t.Errorf("debug level should not log commands, got %q", buf.String())
+	}
+}
+
+func TestVerbosityCount(t *testing.T) {
+	defer func() { currentLogLevel = levelInfo }()
+	tests := []struct {
+		name      string
+		verbosity int
+		quiet     bool
+		want      logLevel
+	}{
+		{"info", 1, false, levelDebug},
+		{"info", 2, false, levelTrace},
+		{"info", 5, false, levelTrace},
+		{"trace", 1, false, levelTrace},
+		{"warn", 1, false, levelDebug},
+		{"info", 2, true, levelError},
+	}
+	for _, tt := range tests {
+		if err := setLogLevel(tt.name, tt.verbosity, tt.quiet); err != nil {
+			t.Fatal(err)
+		}
+		if currentLogLevel != tt.want {
+			t.Errorf("setLogLevel(%q, %d, %v) = %s, want %s", tt.name, tt.verbosity, tt.quiet, logLevelNames[currentLogLevel], logLevelNames[tt.want])
+		}
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+)
+
+// mainStrategy selects how main modules are found, set by --main:
+//   - "auto": the modules of go.work, else every go.mod below --dir
+//     except tools modules, else the module "go list -m" reports.
+//   - "gomod": the module declared by go.mod in --dir, read directly.
+//   - "all-workspace": every module used by go.work, tools included.
+//   - "list": exactly the modules given to --mainModules.
+var mainStrategy string
+
+var mainStrategies = []string{"auto", "gomod", "all-workspace", "list"}
+
+// validateMainStrategy checks --main against the --mainModules given.
+func validateMainStrategy() error {
+	if !contains(mainStrategies, mainStrategy) {
+		return withExitCode(ExitUsage, fmt.Errorf("--main must be one of: auto, gomod, all-workspace, list"))
+	}
+	switch mainStrategy {
+	case "list":
+		if len(mainModules) == 0 {
+			return fmt.Errorf("--main=list needs the modules in --mainModules")
+		}
+	case "gomod", "all-workspace":
+		if len(mainModules) > 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("--main=%s cannot be combined with --mainModules", mainStrategy))
+		}
+	}
+	return nil
+}
+
+// mainModuleStrategy is the strategy recorded in JSON output: "list" when
+// --mainModules names the main modules, --main otherwise.
+func mainModuleStrategy() string {
+	if len(mainModules) > 0 {
+		return "list"
+	}
+	return mainStrategy
+}
+
+// mainModuleBaseDir is the directory main modules are detected in: --dir,
+// or the working directory.
+func mainModuleBaseDir() string {
+	if dir != "" {
+		return dir
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	return wd
+}
+
+// workspaceModules returns the modules used by the go.work in baseDir, or
+// by the file --gowork names; none with --gowork=off.
+func workspaceModules(baseDir string) ([]string, error) {
+	switch goWorkOverride {
+	case "off":
+		return nil, nil
+	case "":
+		return detectModulesFromGoWork(baseDir)
+	default:
+		return detectModulesFromGoWorkFile(goWorkOverride)
+	}
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestValidateMainStrategy(t *testing.T) {
+	defer func() { mainStrategy, mainModules = "auto", nil }()
+	tests := []struct {
+		strategy string
+		mains    []string
+		wantErr  bool
+	}{
+		{"auto", nil, false},
+		{"auto", []string{"example.com/a"}, false},
+		{"list", []string{"example.com/a"}, false},
+		{"list", nil, true},
+		{"gomod", []string{"example.com/a"}, true},
+		{"all-workspace", nil, false},
+		{"first", nil, true},
+	}
+	for _, tt := range tests {
+		mainStrategy, mainModules = tt.strategy, tt.mains
+		if err := validateMainStrategy(); (err != nil) != tt.wantErr {
+			t.Errorf("validateMainStrategy(%q, %v) error = %v, want error %v", tt.strategy, tt.mains, err, tt.wantErr)
+		}
+	}
+}
+
+func TestAutoDetectMainModulesStrategies(t *testing.T) {
+	root := t.TempDir()
+	for path, content := range map[string]string{
+		"go.mod":       "module example.com/root\n\ngo 1.22\n",
+		"a/go.mod":     "module example.com/a\n\ngo 1.22\n",
+		"tools/go.mod": "module example.com/root/tools\n\ngo 1.22\n",
+		"go.work":      "go 1.22\n\nuse (\n\t.\n\t./a\n\t./tools\n)\n",
+	} {
+		file := filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	oldDir, oldLog := dir, logOutput
+	defer func() { dir, logOutput, mainStrategy = oldDir, oldLog, "auto" }()
+	dir, logOutput = root, io.Discard
+
+	tests := []struct {
+		strategy string
+		want     []string
+	}{
+		{"auto", []string{"example.com/a", "example.com/root"}},
+		{"gomod", []string{"example.com/root"}},
+		{"all-workspace", []string{"example.com/a", "example.com/root", "example.com/root/tools"}},
+	}
+	for _, tt := range tests {
+		mainStrategy = tt.strategy
+		got, err := autoDetectMainModules()
+		if err != nil {
+			t.Fatalf("--main=%s: %v", tt.strategy, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("--main=%s detected %v, want %v", tt.strategy, got, tt.want)
+		}
+	}
+
+	mainStrategy = "all-workspace"
+	dir = filepath.Join(root, "a")
+	if _, err := autoDetectMainModules(); err == nil {
+		t.Error("--main=all-workspace without go.work: expected an error")
+	}
+}
@@ -0,0 +1,449 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime/debug"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// mcpProtocolVersion is the Model Context Protocol revision implemented.
+const mcpProtocolVersion = "2024-11-05"
+
+// mcpDefaultLimit caps the paths and modules a tool returns by default.
+const mcpDefaultLimit = 20
+
+// JSON-RPC error codes.
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+	rpcInternalError  = -32603
+)
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Serve graph queries to AI assistants over the Model Context Protocol",
+	Long: `Runs a Model Context Protocol server on stdin and stdout, so IDE assistants
+can answer dependency questions from the project's live dependency graph.
+Configure it as a stdio server running "depstat mcp --dir <module>".
+
+The tools are:
+
+  stats   dependency counts and maximum depth
+  list    direct and transitive dependencies with their versions
+  search  find modules by fuzzy or glob pattern, as depstat search
+  why     paths from the main modules to a module
+  impact  what depends on a module and what would go away with it
+
+The graph is loaded once and reloaded when go.mod, go.sum, go.work or
+go.work.sum change between tool calls; when reloading fails, for instance
+while go.mod is being edited, the tool call reports the error and the
+server keeps the previous graph. Diagnostics go to stderr.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("mcp does not take any arguments"))
+		}
+		server := &mcpServer{load: func() (*DependencyOverview, error) { return loadDepInfo(mainModules) }}
+		if err := server.reload(); err != nil {
+			return err
+		}
+		return server.serve(cmd.InOrStdin(), cmd.OutOrStdout())
+	},
+}
+
+// mcpServer answers MCP requests from a dependency graph.
+type mcpServer struct {
+	load     func() (*DependencyOverview, error)
+	depGraph *DependencyOverview
+	// fingerprint is the state of the module files the graph was loaded
+	// from; a different fingerprint triggers a reload.
+	fingerprint string
+}
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// mcpTool describes a tool in the tools/list response.
+type mcpTool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"inputSchema"`
+}
+
+// mcpToolResult is the result of tools/call: the tool output as JSON text.
+type mcpToolResult struct {
+	Content []mcpContent `json:"content"`
+	IsError bool         `json:"isError,omitempty"`
+}
+
+type mcpContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// mcpToolArgs holds the arguments of every tool; each tool reads its own.
+type mcpToolArgs struct {
+	Module string `json:"module"`
+	Query  string `json:"query"`
+	Limit  int    `json:"limit"`
+}
+
+// mcpSchema returns an object schema with the given properties, of which
+// required must be set.
+func mcpSchema(properties map[string]interface{}, required ...string) map[string]interface{} {
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+var mcpModuleProperty = map[string]interface{}{"type": "string", "description": "Module path, e.g. golang.org/x/net"}
+var mcpLimitProperty = map[string]interface{}{"type": "integer", "description": fmt.Sprintf("Maximum number of entries to return (default %d)", mcpDefaultLimit)}
+
+var mcpTools = []mcpTool{
+	{"stats", "Dependency counts (direct, transitive, total) and the maximum dependency depth of the project.", mcpSchema(map[string]interface{}{})},
+	{"list", "Direct and transitive dependencies of the project with the versions selected in the module graph.", mcpSchema(map[string]interface{}{})},
+	{"search", "Search the module paths in the dependency graph, best matches first, with the version, depth and direct/transitive flags of each match.", mcpSchema(map[string]interface{}{
+		"query": map[string]interface{}{"type": "string", "description": "Glob such as k8s.io/* when it contains *, ? or [; otherwise characters to look for in order, e.g. k8sapimach"},
+		"limit": mcpLimitProperty,
+	}, "query")},
+	{"why", "Explain why a module is in the dependency graph: the shortest paths from the main modules to it and the total number of paths.", mcpSchema(map[string]interface{}{
+		"module": mcpModuleProperty,
+		"limit":  mcpLimitProperty,
+	}, "module")},
+	{"impact", "What depends on a module (direct and transitive dependents, the direct dependencies it is reached through) and which modules would leave the graph if it were removed.", mcpSchema(map[string]interface{}{
+		"module": mcpModuleProperty,
+	}, "module")},
+}
+
+// MCPWhyResult is the output of the why tool.
+type MCPWhyResult struct {
+	Module    string     `json:"module"`
+	Version   string     `json:"version,omitempty"`
+	Depth     int        `json:"depth"`
+	PathCount string     `json:"pathCount"`
+	Paths     [][]string `json:"paths"`
+}
+
+// MCPImpactResult is the output of the impact tool.
+type MCPImpactResult struct {
+	Module  string `json:"module"`
+	Version string `json:"version,omitempty"`
+	Direct  bool   `json:"direct"`
+	// DirectDependents require the module; TransitiveDependents counts
+	// every module it is reachable from.
+	DirectDependents     []string `json:"directDependents"`
+	TransitiveDependents int      `json:"transitiveDependents"`
+	// Via lists the direct dependencies the module is reachable through,
+	// and Owner the one dominating it, if any.
+	Via   []string `json:"via"`
+	Owner string   `json:"owner,omitempty"`
+	// Dominated lists the modules only reachable through the module,
+	// which would leave the graph with it.
+	Dominated []string `json:"dominated"`
+}
+
+// MCPListResult is the output of the list tool.
+type MCPListResult struct {
+	MainModules []string          `json:"mainModules"`
+	Direct      []string          `json:"direct"`
+	Transitive  []string          `json:"transitive"`
+	Versions    map[string]string `json:"versions"`
+}
+
+// reload loads the graph when the module files changed since the last load.
+// When loading fails, the previous graph is kept and the next call retries.
+func (s *mcpServer) reload() error {
+	current := fingerprintFiles(watchedFiles())
+	if s.depGraph != nil && current == s.fingerprint {
+		return nil
+	}
+	if s.depGraph != nil {
+		if err := validateGoMod(); err != nil {
+			return err
+		}
+		infof("module files changed, reloading the dependency graph\n")
+	}
+	depGraph, err := s.load()
+	if err != nil {
+		return err
+	}
+	if len(depGraph.MainModules) == 0 {
+		return errNoMainModules
+	}
+	s.depGraph, s.fingerprint = depGraph, current
+	return nil
+}
+
+// serve answers newline-delimited JSON-RPC messages from in until it ends.
+func (s *mcpServer) serve(in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	enc := json.NewEncoder(out)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if resp := s.handle(line); resp != nil {
+			if err := enc.Encode(resp); err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// handle answers one message; notifications get no response.
+func (s *mcpServer) handle(line []byte) *rpcResponse {
+	var req rpcRequest
+	if err := json.Unmarshal(line, &req); err != nil {
+		return &rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{rpcParseError, err.Error()}}
+	}
+	if len(req.ID) == 0 {
+		return nil
+	}
+	resp := &rpcResponse{JSONRPC: "2.0", ID: req.ID}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		resp.Error = &rpcError{rpcInvalidRequest, "not a JSON-RPC 2.0 request"}
+		return resp
+	}
+	switch req.Method {
+	case "initialize":
+		resp.Result = map[string]interface{}{
+			"protocolVersion": mcpProtocolVersion,
+			"capabilities":    map[string]interface{}{"tools": map[string]interface{}{}},
+			"serverInfo":      map[string]string{"name": "depstat", "version": readBuildInfo(debug.ReadBuildInfo).Version},
+		}
+	case "ping":
+		resp.Result = map[string]interface{}{}
+	case "tools/list":
+		resp.Result = map[string]interface{}{"tools": mcpTools}
+	case "tools/call":
+		var params struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			resp.Error = &rpcError{rpcInvalidParams, err.Error()}
+			return resp
+		}
+		var args mcpToolArgs
+		if len(params.Arguments) > 0 {
+			if err := json.Unmarshal(params.Arguments, &args); err != nil {
+				resp.Error = &rpcError{rpcInvalidParams, err.Error()}
+				return resp
+			}
+		}
+		result, err := s.callTool(params.Name, args)
+		if err != nil {
+			resp.Result = mcpToolResult{Content: []mcpContent{{Type: "text", Text: err.Error()}}, IsError: true}
+			return resp
+		}
+		var text bytes.Buffer
+		if err := writeJSON(&text, result); err != nil {
+			resp.Error = &rpcError{rpcInternalError, err.Error()}
+			return resp
+		}
+		resp.Result = mcpToolResult{Content: []mcpContent{{Type: "text", Text: text.String()}}}
+	default:
+		resp.Error = &rpcError{rpcMethodNotFound, fmt.Sprintf("method %q not found", req.Method)}
+	}
+	return resp
+}
+
+// callTool runs a tool against the current graph.
+func (s *mcpServer) callTool(name string, args mcpToolArgs) (interface{}, error) {
+	known := false
+	for _, t := range mcpTools {
+		known = known || t.Name == name
+	}
+	if !known {
+		return nil, fmt.Errorf("unknown tool %q", name)
+	}
+	if err := s.reload(); err != nil {
+		return nil, fmt.Errorf("reloading the dependency graph: %w", err)
+	}
+	limit := args.Limit
+	if limit <= 0 {
+		limit = mcpDefaultLimit
+	}
+	depGraph := s.depGraph
+	switch name {
+	case "stats":
+		return snapshotFromGraph(depGraph), nil
+	case "list":
+		deps := getAllDeps(depGraph.DirectDepList, depGraph.TransDepList)
+		versions := make(map[string]string, len(deps))
+		for _, dep := range deps {
+			versions[dep] = depGraph.Versions[dep]
+		}
+		return MCPListResult{
+			MainModules: depGraph.MainModules,
+			Direct:      sortedCopy(depGraph.DirectDepList),
+			Transitive:  sortedCopy(depGraph.TransDepList),
+			Versions:    versions,
+		}, nil
+	case "search":
+		if args.Query == "" {
+			return nil, fmt.Errorf("search needs a query")
+		}
+		return searchModules(depGraph, args.Query, limit), nil
+	case "why":
+		if err := s.requireModule(args.Module); err != nil {
+			return nil, err
+		}
+		return mcpWhy(depGraph, args.Module, limit), nil
+	default: // impact
+		if err := s.requireModule(args.Module); err != nil {
+			return nil, err
+		}
+		return mcpImpact(depGraph, args.Module), nil
+	}
+}
+
+// requireModule fails unless mod is a module of the graph, suggesting
+// near matches as why does, or else fuzzy matches.
+func (s *mcpServer) requireModule(mod string) error {
+	if mod == "" {
+		return fmt.Errorf("a module argument is required")
+	}
+	nodes := graphNodes(s.depGraph.Graph)
+	if contains(nodes, mod) {
+		return nil
+	}
+	err := fmt.Sprintf("%s is not in the dependency graph", mod)
+	matches := suggestModules(mod, nodes, 3)
+	if len(matches) == 0 {
+		matches = fuzzySearch(mod, nodes)
+	}
+	if len(matches) > 0 {
+		if len(matches) > 3 {
+			matches = matches[:3]
+		}
+		err += "; similar modules: " + strings.Join(matches, ", ")
+	}
+	return fmt.Errorf("%s", err)
+}
+
+func mcpWhy(depGraph *DependencyOverview, mod string, limit int) MCPWhyResult {
+	result := MCPWhyResult{
+		Module:    mod,
+		Version:   depGraph.Versions[mod],
+		Depth:     shortestDepthByModule(depGraph.MainModules, depGraph.Graph)[mod],
+		PathCount: countPaths(depGraph.MainModules, mod, depGraph.Graph).String(),
+		Paths:     [][]string{},
+	}
+	var paths [][]string
+	for _, mainMod := range depGraph.MainModules {
+		findAllPaths(mainMod, mod, depGraph.Graph, []string{}, make(map[string]bool), &paths, whyDefaultMaxPaths)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if len(paths[i]) != len(paths[j]) {
+			return len(paths[i]) < len(paths[j])
+		}
+		return strings.Join(paths[i], " ") < strings.Join(paths[j], " ")
+	})
+	if len(paths) > limit {
+		paths = paths[:limit]
+	}
+	result.Paths = append(result.Paths, paths...)
+	return result
+}
+
+func mcpImpact(depGraph *DependencyOverview, mod string) MCPImpactResult {
+	result := MCPImpactResult{
+		Module:           mod,
+		Version:          depGraph.Versions[mod],
+		Direct:           contains(depGraph.DirectDepList, mod),
+		DirectDependents: []string{},
+		Via:              []string{},
+		Dominated:        []string{},
+	}
+	dependents := make(map[string]bool)
+	queue := []string{mod}
+	reverse := make(map[string][]string)
+	for from, tos := range depGraph.Graph {
+		for _, to := range tos {
+			reverse[to] = append(reverse[to], from)
+		}
+	}
+	result.DirectDependents = append(result.DirectDependents, sortedCopy(reverse[mod])...)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, from := range reverse[current] {
+			if !dependents[from] && from != mod {
+				dependents[from] = true
+				queue = append(queue, from)
+			}
+		}
+	}
+	result.TransitiveDependents = len(dependents)
+	for _, direct := range sortedCopy(uniqueStrings(depGraph.DirectDepList)) {
+		if direct == mod || dependents[direct] {
+			result.Via = append(result.Via, direct)
+		}
+	}
+
+	idom := computeDominators(depGraph.MainModules, depGraph.Graph)
+	result.Owner = lookupDominator(mod, idom, depGraph.DirectDepList).Owner
+	for node := range idom {
+		for d := idom[node]; d != ""; d = idom[d] {
+			if d == mod {
+				result.Dominated = append(result.Dominated, node)
+				break
+			}
+		}
+	}
+	sort.Strings(result.Dominated)
+	return result
+}
+
+func init() {
+	rootCmd.AddCommand(mcpCmd)
+	mcpCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
+	mcpCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
+	mcpCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Specify main modules")
+}
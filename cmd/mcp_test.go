@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newTestMCPServer(t *testing.T) *mcpServer {
+	t.Helper()
+	s := &mcpServer{load: func() (*DependencyOverview, error) {
+		g := generateGraph("main a@v1.0.0\nmain b@v1.0.0\na@v1.0.0 c@v1.0.0\nb@v1.0.0 c@v1.0.0\nc@v1.0.0 d@v1.0.0\n", []string{"main"})
+		return &g, nil
+	}}
+	if err := s.reload(); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// mcpCall runs a tools/call request and decodes the JSON text it returns.
+func mcpCall(t *testing.T, s *mcpServer, tool, args string, out interface{}) mcpToolResult {
+	t.Helper()
+	resp := s.handle([]byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"` + tool + `","arguments":` + args + `}}`))
+	if resp == nil || resp.Error != nil {
+		t.Fatalf("%s: unexpected response %+v", tool, resp)
+	}
+	result := resp.Result.(mcpToolResult)
+	if !result.IsError && out != nil {
+		if err := json.Unmarshal([]byte(result.Content[0].Text), out); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return result
+}
+
+func TestMCPProtocol(t *testing.T) {
+	s := newTestMCPServer(t)
+	resp := s.handle([]byte(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05"}}`))
+	if resp.Error != nil || resp.Result.(map[string]interface{})["protocolVersion"] != mcpProtocolVersion {
+		t.Errorf("initialize: %+v", resp)
+	}
+	if resp := s.handle([]byte(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)); resp != nil {
+		t.Errorf("notification answered: %+v", resp)
+	}
+	resp = s.handle([]byte(`{"jsonrpc":"2.0","id":"x","method":"tools/list"}`))
+	var names []string
+	for _, tool := range resp.Result.(map[string]interface{})["tools"].([]mcpTool) {
+		names = append(names, tool.Name)
+	}
+	if want := []string{"stats", "list", "search", "why", "impact"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("tools = %v, want %v", names, want)
+	}
+	if resp := s.handle([]byte(`{"jsonrpc":"2.0","id":2,"method":"resources/list"}`)); resp.Error == nil || resp.Error.Code != rpcMethodNotFound {
+		t.Errorf("unknown method: %+v", resp)
+	}
+	if resp := s.handle([]byte(`{not json`)); resp.Error == nil || resp.Error.Code != rpcParseError {
+		t.Errorf("invalid JSON: %+v", resp)
+	}
+}
+
+func TestMCPTools(t *testing.T) {
+	s := newTestMCPServer(t)
+
+	var why MCPWhyResult
+	mcpCall(t, s, "why", `{"module":"d","limit":1}`, &why)
+	if why.PathCount != "2" || why.Depth != 3 || !reflect.DeepEqual(why.Paths, [][]string{{"main", "a", "c", "d"}}) {
+		t.Errorf("why = %+v", why)
+	}
+
+	var impact MCPImpactResult
+	mcpCall(t, s, "impact", `{"module":"c"}`, &impact)
+	want := MCPImpactResult{
+		Module:               "c",
+		Version:              "v1.0.0",
+		DirectDependents:     []string{"a", "b"},
+		TransitiveDependents: 3,
+		Via:                  []string{"a", "b"},
+		Dominated:            []string{"d"},
+	}
+	if !reflect.DeepEqual(impact, want) {
+		t.Errorf("impact = %+v, want %+v", impact, want)
+	}
+
+	var search SearchResult
+	mcpCall(t, s, "search", `{"query":"c"}`, &search)
+	if len(search.Matches) != 1 || search.Matches[0].Module != "c" || search.Matches[0].Depth != 2 {
+		t.Errorf("search = %+v", search.Matches)
+	}
+
+	var list MCPListResult
+	mcpCall(t, s, "list", `{}`, &list)
+	if !reflect.DeepEqual(list.Direct, []string{"a", "b"}) || list.Versions["d"] != "v1.0.0" {
+		t.Errorf("list = %+v", list)
+	}
+
+	result := mcpCall(t, s, "why", `{"module":"e"}`, nil)
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "not in the dependency graph") {
+		t.Errorf("why of a missing module = %+v", result)
+	}
+}
+
+func TestMCPReloadFailure(t *testing.T) {
+	s := newTestMCPServer(t)
+	previous := s.depGraph
+	s.load = func() (*DependencyOverview, error) { return nil, errors.New("go.mod:3: unknown directive") }
+	s.fingerprint = "stale"
+
+	result := mcpCall(t, s, "stats", `{}`, nil)
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "unknown directive") {
+		t.Errorf("stats after a failed reload = %+v", result)
+	}
+	if s.depGraph != previous {
+		t.Error("failed reload replaced the graph")
+	}
+}
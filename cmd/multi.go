@@ -0,0 +1,231 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var multiManifest string
+
+// MultiResult is the fleet view over several repositories.
+type MultiResult struct {
+	Repos []MultiRepo `json:"repos"`
+	// Shared lists the dependencies used by more than one repository.
+	Shared []SharedDependency `json:"sharedDependencies"`
+	// VersionSkew lists shared dependencies selected at different versions
+	// by different repositories.
+	VersionSkew []MultiVersionSkew `json:"versionSkew"`
+}
+
+// MultiRepo is one analyzed repository and its own stats.
+type MultiRepo struct {
+	Dir   string         `json:"dir"`
+	Stats *StatsSnapshot `json:"stats"`
+}
+
+type SharedDependency struct {
+	Module string   `json:"module"`
+	Repos  []string `json:"repos"`
+}
+
+type MultiVersionSkew struct {
+	Module   string            `json:"module"`
+	Versions []MultiVersionUse `json:"versions"`
+}
+
+// MultiVersionUse is a version of a module and the repositories selecting it.
+type MultiVersionUse struct {
+	Version string   `json:"version"`
+	Repos   []string `json:"repos"`
+}
+
+var multiCmd = &cobra.Command{
+	Use:   "multi [dir...]",
+	Short: "Aggregate dependency analysis across several repositories",
+	Long: `Loads the dependency graph of every repository directory given as an
+argument or listed in --manifest (one directory per line, # starts a comment,
+relative paths are resolved against the manifest's directory) and reports
+per-repository stats, the dependencies shared between repositories, and the
+shared dependencies selected at different versions across the fleet.
+
+Main modules of each repository are detected as for the other commands,
+including --auto-main-modules.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dirs := append([]string{}, args...)
+		if multiManifest != "" {
+			listed, err := readMultiManifest(multiManifest)
+			if err != nil {
+				return err
+			}
+			dirs = append(dirs, listed...)
+		}
+		if len(dirs) == 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("multi needs repository directories as arguments or --manifest"))
+		}
+
+		sources := make([]graphSource, len(dirs))
+		for i, d := range dirs {
+			sources[i] = graphSource{Dir: d}
+		}
+		if err := prefetchGraphs(sources...); err != nil {
+			return err
+		}
+		var graphs []*DependencyOverview
+		for _, d := range dirs {
+			depGraph, err := graphSource{Dir: d}.load(nil)
+			if err != nil {
+				return fmt.Errorf("%s: %w", d, err)
+			}
+			if len(depGraph.MainModules) == 0 {
+				return fmt.Errorf("%s: no main modules remain after exclusions; adjust --exclude-modules", d)
+			}
+			graphs = append(graphs, depGraph)
+		}
+		result := computeMulti(dirs, graphs)
+		if jsonOutput {
+			return writeJSON(os.Stdout, result)
+		}
+		printMulti(result)
+		return nil
+	},
+}
+
+// readMultiManifest returns the directories listed in a manifest file.
+func readMultiManifest(path string) ([]string, error) {
+	data, err := readInputFile("--manifest", path)
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+	var dirs []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if !filepath.IsAbs(line) {
+			line = filepath.Join(filepath.Dir(path), line)
+		}
+		dirs = append(dirs, line)
+	}
+	return dirs, nil
+}
+
+// computeMulti combines the graphs of the repositories in dirs. Main modules
+// of any repository are not counted as dependencies of the others, nor are
+// the go and toolchain pseudo-modules.
+func computeMulti(dirs []string, graphs []*DependencyOverview) MultiResult {
+	isMain := make(map[string]bool)
+	for _, g := range graphs {
+		for _, m := range g.MainModules {
+			isMain[m] = true
+		}
+	}
+	users := make(map[string][]string)
+	versions := make(map[string]map[string][]string)
+	result := MultiResult{Repos: []MultiRepo{}, Shared: []SharedDependency{}, VersionSkew: []MultiVersionSkew{}}
+	for i, g := range graphs {
+		repo := dirs[i]
+		result.Repos = append(result.Repos, MultiRepo{Dir: repo, Stats: snapshotFromGraph(g)})
+		for _, dep := range getAllDeps(g.DirectDepList, g.TransDepList) {
+			if isMain[dep] || dep == "go" || dep == "toolchain" {
+				continue
+			}
+			users[dep] = append(users[dep], repo)
+			if v := g.Versions[dep]; v != "" {
+				if versions[dep] == nil {
+					versions[dep] = make(map[string][]string)
+				}
+				versions[dep][v] = append(versions[dep][v], repo)
+			}
+		}
+	}
+
+	for mod, repos := range users {
+		if len(repos) < 2 {
+			continue
+		}
+		result.Shared = append(result.Shared, SharedDependency{Module: mod, Repos: repos})
+		if len(versions[mod]) < 2 {
+			continue
+		}
+		skew := MultiVersionSkew{Module: mod}
+		for v, rs := range versions[mod] {
+			skew.Versions = append(skew.Versions, MultiVersionUse{Version: v, Repos: rs})
+		}
+		sort.Slice(skew.Versions, func(i, j int) bool {
+			return versionGreater(skew.Versions[j].Version, skew.Versions[i].Version)
+		})
+		result.VersionSkew = append(result.VersionSkew, skew)
+	}
+	sort.Slice(result.Shared, func(i, j int) bool {
+		if len(result.Shared[i].Repos) != len(result.Shared[j].Repos) {
+			return len(result.Shared[i].Repos) > len(result.Shared[j].Repos)
+		}
+		return result.Shared[i].Module < result.Shared[j].Module
+	})
+	sort.Slice(result.VersionSkew, func(i, j int) bool {
+		if len(result.VersionSkew[i].Versions) != len(result.VersionSkew[j].Versions) {
+			return len(result.VersionSkew[i].Versions) > len(result.VersionSkew[j].Versions)
+		}
+		return result.VersionSkew[i].Module < result.VersionSkew[j].Module
+	})
+	return result
+}
+
+func printMulti(result MultiResult) {
+	fmt.Printf("Repositories (%d):\n", len(result.Repos))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  Dir\tMain Modules\tDirect\tTransitive\tTotal\tMax Depth")
+	for _, r := range result.Repos {
+		s := r.Stats
+		fmt.Fprintf(w, "  %s\t%s\t%d\t%d\t%d\t%d\n", r.Dir, strings.Join(s.MainModules, ","), s.DirectDeps, s.TransDeps, s.TotalDeps, s.MaxDepth)
+	}
+	_ = w.Flush()
+
+	fmt.Printf("\nShared Dependencies (%d):\n", len(result.Shared))
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, s := range result.Shared {
+		fmt.Fprintf(w, "  %s\t%d repos\n", s.Module, len(s.Repos))
+	}
+	_ = w.Flush()
+
+	fmt.Printf("\nVersion Skew Across Repositories (%d):\n", len(result.VersionSkew))
+	for _, s := range result.VersionSkew {
+		fmt.Printf("  %s\n", s.Module)
+		for _, v := range s.Versions {
+			fmt.Printf("    %s: %s\n", v.Version, strings.Join(v.Repos, ", "))
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(multiCmd)
+	multiCmd.Flags().StringVar(&multiManifest, "manifest", "", "File listing repository directories, one per line; - reads stdin")
+	multiCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Get the output in JSON format")
+	multiCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
+}
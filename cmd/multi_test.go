@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestComputeMulti(t *testing.T) {
+	graphs := []*DependencyOverview{
+		{
+			MainModules:   []string{"example.com/a"},
+			DirectDepList: []string{"x", "example.com/b"},
+			TransDepList:  []string{"y"},
+			Versions:      map[string]string{"x": "v1.0.0", "y": "v0.1.0", "example.com/b": "v1.0.0"},
+		},
+		{
+			MainModules:   []string{"example.com/b"},
+			DirectDepList: []string{"x"},
+			TransDepList:  []string{"y"},
+			Versions:      map[string]string{"x": "v1.2.0", "y": "v0.1.0"},
+		},
+	}
+	got := computeMulti([]string{"a", "b"}, graphs)
+	wantShared := []SharedDependency{
+		{Module: "x", Repos: []string{"a", "b"}},
+		{Module: "y", Repos: []string{"a", "b"}},
+	}
+	if !reflect.DeepEqual(got.Shared, wantShared) {
+		t.Errorf("shared = %+v, want %+v", got.Shared, wantShared)
+	}
+	wantSkew := []MultiVersionSkew{{Module: "x", Versions: []MultiVersionUse{
+		{Version: "v1.0.0", Repos: []string{"a"}},
+		{Version: "v1.2.0", Repos: []string{"b"}},
+	}}}
+	if !reflect.DeepEqual(got.VersionSkew, wantSkew) {
+		t.Errorf("skew = %+v, want %+v", got.VersionSkew, wantSkew)
+	}
+}
+
+func TestReadMultiManifest(t *testing.T) {
+	base := t.TempDir()
+	manifest := filepath.Join(base, "repos.txt")
+	if err := os.WriteFile(manifest, []byte("# fleet\nrepo-a\n\n/abs/repo-b # pinned\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := readMultiManifest(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(base, "repo-a"), "/abs/repo-b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
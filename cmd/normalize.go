@@ -0,0 +1,72 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import "strings"
+
+// normalizePaths makes filters, grouping and dedup counts compare module
+// paths in their normalized form; output keeps the paths as required.
+var normalizePaths bool
+
+// caseInsensitiveHosts resolve repository paths regardless of letter case,
+// so github.com/Foo/bar and github.com/foo/bar name the same repository.
+var caseInsensitiveHosts = map[string]bool{
+	"github.com":    true,
+	"gitlab.com":    true,
+	"bitbucket.org": true,
+}
+
+// caseFoldedHost reports whether modPath is served by a case-insensitive
+// host.
+func caseFoldedHost(modPath string) bool {
+	host, _, _ := strings.Cut(modPath, "/")
+	return caseInsensitiveHosts[strings.ToLower(host)]
+}
+
+// normalizeModulePath lowercases modPath when its host is case-insensitive
+// and returns it unchanged otherwise.
+func normalizeModulePath(modPath string) string {
+	if caseFoldedHost(modPath) {
+		return strings.ToLower(modPath)
+	}
+	return modPath
+}
+
+// moduleKey is the path used to compare and group modules: modPath itself,
+// or its normalized form with --normalize-paths.
+func moduleKey(modPath string) string {
+	if normalizePaths {
+		return normalizeModulePath(modPath)
+	}
+	return modPath
+}
+
+// moduleProject is the project a module belongs to with --normalize-paths:
+// its normalized path without the major version suffix, so
+// github.com/Foo/bar and github.com/foo/bar/v2 count once.
+func moduleProject(modPath string) string {
+	return majorVersionBase(normalizeModulePath(modPath))
+}
+
+// countProjects returns the number of distinct projects among deps.
+func countProjects(deps []string) int {
+	projects := make(map[string]bool)
+	for _, dep := range deps {
+		projects[moduleProject(dep)] = true
+	}
+	return len(projects)
+}
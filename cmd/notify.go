@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// notifySummaryLimit caps the modules listed in a notification; chat
+// services reject or truncate very long messages.
+const notifySummaryLimit = 20
+
+var notifyURL string
+var notifyFormat string
+var notifyAlways bool
+
+func addNotifyFlags(c *cobra.Command) {
+	c.Flags().StringVar(&notifyURL, "notify", "", "Incoming webhook URL to post a summary to when verification fails")
+	c.Flags().StringVar(&notifyFormat, "notify-format", "slack", "Webhook payload format: slack or teams")
+	c.Flags().BoolVar(&notifyAlways, "notify-always", false, "Post the summary with --notify even when verification passes")
+}
+
+func validateNotifyFormat(format string) error {
+	if format != "slack" && format != "teams" {
+		return withExitCode(ExitUsage, fmt.Errorf("--notify-format must be one of: slack, teams"))
+	}
+	return nil
+}
+
+// notificationPayload builds the webhook body. Slack-format payloads are
+// also accepted by Mattermost and Rocket.Chat; teams uses a MessageCard.
+func notificationPayload(format, title, text string) interface{} {
+	if format == "teams" {
+		return map[string]string{
+			"@type":    "MessageCard",
+			"@context": "https://schema.org/extensions",
+			"summary":  title,
+			"title":    title,
+			"text":     strings.ReplaceAll(text, "\n", "\n\n"),
+		}
+	}
+	return map[string]string{"text": "*" + title + "*\n" + text}
+}
+
+// postNotification posts the summary to a webhook and fails on non-2xx
+// responses.
+func postNotification(url, format, title, text string) error {
+	body, err := json.Marshal(notificationPayload(format, title, text))
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: 15 * time.Second}
+	debugf("POST %s\n", url)
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+func verifyNotificationTitle(r VerifyResult) string {
+	status := "passed"
+	if len(r.Violations) > 0 {
+		status = fmt.Sprintf("failed with %d violation(s)", len(r.Violations))
+	}
+	return fmt.Sprintf("depstat verify %s for %s", status, strings.Join(r.MainModules, ", "))
+}
+
+// renderVerifySummary formats a verify result as short plain text for chat.
+func renderVerifySummary(r VerifyResult) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Compared against %s (merge base %s)\n", r.Base, shortCommit(r.MergeBase))
+	fmt.Fprintf(&b, "Total dependencies: %d -> %d (%+d), direct: %d -> %d (%+d), max depth: %d -> %d (%+d)\n",
+		r.Before.TotalDeps, r.After.TotalDeps, r.Delta.TotalDeps,
+		r.Before.DirectDeps, r.After.DirectDeps, r.Delta.DirectDeps,
+		r.Before.MaxDepth, r.After.MaxDepth, r.Delta.MaxDepth)
+	if len(r.Added) > 0 {
+		fmt.Fprintf(&b, "Added (%d): %s\n", len(r.Added), truncatedList(r.Added, notifySummaryLimit))
+	}
+	if len(r.Removed) > 0 {
+		fmt.Fprintf(&b, "Removed (%d): %s\n", len(r.Removed), truncatedList(r.Removed, notifySummaryLimit))
+	}
+	if len(r.Violations) > 0 {
+		b.WriteString("Violations:\n")
+		for _, v := range r.Violations {
+			fmt.Fprintf(&b, "- [%s] %s\n", v.Rule, v.Message)
+		}
+	}
+	return b.String()
+}
+
+func truncatedList(items []string, limit int) string {
+	if len(items) <= limit {
+		return strings.Join(items, ", ")
+	}
+	return fmt.Sprintf("%s, ... %d more", strings.Join(items[:limit], ", "), len(items)-limit)
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPostNotification(t *testing.T) {
+	var got map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer srv.Close()
+
+	if err := postNotification(srv.URL, "slack", "title", "body\n"); err != nil {
+		t.Fatal(err)
+	}
+	if got["text"] != "*title*\nbody\n" {
+		t.Errorf("slack payload = %v", got)
+	}
+	if err := postNotification(srv.URL, "teams", "title", "a\nb"); err != nil {
+		t.Fatal(err)
+	}
+	if got["@type"] != "MessageCard" || got["title"] != "title" || got["text"] != "a\n\nb" {
+		t.Errorf("teams payload = %v", got)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer failing.Close()
+	if err := postNotification(failing.URL, "slack", "t", "b"); err == nil || !strings.Contains(err.Error(), "invalid_token") {
+		t.Errorf("got %v, want webhook error", err)
+	}
+}
+
+func TestVerifyThresholdsAndSummary(t *testing.T) {
+	defer func() { verifyMaxAdded, verifyMaxTotalDelta, verifyMaxDepthDelta = -1, -1, -1 }()
+	verifyMaxAdded, verifyMaxTotalDelta, verifyMaxDepthDelta = 1, 5, -1
+	r := VerifyResult{
+		Base:      "origin/main",
+		MergeBase: "0123456789abcdef",
+		Added:     []string{"a", "b"},
+		Before:    StatsSnapshot{TotalDeps: 10, MaxDepth: 3},
+		After:     StatsSnapshot{TotalDeps: 12, MaxDepth: 9},
+		Delta:     StatsSnapshot{TotalDeps: 2, MaxDepth: 6},
+	}
+	r.Violations = checkVerifyThresholds(r)
+	if len(r.Violations) != 1 || r.Violations[0].Rule != "max-added" {
+		t.Fatalf("violations = %+v, want only max-added", r.Violations)
+	}
+	summary := renderVerifySummary(r)
+	for _, want := range []string{"merge base 0123456789ab", "10 -> 12 (+2)", "Added (2): a, b", "- [max-added] 2 modules added (limit 1)"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("summary missing %q:\n%s", want, summary)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var checkUpdates bool
+var updatesOnly bool
+
+// ModuleUpdate is a newer version available for a dependency.
+type ModuleUpdate struct {
+	Module  string `json:"module"`
+	Version string `json:"version"`
+	Latest  string `json:"latest"`
+	// Kind is major, minor, patch or other (for non-semver versions).
+	Kind string `json:"kind"`
+}
+
+// OutdatedResult lists dependencies with available updates.
+type OutdatedResult struct {
+	Updates     []ModuleUpdate `json:"updates"`
+	Counts      map[string]int `json:"counts"`
+	MainModules []string       `json:"mainModules"`
+}
+
+var outdatedCmd = &cobra.Command{
+	Use:   "outdated",
+	Short: "List dependencies with newer versions available",
+	Long: `Queries the module proxy via "go list -m -u -json all" and lists every
+dependency that has a newer version available, classified as a major, minor
+or patch update.
+
+Modules whose next major version lives at a different path (/v2, /v3, ...)
+are not reported as major updates by go list.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("outdated does not take any arguments"))
+		}
+		depGraph := getDepInfo(mainModules)
+		if len(depGraph.MainModules) == 0 {
+			return errNoMainModules
+		}
+		updates, err := findModuleUpdates(depGraph)
+		if err != nil {
+			return err
+		}
+		result := OutdatedResult{
+			Updates:     sortedModuleUpdates(updates),
+			Counts:      map[string]int{"major": 0, "minor": 0, "patch": 0, "other": 0},
+			MainModules: depGraph.MainModules,
+		}
+		for _, u := range result.Updates {
+			result.Counts[u.Kind]++
+		}
+
+		if jsonOutput {
+			out, err := json.MarshalIndent(result, "", "\t")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+			return nil
+		}
+		if len(result.Updates) == 0 {
+			fmt.Println("All dependencies are up to date.")
+			return nil
+		}
+		fmt.Printf("OUTDATED DEPENDENCIES (%d: %d major, %d minor, %d patch):\n", len(result.Updates), result.Counts["major"], result.Counts["minor"], result.Counts["patch"])
+		printModuleUpdates(result.Updates)
+		return nil
+	},
+}
+
+// findModuleUpdates runs "go list -m -u -json all" and returns the available
+// updates for modules in the graph, keyed by module path.
+func findModuleUpdates(depGraph *DependencyOverview) (map[string]ModuleUpdate, error) {
+	if err := requireNetwork("checking for updates"); err != nil {
+		return nil, err
+	}
+	modules, err := listAllModulesWithFlags(nil, "-u")
+	if err != nil {
+		return nil, fmt.Errorf("checking for updates: %w", err)
+	}
+	return moduleUpdatesFromList(modules, depGraph), nil
+}
+
+func moduleUpdatesFromList(modules []goModule, depGraph *DependencyOverview) map[string]ModuleUpdate {
+	inGraph := make(map[string]bool)
+	for _, dep := range getAllDeps(depGraph.DirectDepList, depGraph.TransDepList) {
+		inGraph[dep] = true
+	}
+	updates := make(map[string]ModuleUpdate)
+	for _, mod := range modules {
+		if mod.Main || mod.Update == nil || !inGraph[mod.Path] {
+			continue
+		}
+		updates[mod.Path] = ModuleUpdate{
+			Module:  mod.Path,
+			Version: mod.Version,
+			Latest:  mod.Update.Version,
+			Kind:    updateKind(mod.Version, mod.Update.Version),
+		}
+	}
+	return updates
+}
+
+// updateKind classifies the update from current to latest by the first
+// differing semver component.
+func updateKind(current, latest string) string {
+	a, okA := parseSemverLike(current)
+	b, okB := parseSemverLike(latest)
+	if !okA || !okB {
+		return "other"
+	}
+	switch {
+	case a[0] != b[0]:
+		return "major"
+	case a[1] != b[1]:
+		return "minor"
+	default:
+		return "patch"
+	}
+}
+
+func sortedModuleUpdates(updates map[string]ModuleUpdate) []ModuleUpdate {
+	sorted := make([]ModuleUpdate, 0, len(updates))
+	for _, u := range updates {
+		sorted = append(sorted, u)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Module < sorted[j].Module })
+	return sorted
+}
+
+func printModuleUpdates(updates []ModuleUpdate) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MODULE\tVERSION\tLATEST\tKIND\t")
+	for _, u := range updates {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t\n", u.Module, u.Version, u.Latest, u.Kind)
+	}
+	_ = w.Flush()
+}
+
+func init() {
+	rootCmd.AddCommand(outdatedCmd)
+	outdatedCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
+	outdatedCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Get the output in JSON format")
+	outdatedCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
+	outdatedCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Specify main modules")
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUpdateKind(t *testing.T) {
+	tests := []struct {
+		current, latest, want string
+	}{
+		{"v1.2.3", "v1.2.4", "patch"},
+		{"v1.2.3", "v1.3.0", "minor"},
+		{"v0.9.1", "v1.0.0", "major"},
+		{"v0.0.0-20230102150405-abcdef123456", "v0.0.0-20240102150405-abcdef123456", "patch"},
+		{"master", "v1.0.0", "other"},
+	}
+	for _, tt := range tests {
+		if got := updateKind(tt.current, tt.latest); got != tt.want {
+			t.Errorf("updateKind(%q, %q) = %q, want %q", tt.current, tt.latest, got, tt.want)
+		}
+	}
+}
+
+func TestModuleUpdatesFromList(t *testing.T) {
+	depGraph := generateGraph(`main A@v1.0.0
+A@v1.0.0 B@v1.2.0`, []string{"main"})
+	modules := []goModule{
+		{Path: "main", Main: true},
+		{Path: "A", Version: "v1.0.0", Update: &goModule{Path: "A", Version: "v1.1.0"}},
+		{Path: "B", Version: "v1.2.0"},
+		{Path: "C", Version: "v1.0.0", Update: &goModule{Path: "C", Version: "v1.0.1"}},
+	}
+	got := sortedModuleUpdates(moduleUpdatesFromList(modules, &depGraph))
+	want := []ModuleUpdate{{Module: "A", Version: "v1.0.0", Latest: "v1.1.0", Kind: "minor"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+var ndjsonOutput bool
+
+// writeBuffered runs write against a buffered writer on top of w and
+// flushes it. Large outputs such as the full DOT graph of a big module are
+// written piecewise instead of being assembled in memory first.
+func writeBuffered(w io.Writer, write func(io.Writer) error) error {
+	bw := bufio.NewWriterSize(w, 64*1024)
+	if err := write(bw); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// writeJSON encodes v as tab-indented JSON followed by a newline, without
+// holding a second copy of the document as a string.
+func writeJSON(w io.Writer, v interface{}) error {
+	err := writeBuffered(w, func(bw io.Writer) error {
+		enc := json.NewEncoder(bw)
+		enc.SetIndent("", "\t")
+		return enc.Encode(v)
+	})
+	if err == nil && w == io.Writer(os.Stdout) {
+		jsonResultWritten = true
+	}
+	return err
+}
+
+// ndjsonFlushInterval bounds how long an encoded line may sit in the
+// ndjsonWriter buffer, so consumers of a slow enumeration see results while
+// it runs instead of in 4KB bursts or at the end.
+var ndjsonFlushInterval = 100 * time.Millisecond
+
+// ndjsonWriter writes one compact JSON document per line. Buffered lines are
+// flushed by a timer at most ndjsonFlushInterval after they were written.
+type ndjsonWriter struct {
+	mu    sync.Mutex
+	bw    *bufio.Writer
+	enc   *json.Encoder
+	timer *time.Timer
+	err   error
+}
+
+func newNDJSONWriter(w io.Writer) *ndjsonWriter {
+	bw := bufio.NewWriter(w)
+	return &ndjsonWriter{bw: bw, enc: json.NewEncoder(bw)}
+}
+
+func (n *ndjsonWriter) Write(v interface{}) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.err != nil {
+		return n.err
+	}
+	if err := n.enc.Encode(v); err != nil {
+		return err
+	}
+	if n.timer == nil {
+		n.timer = time.AfterFunc(ndjsonFlushInterval, func() {
+			n.mu.Lock()
+			defer n.mu.Unlock()
+			n.flushLocked()
+		})
+	}
+	return nil
+}
+
+func (n *ndjsonWriter) Flush() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.flushLocked()
+	return n.err
+}
+
+func (n *ndjsonWriter) flushLocked() {
+	if n.timer != nil {
+		n.timer.Stop()
+		n.timer = nil
+	}
+	if err := n.bw.Flush(); err != nil && n.err == nil {
+		n.err = err
+	}
+}
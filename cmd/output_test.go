@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+)
+
+// lockedBuffer is a bytes.Buffer safe to read while the ndjsonWriter timer
+// writes to it.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestNDJSONWriterFlushInterval(t *testing.T) {
+	defer func(old time.Duration) { ndjsonFlushInterval = old }(ndjsonFlushInterval)
+	ndjsonFlushInterval = 10 * time.Millisecond
+
+	var buf lockedBuffer
+	nw := newNDJSONWriter(&buf)
+	if err := nw.Write(WhyPath{Path: []string{"a", "b"}}); err != nil {
+		t.Fatal(err)
+	}
+	want := "{\"path\":[\"a\",\"b\"],\"direct\":false}\n"
+	deadline := time.Now().Add(5 * time.Second)
+	for buf.String() != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("buffered line not flushed without a further write, got %q", buf.String())
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if err := nw.Flush(); err != nil {
+		t.Fatal(err)
+	}
+}
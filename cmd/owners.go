@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// unownedLabel groups dependencies no owners rule matches.
+const unownedLabel = "(unowned)"
+
+var ownersFile string
+
+// OwnerRule maps a module path pattern to the teams owning matching modules.
+type OwnerRule struct {
+	Pattern string
+	Owners  []string
+}
+
+// OwnerCount is the number of dependencies owned by one team.
+type OwnerCount struct {
+	Owner   string   `json:"owner"`
+	Count   int      `json:"count"`
+	Modules []string `json:"modules"`
+}
+
+// loadOwners reads a CODEOWNERS-style file: every line is a module path
+// pattern followed by one or more owners, # starts a comment, and the last
+// matching line wins. Patterns match like --allowed-hosts: "k8s.io" matches
+// k8s.io and everything below it, and * matches within a path element.
+func loadOwners(path string) ([]OwnerRule, error) {
+	data, err := readInputFile("--owners", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read owners file: %w", err)
+	}
+	var rules []OwnerRule
+	for i, line := range strings.Split(string(data), "\n") {
+		if j := strings.Index(line, "#"); j >= 0 {
+			line = line[:j]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("%s:%d: pattern %q has no owner", path, i+1, fields[0])
+		}
+		rules = append(rules, OwnerRule{Pattern: fields[0], Owners: fields[1:]})
+	}
+	return rules, nil
+}
+
+// matchOwner returns the owners of the last rule matching mod, or "".
+func matchOwner(mod string, rules []OwnerRule) string {
+	for i := len(rules) - 1; i >= 0; i-- {
+		if hostAllowed(mod, []string{rules[i].Pattern}) {
+			return strings.Join(rules[i].Owners, " ")
+		}
+	}
+	return ""
+}
+
+// assignOwners maps every dependency to its owners. A transitive dependency
+// no rule matches inherits the owners of the direct dependency dominating
+// it, since that team is the one that can drop it.
+func assignOwners(depGraph *DependencyOverview, rules []OwnerRule) map[string]string {
+	owners := make(map[string]string)
+	var idom map[string]string
+	for _, dep := range getAllDeps(depGraph.DirectDepList, depGraph.TransDepList) {
+		if contains(depGraph.MainModules, dep) {
+			continue
+		}
+		owner := matchOwner(dep, rules)
+		if owner == "" && !contains(depGraph.DirectDepList, dep) {
+			if idom == nil {
+				idom = computeDominators(depGraph.MainModules, depGraph.Graph)
+			}
+			if direct := lookupDominator(dep, idom, depGraph.DirectDepList).Owner; direct != "" {
+				owner = matchOwner(direct, rules)
+			}
+		}
+		if owner == "" {
+			owner = unownedLabel
+		}
+		owners[dep] = owner
+	}
+	return owners
+}
+
+// countByOwner groups dependencies by owner, largest groups first.
+func countByOwner(depGraph *DependencyOverview, rules []OwnerRule) []OwnerCount {
+	groups := map[string][]string{}
+	for dep, owner := range assignOwners(depGraph, rules) {
+		groups[owner] = append(groups[owner], dep)
+	}
+	counts := make([]OwnerCount, 0, len(groups))
+	for owner, mods := range groups {
+		sort.Strings(mods)
+		counts = append(counts, OwnerCount{Owner: owner, Count: len(mods), Modules: mods})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count == counts[j].Count {
+			return counts[i].Owner < counts[j].Owner
+		}
+		return counts[i].Count > counts[j].Count
+	})
+	return counts
+}
+
+// ownerCountsFromFile loads --owners and groups the graph by owner, or
+// returns nil when no owners file is set.
+func ownerCountsFromFile(depGraph *DependencyOverview) ([]OwnerCount, error) {
+	if ownersFile == "" {
+		return nil, nil
+	}
+	rules, err := loadOwners(ownersFile)
+	if err != nil {
+		return nil, err
+	}
+	return countByOwner(depGraph, rules), nil
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadOwnersAndCountByOwner(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "OWNERS")
+	content := `# module ownership
+k8s.io           @api-machinery
+k8s.io/klog*     @observability @sig-instrumentation
+github.com/a/*   @team-a
+`
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := loadOwners(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := matchOwner("k8s.io/klog/v2", rules); got != "@observability @sig-instrumentation" {
+		t.Errorf("last matching rule should win, got %q", got)
+	}
+
+	depGraph := &DependencyOverview{
+		MainModules: []string{"m"},
+		Graph: map[string][]string{
+			"m":              {"github.com/a/x", "k8s.io/api", "other"},
+			"github.com/a/x": {"dep-of-a"},
+			"other":          {"dep-of-other"},
+		},
+		DirectDepList: []string{"github.com/a/x", "k8s.io/api", "other"},
+		TransDepList:  []string{"dep-of-a", "dep-of-other"},
+	}
+	got := countByOwner(depGraph, rules)
+	want := []OwnerCount{
+		{Owner: unownedLabel, Count: 2, Modules: []string{"dep-of-other", "other"}},
+		{Owner: "@team-a", Count: 2, Modules: []string{"dep-of-a", "github.com/a/x"}},
+		{Owner: "@api-machinery", Count: 1, Modules: []string{"k8s.io/api"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadOwnersMissingOwner(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "OWNERS")
+	if err := os.WriteFile(file, []byte("k8s.io\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadOwners(file); err == nil {
+		t.Error("expected an error for a pattern without owners")
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var pathMaxPaths int
+
+// PathResult holds the paths found between two modules.
+type PathResult struct {
+	From       string     `json:"from"`
+	To         string     `json:"to"`
+	Found      bool       `json:"found"`
+	Paths      [][]string `json:"paths"`
+	Truncated  bool       `json:"truncated,omitempty"`
+	TotalPaths int        `json:"totalPaths"`
+	// PathCount is the exact number of paths, see WhyResult.PathCount.
+	PathCount *big.Int `json:"pathCount"`
+}
+
+var pathCmd = &cobra.Command{
+	Use:   "path <from> <to>",
+	Short: "Show dependency paths between two modules",
+	Long: `Show all dependency paths from one module to another. Unlike "why", the
+starting point can be any module in the graph, which helps explain how one
+third-party module drags in another.
+
+Examples:
+  depstat path github.com/spf13/cobra gopkg.in/yaml.v3
+  depstat path github.com/spf13/cobra gopkg.in/yaml.v3 --svg > path.svg`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, to := args[0], args[1]
+		outputs := 0
+		for _, set := range []bool{jsonOutput, ndjsonOutput, dotOutput, svgOutput} {
+			if set {
+				outputs++
+			}
+		}
+		if outputs > 1 {
+			return withExitCode(ExitUsage, fmt.Errorf("--dot, --svg, --json, and --ndjson are mutually exclusive"))
+		}
+		depGraph := getDepInfo(mainModules)
+		if len(depGraph.MainModules) == 0 {
+			return errNoMainModules
+		}
+		nodes := graphNodes(depGraph.Graph)
+		for _, m := range []string{from, to} {
+			if !contains(nodes, m) {
+				return withExitCode(ExitNotFound, fmt.Errorf("module %q not found in the dependency graph", m))
+			}
+		}
+
+		if ndjsonOutput {
+			return streamWhyPaths(os.Stdout, []string{from}, to, depGraph.Graph, pathMaxPaths, 0)
+		}
+		result := findPathsBetween(from, to, depGraph.Graph, pathMaxPaths)
+		if jsonOutput {
+			return writeJSON(os.Stdout, result)
+		}
+		if dotOutput || svgOutput {
+			// render through the why machinery with from as the root
+			whyResult := WhyResult{
+				Target:      to,
+				Found:       result.Found,
+				MainModules: []string{from},
+				TotalPaths:  result.TotalPaths,
+				PathCount:   result.PathCount,
+			}
+			for _, p := range result.Paths {
+				whyResult.Paths = append(whyResult.Paths, WhyPath{Path: p, Direct: len(p) == 2})
+			}
+			if dotOutput {
+				return outputWhyDOT(whyResult, depGraph)
+			}
+			return outputWhySVG(whyResult)
+		}
+		printPaths(result)
+		return nil
+	},
+}
+
+// findPathsBetween finds paths from one module to another, shortest first.
+func findPathsBetween(from, to string, graph map[string][]string, maxPaths int) PathResult {
+	var paths [][]string
+	findAllPaths(from, to, graph, []string{}, make(map[string]bool), &paths, maxPaths)
+	sort.Slice(paths, func(i, j int) bool {
+		if len(paths[i]) != len(paths[j]) {
+			return len(paths[i]) < len(paths[j])
+		}
+		return strings.Join(paths[i], " -> ") < strings.Join(paths[j], " -> ")
+	})
+	if paths == nil {
+		paths = [][]string{}
+	}
+	return PathResult{
+		From:       from,
+		To:         to,
+		Found:      len(paths) > 0,
+		Paths:      paths,
+		Truncated:  maxPaths > 0 && len(paths) >= maxPaths,
+		TotalPaths: len(paths),
+		PathCount:  countPaths([]string{from}, to, graph),
+	}
+}
+
+func printPaths(result PathResult) {
+	if !result.Found {
+		fmt.Printf("No dependency path from %s to %s.\n", result.From, result.To)
+		return
+	}
+	pathsToShow := result.Paths
+	if len(pathsToShow) > whyDefaultTextPaths {
+		pathsToShow = pathsToShow[:whyDefaultTextPaths]
+	}
+	fmt.Printf("Dependency paths from %s to %s (showing %d of %d):\n", result.From, result.To, len(pathsToShow), result.TotalPaths)
+	fmt.Println()
+	for i, p := range pathsToShow {
+		fmt.Printf("  %d. %s\n", i+1, colorPath(p, []string{result.From}, result.To))
+	}
+	if result.Truncated {
+		fmt.Println()
+		fmt.Printf("  (search truncated at --max-paths=%d; %s paths in total)\n", pathMaxPaths, result.PathCount)
+	} else if len(result.Paths) > len(pathsToShow) {
+		fmt.Println()
+		fmt.Printf("  (showing first %d in text output; use --json/--dot/--svg for full set)\n", whyDefaultTextPaths)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(pathCmd)
+	pathCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate")
+	pathCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Output in JSON format")
+	pathCmd.Flags().BoolVar(&ndjsonOutput, "ndjson", false, "Stream paths as newline-delimited JSON, one {\"path\", \"direct\"} object per line in search order")
+	pathCmd.Flags().BoolVar(&dotOutput, "dot", false, "Output in DOT format for Graphviz")
+	pathCmd.Flags().BoolVarP(&svgOutput, "svg", "s", false, "Output as self-contained SVG diagram")
+	pathCmd.Flags().StringVar(&svgThemeFlag, "svg-theme", "light", "SVG color theme: light, dark, or a JSON file overriding light theme colors")
+	pathCmd.Flags().StringVar(&svgTitle, "svg-title", "", "Title of the SVG diagram")
+	pathCmd.Flags().BoolVar(&svgCommandFooter, "svg-command-footer", false, "Show the depstat command line in the SVG footer")
+	pathCmd.Flags().IntVar(&pathMaxPaths, "max-paths", whyDefaultMaxPaths, "Maximum dependency paths to search. Set 0 for no limit")
+	pathCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
+	pathCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Specify main modules")
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindPathsBetween(t *testing.T) {
+	graph := map[string][]string{
+		"main": {"A"},
+		"A":    {"B", "C"},
+		"B":    {"D"},
+		"C":    {"B"},
+	}
+	result := findPathsBetween("A", "D", graph, 0)
+	want := [][]string{{"A", "B", "D"}, {"A", "C", "B", "D"}}
+	if !result.Found || !reflect.DeepEqual(result.Paths, want) {
+		t.Fatalf("unexpected paths %v", result.Paths)
+	}
+
+	result = findPathsBetween("D", "A", graph, 0)
+	if result.Found || len(result.Paths) != 0 {
+		t.Fatalf("expected no path from D to A, got %v", result.Paths)
+	}
+
+	result = findPathsBetween("A", "D", graph, 1)
+	if !result.Truncated || result.TotalPaths != 1 {
+		t.Fatalf("expected truncation at one path, got %+v", result)
+	}
+}
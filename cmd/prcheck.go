@@ -0,0 +1,206 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var prCheckBase string
+var prCheckMarkdownFile string
+var prCheckJSONFile string
+
+// NewModule is a module introduced by a pull request.
+type NewModule struct {
+	Module   string   `json:"module"`
+	Version  string   `json:"version"`
+	Path     []string `json:"path"`
+	TestOnly bool     `json:"testOnly"`
+	Licenses []string `json:"licenses,omitempty"`
+}
+
+// PRCheckResult is the payload produced by pr-check.
+type PRCheckResult struct {
+	Base           string          `json:"base"`
+	MergeBase      string          `json:"mergeBase"`
+	Added          []NewModule     `json:"added"`
+	Removed        []string        `json:"removed"`
+	VersionChanges []VersionChange `json:"versionChanges"`
+	MainModules    []string        `json:"mainModules"`
+	Warnings       []string        `json:"warnings,omitempty"`
+}
+
+var prCheckCmd = &cobra.Command{
+	Use:   "pr-check",
+	Short: "Report dependencies introduced by the current branch for pull-request CI",
+	Long: `Compares the dependency graph of the current checkout against the merge
+base with --base, and lists every newly introduced module together with its
+shortest path from a main module and whether it is only needed by tests.
+With --enrich depsdev, licenses are included as well.
+
+The markdown output is meant to be posted as a pull-request comment; the JSON
+output is meant for dependency review bots. Use --markdown-file and
+--json-file to write both in one run.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("pr-check does not take any arguments"))
+		}
+		if err := validateEnrichSources(enrichSources); err != nil {
+			return err
+		}
+		mergeBase, err := gitMergeBase(prCheckBase, "HEAD")
+		if err != nil {
+			return err
+		}
+		worktreeDir, cleanup, err := gitTempWorktree(mergeBase)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+
+		if err := prefetchGraphs(graphSource{Dir: worktreeDir}, graphSource{}); err != nil {
+			return err
+		}
+		baseGraph, err := graphSource{Dir: worktreeDir}.load(mainModules)
+		if err != nil {
+			return err
+		}
+		headGraph := getDepInfo(mainModules)
+		if len(headGraph.MainModules) == 0 {
+			return errNoMainModules
+		}
+
+		result := PRCheckResult{Base: prCheckBase, MergeBase: mergeBase, MainModules: headGraph.MainModules}
+		var added []string
+		result.Removed, added, result.VersionChanges = compareDependencySets(baseGraph, headGraph)
+
+		testOnlySet, err := classifyTestDeps(added)
+		if err != nil {
+			return fmt.Errorf("failed to classify dependencies: %w", err)
+		}
+		var enrichment map[string]*ModuleEnrichment
+		if len(enrichSources) > 0 {
+			enrichment, result.Warnings = enrichModules(added, headGraph.Versions, enrichSources)
+		}
+		result.Added = []NewModule{}
+		for _, mod := range added {
+			nm := NewModule{
+				Module:   mod,
+				Version:  headGraph.Versions[mod],
+				Path:     shortestPath(headGraph.MainModules, mod, headGraph.Graph),
+				TestOnly: testOnlySet[mod],
+			}
+			if e := enrichment[mod]; e != nil {
+				nm.Licenses = e.Licenses
+			}
+			result.Added = append(result.Added, nm)
+		}
+
+		raw, err := json.MarshalIndent(result, "", "\t")
+		if err != nil {
+			return err
+		}
+		markdown := renderPRCheckMarkdown(result)
+		if prCheckJSONFile != "" {
+			if err := os.WriteFile(prCheckJSONFile, append(raw, '\n'), 0644); err != nil {
+				return err
+			}
+		}
+		if prCheckMarkdownFile != "" {
+			if err := os.WriteFile(prCheckMarkdownFile, []byte(markdown), 0644); err != nil {
+				return err
+			}
+		}
+		if jsonOutput {
+			fmt.Println(string(raw))
+		} else if prCheckMarkdownFile == "" && prCheckJSONFile == "" {
+			fmt.Print(markdown)
+		}
+		return nil
+	},
+}
+
+func gitMergeBase(a, b string) (string, error) {
+	cmd := exec.Command("git", "merge-base", a, b)
+	if dir != "" {
+		cmd.Dir = dir
+	}
+	logCommand(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git merge-base %s %s: %w", a, b, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func renderPRCheckMarkdown(result PRCheckResult) string {
+	var b strings.Builder
+	b.WriteString("### Dependency review\n\n")
+	if len(result.Added) == 0 {
+		b.WriteString("No new dependencies introduced.\n")
+	} else {
+		testOnly := 0
+		for _, m := range result.Added {
+			if m.TestOnly {
+				testOnly++
+			}
+		}
+		fmt.Fprintf(&b, "This change introduces **%d** new module(s), %d of them test-only.\n\n", len(result.Added), testOnly)
+		b.WriteString("| Module | Version | Test-only | License | Introduced via |\n|---|---|---|---|---|\n")
+		for _, m := range result.Added {
+			license := strings.Join(m.Licenses, ", ")
+			if license == "" {
+				license = "-"
+			}
+			testOnly := "no"
+			if m.TestOnly {
+				testOnly = "yes"
+			}
+			var via []string
+			for _, p := range m.Path {
+				via = append(via, "`"+p+"`")
+			}
+			fmt.Fprintf(&b, "| `%s` | %s | %s | %s | %s |\n", m.Module, m.Version, testOnly, license, strings.Join(via, " → "))
+		}
+	}
+	if len(result.Removed) > 0 || len(result.VersionChanges) > 0 {
+		fmt.Fprintf(&b, "\n%d module(s) removed, %d version change(s).\n", len(result.Removed), len(result.VersionChanges))
+	}
+	if result.MergeBase != "" {
+		fmt.Fprintf(&b, "\n<sub>Compared against merge base %.12s with %s.</sub>\n", result.MergeBase, result.Base)
+	}
+	return b.String()
+}
+
+func init() {
+	rootCmd.AddCommand(prCheckCmd)
+	prCheckCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
+	prCheckCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Print the JSON payload instead of markdown")
+	prCheckCmd.Flags().StringVar(&prCheckBase, "base", "origin/main", "Base branch to compute the merge base against")
+	prCheckCmd.Flags().StringVar(&prCheckMarkdownFile, "markdown-file", "", "Write the markdown comment body to this file")
+	prCheckCmd.Flags().StringVar(&prCheckJSONFile, "json-file", "", "Write the JSON payload to this file")
+	prCheckCmd.Flags().StringSliceVar(&enrichSources, "enrich", []string{}, "Attach external metadata such as licenses to new modules (supported: depsdev, github, osv, proxy)")
+	prCheckCmd.Flags().StringVar(&enrichCacheDir, "enrich-cache-dir", "", "Directory for cached enrichment data. Defaults to the user cache directory.")
+	prCheckCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
+	prCheckCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Specify main modules")
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderPRCheckMarkdown(t *testing.T) {
+	md := renderPRCheckMarkdown(PRCheckResult{
+		Base:      "origin/main",
+		MergeBase: "0123456789abcdef0123",
+		Added: []NewModule{
+			{Module: "example.com/a", Version: "v1.0.0", Path: []string{"main", "example.com/a"}, Licenses: []string{"MIT"}},
+			{Module: "example.com/mock", Version: "v0.1.0", Path: []string{"main", "example.com/a", "example.com/mock"}, TestOnly: true},
+		},
+		Removed: []string{"example.com/old"},
+	})
+	for _, want := range []string{
+		"**2** new module(s), 1 of them test-only",
+		"| `example.com/a` | v1.0.0 | no | MIT | `main` → `example.com/a` |",
+		"| `example.com/mock` | v0.1.0 | yes | - |",
+		"1 module(s) removed, 0 version change(s).",
+		"merge base 0123456789ab with origin/main",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+
+	if md := renderPRCheckMarkdown(PRCheckResult{}); !strings.Contains(md, "No new dependencies introduced.") {
+		t.Errorf("unexpected empty markdown:\n%s", md)
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sync"
+)
+
+// graphJobs limits how many "go mod graph" commands run at once when a
+// command loads several graphs, such as stats --compare or multi.
+var graphJobs int
+
+// prefetchedGraphs holds "go mod graph" outputs by absolute module
+// directory until getDepInfo takes them.
+var prefetchedGraphs = map[string]string{}
+var prefetchedGraphsMu sync.Mutex
+
+// prefetchGraphs runs "go mod graph" for the module directories of sources
+// concurrently, at most --jobs at a time, so the sequential loads that
+// follow find their output ready. Sources read from a graph file are
+// skipped. The failures of all directories are returned together.
+func prefetchGraphs(sources ...graphSource) error {
+	var dirs []string
+	seen := make(map[string]bool)
+	for _, s := range sources {
+		if s.GraphFile != "" {
+			continue
+		}
+		d := s.Dir
+		if d == "" {
+			d = dir
+		}
+		abs, err := filepath.Abs(d)
+		if err != nil {
+			return err
+		}
+		if !seen[abs] {
+			seen[abs] = true
+			dirs = append(dirs, abs)
+		}
+	}
+	if len(dirs) < 2 {
+		return nil
+	}
+
+	// build and log the commands up front, as goCommand reads --dir
+	cmds := make([]*exec.Cmd, len(dirs))
+	for i, d := range dirs {
+		cmds[i] = newGoCommand([]string{"mod", "graph"})
+		cmds[i].Dir = d
+		logCommand(cmds[i])
+	}
+	errs := make([]error, len(dirs))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, max(graphJobs, 1))
+	for i := range cmds {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			out, err := cmds[i].Output()
+			if err != nil {
+				errs[i] = fmt.Errorf("%s: %w", dirs[i], goCommandError(cmds[i], err))
+				return
+			}
+			prefetchedGraphsMu.Lock()
+			defer prefetchedGraphsMu.Unlock()
+			prefetchedGraphs[dirs[i]] = string(out)
+		}(i)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// takePrefetchedGraph returns and forgets the prefetched "go mod graph"
+// output for --dir, so later loads see changes made since.
+func takePrefetchedGraph() (string, bool) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+	prefetchedGraphsMu.Lock()
+	defer prefetchedGraphsMu.Unlock()
+	out, ok := prefetchedGraphs[abs]
+	delete(prefetchedGraphs, abs)
+	return out, ok
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPrefetchGraphs(t *testing.T) {
+	for k, v := range map[string]string{"GOFLAGS": "-mod=mod", "GOWORK": "off", "GOPROXY": "off", "GOTOOLCHAIN": "local"} {
+		t.Setenv(k, v)
+	}
+	appDir, err := filepath.Abs(filepath.Join("testdata", "golden", "app"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	missingA := filepath.Join(t.TempDir(), "missing-a")
+	missingB := filepath.Join(t.TempDir(), "missing-b")
+	oldDir := dir
+	defer func() { dir = oldDir }()
+	defer func() { prefetchedGraphs = map[string]string{} }()
+
+	err = prefetchGraphs(graphSource{Dir: appDir}, graphSource{Dir: missingA}, graphSource{Dir: missingB}, graphSource{GraphFile: "graph.txt"})
+	if err == nil {
+		t.Fatal("expected an error for the missing directories")
+	}
+	for _, d := range []string{missingA, missingB} {
+		if !strings.Contains(err.Error(), d) {
+			t.Errorf("error does not mention %s:\n%v", d, err)
+		}
+	}
+
+	dir = appDir
+	out, ok := takePrefetchedGraph()
+	if !ok || !strings.Contains(out, "example.com/app example.com/a@v0.0.0") {
+		t.Fatalf("prefetched graph for %s = %q, %v", appDir, out, ok)
+	}
+	if _, ok := takePrefetchedGraph(); ok {
+		t.Error("prefetched graph was not forgotten after it was taken")
+	}
+}
+
+func TestPrefetchGraphsSingleDir(t *testing.T) {
+	defer func() { prefetchedGraphs = map[string]string{} }()
+	// a single directory is loaded as usual, without a prefetch
+	if err := prefetchGraphs(graphSource{Dir: "x"}, graphSource{Dir: "x"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(prefetchedGraphs) != 0 {
+		t.Errorf("prefetched %v for a single directory", prefetchedGraphs)
+	}
+}
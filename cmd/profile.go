@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+)
+
+// Hidden flags for performance bug reports.
+var cpuProfile string
+var memProfile string
+var traceOutput string
+
+// profileStops finish the profiles started by startProfiling, in order.
+var profileStops []func() error
+
+// startProfiling starts the CPU profile and execution trace requested by
+// --cpuprofile and --trace, and arranges for --memprofile to be written
+// when stopProfiling runs.
+func startProfiling() error {
+	if cpuProfile != "" {
+		f, err := os.Create(cpuProfile)
+		if err != nil {
+			return fmt.Errorf("--cpuprofile: %w", err)
+		}
+		if err := pprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			return fmt.Errorf("--cpuprofile: %w", err)
+		}
+		profileStops = append(profileStops, func() error {
+			pprof.StopCPUProfile()
+			return f.Close()
+		})
+	}
+	if traceOutput != "" {
+		f, err := os.Create(traceOutput)
+		if err != nil {
+			return fmt.Errorf("--trace: %w", err)
+		}
+		if err := trace.Start(f); err != nil {
+			f.Close()
+			return fmt.Errorf("--trace: %w", err)
+		}
+		profileStops = append(profileStops, func() error {
+			trace.Stop()
+			return f.Close()
+		})
+	}
+	if memProfile != "" {
+		path := memProfile
+		profileStops = append(profileStops, func() error {
+			f, err := os.Create(path)
+			if err != nil {
+				return fmt.Errorf("--memprofile: %w", err)
+			}
+			defer f.Close()
+			runtime.GC() // report up-to-date statistics
+			if err := pprof.WriteHeapProfile(f); err != nil {
+				return fmt.Errorf("--memprofile: %w", err)
+			}
+			return nil
+		})
+	}
+	return nil
+}
+
+// stopProfiling stops the profiles started by startProfiling and writes
+// them out. It does nothing when none were started.
+func stopProfiling() error {
+	var errs []error
+	for _, stop := range profileStops {
+		errs = append(errs, stop())
+	}
+	profileStops = nil
+	return errors.Join(errs...)
+}
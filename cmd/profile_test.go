@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProfiling(t *testing.T) {
+	tmp := t.TempDir()
+	cpu, mem, tr := filepath.Join(tmp, "cpu.pprof"), filepath.Join(tmp, "mem.pprof"), filepath.Join(tmp, "trace.out")
+	cpuProfile, memProfile, traceOutput = cpu, mem, tr
+	defer func() { cpuProfile, memProfile, traceOutput = "", "", "" }()
+
+	if err := startProfiling(); err != nil {
+		t.Fatal(err)
+	}
+	generateGraph("a b@v1.0.0\nb@v1.0.0 c@v1.0.0\n", []string{"a"})
+	if err := stopProfiling(); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{cpu, mem, tr} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Size() == 0 {
+			t.Errorf("%s is empty", path)
+		}
+	}
+	if err := stopProfiling(); err != nil {
+		t.Errorf("second stopProfiling: %v", err)
+	}
+}
+
+func TestProfilingBadPath(t *testing.T) {
+	cpuProfile = filepath.Join(t.TempDir(), "missing", "cpu.pprof")
+	defer func() { cpuProfile = "" }()
+	if err := startProfiling(); err == nil {
+		t.Error("expected an error for an unwritable --cpuprofile")
+	}
+	if err := stopProfiling(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,721 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var reportFormat string
+var reportOutputFile string
+var reportTopN int
+var reportMaxCycleLength int
+var reportSplitTestOnly bool
+
+// DependencyReport combines the output of several analyses over a single
+// graph load.
+type DependencyReport struct {
+	GeneratedAt     time.Time                    `json:"generatedAt"`
+	GoEnv           *GoEnvironment               `json:"goEnv,omitempty"`
+	Git             *GitMetadata                 `json:"git,omitempty"`
+	MainModules     []string                     `json:"mainModules"`
+	Stats           *StatsSnapshot               `json:"stats"`
+	TopContributors []ReportContributor          `json:"topContributors"`
+	VersionSkew     []ReportVersionSkew          `json:"versionSkew"`
+	ByOwner         []OwnerCount                 `json:"byOwner,omitempty"`
+	Cycles          cycleSummary                 `json:"cycles"`
+	TestOnly        []string                     `json:"testOnly,omitempty"`
+	Enrichment      map[string]*ModuleEnrichment `json:"enrichment,omitempty"`
+	Modules         map[string]ModuleInfo        `json:"modules,omitempty"`
+	Replacements    []ReportReplacement          `json:"replacements,omitempty"`
+	Updates         []ModuleUpdate               `json:"updates,omitempty"`
+	Warnings        []string                     `json:"warnings,omitempty"`
+	DepthHistogram  *DepthHistogram              `json:"depthHistogram,omitempty"`
+	Findings        []AnalyzerFinding            `json:"findings,omitempty"`
+
+	// graph is the dependency graph the report was built from.
+	graph *DependencyOverview
+}
+
+// ReportReplacement is a module whose selected version is replaced by a
+// replace directive.
+type ReportReplacement struct {
+	Module  string `json:"module"`
+	Version string `json:"version"`
+	Replace string `json:"replace"`
+}
+
+// ReportContributor is a direct dependency together with the number of
+// modules reachable through it.
+type ReportContributor struct {
+	Module     string `json:"module"`
+	Transitive int    `json:"transitive"`
+}
+
+// ReportVersionSkew is a module that is requested at more than one version
+// across the graph.
+type ReportVersionSkew struct {
+	Module    string   `json:"module"`
+	Selected  string   `json:"selected"`
+	Requested []string `json:"requested"`
+}
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate a combined dependency report in markdown, HTML or PDF",
+	Long: `Loads the dependency graph once and runs stats, top contributors, version
+skew and cycle analysis over it, emitting a single markdown or HTML document
+suitable for attaching to a release. --pdf renders the markdown report as a
+PDF document, generated directly without external tools.
+
+Use --split-test-only to include the test-only dependency split and --enrich
+to include external metadata such as licenses and scorecards; --enrich osv
+adds the known vulnerabilities of every dependency version from the OSV
+database. --analyzer adds
+the findings of external analyzers, which use the same protocol as in check.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("report does not take any arguments"))
+		}
+		if reportFormat != "markdown" && reportFormat != "html" {
+			return withExitCode(ExitUsage, fmt.Errorf("--format must be one of: markdown, html"))
+		}
+		if reportPDF && (jsonOutput || reportFormat == "html") {
+			return withExitCode(ExitUsage, fmt.Errorf("--pdf cannot be used with --json or --format html"))
+		}
+		if reportTopN <= 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("--top must be > 0"))
+		}
+		if reportMaxCycleLength != 0 && reportMaxCycleLength < 2 {
+			return withExitCode(ExitUsage, fmt.Errorf("--max-cycle-length must be >= 2 (minimum cycle length is 2)"))
+		}
+		if err := validateEnrichSources(enrichSources); err != nil {
+			return err
+		}
+
+		depGraph := getDepInfo(mainModules)
+		if len(depGraph.MainModules) == 0 {
+			return errNoMainModules
+		}
+		report := buildReport(depGraph, reportTopN, reportMaxCycleLength)
+		var err error
+		report.ByOwner, err = ownerCountsFromFile(depGraph)
+		if err != nil {
+			return err
+		}
+
+		allDeps := getAllDeps(depGraph.DirectDepList, depGraph.TransDepList)
+		sort.Strings(allDeps)
+		if reportSplitTestOnly {
+			testOnlySet, err := classifyTestDeps(allDeps)
+			if err != nil {
+				return fmt.Errorf("failed to classify dependencies: %w", err)
+			}
+			report.TestOnly = filterDepsByTestStatus(allDeps, testOnlySet, true)
+			sort.Strings(report.TestOnly)
+			testOnlyCount := len(report.TestOnly)
+			nonTestCount := len(allDeps) - testOnlyCount
+			report.Stats.TestOnlyDeps = &testOnlyCount
+			report.Stats.NonTestOnly = &nonTestCount
+		}
+		if len(enrichSources) > 0 {
+			report.Enrichment, report.Warnings = enrichModules(allDeps, depGraph.Versions, enrichSources)
+		}
+		if checkUpdates {
+			updates, err := findModuleUpdates(depGraph)
+			if err != nil {
+				return err
+			}
+			report.Updates = sortedModuleUpdates(updates)
+		}
+		report.Findings, err = runAnalyzers(analyzerCommands, depGraph)
+		if err != nil {
+			return err
+		}
+		report.Git = gitMetadataForOutput()
+
+		out := io.Writer(os.Stdout)
+		if reportOutputFile != "" {
+			f, err := os.Create(reportOutputFile)
+			if err != nil {
+				return fmt.Errorf("creating report file: %w", err)
+			}
+			defer f.Close()
+			out = f
+		}
+
+		if jsonOutput {
+			report.GoEnv = goEnvironmentForOutput()
+			raw, err := json.MarshalIndent(report, "", "\t")
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(out, string(raw))
+			return err
+		}
+		if reportPDF {
+			return renderReportPDF(out, report)
+		}
+		if reportFormat == "html" {
+			return renderReportHTML(out, report)
+		}
+		return renderReportMarkdown(out, report)
+	},
+}
+
+// buildReport runs the graph-only analyses that make up a report.
+func buildReport(depGraph *DependencyOverview, topN int, maxCycleLength int) *DependencyReport {
+	stats := snapshotFromGraph(depGraph)
+	stats.Concentration = computeConcentration(depGraph, concentrationTopN)
+	return &DependencyReport{
+		GeneratedAt:     time.Now().UTC(),
+		MainModules:     depGraph.MainModules,
+		Stats:           stats,
+		TopContributors: topContributors(depGraph, topN),
+		VersionSkew:     findVersionSkew(depGraph),
+		Cycles:          summarizeCycles(findAllCyclesWithMaxLength(depGraph.Graph, maxCycleLength), topN),
+		Modules:         depGraph.Modules,
+		Replacements:    findReplacements(depGraph.Modules),
+		DepthHistogram:  computeDepthHistogram(depGraph),
+		graph:           depGraph,
+	}
+}
+
+// findReplacements lists modules redirected by replace directives, as
+// reported by the golist backend.
+func findReplacements(modules map[string]ModuleInfo) []ReportReplacement {
+	var replacements []ReportReplacement
+	for mod, info := range modules {
+		if info.Replace != "" {
+			replacements = append(replacements, ReportReplacement{Module: mod, Version: info.Version, Replace: info.Replace})
+		}
+	}
+	sort.Slice(replacements, func(i, j int) bool { return replacements[i].Module < replacements[j].Module })
+	return replacements
+}
+
+// topContributors ranks direct dependencies by how many transitive modules
+// are reachable through them.
+func topContributors(depGraph *DependencyOverview, topN int) []ReportContributor {
+	isMain := make(map[string]bool)
+	for _, m := range depGraph.MainModules {
+		isMain[m] = true
+	}
+	var contributors []ReportContributor
+	for _, direct := range depGraph.DirectDepList {
+		seen := map[string]bool{direct: true}
+		queue := []string{direct}
+		for len(queue) > 0 {
+			node := queue[0]
+			queue = queue[1:]
+			for _, next := range depGraph.Graph[node] {
+				if !seen[next] && !isMain[next] {
+					seen[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+		contributors = append(contributors, ReportContributor{Module: direct, Transitive: len(seen) - 1})
+	}
+	sort.Slice(contributors, func(i, j int) bool {
+		if contributors[i].Transitive == contributors[j].Transitive {
+			return contributors[i].Module < contributors[j].Module
+		}
+		return contributors[i].Transitive > contributors[j].Transitive
+	})
+	if len(contributors) > topN {
+		contributors = contributors[:topN]
+	}
+	return contributors
+}
+
+// findVersionSkew returns modules that are requested at more than one
+// version by modules in the graph.
+func findVersionSkew(depGraph *DependencyOverview) []ReportVersionSkew {
+	skew := []ReportVersionSkew{}
+	for mod, reqs := range depGraph.Requirements {
+		seen := map[string]bool{}
+		var versions []string
+		for _, r := range reqs {
+			if !seen[r.Version] {
+				seen[r.Version] = true
+				versions = append(versions, r.Version)
+			}
+		}
+		if len(versions) < 2 {
+			continue
+		}
+		sort.Slice(versions, func(i, j int) bool { return versionGreater(versions[j], versions[i]) })
+		skew = append(skew, ReportVersionSkew{
+			Module:    mod,
+			Selected:  depGraph.Versions[mod],
+			Requested: versions,
+		})
+	}
+	sort.Slice(skew, func(i, j int) bool {
+		if len(skew[i].Requested) == len(skew[j].Requested) {
+			return skew[i].Module < skew[j].Module
+		}
+		return len(skew[i].Requested) > len(skew[j].Requested)
+	})
+	return skew
+}
+
+func renderReportMarkdown(w io.Writer, r *DependencyReport) error {
+	var b strings.Builder
+	b.WriteString("# Dependency report\n\n")
+	fmt.Fprintf(&b, "Generated %s for %s.\n\n", r.GeneratedAt.Format(time.RFC3339), strings.Join(r.MainModules, ", "))
+	if r.Git != nil {
+		fmt.Fprintf(&b, "Source: %s.\n\n", describeGitMetadata(r.Git, "`"))
+	}
+
+	b.WriteString("## Summary\n\n")
+	b.WriteString("| Metric | Value |\n|---|---|\n")
+	fmt.Fprintf(&b, "| Direct dependencies | %d |\n", r.Stats.DirectDeps)
+	fmt.Fprintf(&b, "| Transitive dependencies | %d |\n", r.Stats.TransDeps)
+	fmt.Fprintf(&b, "| Total dependencies | %d |\n", r.Stats.TotalDeps)
+	fmt.Fprintf(&b, "| Max depth of dependencies | %d |\n", r.Stats.MaxDepth)
+	if r.TestOnly != nil {
+		fmt.Fprintf(&b, "| Non-test dependencies | %d |\n", *r.Stats.NonTestOnly)
+		fmt.Fprintf(&b, "| Test-only dependencies | %d |\n", *r.Stats.TestOnlyDeps)
+	}
+	if r.Stats.Concentration != nil {
+		fmt.Fprintf(&b, "| Concentration | %s |\n", r.Stats.Concentration)
+	}
+	fmt.Fprintf(&b, "| Cycles | %d |\n", r.Cycles.TotalCycles)
+	fmt.Fprintf(&b, "| Modules with version skew | %d |\n\n", len(r.VersionSkew))
+
+	b.WriteString("## Top contributors\n\n")
+	if len(r.TopContributors) == 0 {
+		b.WriteString("No direct dependencies.\n\n")
+	} else {
+		b.WriteString("| Direct dependency | Transitive modules |\n|---|---|\n")
+		for _, c := range r.TopContributors {
+			fmt.Fprintf(&b, "| `%s` | %d |\n", c.Module, c.Transitive)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Version skew\n\n")
+	if len(r.VersionSkew) == 0 {
+		b.WriteString("No module is requested at more than one version.\n\n")
+	} else {
+		b.WriteString("| Module | Selected | Requested |\n|---|---|---|\n")
+		for _, s := range r.VersionSkew {
+			fmt.Fprintf(&b, "| `%s` | %s | %s |\n", s.Module, s.Selected, strings.Join(s.Requested, ", "))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Cycles\n\n")
+	if r.Cycles.TotalCycles == 0 {
+		b.WriteString("No cycles found.\n\n")
+	} else {
+		fmt.Fprintf(&b, "Total cycles: %d\n\n", r.Cycles.TotalCycles)
+		if len(r.Cycles.TwoNodeCycles) > 0 {
+			b.WriteString("Two-node cycles:\n\n")
+			for _, c := range r.Cycles.TwoNodeCycles {
+				fmt.Fprintf(&b, "- `%s` <-> `%s`\n", c[0], c[1])
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("| Module | Cycles |\n|---|---|\n")
+		for _, p := range r.Cycles.TopParticipants {
+			fmt.Fprintf(&b, "| `%s` | %d |\n", p.Module, p.CycleCount)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(r.ByOwner) > 0 {
+		b.WriteString("## Dependencies by owner\n\n")
+		b.WriteString("| Owner | Dependencies |\n|---|---|\n")
+		for _, o := range r.ByOwner {
+			fmt.Fprintf(&b, "| %s | %d |\n", o.Owner, o.Count)
+		}
+		b.WriteString("\n")
+	}
+
+	if r.TestOnly != nil {
+		fmt.Fprintf(&b, "## Test-only dependencies (%d)\n\n", len(r.TestOnly))
+		for _, dep := range r.TestOnly {
+			fmt.Fprintf(&b, "- `%s`\n", dep)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(r.Updates) > 0 {
+		fmt.Fprintf(&b, "## Available updates (%d)\n\n", len(r.Updates))
+		b.WriteString("| Module | Version | Latest | Kind |\n|---|---|---|---|\n")
+		for _, u := range r.Updates {
+			fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n", u.Module, u.Version, u.Latest, u.Kind)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(r.Findings) > 0 {
+		fmt.Fprintf(&b, "## Analyzer findings (%d)\n\n", len(r.Findings))
+		b.WriteString("| Severity | Rule | Module | Message |\n|---|---|---|---|\n")
+		for _, f := range r.Findings {
+			module := "-"
+			if f.Module != "" {
+				module = "`" + f.Module + "`"
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", f.Severity, analyzerViolation(f).Rule, module, strings.ReplaceAll(f.Message, "|", "\\|"))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(r.Replacements) > 0 {
+		fmt.Fprintf(&b, "## Replacements (%d)\n\n", len(r.Replacements))
+		b.WriteString("| Module | Version | Replaced by |\n|---|---|---|\n")
+		for _, rep := range r.Replacements {
+			fmt.Fprintf(&b, "| `%s` | %s | `%s` |\n", rep.Module, rep.Version, rep.Replace)
+		}
+		b.WriteString("\n")
+	}
+
+	if r.Enrichment != nil {
+		b.WriteString("## Metadata\n\n")
+		b.WriteString("| Module | Metadata |\n|---|---|\n")
+		for _, mod := range sortedEnrichmentKeys(r.Enrichment) {
+			if meta := formatEnrichment(r.Enrichment[mod]); meta != "" {
+				fmt.Fprintf(&b, "| `%s` | %s |\n", mod, strings.ReplaceAll(meta, "|", "\\|"))
+			}
+		}
+		b.WriteString("\n")
+		for _, warning := range r.Warnings {
+			fmt.Fprintf(&b, "> warning: %s\n", warning)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var reportHTMLTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"join":   strings.Join,
+	"enrich": formatEnrichment,
+	"date":   func(t time.Time) string { return t.Format(time.RFC3339) },
+	"keys":   sortedEnrichmentKeys,
+	"bar":    barPercent,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Dependency report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+th { background: #f0f0f0; }
+code { font-size: 90%; }
+.chart { max-width: 900px; margin-bottom: 1.5em; }
+.bar-row { display: flex; align-items: center; margin: 2px 0; font-size: 90%; }
+.bar-label { width: 40%; overflow: hidden; text-overflow: ellipsis; white-space: nowrap; padding-right: 8px; }
+.bar-track { flex: 1; }
+.bar { background: #4c78a8; height: 14px; display: inline-block; vertical-align: middle; min-width: 1px; }
+.bar-value { padding-left: 6px; }
+.histogram { display: flex; align-items: flex-end; height: 180px; gap: 4px; max-width: 900px; border-bottom: 1px solid #ccc; }
+.histogram .col { flex: 1; display: flex; flex-direction: column; justify-content: flex-end; align-items: center; height: 100%; font-size: 80%; }
+.histogram .col .bar { width: 100%; }
+.histogram-axis { display: flex; gap: 4px; max-width: 900px; font-size: 80%; color: #555; margin-bottom: 1.5em; }
+.histogram-axis span { flex: 1; text-align: center; }
+#graph-view { border: 1px solid #ccc; overflow: auto; max-height: 640px; margin: .5em 0 1.5em; }
+#graph-view text { font-size: 11px; font-family: monospace; cursor: pointer; }
+#graph-view rect { fill: #fff3e0; stroke: #f57c00; cursor: pointer; }
+#graph-view .main rect { fill: #e8f5e9; stroke: #388e3c; }
+#graph-view path { fill: none; stroke: #bbb; }
+#graph-view .dim { opacity: .12; }
+#graph-view .selected rect { fill: #ffe0e0; stroke: #d32f2f; stroke-width: 2; }
+#graph-view .match rect { stroke: #1976d2; stroke-width: 2; }
+#graph-view path.hl { stroke: #d32f2f; }
+#graph-info { font-size: 90%; min-height: 1.2em; }
+</style>
+</head>
+<body>
+<h1>Dependency report</h1>
+<p>Generated {{date .GeneratedAt}} for {{join .MainModules ", "}}.</p>
+{{- with .Git}}
+<p>Source: commit <code>{{.Commit}}</code>{{with .Branch}} on branch <code>{{.}}</code>{{end}}{{if .Dirty}} with uncommitted changes{{end}}.</p>
+{{- end}}
+<h2>Summary</h2>
+<table>
+<tr><th>Metric</th><th>Value</th></tr>
+<tr><td>Direct dependencies</td><td>{{.Stats.DirectDeps}}</td></tr>
+<tr><td>Transitive dependencies</td><td>{{.Stats.TransDeps}}</td></tr>
+<tr><td>Total dependencies</td><td>{{.Stats.TotalDeps}}</td></tr>
+<tr><td>Max depth of dependencies</td><td>{{.Stats.MaxDepth}}</td></tr>
+{{- if .Stats.TestOnlyDeps}}
+<tr><td>Non-test dependencies</td><td>{{.Stats.NonTestOnly}}</td></tr>
+<tr><td>Test-only dependencies</td><td>{{.Stats.TestOnlyDeps}}</td></tr>
+{{- end}}
+{{- with .Stats.Concentration}}
+<tr><td>Concentration</td><td>{{.}}</td></tr>
+{{- end}}
+<tr><td>Cycles</td><td>{{.Cycles.TotalCycles}}</td></tr>
+<tr><td>Modules with version skew</td><td>{{len .VersionSkew}}</td></tr>
+</table>
+<h2>Top contributors</h2>
+{{if .TopContributors -}}
+<div class="chart">
+{{- $max := .ContributorsMax}}
+{{- range .TopContributors}}
+<div class="bar-row"><span class="bar-label" title="{{.Module}}"><code>{{.Module}}</code></span><span class="bar-track"><span class="bar" style="width: {{bar .Transitive $max}}%"></span><span class="bar-value">{{.Transitive}}</span></span></div>
+{{- end}}
+</div>
+<table>
+<tr><th>Direct dependency</th><th>Transitive modules</th></tr>
+{{- range .TopContributors}}
+<tr><td><code>{{.Module}}</code></td><td>{{.Transitive}}</td></tr>
+{{- end}}
+</table>
+{{- else -}}
+<p>No direct dependencies.</p>
+{{- end}}
+{{- with .DepthHistogram}}{{if .Buckets}}
+<h2>Depth histogram</h2>
+<p>Shortest path from the main modules: p50 {{.P50}}, p90 {{.P90}}, max {{.Max}}.</p>
+<div class="histogram">
+{{- range .Buckets}}
+<div class="col" title="depth {{.Depth}}: {{.Count}}"><span>{{.Count}}</span><span class="bar" style="height: {{bar .Count $.DepthMax}}%"></span></div>
+{{- end}}
+</div>
+<div class="histogram-axis">
+{{- range .Buckets}}<span>{{.Depth}}</span>{{end}}
+</div>
+{{- end}}{{end}}
+<h2>Version skew</h2>
+{{if .VersionSkew -}}
+<table>
+<tr><th>Module</th><th>Selected</th><th>Requested</th></tr>
+{{- range .VersionSkew}}
+<tr><td><code>{{.Module}}</code></td><td>{{.Selected}}</td><td>{{join .Requested ", "}}</td></tr>
+{{- end}}
+</table>
+{{- else -}}
+<p>No module is requested at more than one version.</p>
+{{- end}}
+<h2>Cycles</h2>
+{{if .Cycles.TotalCycles -}}
+<p>Total cycles: {{.Cycles.TotalCycles}}</p>
+{{- if .Cycles.TwoNodeCycles}}
+<ul>
+{{- range .Cycles.TwoNodeCycles}}
+<li><code>{{index . 0}}</code> &harr; <code>{{index . 1}}</code></li>
+{{- end}}
+</ul>
+{{- end}}
+<table>
+<tr><th>Module</th><th>Cycles</th></tr>
+{{- range .Cycles.TopParticipants}}
+<tr><td><code>{{.Module}}</code></td><td>{{.CycleCount}}</td></tr>
+{{- end}}
+</table>
+{{- else -}}
+<p>No cycles found.</p>
+{{- end}}
+{{- if .ByOwner}}
+<h2>Dependencies by owner</h2>
+<table>
+<tr><th>Owner</th><th>Dependencies</th></tr>
+{{- range .ByOwner}}
+<tr><td>{{.Owner}}</td><td>{{.Count}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .TestOnly}}
+<h2>Test-only dependencies ({{len .TestOnly}})</h2>
+<ul>
+{{- range .TestOnly}}
+<li><code>{{.}}</code></li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .Updates}}
+<h2>Available updates ({{len .Updates}})</h2>
+<table>
+<tr><th>Module</th><th>Version</th><th>Latest</th><th>Kind</th></tr>
+{{- range .Updates}}
+<tr><td><code>{{.Module}}</code></td><td>{{.Version}}</td><td>{{.Latest}}</td><td>{{.Kind}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Findings}}
+<h2>Analyzer findings ({{len .Findings}})</h2>
+<table>
+<tr><th>Severity</th><th>Analyzer</th><th>Rule</th><th>Module</th><th>Message</th></tr>
+{{- range .Findings}}
+<tr><td>{{.Severity}}</td><td>{{.Analyzer}}</td><td>{{.Rule}}</td><td>{{if .Module}}<code>{{.Module}}</code>{{end}}</td><td>{{.Message}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Replacements}}
+<h2>Replacements ({{len .Replacements}})</h2>
+<table>
+<tr><th>Module</th><th>Version</th><th>Replaced by</th></tr>
+{{- range .Replacements}}
+<tr><td><code>{{.Module}}</code></td><td>{{.Version}}</td><td><code>{{.Replace}}</code></td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Graph.Nodes}}
+<h2>Dependency graph</h2>
+<p>Modules are laid out by their shortest distance from the main modules. Click a module to highlight what it requires and what requires it; click the background to reset.</p>
+<p><input id="graph-filter" type="search" placeholder="Filter modules" size="40"> <span id="graph-info"></span></p>
+<div id="graph-view"></div>
+<script>
+(function () {
+  var data = {{.Graph}};
+  var ns = "http://www.w3.org/2000/svg";
+  var colWidth = 300, rowHeight = 22, boxWidth = 260, boxHeight = 16, pad = 10;
+  var columns = {}, maxDepth = 0;
+  data.nodes.forEach(function (n) { maxDepth = Math.max(maxDepth, n.depth); });
+  data.nodes.forEach(function (n, i) {
+    var d = n.depth < 0 ? maxDepth + 1 : n.depth;
+    (columns[d] = columns[d] || []).push(i);
+  });
+  var pos = [], rows = 0;
+  Object.keys(columns).forEach(function (d) {
+    columns[d].forEach(function (i, row) { pos[i] = {x: pad + d * colWidth, y: pad + row * rowHeight}; });
+    rows = Math.max(rows, columns[d].length);
+  });
+  var svg = document.createElementNS(ns, "svg");
+  svg.setAttribute("width", pad * 2 + (Object.keys(columns).length - 1) * colWidth + boxWidth);
+  svg.setAttribute("height", pad * 2 + rows * rowHeight);
+  var out = data.nodes.map(function () { return []; }), inc = data.nodes.map(function () { return []; });
+  var edgeEls = data.edges.map(function (e, k) {
+    out[e[0]].push(k); inc[e[1]].push(k);
+    var a = pos[e[0]], b = pos[e[1]];
+    var x1 = a.x + boxWidth, y1 = a.y + boxHeight / 2, x2 = b.x, y2 = b.y + boxHeight / 2;
+    var p = document.createElementNS(ns, "path");
+    p.setAttribute("d", "M" + x1 + "," + y1 + " C" + (x1 + 30) + "," + y1 + " " + (x2 - 30) + "," + y2 + " " + x2 + "," + y2);
+    svg.appendChild(p);
+    return p;
+  });
+  var nodeEls = data.nodes.map(function (n, i) {
+    var g = document.createElementNS(ns, "g");
+    if (n.main) g.setAttribute("class", "main");
+    var r = document.createElementNS(ns, "rect");
+    r.setAttribute("x", pos[i].x); r.setAttribute("y", pos[i].y);
+    r.setAttribute("width", boxWidth); r.setAttribute("height", boxHeight); r.setAttribute("rx", 3);
+    var t = document.createElementNS(ns, "text");
+    t.setAttribute("x", pos[i].x + 4); t.setAttribute("y", pos[i].y + 12);
+    t.textContent = n.id.length > 40 ? "…" + n.id.slice(-39) : n.id;
+    var title = document.createElementNS(ns, "title");
+    title.textContent = n.id + (n.version ? "@" + n.version : "");
+    g.appendChild(r); g.appendChild(t); g.appendChild(title);
+    g.addEventListener("click", function (ev) { ev.stopPropagation(); select(i); });
+    svg.appendChild(g);
+    return g;
+  });
+  var info = document.getElementById("graph-info");
+  function reset() {
+    nodeEls.forEach(function (g, i) { g.setAttribute("class", data.nodes[i].main ? "main" : ""); });
+    edgeEls.forEach(function (p) { p.removeAttribute("class"); });
+    info.textContent = "";
+  }
+  function select(i) {
+    reset();
+    var keep = {}; keep[i] = true;
+    out[i].concat(inc[i]).forEach(function (k) {
+      keep[data.edges[k][0]] = keep[data.edges[k][1]] = true;
+      edgeEls[k].setAttribute("class", "hl");
+    });
+    nodeEls.forEach(function (g, j) { if (!keep[j]) g.setAttribute("class", "dim"); });
+    edgeEls.forEach(function (p, k) { if (out[i].indexOf(k) < 0 && inc[i].indexOf(k) < 0) p.setAttribute("class", "dim"); });
+    nodeEls[i].setAttribute("class", "selected");
+    var n = data.nodes[i];
+    info.textContent = n.id + (n.version ? "@" + n.version : "") + ": requires " + out[i].length + ", required by " + inc[i].length;
+  }
+  svg.addEventListener("click", reset);
+  document.getElementById("graph-filter").addEventListener("input", function (ev) {
+    var q = ev.target.value.toLowerCase();
+    reset();
+    if (!q) return;
+    var hits = 0;
+    nodeEls.forEach(function (g, i) {
+      if (data.nodes[i].id.toLowerCase().indexOf(q) >= 0) { g.setAttribute("class", "match"); hits++; }
+    });
+    info.textContent = hits + " matching module(s)";
+  });
+  document.getElementById("graph-view").appendChild(svg);
+})();
+</script>
+{{- end}}
+{{- if .Enrichment}}
+<h2>Metadata</h2>
+<table>
+<tr><th>Module</th><th>Metadata</th></tr>
+{{- $e := .Enrichment}}
+{{- range keys $e}}
+<tr><td><code>{{.}}</code></td><td>{{enrich (index $e .)}}</td></tr>
+{{- end}}
+</table>
+{{- range .Warnings}}
+<p>warning: {{.}}</p>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
+
+// renderReportHTML writes the report as a single self-contained HTML page:
+// charts are plain HTML and CSS, and the interactive graph is drawn by an
+// inline script, so the page works offline and can be archived as is.
+func renderReportHTML(w io.Writer, r *DependencyReport) error {
+	return reportHTMLTemplate.Execute(w, newReportHTMLView(r))
+}
+
+func sortedEnrichmentKeys(enrichment map[string]*ModuleEnrichment) []string {
+	keys := make([]string, 0, len(enrichment))
+	for k := range enrichment {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
+	reportCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Get the report data in JSON format")
+	reportCmd.Flags().StringVar(&reportFormat, "format", "markdown", "Report format: markdown or html")
+	reportCmd.Flags().BoolVar(&reportPDF, "pdf", false, "Write the report as a PDF document, laid out from its markdown form")
+	reportCmd.Flags().StringVarP(&reportOutputFile, "output", "o", "", "Write the report to this file instead of stdout")
+	reportCmd.Flags().IntVarP(&reportTopN, "top", "n", 10, "Number of entries to show in ranked sections")
+	reportCmd.Flags().IntVar(&reportMaxCycleLength, "max-cycle-length", 0, "Limit cycles to length <= N (0 = no limit)")
+	reportCmd.Flags().BoolVar(&reportSplitTestOnly, "split-test-only", false, "Include the test-only dependency split (uses go mod why -m)")
+	reportCmd.Flags().StringVar(&ownersFile, "owners", "", "CODEOWNERS-style file mapping module path patterns to teams (- reads stdin); adds a dependencies by owner section")
+	reportCmd.Flags().BoolVar(&checkUpdates, "check-updates", false, "Include available updates and their kind (uses go list -m -u)")
+	reportCmd.Flags().StringArrayVar(&analyzerCommands, "analyzer", nil, "External analyzer command reading the graph as JSON on stdin and writing findings to stdout, added as a section. Repeatable")
+	reportCmd.Flags().StringSliceVar(&enrichSources, "enrich", []string{}, "Attach external metadata to each dependency (supported: depsdev, github, osv, proxy)")
+	reportCmd.Flags().StringVar(&enrichCacheDir, "enrich-cache-dir", "", "Directory for cached enrichment data. Defaults to the user cache directory.")
+	reportCmd.Flags().IntVar(&enrichStaleDays, "stale-days", 365, "With --enrich github, flag dependencies whose upstream has no commits in this many days (0 disables)")
+	reportCmd.Flags().IntVar(&enrichMaxVersionAgeDays, "version-age-days", 730, "With --enrich proxy, flag dependencies whose pinned version was released more than this many days ago (0 disables)")
+	reportCmd.Flags().StringVar(&githubTokenPath, "github-token-path", "", "Path to a file containing the GitHub API token. If not set, uses GITHUB_TOKEN env var.")
+	reportCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
+	reportCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Specify main modules")
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import "sort"
+
+// reportGraphData is the dependency graph embedded in the HTML report for
+// its interactive view. Edges index into Nodes.
+type reportGraphData struct {
+	Nodes []reportGraphNode `json:"nodes"`
+	Edges [][2]int          `json:"edges"`
+}
+
+type reportGraphNode struct {
+	ID      string `json:"id"`
+	Version string `json:"version,omitempty"`
+	// Depth is the shortest distance from a main module, -1 when
+	// unreachable.
+	Depth int  `json:"depth"`
+	Main  bool `json:"main,omitempty"`
+}
+
+// reportHTMLView is what the HTML report template renders: the report plus
+// the data of its charts.
+type reportHTMLView struct {
+	*DependencyReport
+	Graph           reportGraphData
+	ContributorsMax int
+	DepthMax        int
+}
+
+func buildReportGraphData(depGraph *DependencyOverview) reportGraphData {
+	depthOf := shortestDepthByModule(depGraph.MainModules, depGraph.Graph)
+	nodes := uniqueStrings(append(append([]string{}, depGraph.MainModules...), getAllDeps(depGraph.DirectDepList, depGraph.TransDepList)...))
+	data := reportGraphData{Nodes: []reportGraphNode{}, Edges: [][2]int{}}
+	index := make(map[string]int, len(nodes))
+	for i, mod := range nodes {
+		index[mod] = i
+		depth, ok := depthOf[mod]
+		if !ok {
+			depth = -1
+		}
+		data.Nodes = append(data.Nodes, reportGraphNode{
+			ID:      mod,
+			Version: depGraph.Versions[mod],
+			Depth:   depth,
+			Main:    contains(depGraph.MainModules, mod),
+		})
+	}
+	for _, from := range nodes {
+		for _, to := range depGraph.Graph[from] {
+			if j, ok := index[to]; ok {
+				data.Edges = append(data.Edges, [2]int{index[from], j})
+			}
+		}
+	}
+	sort.Slice(data.Edges, func(i, j int) bool {
+		if data.Edges[i][0] != data.Edges[j][0] {
+			return data.Edges[i][0] < data.Edges[j][0]
+		}
+		return data.Edges[i][1] < data.Edges[j][1]
+	})
+	return data
+}
+
+func newReportHTMLView(r *DependencyReport) reportHTMLView {
+	view := reportHTMLView{DependencyReport: r, Graph: reportGraphData{Nodes: []reportGraphNode{}, Edges: [][2]int{}}}
+	if r.graph != nil {
+		view.Graph = buildReportGraphData(r.graph)
+	}
+	for _, c := range r.TopContributors {
+		view.ContributorsMax = max(view.ContributorsMax, c.Transitive)
+	}
+	if r.DepthHistogram != nil {
+		for _, b := range r.DepthHistogram.Buckets {
+			view.DepthMax = max(view.DepthMax, b.Count)
+		}
+	}
+	return view
+}
+
+// barPercent is the length of a bar of value n in a chart whose longest
+// bar is largest, as a CSS percentage.
+func barPercent(n, largest int) float64 {
+	if largest <= 0 {
+		return 0
+	}
+	return float64(n) * 100 / float64(largest)
+}
@@ -0,0 +1,293 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+var reportPDF bool
+
+// PDF page geometry in points (A4). The body is set in Courier, whose
+// glyphs are all 0.6em wide, so lines can be wrapped and table columns
+// aligned by counting characters.
+const (
+	pdfPageWidth    = 595
+	pdfPageHeight   = 842
+	pdfMargin       = 50
+	pdfBodySize     = 9
+	pdfBodyLeading  = 12
+	pdfCharsPerLine = (pdfPageWidth - 2*pdfMargin) * 10 / (pdfBodySize * 6)
+)
+
+// pdfLine is a line of text placed on a page.
+type pdfLine struct {
+	Font string // resource name: F1 Helvetica-Bold, F2 Courier, F3 Courier-Bold
+	Size int
+	X, Y int
+	Text string
+}
+
+// pdfLayout flows lines of text onto pages, top to bottom.
+type pdfLayout struct {
+	pages [][]pdfLine
+	y     int
+}
+
+func (l *pdfLayout) add(font string, size, leading int, text string) {
+	if l.pages == nil || l.y-leading < pdfMargin {
+		l.pages = append(l.pages, nil)
+		l.y = pdfPageHeight - pdfMargin
+	}
+	l.y -= leading
+	page := len(l.pages) - 1
+	l.pages[page] = append(l.pages[page], pdfLine{Font: font, Size: size, X: pdfMargin, Y: l.y, Text: text})
+}
+
+func (l *pdfLayout) space(points int) {
+	if l.pages != nil && l.y-points >= pdfMargin {
+		l.y -= points
+	}
+}
+
+func (l *pdfLayout) body(font, text string) {
+	for _, line := range wrapText(text, pdfCharsPerLine) {
+		l.add(font, pdfBodySize, pdfBodyLeading, line)
+	}
+}
+
+// renderReportPDF writes the report as a PDF document, laid out from its
+// markdown form: headings, paragraphs, lists and tables with aligned
+// columns. It only uses the standard PDF fonts, so nothing is embedded and
+// no external tool is needed.
+func renderReportPDF(w io.Writer, r *DependencyReport) error {
+	var md bytes.Buffer
+	if err := renderReportMarkdown(&md, r); err != nil {
+		return err
+	}
+	var layout pdfLayout
+	lines := strings.Split(md.String(), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case strings.HasPrefix(line, "# "):
+			layout.add("F1", 18, 26, pdfPlainText(line[2:]))
+		case strings.HasPrefix(line, "## "):
+			layout.space(8)
+			layout.add("F1", 13, 20, pdfPlainText(line[3:]))
+		case strings.HasPrefix(line, "|"):
+			var table [][]string
+			for ; i < len(lines) && strings.HasPrefix(lines[i], "|"); i++ {
+				if row := splitMarkdownRow(lines[i]); !isMarkdownSeparator(row) {
+					table = append(table, row)
+				}
+			}
+			i--
+			for j, row := range formatPDFTable(table, pdfCharsPerLine) {
+				font := "F2"
+				if j == 0 {
+					font = "F3"
+				}
+				layout.add(font, pdfBodySize, pdfBodyLeading, row)
+			}
+		case strings.TrimSpace(line) == "":
+			layout.space(pdfBodyLeading / 2)
+		default:
+			layout.body("F2", pdfPlainText(line))
+		}
+	}
+	return writePDF(w, layout.pages, "Dependency report", r.GeneratedAt)
+}
+
+// pdfPlainText drops the markdown emphasis and code markers of a line.
+func pdfPlainText(s string) string {
+	s = strings.ReplaceAll(s, "`", "")
+	s = strings.ReplaceAll(s, "**", "")
+	return strings.ReplaceAll(s, `\|`, "|")
+}
+
+// splitMarkdownRow returns the cells of a markdown table row, keeping
+// escaped pipes inside cells.
+func splitMarkdownRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, pdfPlainText(strings.TrimSpace(cell.String())))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, pdfPlainText(strings.TrimSpace(cell.String())))
+}
+
+func isMarkdownSeparator(row []string) bool {
+	for _, cell := range row {
+		if strings.Trim(cell, "-:") != "" || cell == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// formatPDFTable aligns the table in columns, shortening the widest column
+// until a row fits in width characters.
+func formatPDFTable(table [][]string, width int) []string {
+	var widths []int
+	for _, row := range table {
+		for c, cell := range row {
+			if c == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[c] = max(widths[c], len(cell))
+		}
+	}
+	total := func() int {
+		n := 2 * (len(widths) - 1)
+		for _, w := range widths {
+			n += w
+		}
+		return n
+	}
+	for total() > width {
+		widest := 0
+		for c := range widths {
+			if widths[c] > widths[widest] {
+				widest = c
+			}
+		}
+		if widths[widest] <= 4 {
+			break
+		}
+		widths[widest]--
+	}
+	var rows []string
+	for _, row := range table {
+		var b strings.Builder
+		for c, cell := range row {
+			if len(cell) > widths[c] {
+				cell = cell[:widths[c]-3] + "..."
+			}
+			if c < len(row)-1 {
+				fmt.Fprintf(&b, "%-*s  ", widths[c], cell)
+			} else {
+				b.WriteString(cell)
+			}
+		}
+		rows = append(rows, b.String())
+	}
+	return rows
+}
+
+// wrapText splits s into lines of at most width characters, breaking at
+// spaces where possible.
+func wrapText(s string, width int) []string {
+	var lines []string
+	for len(s) > width {
+		cut := strings.LastIndex(s[:width+1], " ")
+		if cut <= 0 {
+			cut = width
+		}
+		lines = append(lines, strings.TrimRight(s[:cut], " "))
+		s = strings.TrimLeft(s[cut:], " ")
+	}
+	return append(lines, s)
+}
+
+// pdfString encodes s as a PDF literal string. The standard fonts only
+// cover Latin-1 here, so other characters become "?".
+func pdfString(s string) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '→':
+			b.WriteString("->")
+		case r < 32 || r > 255:
+			b.WriteByte('?')
+		case r < 127:
+			b.WriteRune(r)
+		default:
+			fmt.Fprintf(&b, "\\%03o", r)
+		}
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// writePDF writes a PDF 1.4 document with one page per entry of pages and
+// a "page N of M" footer.
+func writePDF(w io.Writer, pages [][]pdfLine, title string, created time.Time) error {
+	if len(pages) == 0 {
+		pages = [][]pdfLine{nil}
+	}
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) int {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+		return len(offsets)
+	}
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	// objects 1-5 are fixed; every page adds a page and a content object
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+2*i)
+	}
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier-Bold /Encoding /WinAnsiEncoding >>")
+	for i, page := range pages {
+		var content strings.Builder
+		for _, l := range append(page, pdfLine{
+			Font: "F2", Size: 8, X: pdfPageWidth/2 - 30, Y: pdfMargin / 2,
+			Text: fmt.Sprintf("page %d of %d", i+1, len(pages)),
+		}) {
+			fmt.Fprintf(&content, "BT /%s %d Tf %d %d Td %s Tj ET\n", l.Font, l.Size, l.X, l.Y, pdfString(l.Text))
+		}
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R /F3 5 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 7+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+	info := object(fmt.Sprintf("<< /Title %s /Producer (depstat) /CreationDate (D:%s) >>",
+		pdfString(title), created.UTC().Format("20060102150405Z")))
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, info, xref)
+	_, err := w.Write(out.Bytes())
+	return err
+}
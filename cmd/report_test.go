@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFindVersionSkew(t *testing.T) {
+	depGraph := generateGraph(`A B@v1.0.0
+A C@v1.0.0
+A D@v1.10.0
+B@v1.0.0 D@v1.9.0
+C@v1.0.0 D@v1.10.0
+C@v1.0.0 E@v1.0.0`, nil)
+
+	skew := findVersionSkew(&depGraph)
+	if len(skew) != 1 {
+		t.Fatalf("expected a single skewed module, got %+v", skew)
+	}
+	if skew[0].Module != "D" || skew[0].Selected != "v1.10.0" {
+		t.Fatalf("unexpected skew entry %+v", skew[0])
+	}
+	if got := strings.Join(skew[0].Requested, ","); got != "v1.9.0,v1.10.0" {
+		t.Fatalf("unexpected requested versions %q", got)
+	}
+}
+
+func TestTopContributors(t *testing.T) {
+	depGraph := &DependencyOverview{
+		MainModules:   []string{"main"},
+		DirectDepList: []string{"A", "B"},
+		Graph: map[string][]string{
+			"main": {"A", "B"},
+			"A":    {"C", "D"},
+			"B":    {"C"},
+			"D":    {"main"},
+		},
+	}
+	got := topContributors(depGraph, 10)
+	if len(got) != 2 || got[0].Module != "A" || got[0].Transitive != 2 || got[1].Transitive != 1 {
+		t.Fatalf("unexpected contributors %+v", got)
+	}
+}
+
+func TestRenderReportFormats(t *testing.T) {
+	depGraph := generateGraph(`A B@v1.0.0
+B@v1.0.0 C@v1.0.0
+C@v1.0.0 B@v1.0.0`, nil)
+	report := buildReport(&depGraph, 5, 0)
+
+	var md bytes.Buffer
+	if err := renderReportMarkdown(&md, report); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"# Dependency report", "| Total dependencies | 2 |", "| `B` | 1 |", "- `B` <-> `C`"} {
+		if !strings.Contains(md.String(), want) {
+			t.Errorf("markdown report missing %q:\n%s", want, md.String())
+		}
+	}
+
+	var html bytes.Buffer
+	if err := renderReportHTML(&html, report); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(html.String(), "<td>Total dependencies</td><td>2</td>") {
+		t.Errorf("html report missing totals:\n%s", html.String())
+	}
+	for _, want := range []string{
+		`<span class="bar" style="width: 100%"></span><span class="bar-value">1</span>`,
+		`<div class="col" title="depth 1: 1"><span>1</span><span class="bar" style="height: 100%"></span></div>`,
+		`var data = {"nodes":[{"id":"A","depth":0,"main":true},{"id":"B","version":"v1.0.0","depth":1},{"id":"C","version":"v1.0.0","depth":2}],"edges":[[0,1],[1,2],[2,1]]};`,
+	} {
+		if !strings.Contains(html.String(), want) {
+			t.Errorf("html report missing %q", want)
+		}
+	}
+	if strings.Contains(html.String(), "src=") || strings.Contains(html.String(), "href=") {
+		t.Errorf("html report references external resources")
+	}
+}
+
+func TestRenderReportPDF(t *testing.T) {
+	var graph strings.Builder
+	for i := 0; i < 120; i++ {
+		fmt.Fprintf(&graph, "A example.com/dependency-with-a-rather-long-module-path/number-%03d@v1.0.0\n", i)
+	}
+	depGraph := generateGraph(graph.String(), nil)
+	report := buildReport(&depGraph, 100, 0)
+
+	var out bytes.Buffer
+	if err := renderReportPDF(&out, report); err != nil {
+		t.Fatal(err)
+	}
+	pdf := out.String()
+	if !strings.HasPrefix(pdf, "%PDF-1.4\n") || !strings.HasSuffix(pdf, "%%EOF\n") {
+		t.Fatalf("not a PDF document:\n%s", pdf)
+	}
+	// every xref entry points at the start of its object
+	xref := pdf[strings.LastIndex(pdf, "\nxref\n")+1:]
+	entries := strings.Split(xref, "\n")[3:]
+	pages := 0
+	for i, entry := range entries {
+		if strings.HasPrefix(entry, "trailer") {
+			break
+		}
+		var offset int
+		fmt.Sscanf(entry, "%d", &offset)
+		if want := fmt.Sprintf("%d 0 obj\n", i+1); !strings.HasPrefix(pdf[offset:], want) {
+			t.Errorf("xref entry %d points at %q", i+1, pdf[offset:min(offset+20, len(pdf))])
+		}
+		if strings.HasPrefix(pdf[offset:], fmt.Sprintf("%d 0 obj\n<< /Type /Page ", i+1)) {
+			pages++
+		}
+	}
+	if pages < 2 {
+		t.Errorf("expected the top contributors to spill onto a second page, got %d page(s)", pages)
+	}
+	for _, want := range []string{"(Dependency report) Tj", "(Top contributors) Tj", "(page 2 of ", "/Count "} {
+		if !strings.Contains(pdf, want) {
+			t.Errorf("PDF missing %q", want)
+		}
+	}
+}
+
+func TestFormatPDFTable(t *testing.T) {
+	rows := formatPDFTable([][]string{{"Module", "Version"}, {"example.com/a-long-module-path", "v1.0.0"}}, 30)
+	want := []string{"Module                 Version", "example.com/a-long...  v1.0.0"}
+	if strings.Join(rows, "\n") != strings.Join(want, "\n") {
+		t.Errorf("formatPDFTable() =\n%s\nwant\n%s", strings.Join(rows, "\n"), strings.Join(want, "\n"))
+	}
+	if got := wrapText("one two three four", 9); strings.Join(got, "|") != "one two|three|four" {
+		t.Errorf("wrapText() = %q", got)
+	}
+}
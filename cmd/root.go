@@ -19,6 +19,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -27,12 +28,55 @@ var DepstatVersion string
 var autoMainModules bool
 var autoMainModulesDepth int
 
+// depBackend selects how dependency data is loaded: "graph" uses only
+// "go mod graph", "golist" additionally attaches "go list -m -json all"
+// metadata to every module.
+var depBackend string
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
-	Use:     "depstat",
-	Short:   "Analyze your Go project's dependencies",
-	Long:    `depstat will help you get details about the dependencies of your Go modules enabled project`,
-	Version: DepstatVersion,
+	Use:   "depstat",
+	Short: "Analyze your Go project's dependencies",
+	Long:  `depstat will help you get details about the dependencies of your Go modules enabled project`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if jsonFlagSet(cmd) {
+			// the JSON error object replaces cobra's message and usage
+			jsonErrors = true
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+		}
+		if err := validateMainStrategy(); err != nil {
+			return withExitCode(ExitUsage, err)
+		}
+		if depBackend != "graph" && depBackend != "golist" {
+			return withExitCode(ExitUsage, fmt.Errorf("--backend must be one of: graph, golist"))
+		}
+		if graphJobs < 1 {
+			return withExitCode(ExitUsage, fmt.Errorf("--jobs must be >= 1"))
+		}
+		if testClassifier != "modwhy" && testClassifier != "packages" {
+			return withExitCode(ExitUsage, fmt.Errorf("--classifier must be one of: modwhy, packages"))
+		}
+		if err := setLogLevel(logLevelFlag, verbosity, quiet); err != nil {
+			return err
+		}
+		colorOutput = detectColor(os.Stdout)
+		if err := startProfiling(); err != nil {
+			return err
+		}
+		rules, err := parseEdgeExclusions(excludeEdges)
+		if err != nil {
+			return withExitCode(ExitUsage, err)
+		}
+		edgeExclusions = rules
+		if selectedOnly {
+			infof("graph view: MVS-selected versions only (--selected-only)\n")
+		}
+		if dryRun {
+			return startDryRun(cmd)
+		}
+		return startDigestCapture(cmd)
+	},
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
 	//	Run: func(cmd *cobra.Command, args []string) { },
@@ -41,13 +85,96 @@ var rootCmd = &cobra.Command{
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+	usageArgs(rootCmd)
+	cmd, err := rootCmd.ExecuteC()
+	if err != nil && exitCode(err) == ExitError && strings.HasPrefix(err.Error(), "unknown command ") {
+		err = withExitCode(ExitUsage, err)
+	}
+	if cmd != nil && jsonFlagSet(cmd) {
+		jsonErrors = true
+	}
+	finishDryRun()
+	// The digest also covers JSON printed by commands that fail with a
+	// violation exit code, such as check.
+	if digestErr := finishDigestCapture(); digestErr != nil {
+		if err == nil {
+			err = digestErr
+		} else {
+			fmt.Fprintln(os.Stderr, digestErr)
+		}
+	}
+	if profileErr := stopProfiling(); profileErr != nil {
+		if err == nil {
+			err = profileErr
+		} else {
+			fmt.Fprintln(os.Stderr, profileErr)
+		}
+	}
+	if err != nil {
+		reportError(err)
+		os.Exit(exitCode(err))
+	}
+}
+
+// usageArgs makes the positional argument checks of c and its subcommands
+// fail with ExitUsage, as flag errors do.
+func usageArgs(c *cobra.Command) {
+	if args := c.Args; args != nil {
+		c.Args = func(cmd *cobra.Command, a []string) error {
+			if err := args(cmd, a); err != nil {
+				return withExitCode(ExitUsage, err)
+			}
+			return nil
+		}
+	}
+	for _, sub := range c.Commands() {
+		usageArgs(sub)
 	}
 }
 
+// jsonFlagSet reports whether the command has a --json flag and it is set.
+func jsonFlagSet(cmd *cobra.Command) bool {
+	f := cmd.Flags().Lookup("json")
+	return f != nil && f.Value.String() == "true"
+}
+
 func init() {
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		if jsonFlagSet(cmd) {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+		}
+		return withExitCode(ExitUsage, err)
+	})
 	rootCmd.PersistentFlags().BoolVar(&autoMainModules, "auto-main-modules", true, "Auto-detect main modules from go.work or go.mod files")
+	rootCmd.PersistentFlags().StringVar(&mainStrategy, "main", "auto", "How main modules are found without --mainModules: auto (go.work, else go.mod files below --dir except tools, else go list -m), gomod (the go.mod in --dir), all-workspace (every go.work module) or list (only --mainModules)")
+	rootCmd.PersistentFlags().StringVar(&depBackend, "backend", "graph", "Dependency data backend: graph (go mod graph) or golist (also go list -m -json all for selected versions, replacements and indirect markers)")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored text output (also disabled by NO_COLOR and when stdout is not a terminal)")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Suppress progress and warnings on stderr; errors are still printed")
+	rootCmd.PersistentFlags().StringVar(&logLevelFlag, "log-level", "info", "Diagnostics printed to stderr: error, warn, info, debug or trace (trace also logs every go/git command run)")
+	rootCmd.PersistentFlags().CountVarP(&verbosity, "verbosity", "V", "Raise the log level: -V for debug, -VV for trace")
+	rootCmd.PersistentFlags().StringVar(&goFlagsOverride, "goflags", "", "GOFLAGS for the go commands depstat runs, e.g. -mod=mod (default: inherited)")
+	rootCmd.PersistentFlags().StringVar(&goOSOverride, "goos", "", "GOOS for the go commands depstat runs (default: inherited)")
+	rootCmd.PersistentFlags().StringVar(&goArchOverride, "goarch", "", "GOARCH for the go commands depstat runs (default: inherited)")
+	rootCmd.PersistentFlags().BoolVar(&offline, "offline", false, "Use only the module cache: run go with GOFLAGS=-mod=mod GOPROXY=off, serve --enrich from its cache and refuse features that need the network")
+	rootCmd.PersistentFlags().StringVar(&testClassifier, "classifier", "modwhy", "Test-only classification: modwhy (go mod why -m per module) or packages (go list -deps of non-test and test packages; faster, for the current GOOS/GOARCH and tags)")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "Do not read or write the test-only classification cache")
+	rootCmd.PersistentFlags().StringVar(&classifyCacheDir, "classify-cache-dir", "", "Directory for cached test-only classifications. Defaults to the user cache directory.")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Print the go commands the command runs, with their directory and environment, instead of its output")
+	rootCmd.PersistentFlags().StringVar(&goWorkOverride, "gowork", "", "GOWORK for the go commands depstat runs, e.g. off or a go.work path (default: inherited)")
+	rootCmd.PersistentFlags().BoolVar(&digestOutput, "digest", false, "With --json, print the SHA-256 digest of the canonicalized JSON result to stderr")
+	rootCmd.PersistentFlags().StringVar(&inTotoFile, "in-toto", "", "With --json, write an in-toto statement with the digest of the JSON result to this file")
+	rootCmd.PersistentFlags().BoolVar(&noGitMetadata, "no-git-metadata", false, "Do not record the git commit, branch and dirty state of --dir in JSON output and reports")
+	rootCmd.PersistentFlags().BoolVar(&selectedOnly, "selected-only", false, "Analyze only the versions MVS selects, one per module, and the requirements of those versions; results note the view as \"selected\" instead of the default \"requested\"")
+	rootCmd.PersistentFlags().StringArrayVar(&excludeEdges, "exclude-edges", nil, "Drop the requirement edges from=pattern,to=pattern (path.Match patterns, either side optional); modules stay while another path reaches them. Repeatable")
+	rootCmd.PersistentFlags().BoolVar(&explainExclusions, "explain-exclusions", false, "Report on stderr which modules and edges each --exclude-modules pattern and --exclude-edges rule removed, and those that matched nothing")
+	rootCmd.PersistentFlags().BoolVar(&normalizePaths, "normalize-paths", false, "Compare module paths case-insensitively on hosts such as github.com in filters, grouping and duplicate detection, and count distinct projects ignoring /vN suffixes in stats; output keeps the paths as required")
+	rootCmd.PersistentFlags().IntVar(&graphJobs, "jobs", 4, "Maximum number of go mod graph commands run concurrently when several graphs are loaded, as by stats --compare, pr-check, verify and multi")
+	rootCmd.PersistentFlags().StringVar(&cpuProfile, "cpuprofile", "", "Write a CPU profile to this file")
+	rootCmd.PersistentFlags().StringVar(&memProfile, "memprofile", "", "Write a heap profile to this file when the command finishes")
+	rootCmd.PersistentFlags().StringVar(&traceOutput, "trace", "", "Write an execution trace to this file")
+	for _, name := range []string{"cpuprofile", "memprofile", "trace"} {
+		_ = rootCmd.PersistentFlags().MarkHidden(name)
+	}
 	rootCmd.PersistentFlags().IntVar(&autoMainModulesDepth, "auto-main-modules-depth", 0, "Limit auto-detected modules to this directory depth (0 = no limit)")
 }
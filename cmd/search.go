@@ -0,0 +1,221 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var searchLimit int
+var searchClassify bool
+
+// SearchResult lists the modules of the graph matching a pattern.
+type SearchResult struct {
+	Pattern string        `json:"pattern"`
+	Mode    string        `json:"mode"` // "fuzzy" or "glob"
+	Matches []SearchMatch `json:"matches"`
+	// Total is the number of matches before --limit.
+	Total       int      `json:"total"`
+	MainModules []string `json:"mainModules"`
+}
+
+// SearchMatch is a module matching the search pattern.
+type SearchMatch struct {
+	Module     string `json:"module"`
+	Version    string `json:"version,omitempty"`
+	MainModule bool   `json:"mainModule,omitempty"`
+	Direct     bool   `json:"direct"`
+	Transitive bool   `json:"transitive"`
+	// TestOnly is nil when the matches were not classified.
+	TestOnly *bool `json:"testOnly,omitempty"`
+	// Depth is the number of hops from the nearest main module, -1 when
+	// no main module reaches the module.
+	Depth int `json:"depth"`
+}
+
+var searchCmd = &cobra.Command{
+	Use:   "search <pattern>",
+	Short: "Find modules in the dependency graph by fuzzy or glob pattern",
+	Long: `Searches the module paths of the dependency graph, as the step before
+running why on an exact path.
+
+A pattern containing *, ? or [ is a glob matched against the whole module
+path, as for --exclude-modules, e.g. "k8s.io/*" or "github.com/*/yaml".
+Any other pattern is a fuzzy search for its characters in order, e.g.
+"k8sapimach"; modules containing the pattern as a substring, then the
+tightest matches, come first.
+
+Every match is printed with its version, its depth, whether it is a direct
+or transitive dependency, and whether it is only needed by tests (classified
+with --classifier; pass --classify=false to skip). Exits with code 2 when
+nothing matches.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("search requires exactly one pattern")
+		}
+		if searchLimit < 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("--limit must be >= 0"))
+		}
+		depGraph := getDepInfo(mainModules)
+		if len(depGraph.MainModules) == 0 {
+			return errNoMainModules
+		}
+		result := searchModules(depGraph, args[0], searchLimit)
+		if searchClassify && len(result.Matches) > 0 {
+			var deps []string
+			for _, m := range result.Matches {
+				if !m.MainModule {
+					deps = append(deps, m.Module)
+				}
+			}
+			testOnlySet, err := classifyTestDeps(deps)
+			if err != nil {
+				return fmt.Errorf("failed to classify dependencies: %w", err)
+			}
+			for i, m := range result.Matches {
+				if !m.MainModule {
+					testOnly := testOnlySet[m.Module]
+					result.Matches[i].TestOnly = &testOnly
+				}
+			}
+		}
+
+		if jsonOutput {
+			if err := writeJSON(os.Stdout, result); err != nil {
+				return err
+			}
+		} else {
+			printSearchResult(result)
+		}
+		if result.Total == 0 {
+			cmd.SilenceUsage = true
+			return withExitCode(ExitNotFound, fmt.Errorf("no module matches %q", args[0]))
+		}
+		return nil
+	},
+}
+
+// isGlobPattern reports whether pattern uses path.Match syntax.
+func isGlobPattern(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+// searchModules returns the modules of the graph matching pattern, at most
+// limit of them when limit > 0. Glob matches are sorted by path, fuzzy
+// matches best first.
+func searchModules(depGraph *DependencyOverview, pattern string, limit int) SearchResult {
+	modules := graphNodes(depGraph.Graph)
+	for _, m := range depGraph.MainModules {
+		if !contains(modules, m) {
+			modules = append(modules, m)
+		}
+	}
+	result := SearchResult{Pattern: pattern, Mode: "fuzzy", Matches: []SearchMatch{}, MainModules: depGraph.MainModules}
+	var found []string
+	if isGlobPattern(pattern) {
+		result.Mode = "glob"
+		for _, mod := range modules {
+			if matchModulePattern(mod, pattern) {
+				found = append(found, mod)
+			}
+		}
+		sort.Strings(found)
+	} else {
+		found = fuzzySearch(pattern, modules)
+	}
+	result.Total = len(found)
+	if limit > 0 && len(found) > limit {
+		found = found[:limit]
+	}
+
+	depths := shortestDepthByModule(depGraph.MainModules, depGraph.Graph)
+	for _, mod := range found {
+		depth, ok := depths[mod]
+		if !ok {
+			depth = -1
+		}
+		result.Matches = append(result.Matches, SearchMatch{
+			Module:     mod,
+			Version:    depGraph.Versions[mod],
+			MainModule: contains(depGraph.MainModules, mod),
+			Direct:     contains(depGraph.DirectDepList, mod),
+			Transitive: contains(depGraph.TransDepList, mod),
+			Depth:      depth,
+		})
+	}
+	return result
+}
+
+// searchFlags describes a match in the FLAGS column.
+func searchFlags(m SearchMatch) string {
+	var flags []string
+	if m.MainModule {
+		flags = append(flags, "main")
+	}
+	if m.Direct {
+		flags = append(flags, "direct")
+	}
+	if m.Transitive {
+		flags = append(flags, "transitive")
+	}
+	if m.TestOnly != nil && *m.TestOnly {
+		flags = append(flags, "test-only")
+	}
+	if len(flags) == 0 {
+		return "-"
+	}
+	return strings.Join(flags, ",")
+}
+
+func printSearchResult(result SearchResult) {
+	if result.Total == 0 {
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MODULE\tVERSION\tDEPTH\tFLAGS\t")
+	for _, m := range result.Matches {
+		version := m.Version
+		if version == "" {
+			version = "-"
+		}
+		depth := "-"
+		if m.Depth >= 0 {
+			depth = fmt.Sprint(m.Depth)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t\n", m.Module, version, depth, searchFlags(m))
+	}
+	_ = w.Flush()
+	if len(result.Matches) < result.Total {
+		fmt.Printf("... %d more; raise --limit to see them\n", result.Total-len(result.Matches))
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+	searchCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
+	searchCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Get the output in JSON format")
+	searchCmd.Flags().IntVarP(&searchLimit, "limit", "n", 20, "Show at most this many matches (0 = all)")
+	searchCmd.Flags().BoolVar(&searchClassify, "classify", true, "Mark test-only matches, classified with --classifier")
+	searchCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
+	searchCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Specify main modules")
+}
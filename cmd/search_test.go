@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSearchModules(t *testing.T) {
+	depGraph := generateGraph(`main k8s.io/api@v0.30.0
+main github.com/grpc-ecosystem/go-grpc-middleware@v1.0.0
+k8s.io/api@v0.30.0 k8s.io/apimachinery@v0.30.0
+github.com/grpc-ecosystem/go-grpc-middleware@v1.0.0 google.golang.org/grpc@v1.60.0
+github.com/grpc-ecosystem/go-grpc-middleware@v1.0.0 github.com/gogo/protobuf@v1.3.2`, []string{"main"})
+
+	glob := searchModules(&depGraph, "k8s.io/*", 0)
+	if glob.Mode != "glob" || glob.Total != 2 {
+		t.Fatalf("glob search = %+v", glob)
+	}
+	want := SearchMatch{Module: "k8s.io/apimachinery", Version: "v0.30.0", Transitive: true, Depth: 2}
+	if !reflect.DeepEqual(glob.Matches[1], want) {
+		t.Errorf("match = %+v, want %+v", glob.Matches[1], want)
+	}
+
+	// a substring starting a path element beats scattered matches
+	fuzzy := searchModules(&depGraph, "grpc", 2)
+	var got []string
+	for _, m := range fuzzy.Matches {
+		got = append(got, m.Module)
+	}
+	if want := []string{"google.golang.org/grpc", "github.com/grpc-ecosystem/go-grpc-middleware"}; fuzzy.Mode != "fuzzy" || !reflect.DeepEqual(got, want) {
+		t.Errorf("fuzzy search = %v (%s), want %v", got, fuzzy.Mode, want)
+	}
+	if fuzzy.Total != 2 {
+		t.Errorf("total = %d, want 2", fuzzy.Total)
+	}
+
+	if none := searchModules(&depGraph, "zzz", 0); none.Total != 0 || len(none.Matches) != 0 {
+		t.Errorf("expected no matches, got %+v", none)
+	}
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var skewAll bool
+
+// RequestSkew compares the highest version of a module requested by any
+// edge of the graph with the version actually used.
+type RequestSkew struct {
+	Module       string `json:"module"`
+	MaxRequested string `json:"maxRequested"`
+	Selected     string `json:"selected"`
+	// Replacement is the version a replace directive substitutes for the
+	// selected one, when it keeps the module path.
+	Replacement string `json:"replacement,omitempty"`
+	// NewerRequested is set when some module requests a version newer than
+	// the one used, which MVS alone never does: a replace or exclude
+	// directive is pinning the module.
+	NewerRequested bool `json:"newerRequested"`
+	// Requests lists the edges requesting a newer version.
+	Requests []SkewRequest `json:"requests,omitempty"`
+}
+
+// SkewRequest is an edge requesting a newer version than the one used, with
+// the shortest path from a main module to the requesting module.
+type SkewRequest struct {
+	From    string   `json:"from"`
+	Version string   `json:"version"`
+	Path    []string `json:"path"`
+}
+
+var skewCmd = &cobra.Command{
+	Use:   "skew",
+	Short: "Compare the versions modules request with the versions selected",
+	Long: `For every module, compares the highest version requested by an edge of
+the module graph with the version selected by MVS (go list -m all), or the
+version a replace directive substitutes for it.
+
+MVS never selects a version older than one requested, so a module where some
+dependency requests a newer version than the one used is pinned by a
+replace or exclude directive: that dependency is built against code older
+than it asked for. Those modules are listed with the paths to the modules
+requesting the newer versions. Use --all to list every module.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("skew does not take any arguments"))
+		}
+		depGraph := getDepInfo(mainModules)
+		if len(depGraph.MainModules) == 0 {
+			return errNoMainModules
+		}
+		if depGraph.Modules == nil {
+			modules, err := listAllModules(nil)
+			if err != nil {
+				return fmt.Errorf("go list -m -json all: %w", err)
+			}
+			attachModuleMetadata(depGraph, modules)
+		}
+		skews := findRequestSkew(depGraph)
+		if !skewAll {
+			var pinned []RequestSkew
+			for _, s := range skews {
+				if s.NewerRequested {
+					pinned = append(pinned, s)
+				}
+			}
+			skews = pinned
+		}
+		if skews == nil {
+			skews = []RequestSkew{}
+		}
+		if jsonOutput {
+			return writeJSON(os.Stdout, skews)
+		}
+		printRequestSkew(skews, depGraph.MainModules)
+		return nil
+	},
+}
+
+// findRequestSkew compares, for every dependency, the highest requested
+// version with the selected one. depGraph.Modules must hold the "go list"
+// metadata, so that Versions are the selected versions.
+func findRequestSkew(depGraph *DependencyOverview) []RequestSkew {
+	var skews []RequestSkew
+	for _, dep := range uniqueStrings(getAllDeps(depGraph.DirectDepList, depGraph.TransDepList)) {
+		if contains(depGraph.MainModules, dep) {
+			continue
+		}
+		s := RequestSkew{Module: dep, Selected: depGraph.Versions[dep]}
+		used := s.Selected
+		if path, version, ok := strings.Cut(depGraph.Modules[dep].Replace, "@"); ok && path == dep {
+			s.Replacement = version
+			used = version
+		}
+		for _, r := range depGraph.Requirements[dep] {
+			if versionGreater(r.Version, s.MaxRequested) {
+				s.MaxRequested = r.Version
+			}
+			if versionGreater(r.Version, used) {
+				s.Requests = append(s.Requests, SkewRequest{
+					From:    r.From,
+					Version: r.Version,
+					Path:    shortestPath(depGraph.MainModules, r.From, depGraph.Graph),
+				})
+			}
+		}
+		s.NewerRequested = len(s.Requests) > 0
+		sort.Slice(s.Requests, func(i, j int) bool {
+			if s.Requests[i].Version != s.Requests[j].Version {
+				return versionGreater(s.Requests[i].Version, s.Requests[j].Version)
+			}
+			return s.Requests[i].From < s.Requests[j].From
+		})
+		skews = append(skews, s)
+	}
+	return skews
+}
+
+// ReplaceDowngrade is a module a replace directive pins below the version
+// other dependencies request.
+type ReplaceDowngrade struct {
+	Module string `json:"module"`
+	// Replacement is the replace target: module@version or a directory.
+	Replacement string `json:"replacement"`
+	// Pinned is the version used: the replacement version, or for a
+	// directory the version the main modules require.
+	Pinned       string        `json:"pinned"`
+	MaxRequested string        `json:"maxRequested"`
+	Delta        string        `json:"delta"`
+	RequestedBy  []SkewRequest `json:"requestedBy"`
+}
+
+// findReplaceDowngrades lists the replaced modules requested by some
+// dependency at a newer version than the replacement provides. Like
+// findRequestSkew it needs the "go list" metadata in depGraph.Modules.
+func findReplaceDowngrades(depGraph *DependencyOverview) []ReplaceDowngrade {
+	downgrades := []ReplaceDowngrade{}
+	for _, dep := range uniqueStrings(getAllDeps(depGraph.DirectDepList, depGraph.TransDepList)) {
+		replacement := depGraph.Modules[dep].Replace
+		if replacement == "" || contains(depGraph.MainModules, dep) {
+			continue
+		}
+		d := ReplaceDowngrade{Module: dep, Replacement: replacement}
+		if path, version, ok := strings.Cut(replacement, "@"); ok {
+			if path != dep {
+				continue // a fork's versions do not compare with the original's
+			}
+			d.Pinned = version
+		} else {
+			// a directory, such as a Kubernetes staging module: it stands
+			// for the version the main modules require
+			for _, r := range depGraph.Requirements[dep] {
+				if contains(depGraph.MainModules, r.From) && versionGreater(r.Version, d.Pinned) {
+					d.Pinned = r.Version
+				}
+			}
+			if d.Pinned == "" {
+				continue
+			}
+		}
+		for _, r := range depGraph.Requirements[dep] {
+			if contains(depGraph.MainModules, r.From) || !versionGreater(r.Version, d.Pinned) {
+				continue
+			}
+			if versionGreater(r.Version, d.MaxRequested) {
+				d.MaxRequested = r.Version
+			}
+			d.RequestedBy = append(d.RequestedBy, SkewRequest{
+				From:    r.From,
+				Version: r.Version,
+				Path:    shortestPath(depGraph.MainModules, r.From, depGraph.Graph),
+			})
+		}
+		if len(d.RequestedBy) == 0 {
+			continue
+		}
+		sort.Slice(d.RequestedBy, func(i, j int) bool { return d.RequestedBy[i].From < d.RequestedBy[j].From })
+		d.Delta = versionDelta(d.Pinned, d.MaxRequested)
+		downgrades = append(downgrades, d)
+	}
+	return downgrades
+}
+
+// versionDelta describes how far newer is than older in its most
+// significant differing semver component, e.g. "+2 minor".
+func versionDelta(older, newer string) string {
+	a, oka := parseSemverLike(older)
+	b, okb := parseSemverLike(newer)
+	if !oka || !okb {
+		return ""
+	}
+	for i, name := range []string{"major", "minor", "patch"} {
+		if a[i] != b[i] {
+			return fmt.Sprintf("%+d %s", b[i]-a[i], name)
+		}
+	}
+	return "pre-release"
+}
+
+func printReplaceDowngrades(downgrades []ReplaceDowngrade) {
+	for _, d := range downgrades {
+		fmt.Printf("  %s pinned to %s by replace %s, requested up to %s (%s)\n", d.Module, d.Pinned, d.Replacement, d.MaxRequested, d.Delta)
+		for _, r := range d.RequestedBy {
+			fmt.Printf("    %s requests %s\n", r.From, r.Version)
+		}
+	}
+}
+
+func printRequestSkew(skews []RequestSkew, mainModules []string) {
+	if len(skews) == 0 {
+		fmt.Println("No module is requested at a newer version than the one used.")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MODULE\tMAX REQUESTED\tSELECTED\tREPLACEMENT\t")
+	for _, s := range skews {
+		replacement := s.Replacement
+		if replacement == "" {
+			replacement = "-"
+		}
+		module := s.Module
+		if s.NewerRequested {
+			module = colorize(ansiYellow, module+" !")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t\n", module, s.MaxRequested, s.Selected, replacement)
+	}
+	_ = w.Flush()
+	for _, s := range skews {
+		if !s.NewerRequested {
+			continue
+		}
+		used := s.Selected
+		if s.Replacement != "" {
+			used = s.Replacement
+		}
+		fmt.Printf("\n%s is used at %s but requested newer by:\n", s.Module, used)
+		for _, r := range s.Requests {
+			fmt.Printf("  %s %s\n    path: %s\n", r.From, r.Version, colorPath(append(append([]string{}, r.Path...), s.Module), mainModules, s.Module))
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(skewCmd)
+	skewCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
+	skewCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Get the output in JSON format")
+	skewCmd.Flags().BoolVar(&skewAll, "all", false, "List every module, not only those requested at a newer version than the one used")
+	skewCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
+	skewCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Specify main modules")
+}
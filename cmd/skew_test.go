@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindRequestSkew(t *testing.T) {
+	depGraph := generateGraph(`main A@v1.0.0
+main B@v1.0.0
+main C@v1.3.0
+B@v1.0.0 A@v1.2.0
+B@v1.0.0 C@v1.1.0`, []string{"main"})
+	// go list reports the selected versions and a replace pinning A back
+	depGraph.Versions["A"] = "v1.2.0"
+	depGraph.Modules = map[string]ModuleInfo{
+		"A": {Version: "v1.2.0", Replace: "A@v1.0.0"},
+		"B": {Version: "v1.0.0"},
+		"C": {Version: "v1.3.0"},
+	}
+
+	got := findRequestSkew(&depGraph)
+	want := []RequestSkew{
+		{
+			Module: "A", MaxRequested: "v1.2.0", Selected: "v1.2.0", Replacement: "v1.0.0", NewerRequested: true,
+			Requests: []SkewRequest{{From: "B", Version: "v1.2.0", Path: []string{"main", "B"}}},
+		},
+		{Module: "B", MaxRequested: "v1.0.0", Selected: "v1.0.0"},
+		{Module: "C", MaxRequested: "v1.3.0", Selected: "v1.3.0"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findRequestSkew() = %+v\nwant %+v", got, want)
+	}
+}
+
+func TestFindReplaceDowngrades(t *testing.T) {
+	depGraph := generateGraph(`main k8s.io/api@v0.0.0
+main A@v1.0.0
+main B@v1.0.0
+main F@v1.0.0
+B@v1.0.0 k8s.io/api@v0.30.1
+B@v1.0.0 A@v1.4.0
+B@v1.0.0 F@v1.2.0`, []string{"main"})
+	depGraph.Modules = map[string]ModuleInfo{
+		"k8s.io/api": {Version: "v0.30.1", Replace: "./staging/src/k8s.io/api"},
+		"A":          {Version: "v1.4.0", Replace: "A@v1.1.0"},
+		"B":          {Version: "v1.0.0"},
+		"F":          {Version: "v1.2.0", Replace: "example.com/fork@v1.0.0"},
+	}
+
+	got := findReplaceDowngrades(&depGraph)
+	want := []ReplaceDowngrade{
+		{
+			Module: "A", Replacement: "A@v1.1.0", Pinned: "v1.1.0", MaxRequested: "v1.4.0", Delta: "+3 minor",
+			RequestedBy: []SkewRequest{{From: "B", Version: "v1.4.0", Path: []string{"main", "B"}}},
+		},
+		{
+			Module: "k8s.io/api", Replacement: "./staging/src/k8s.io/api", Pinned: "v0.0.0", MaxRequested: "v0.30.1", Delta: "+30 minor",
+			RequestedBy: []SkewRequest{{From: "B", Version: "v0.30.1", Path: []string{"main", "B"}}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findReplaceDowngrades() = %+v\nwant %+v", got, want)
+	}
+}
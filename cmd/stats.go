@@ -17,9 +17,14 @@ limitations under the License.
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -36,6 +41,27 @@ var compareSetA string
 var compareSetB string
 var compareMainModulesA []string
 var compareMainModulesB []string
+var statsHistogram bool
+var statsChains int
+var statsByOrg bool
+var statsDuplicateMajors bool
+var statsReplaceDowngrades bool
+var statsAppendFile string
+var compareDirA string
+var compareDirB string
+var compareGraphFileA string
+var statsGraphFile string
+var compareGraphFileB string
+var compareRef string
+
+// orgNamespacedHosts are hosts whose first path element is an owner, so the
+// organization is host/owner rather than the host alone.
+var orgNamespacedHosts = map[string]bool{
+	"github.com":    true,
+	"gitlab.com":    true,
+	"bitbucket.org": true,
+	"golang.org":    true,
+}
 
 type Chain []string
 
@@ -47,19 +73,78 @@ var statsCmd = &cobra.Command{
 	1. Direct Dependencies: Total number of dependencies required by the mainModule(s) directly
 	2. Transitive Dependencies: Total number of transitive dependencies (dependencies which are further needed by direct dependencies of the project)
 	3. Total Dependencies: Total number of dependencies of the mainModule(s)
-	4. Max Depth of Dependencies: Length of the longest chain starting from the first mainModule; defaults to length from the first module encountered in "go mod graph" output`,
+	4. Max Depth of Dependencies: Length of the longest chain starting from any of the mainModule(s); with several, the JSON output also holds the value for each`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) != 0 {
-			return fmt.Errorf("stats does not take any arguments")
+			return withExitCode(ExitUsage, fmt.Errorf("stats does not take any arguments"))
 		}
-		if statsCompare {
-			return runStatsCompare(cmd)
+		if statsChains < 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("--chains must be >= 0"))
 		}
-		result, err := computeStatsSnapshot(mainModules, excludeModules, splitTestOnly)
-		if err != nil {
+		if err := validateToolsScope(toolsScope); err != nil {
+			return err
+		}
+		if statsChainWeight != "" && statsChainWeight != "packages" && statsChainWeight != "loc" {
+			return withExitCode(ExitUsage, fmt.Errorf("--chain-weight must be one of: packages, loc"))
+		}
+		if statsChainWeight != "" && (statsCompare || compareRef != "") {
+			return withExitCode(ExitUsage, fmt.Errorf("--chain-weight is not supported with --compare"))
+		}
+		if statsAppendFile != "" && !csvOutput {
+			return withExitCode(ExitUsage, fmt.Errorf("--append requires --csv"))
+		}
+		if statsAppendFile != "" && (statsCompare || compareRef != "" || statsDiscover) {
+			return withExitCode(ExitUsage, fmt.Errorf("--append cannot be combined with --compare or --discover"))
+		}
+		if statsReplaceDowngrades && (statsCompare || compareRef != "") {
+			return withExitCode(ExitUsage, fmt.Errorf("--replace-downgrades is not supported with --compare"))
+		}
+		if len(enrichSources) > 0 && !statsCompare && compareRef == "" {
+			return withExitCode(ExitUsage, fmt.Errorf("--enrich requires --compare or --compare-ref"))
+		}
+		if err := validateEnrichSources(enrichSources); err != nil {
 			return err
 		}
-		return renderStatsSnapshot(result, mainModules, excludeModules)
+		if !statsCompare && (compareDirA != "" || compareDirB != "" || compareGraphFileA != "" || compareGraphFileB != "") {
+			return withExitCode(ExitUsage, fmt.Errorf("--dir-a, --dir-b, --graph-file-a and --graph-file-b require --compare"))
+		}
+		if (dotOutput || svgOutput) && !statsCompare && compareRef == "" {
+			return withExitCode(ExitUsage, fmt.Errorf("--dot and --svg require --compare or --compare-ref"))
+		}
+		if dotOutput && svgOutput {
+			return withExitCode(ExitUsage, fmt.Errorf("--dot and --svg are mutually exclusive"))
+		}
+		if watchMode && (statsCompare || compareRef != "" || statsDiscover) {
+			return withExitCode(ExitUsage, fmt.Errorf("--watch cannot be combined with --compare or --discover"))
+		}
+		if statsGraphFile != "" && (statsCompare || compareRef != "" || statsDiscover || watchMode || splitTestOnly || statsReplaceDowngrades) {
+			return withExitCode(ExitUsage, fmt.Errorf("--graph-file cannot be combined with --compare, --discover, --watch, --split-test-only or --replace-downgrades; use --graph-file-a and --graph-file-b to compare captured graphs"))
+		}
+		if statsCompare || compareRef != "" {
+			return runStatsCompare(cmd)
+		}
+		if statsDiscover {
+			if len(mainModules) > 0 || splitTestOnly {
+				return withExitCode(ExitUsage, fmt.Errorf("--discover cannot be combined with --mainModules or --split-test-only"))
+			}
+			result, err := computeDiscoverStats(excludeModules)
+			if err != nil {
+				return err
+			}
+			return renderDiscoverStats(result)
+		}
+		mods, excludes := mainModules, excludeModules
+		run := func() error {
+			result, err := computeStatsSnapshot(mods, excludes, splitTestOnly)
+			if err != nil {
+				return err
+			}
+			return renderStatsSnapshot(result)
+		}
+		if watchMode {
+			return watchAndRun(run)
+		}
+		return run()
 	},
 }
 
@@ -72,44 +157,130 @@ type StatsSnapshot struct {
 	NonTestOnly   *int     `json:"nonTestOnlyDependencies,omitempty"`
 	MainModules   []string `json:"mainModules,omitempty"`
 	ExcludeValues []string `json:"excludeModules,omitempty"`
+
+	// MaxDepthByModule is the length of the longest chain from each main
+	// module, when there are several; MaxDepth is the largest.
+	MaxDepthByModule map[string]int `json:"maxDepthByMainModule,omitempty"`
+	// DistinctProjects counts dependencies by normalized path without
+	// major version suffix; set with --normalize-paths.
+	DistinctProjects *int `json:"distinctProjects,omitempty"`
+
+	Edges          *EdgeStats      `json:"edges,omitempty"`
+	Concentration  *Concentration  `json:"concentration,omitempty"`
+	DepthHistogram *DepthHistogram `json:"depthHistogram,omitempty"`
+	LongestChains  []Chain         `json:"longestChains,omitempty"`
+	HeaviestChain  *WeightedChain  `json:"heaviestChain,omitempty"`
+	ByOrg          []OrgCount      `json:"byOrg,omitempty"`
+	ByOwner        []OwnerCount    `json:"byOwner,omitempty"`
+
+	DuplicateMajors   []MajorVersionDuplicate `json:"duplicateMajors,omitempty"`
+	ReplaceDowngrades []ReplaceDowngrade      `json:"replaceDowngrades,omitempty"`
+
+	// graph is the dependency graph the snapshot was computed from.
+	graph *DependencyOverview
+}
+
+// OrgCount is the number of dependencies under one organization prefix.
+type OrgCount struct {
+	Org     string   `json:"org"`
+	Count   int      `json:"count"`
+	Modules []string `json:"modules"`
+}
+
+// DepthHistogram is the distribution of shortest-path depths from the main
+// modules to every dependency.
+type DepthHistogram struct {
+	Buckets []DepthBucket `json:"buckets"`
+	P50     int           `json:"p50"`
+	P90     int           `json:"p90"`
+	Max     int           `json:"max"`
+}
+
+// EdgeStats describes the requirement edges of the graph: how many there
+// are, how they spread over the modules and how close the graph is to
+// complete (Density is edges over the n*(n-1) possible ones).
+type EdgeStats struct {
+	TotalEdges         int     `json:"totalEdges"`
+	AverageOutDegree   float64 `json:"averageOutDegree"`
+	MaxOutDegree       int     `json:"maxOutDegree"`
+	MaxOutDegreeModule string  `json:"maxOutDegreeModule,omitempty"`
+	Density            float64 `json:"density"`
+}
+
+type DepthBucket struct {
+	Depth int `json:"depth"`
+	Count int `json:"count"`
 }
 
 type StatsCompareResult struct {
-	SetA    string        `json:"setA"`
-	SetB    string        `json:"setB"`
-	Before  StatsSnapshot `json:"before"`
-	After   StatsSnapshot `json:"after"`
-	Delta   StatsSnapshot `json:"delta"`
-	OnlyInB []string      `json:"onlyInB"`
+	SetA           string          `json:"setA"`
+	SetB           string          `json:"setB"`
+	Before         StatsSnapshot   `json:"before"`
+	After          StatsSnapshot   `json:"after"`
+	Delta          StatsSnapshot   `json:"delta"`
+	OnlyInA        []string        `json:"onlyInA"`
+	OnlyInB        []string        `json:"onlyInB"`
+	VersionChanges []VersionChange `json:"versionChanges"`
+	LicenseChanges []LicenseChange `json:"licenseChanges,omitempty"`
 }
 
 func computeStatsSnapshot(mods []string, excludes []string, includeSplit bool) (*StatsSnapshot, error) {
+	return computeStatsSnapshotFrom(graphSource{GraphFile: statsGraphFile}, mods, excludes, includeSplit)
+}
+
+func computeStatsSnapshotFrom(src graphSource, mods []string, excludes []string, includeSplit bool) (*StatsSnapshot, error) {
 	excludeModules = excludes
 	defer func() {
 		excludeModules = nil
 	}()
-	depGraph := getDepInfo(mods)
-	if len(depGraph.MainModules) == 0 {
-		return nil, fmt.Errorf("no main modules remain after exclusions; adjust --exclude-modules or --mainModules")
+	depGraph, err := src.load(mods)
+	if err != nil {
+		return nil, err
 	}
-	var longestChain Chain
-	if len(depGraph.MainModules) > 0 {
-		var temp Chain
-		longestChain = getLongestChain(depGraph.MainModules[0], depGraph.Graph, temp, map[string]Chain{})
+	if len(depGraph.MainModules) == 0 {
+		return nil, errNoMainModules
 	}
-	maxDepth := len(longestChain)
-	directDeps := len(depGraph.DirectDepList)
-	transitiveDeps := len(depGraph.TransDepList)
+	result := snapshotFromGraph(depGraph)
+	result.ExcludeValues = excludes
+	result.graph = depGraph
+	result.Edges = computeEdgeStats(depGraph)
+	result.Concentration = computeConcentration(depGraph, concentrationTopN)
 	allDeps := getAllDeps(depGraph.DirectDepList, depGraph.TransDepList)
-	totalDeps := len(allDeps)
-
-	result := &StatsSnapshot{
-		DirectDeps:    directDeps,
-		TransDeps:     transitiveDeps,
-		TotalDeps:     totalDeps,
-		MaxDepth:      maxDepth,
-		MainModules:   depGraph.MainModules,
-		ExcludeValues: excludes,
+	if statsHistogram {
+		result.DepthHistogram = computeDepthHistogram(depGraph)
+	}
+	if statsChains > 0 {
+		result.LongestChains = topLongestChains(depGraph, statsChains)
+	}
+	if statsChainWeight != "" {
+		result.HeaviestChain, err = computeHeaviestChain(depGraph, statsChainWeight)
+		if err != nil {
+			return nil, fmt.Errorf("measuring modules: %w", err)
+		}
+	}
+	if normalizePaths {
+		projects := countProjects(allDeps)
+		result.DistinctProjects = &projects
+	}
+	if statsByOrg {
+		result.ByOrg = countByOrg(allDeps)
+	}
+	if statsDuplicateMajors {
+		result.DuplicateMajors = findDuplicateMajors(depGraph)
+	}
+	if statsReplaceDowngrades {
+		if depGraph.Modules == nil {
+			modules, err := listAllModules(nil)
+			if err != nil {
+				return nil, fmt.Errorf("go list -m -json all: %w", err)
+			}
+			attachModuleMetadata(depGraph, modules)
+		}
+		result.ReplaceDowngrades = findReplaceDowngrades(depGraph)
+	}
+	result.ByOwner, err = ownerCountsFromFile(depGraph)
+	if err != nil {
+		return nil, err
 	}
 
 	if includeSplit {
@@ -126,25 +297,91 @@ func computeStatsSnapshot(mods []string, excludes []string, includeSplit bool) (
 	return result, nil
 }
 
-func renderStatsSnapshot(result *StatsSnapshot, mods []string, excludes []string) error {
+// snapshotFromGraph computes the basic stats counters for an already loaded graph.
+func snapshotFromGraph(depGraph *DependencyOverview) *StatsSnapshot {
+	snapshot := &StatsSnapshot{
+		DirectDeps:  len(depGraph.DirectDepList),
+		TransDeps:   len(depGraph.TransDepList),
+		TotalDeps:   len(getAllDeps(depGraph.DirectDepList, depGraph.TransDepList)),
+		MaxDepth:    len(longestChainFromMains(depGraph)),
+		MainModules: depGraph.MainModules,
+	}
+	if len(depGraph.MainModules) > 1 {
+		snapshot.MaxDepthByModule = make(map[string]int)
+		for mod, chain := range longestChainsByMainModule(depGraph) {
+			snapshot.MaxDepthByModule[mod] = len(chain)
+		}
+	}
+	return snapshot
+}
+
+func renderStatsSnapshot(result *StatsSnapshot) error {
 	if !jsonOutput && !csvOutput {
 		fmt.Printf("Direct Dependencies: %d \n", result.DirectDeps)
 		fmt.Printf("Transitive Dependencies: %d \n", result.TransDeps)
 		fmt.Printf("Total Dependencies: %d \n", result.TotalDeps)
 		fmt.Printf("Max Depth Of Dependencies: %d \n", result.MaxDepth)
+		for _, mod := range result.MainModules {
+			if depth, ok := result.MaxDepthByModule[mod]; ok {
+				fmt.Printf("  %s: %d\n", mod, depth)
+			}
+		}
 		if result.TestOnlyDeps != nil && result.NonTestOnly != nil {
 			fmt.Printf("Test-only Dependencies: %d \n", *result.TestOnlyDeps)
 			fmt.Printf("Non-test Dependencies: %d \n", *result.NonTestOnly)
 		}
+		if result.DistinctProjects != nil {
+			fmt.Printf("Distinct Projects: %d \n", *result.DistinctProjects)
+		}
+		if result.Concentration != nil {
+			fmt.Printf("Concentration: %s\n", result.Concentration)
+		}
+		if result.DepthHistogram != nil {
+			printDepthHistogram(result.DepthHistogram)
+		}
+		if len(result.LongestChains) > 0 {
+			fmt.Printf("Longest Chains (top %d):\n", len(result.LongestChains))
+			for i, chain := range result.LongestChains {
+				fmt.Printf("  %d. [%d] %s\n", i+1, len(chain), strings.Join(chain, " -> "))
+			}
+		}
+		if hc := result.HeaviestChain; hc != nil {
+			fmt.Printf("Heaviest Chain (by %s, total %d):\n", hc.Weight, hc.Total)
+			for _, n := range hc.Modules {
+				fmt.Printf("  %s (%d)\n", n.Module, n.Weight)
+			}
+			if hc.Unmeasured > 0 {
+				fmt.Printf("  %d modules not in the module cache were counted as 0\n", hc.Unmeasured)
+			}
+		}
+		if len(result.ByOrg) > 0 {
+			fmt.Println("Dependencies By Organization:")
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			for _, o := range result.ByOrg {
+				fmt.Fprintf(w, "  %s\t%d\n", o.Org, o.Count)
+			}
+			_ = w.Flush()
+		}
+		if len(result.ByOwner) > 0 {
+			fmt.Println("Dependencies By Owner:")
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			for _, o := range result.ByOwner {
+				fmt.Fprintf(w, "  %s\t%d\n", o.Owner, o.Count)
+			}
+			_ = w.Flush()
+		}
+		if statsDuplicateMajors {
+			fmt.Printf("Modules With Multiple Major Versions: %d \n", len(result.DuplicateMajors))
+			printDuplicateMajors(result.DuplicateMajors)
+		}
+		if statsReplaceDowngrades {
+			fmt.Printf("Modules Downgraded By Replace: %d \n", len(result.ReplaceDowngrades))
+			printReplaceDowngrades(result.ReplaceDowngrades)
+		}
 	}
 	if verbose {
 		fmt.Println("All dependencies:")
-		excludeModules = excludes
-		defer func() {
-			excludeModules = nil
-		}()
-		depGraph := getDepInfo(mods)
-		printDeps(getAllDeps(depGraph.DirectDepList, depGraph.TransDepList))
+		printDeps(getAllDeps(result.graph.DirectDepList, result.graph.TransDepList))
 	}
 	if jsonOutput {
 		outputObj := struct {
@@ -154,35 +391,143 @@ func renderStatsSnapshot(result *StatsSnapshot, mods []string, excludes []string
 			MaxDepth     int  `json:"maxDepthOfDependencies"`
 			TestOnlyDeps *int `json:"testOnlyDependencies,omitempty"`
 			NonTestOnly  *int `json:"nonTestOnlyDependencies,omitempty"`
+
+			MaxDepthByModule map[string]int `json:"maxDepthByMainModule,omitempty"`
+			DistinctProjects *int           `json:"distinctProjects,omitempty"`
+
+			Edges          *EdgeStats      `json:"edges,omitempty"`
+			Concentration  *Concentration  `json:"concentration,omitempty"`
+			DepthHistogram *DepthHistogram `json:"depthHistogram,omitempty"`
+			LongestChains  []Chain         `json:"longestChains,omitempty"`
+			HeaviestChain  *WeightedChain  `json:"heaviestChain,omitempty"`
+			ByOrg          []OrgCount      `json:"byOrg,omitempty"`
+			ByOwner        []OwnerCount    `json:"byOwner,omitempty"`
+
+			DuplicateMajors   []MajorVersionDuplicate `json:"duplicateMajors,omitempty"`
+			ReplaceDowngrades []ReplaceDowngrade      `json:"replaceDowngrades,omitempty"`
+			View              string                  `json:"view"`
+			MainStrategy      string                  `json:"mainStrategy"`
+			GoEnv             *GoEnvironment          `json:"goEnv,omitempty"`
+			Git               *GitMetadata            `json:"git,omitempty"`
 		}{
-			DirectDeps:   result.DirectDeps,
-			TransDeps:    result.TransDeps,
-			TotalDeps:    result.TotalDeps,
-			MaxDepth:     result.MaxDepth,
-			TestOnlyDeps: result.TestOnlyDeps,
-			NonTestOnly:  result.NonTestOnly,
-		}
-		outputRaw, err := json.MarshalIndent(outputObj, "", "\t")
-		if err != nil {
+			DirectDeps:     result.DirectDeps,
+			TransDeps:      result.TransDeps,
+			TotalDeps:      result.TotalDeps,
+			MaxDepth:       result.MaxDepth,
+			TestOnlyDeps:   result.TestOnlyDeps,
+			NonTestOnly:    result.NonTestOnly,
+			Edges:          result.Edges,
+			Concentration:  result.Concentration,
+			DepthHistogram: result.DepthHistogram,
+			LongestChains:  result.LongestChains,
+			HeaviestChain:  result.HeaviestChain,
+			ByOrg:          result.ByOrg,
+			ByOwner:        result.ByOwner,
+
+			MaxDepthByModule:  result.MaxDepthByModule,
+			DistinctProjects:  result.DistinctProjects,
+			DuplicateMajors:   result.DuplicateMajors,
+			ReplaceDowngrades: result.ReplaceDowngrades,
+			View:              graphView(),
+			MainStrategy:      mainModuleStrategy(),
+			GoEnv:             goEnvironmentForOutput(),
+			Git:               gitMetadataForOutput(),
+		}
+		if err := writeJSON(os.Stdout, outputObj); err != nil {
 			return err
 		}
-		fmt.Print(string(outputRaw))
+	}
+	if csvOutput && statsAppendFile != "" {
+		return appendStatsCSV(statsAppendFile, result, time.Now(), gitHeadCommit())
 	}
 	if csvOutput {
+		header := "Direct,Transitive,Total,MaxDepth"
+		row := fmt.Sprintf("%d,%d,%d,%d", result.DirectDeps, result.TransDeps, result.TotalDeps, result.MaxDepth)
 		if result.TestOnlyDeps != nil && result.NonTestOnly != nil {
-			fmt.Println("Direct,Transitive,Total,MaxDepth,TestOnly,NonTestOnly")
-			fmt.Printf("%d,%d,%d,%d,%d,%d\n", result.DirectDeps, result.TransDeps, result.TotalDeps, result.MaxDepth, *result.TestOnlyDeps, *result.NonTestOnly)
-		} else {
-			fmt.Println("Direct,Transitive,Total,MaxDepth")
-			fmt.Printf("%d,%d,%d,%d\n", result.DirectDeps, result.TransDeps, result.TotalDeps, result.MaxDepth)
+			header += ",TestOnly,NonTestOnly"
+			row += fmt.Sprintf(",%d,%d", *result.TestOnlyDeps, *result.NonTestOnly)
+		}
+		if result.DistinctProjects != nil {
+			header += ",DistinctProjects"
+			row += fmt.Sprintf(",%d", *result.DistinctProjects)
+		}
+		if e := result.Edges; e != nil {
+			header += ",Edges,AvgOutDegree,MaxOutDegree,MaxOutDegreeModule,Density"
+			row += fmt.Sprintf(",%d,%s,%d,%s,%s", e.TotalEdges, formatRatio(e.AverageOutDegree), e.MaxOutDegree, e.MaxOutDegreeModule, formatRatio(e.Density))
+		}
+		if c := result.Concentration; c != nil {
+			header += ",Gini,TopShare"
+			row += fmt.Sprintf(",%s,%s", formatRatio(c.Gini), formatRatio(c.TopShare))
+		}
+		fmt.Println(header)
+		fmt.Println(row)
+		if len(result.ByOrg) > 0 {
+			fmt.Println()
+			fmt.Println("Org,Count")
+			for _, o := range result.ByOrg {
+				fmt.Printf("%s,%d\n", o.Org, o.Count)
+			}
+		}
+		if len(result.ByOwner) > 0 {
+			fmt.Println()
+			fmt.Println("Owner,Count")
+			for _, o := range result.ByOwner {
+				fmt.Printf("%s,%d\n", o.Owner, o.Count)
+			}
 		}
 	}
 	return nil
 }
 
+// appendStatsCSV appends one row with the timestamp, commit and counters of
+// result to file, writing the header first when the file is new or empty.
+// An existing header must match, so a dataset keeps a single set of columns.
+func appendStatsCSV(file string, result *StatsSnapshot, now time.Time, commit string) error {
+	header := "Timestamp,Commit,Direct,Transitive,Total,MaxDepth"
+	row := fmt.Sprintf("%s,%s,%d,%d,%d,%d", now.UTC().Format(time.RFC3339), commit, result.DirectDeps, result.TransDeps, result.TotalDeps, result.MaxDepth)
+	if result.TestOnlyDeps != nil && result.NonTestOnly != nil {
+		header += ",TestOnly,NonTestOnly"
+		row += fmt.Sprintf(",%d,%d", *result.TestOnlyDeps, *result.NonTestOnly)
+	}
+	existing, err := os.ReadFile(file)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	var prefix string
+	first, _, _ := strings.Cut(string(existing), "\n")
+	switch {
+	case len(existing) == 0:
+		prefix = header + "\n"
+	case strings.TrimSpace(first) != header:
+		return fmt.Errorf("%s has header %q, but this run writes %q; use another file", file, strings.TrimSpace(first), header)
+	case !strings.HasSuffix(string(existing), "\n"):
+		prefix = "\n"
+	}
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(f, "%s%s\n", prefix, row); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// gitHeadCommit returns the commit checked out in --dir, or "" outside a
+// git repository.
+func gitHeadCommit() string {
+	commit, err := gitResolveRef("HEAD")
+	if err != nil {
+		debugf("no git commit for --append: %v\n", err)
+		return ""
+	}
+	return commit
+}
+
 func runStatsCompare(cmd *cobra.Command) error {
 	if splitTestOnly {
-		return fmt.Errorf("--compare cannot be combined with --split-test-only")
+		return withExitCode(ExitUsage, fmt.Errorf("--compare cannot be combined with --split-test-only"))
 	}
 	modsA := mainModules
 	modsB := mainModules
@@ -195,16 +540,46 @@ func runStatsCompare(cmd *cobra.Command) error {
 	setA := compareSetA
 	if setA == "" {
 		setA = "A"
+		if compareRef != "" {
+			setA = compareRef
+		}
 	}
 	setB := compareSetB
 	if setB == "" {
 		setB = "B"
+		if compareRef != "" {
+			setB = "working tree"
+		}
+	}
+	if compareDirA != "" && compareGraphFileA != "" {
+		return withExitCode(ExitUsage, fmt.Errorf("--dir-a and --graph-file-a are mutually exclusive"))
+	}
+	if compareDirB != "" && compareGraphFileB != "" {
+		return withExitCode(ExitUsage, fmt.Errorf("--dir-b and --graph-file-b are mutually exclusive"))
 	}
-	before, err := computeStatsSnapshot(modsA, excludeModules, false)
+	if compareRef != "" && (compareDirA != "" || compareGraphFileA != "") {
+		return withExitCode(ExitUsage, fmt.Errorf("--compare-ref cannot be combined with --dir-a or --graph-file-a"))
+	}
+	srcA := graphSource{Dir: compareDirA, GraphFile: compareGraphFileA, GraphFlag: "--graph-file-a"}
+	srcB := graphSource{Dir: compareDirB, GraphFile: compareGraphFileB, GraphFlag: "--graph-file-b"}
+	if compareRef != "" {
+		worktreeDir, cleanup, err := gitTempWorktree(compareRef)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+		srcA.Dir = worktreeDir
+	}
+	if err := prefetchGraphs(srcA, srcB); err != nil {
+		return err
+	}
+	// computeStatsSnapshotFrom resets excludeModules when it returns
+	excludes := excludeModules
+	before, err := computeStatsSnapshotFrom(srcA, modsA, excludes, false)
 	if err != nil {
 		return err
 	}
-	after, err := computeStatsSnapshot(modsB, excludeModules, false)
+	after, err := computeStatsSnapshotFrom(srcB, modsB, excludes, false)
 	if err != nil {
 		return err
 	}
@@ -213,21 +588,27 @@ func runStatsCompare(cmd *cobra.Command) error {
 		SetB:   setB,
 		Before: *before,
 		After:  *after,
-		Delta: StatsSnapshot{
-			DirectDeps: after.DirectDeps - before.DirectDeps,
-			TransDeps:  after.TransDeps - before.TransDeps,
-			TotalDeps:  after.TotalDeps - before.TotalDeps,
-			MaxDepth:   after.MaxDepth - before.MaxDepth,
-		},
-		OnlyInB: diffSlices(getAllDeps(before.MainModules, nil), getAllDeps(after.MainModules, nil)),
+		Delta:  snapshotDelta(before, after),
+	}
+	result.OnlyInA, result.OnlyInB, result.VersionChanges = compareDependencySets(before.graph, after.graph)
+	if len(enrichSources) > 0 {
+		var warnings []string
+		result.LicenseChanges, warnings = findLicenseChanges(result.VersionChanges, enrichSources)
+		printEnrichWarnings(warnings)
+	}
+
+	if dotOutput || svgOutput {
+		diff := graphDiffResult(before.graph, after.graph, setA, setB)
+		if svgOutput {
+			return outputSVG(diff, before.graph, after.graph)
+		}
+		return outputDOT(diff, before.graph, after.graph)
 	}
 
 	if jsonOutput {
-		out, err := json.MarshalIndent(result, "", "\t")
-		if err != nil {
+		if err := writeJSON(os.Stdout, result); err != nil {
 			return err
 		}
-		fmt.Print(string(out))
 		return nil
 	}
 	if csvOutput {
@@ -235,22 +616,281 @@ func runStatsCompare(cmd *cobra.Command) error {
 		fmt.Printf("%s,%d,%d,%d,%d\n", setA, before.DirectDeps, before.TransDeps, before.TotalDeps, before.MaxDepth)
 		fmt.Printf("%s,%d,%d,%d,%d\n", setB, after.DirectDeps, after.TransDeps, after.TotalDeps, after.MaxDepth)
 		fmt.Printf("Delta,%d,%d,%d,%d\n", result.Delta.DirectDeps, result.Delta.TransDeps, result.Delta.TotalDeps, result.Delta.MaxDepth)
+		if len(result.OnlyInA) > 0 {
+			fmt.Printf("OnlyIn%s,%s\n", setA, strings.Join(result.OnlyInA, ";"))
+		}
 		if len(result.OnlyInB) > 0 {
 			fmt.Printf("OnlyIn%s,%s\n", setB, strings.Join(result.OnlyInB, ";"))
 		}
+		if len(result.VersionChanges) > 0 {
+			var changes []string
+			for _, c := range result.VersionChanges {
+				changes = append(changes, fmt.Sprintf("%s@%s->%s", c.Path, c.Before, c.After))
+			}
+			fmt.Printf("VersionChanges,%s\n", strings.Join(changes, ";"))
+		}
+		if len(result.LicenseChanges) > 0 {
+			var changes []string
+			for _, c := range result.LicenseChanges {
+				changes = append(changes, fmt.Sprintf("%s@%s->%s:%s->%s", c.Module, c.Before, c.After, strings.Join(c.BeforeLicenses, "|"), strings.Join(c.AfterLicenses, "|")))
+			}
+			fmt.Printf("LicenseChanges,%s\n", strings.Join(changes, ";"))
+		}
 		return nil
 	}
 	fmt.Printf("Stats compare (%s -> %s)\n", setA, setB)
-	fmt.Printf("Direct Dependencies: %d -> %d (delta %+d)\n", before.DirectDeps, after.DirectDeps, result.Delta.DirectDeps)
-	fmt.Printf("Transitive Dependencies: %d -> %d (delta %+d)\n", before.TransDeps, after.TransDeps, result.Delta.TransDeps)
-	fmt.Printf("Total Dependencies: %d -> %d (delta %+d)\n", before.TotalDeps, after.TotalDeps, result.Delta.TotalDeps)
-	fmt.Printf("Max Depth Of Dependencies: %d -> %d (delta %+d)\n", before.MaxDepth, after.MaxDepth, result.Delta.MaxDepth)
+	fmt.Printf("Direct Dependencies: %d -> %d (delta %s)\n", before.DirectDeps, after.DirectDeps, colorDelta(fmt.Sprintf("%+d", result.Delta.DirectDeps), result.Delta.DirectDeps))
+	fmt.Printf("Transitive Dependencies: %d -> %d (delta %s)\n", before.TransDeps, after.TransDeps, colorDelta(fmt.Sprintf("%+d", result.Delta.TransDeps), result.Delta.TransDeps))
+	fmt.Printf("Total Dependencies: %d -> %d (delta %s)\n", before.TotalDeps, after.TotalDeps, colorDelta(fmt.Sprintf("%+d", result.Delta.TotalDeps), result.Delta.TotalDeps))
+	fmt.Printf("Max Depth Of Dependencies: %d -> %d (delta %s)\n", before.MaxDepth, after.MaxDepth, colorDelta(fmt.Sprintf("%+d", result.Delta.MaxDepth), result.Delta.MaxDepth))
+	if len(result.OnlyInA) > 0 {
+		fmt.Printf("Only in %s (%d): %s\n", setA, len(result.OnlyInA), colorize(ansiRed, strings.Join(result.OnlyInA, ", ")))
+	}
 	if len(result.OnlyInB) > 0 {
-		fmt.Printf("Only in %s: %s\n", setB, strings.Join(result.OnlyInB, ", "))
+		fmt.Printf("Only in %s (%d): %s\n", setB, len(result.OnlyInB), colorize(ansiGreen, strings.Join(result.OnlyInB, ", ")))
+	}
+	if len(result.VersionChanges) > 0 {
+		fmt.Printf("Version changes (%d):\n", len(result.VersionChanges))
+		for _, c := range result.VersionChanges {
+			fmt.Printf("  %s: %s -> %s\n", c.Path, c.Before, c.After)
+		}
+	}
+	if len(result.LicenseChanges) > 0 {
+		fmt.Printf("License changes (%d):\n", len(result.LicenseChanges))
+		for _, c := range result.LicenseChanges {
+			fmt.Printf("  %s: %s -> %s: %s\n", c.Module, c.Before, c.After, colorize(ansiYellow, strings.Join(c.BeforeLicenses, "|")+" -> "+strings.Join(c.AfterLicenses, "|")))
+		}
 	}
 	return nil
 }
 
+// compareDependencySets returns the dependencies only present in a, only
+// present in b, and the modules whose selected version differs.
+func compareDependencySets(a, b *DependencyOverview) ([]string, []string, []VersionChange) {
+	depsA := getAllDeps(a.DirectDepList, a.TransDepList)
+	depsB := getAllDeps(b.DirectDepList, b.TransDepList)
+	onlyInA := diffSlices(depsB, depsA)
+	onlyInB := diffSlices(depsA, depsB)
+	changes := computeVersionChanges(a, b)
+	if onlyInA == nil {
+		onlyInA = []string{}
+	}
+	if onlyInB == nil {
+		onlyInB = []string{}
+	}
+	if changes == nil {
+		changes = []VersionChange{}
+	}
+	return onlyInA, onlyInB, changes
+}
+
+// moduleOrg returns the organization prefix of a module path, such as
+// "github.com/google", "golang.org/x" or "k8s.io".
+func moduleOrg(mod string) string {
+	parts := strings.SplitN(mod, "/", 3)
+	if len(parts) >= 2 && orgNamespacedHosts[parts[0]] {
+		return parts[0] + "/" + parts[1]
+	}
+	return parts[0]
+}
+
+// countByOrg groups dependencies by organization, largest group first.
+func countByOrg(deps []string) []OrgCount {
+	groups := map[string][]string{}
+	for _, dep := range deps {
+		org := moduleOrg(moduleKey(dep))
+		groups[org] = append(groups[org], dep)
+	}
+	counts := make([]OrgCount, 0, len(groups))
+	for org, mods := range groups {
+		sort.Strings(mods)
+		counts = append(counts, OrgCount{Org: org, Count: len(mods), Modules: mods})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count == counts[j].Count {
+			return counts[i].Org < counts[j].Org
+		}
+		return counts[i].Count > counts[j].Count
+	})
+	return counts
+}
+
+// computeDepthHistogram buckets every dependency by its shortest distance
+// from any main module.
+func computeDepthHistogram(depGraph *DependencyOverview) *DepthHistogram {
+	depthOf := shortestDepthByModule(depGraph.MainModules, depGraph.Graph)
+	counts := map[int]int{}
+	var depths []int
+	for _, dep := range getAllDeps(depGraph.DirectDepList, depGraph.TransDepList) {
+		d, ok := depthOf[dep]
+		if !ok || contains(depGraph.MainModules, dep) {
+			continue
+		}
+		counts[d]++
+		depths = append(depths, d)
+	}
+	sort.Ints(depths)
+
+	h := &DepthHistogram{Buckets: []DepthBucket{}}
+	if len(depths) == 0 {
+		return h
+	}
+	h.Max = depths[len(depths)-1]
+	h.P50 = percentile(depths, 50)
+	h.P90 = percentile(depths, 90)
+	for d := 1; d <= h.Max; d++ {
+		h.Buckets = append(h.Buckets, DepthBucket{Depth: d, Count: counts[d]})
+	}
+	return h
+}
+
+// computeEdgeStats measures the requirement edges between the modules left
+// in the graph. Ties for the highest out-degree go to the first module in
+// path order.
+func computeEdgeStats(depGraph *DependencyOverview) *EdgeStats {
+	nodes := uniqueStrings(append(append([]string{}, depGraph.MainModules...), getAllDeps(depGraph.DirectDepList, depGraph.TransDepList)...))
+	stats := &EdgeStats{}
+	for _, from := range sortedCopy(nodes) {
+		out := len(uniqueStrings(depGraph.Graph[from]))
+		stats.TotalEdges += out
+		if out > stats.MaxOutDegree {
+			stats.MaxOutDegree = out
+			stats.MaxOutDegreeModule = from
+		}
+	}
+	if n := len(nodes); n > 0 {
+		stats.AverageOutDegree = roundRatio(float64(stats.TotalEdges) / float64(n))
+		if n > 1 {
+			stats.Density = roundRatio(float64(stats.TotalEdges) / float64(n*(n-1)))
+		}
+	}
+	return stats
+}
+
+// roundRatio keeps four decimals, so JSON output stays stable and readable.
+func roundRatio(x float64) float64 {
+	return math.Round(x*1e4) / 1e4
+}
+
+func formatRatio(x float64) string {
+	return strconv.FormatFloat(x, 'f', -1, 64)
+}
+
+// percentile returns the nearest-rank percentile of sorted values.
+func percentile(sorted []int, p int) int {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func printDepthHistogram(h *DepthHistogram) {
+	const barWidth = 40
+	largest := 0
+	for _, b := range h.Buckets {
+		largest = max(largest, b.Count)
+	}
+	fmt.Println("Depth Histogram (shortest path from main modules):")
+	for _, b := range h.Buckets {
+		bar := 0
+		if largest > 0 {
+			bar = (b.Count*barWidth + largest - 1) / largest
+		}
+		fmt.Printf("  %3d: %5d %s\n", b.Depth, b.Count, strings.Repeat("#", bar))
+	}
+	fmt.Printf("Depth p50: %d, p90: %d, max: %d\n", h.P50, h.P90, h.Max)
+}
+
+// topLongestChains returns up to n of the longest chains from a main module,
+// one per end module. Chains that are a prefix of a longer chain already
+// selected are skipped.
+func topLongestChains(depGraph *DependencyOverview, n int) []Chain {
+	// longest chains in the reversed graph end at a main module
+	reversed := map[string][]string{}
+	for from, tos := range depGraph.Graph {
+		for _, to := range tos {
+			reversed[to] = append(reversed[to], from)
+		}
+	}
+	for node := range reversed {
+		sort.Strings(reversed[node])
+	}
+	memo := map[string]Chain{}
+	var candidates []Chain
+	for _, dep := range getAllDeps(depGraph.DirectDepList, depGraph.TransDepList) {
+		rev := getLongestChain(dep, reversed, nil, memo)
+		if len(rev) < 2 || !contains(depGraph.MainModules, rev[len(rev)-1]) {
+			continue
+		}
+		chain := make(Chain, len(rev))
+		for i, m := range rev {
+			chain[len(rev)-1-i] = m
+		}
+		candidates = append(candidates, chain)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if len(candidates[i]) != len(candidates[j]) {
+			return len(candidates[i]) > len(candidates[j])
+		}
+		return strings.Join(candidates[i], " ") < strings.Join(candidates[j], " ")
+	})
+
+	var selected []Chain
+	for _, c := range candidates {
+		if len(selected) == n {
+			break
+		}
+		redundant := false
+		for _, s := range selected {
+			if isChainPrefix(c, s) {
+				redundant = true
+				break
+			}
+		}
+		if !redundant {
+			selected = append(selected, c)
+		}
+	}
+	return selected
+}
+
+func isChainPrefix(prefix, chain Chain) bool {
+	if len(prefix) > len(chain) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != chain[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// longestChainsByMainModule returns the longest chain starting from each
+// main module.
+func longestChainsByMainModule(depGraph *DependencyOverview) map[string]Chain {
+	chains := make(map[string]Chain, len(depGraph.MainModules))
+	memo := map[string]Chain{}
+	for _, mod := range depGraph.MainModules {
+		chains[mod] = getLongestChain(mod, depGraph.Graph, nil, memo)
+	}
+	return chains
+}
+
+// longestChainFromMains returns the longest chain starting from any main
+// module; of equally long chains, the one from the earliest main module.
+func longestChainFromMains(depGraph *DependencyOverview) Chain {
+	chains := longestChainsByMainModule(depGraph)
+	var longest Chain
+	for _, mod := range depGraph.MainModules {
+		if len(chains[mod]) > len(longest) {
+			longest = chains[mod]
+		}
+	}
+	return longest
+}
+
 // get the longest chain starting from currentDep
 func getLongestChain(currentDep string, graph map[string][]string, currentChain Chain, longestChains map[string]Chain) Chain {
 	// fmt.Println(strings.Repeat("  ", len(currentChain)), currentDep)
@@ -293,12 +933,34 @@ func init() {
 	statsCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Get additional details")
 	statsCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Get the output in JSON format")
 	statsCmd.Flags().BoolVarP(&csvOutput, "csv", "c", false, "Get the output in CSV format")
+	statsCmd.Flags().StringVar(&statsAppendFile, "append", "", "With --csv, append one timestamped row with the git commit to this file instead of printing, writing the header only when the file is new")
 	statsCmd.Flags().BoolVar(&splitTestOnly, "split-test-only", false, "Split dependency totals into test-only and non-test sections using `go mod why -m`")
+	statsCmd.Flags().BoolVar(&statsHistogram, "histogram", false, "Show the distribution of shortest-path depths to every dependency")
+	statsCmd.Flags().IntVar(&statsChains, "chains", 0, "Show the N longest dependency chains with their full paths")
+	statsCmd.Flags().StringVar(&statsChainWeight, "chain-weight", "", "Show the heaviest chain, weighting modules by size: packages or loc (needs module sources in the module cache)")
+	statsCmd.Flags().BoolVar(&statsByOrg, "by-org", false, "Break dependencies down by host/organization prefix")
+	statsCmd.Flags().StringVar(&ownersFile, "owners", "", "CODEOWNERS-style file mapping module path patterns to teams (- reads stdin); breaks dependencies down by owner")
+	statsCmd.Flags().BoolVar(&statsDuplicateMajors, "duplicate-majors", false, "List modules present under more than one major version")
+	statsCmd.Flags().BoolVar(&statsReplaceDowngrades, "replace-downgrades", false, "List modules a replace directive pins below the version other dependencies request, with the requesting modules")
+	statsCmd.Flags().BoolVar(&watchMode, "watch", false, "Re-run and re-print the stats whenever go.mod, go.sum, go.work or go.work.sum change")
+	statsCmd.Flags().DurationVar(&watchInterval, "watch-interval", time.Second, "How often --watch checks the module files for changes")
+	statsCmd.Flags().BoolVar(&statsDiscover, "discover", false, "Treat every go.mod below --dir (respecting .gitignore) as a main module and show per-module and combined stats")
 	statsCmd.Flags().BoolVar(&statsCompare, "compare", false, "Compare stats between two module sets")
 	statsCmd.Flags().StringVar(&compareSetA, "set-a", "", "Label for the first comparison set")
 	statsCmd.Flags().StringVar(&compareSetB, "set-b", "", "Label for the second comparison set")
 	statsCmd.Flags().StringSliceVar(&compareMainModulesA, "main-modules-a", []string{}, "Main modules for comparison set A")
 	statsCmd.Flags().StringSliceVar(&compareMainModulesB, "main-modules-b", []string{}, "Main modules for comparison set B")
+	statsCmd.Flags().StringVar(&compareDirA, "dir-a", "", "Module directory for comparison set A (defaults to --dir)")
+	statsCmd.Flags().StringVar(&compareDirB, "dir-b", "", "Module directory for comparison set B (defaults to --dir)")
+	statsCmd.Flags().StringVar(&statsGraphFile, "graph-file", "", "Read captured `go mod graph` output instead of running go in --dir; - reads stdin")
+	statsCmd.Flags().StringVar(&compareGraphFileA, "graph-file-a", "", "Captured `go mod graph` output to use for comparison set A; - reads stdin")
+	statsCmd.Flags().StringVar(&compareRef, "compare-ref", "", "Compare a temporary worktree of this git ref (set A) against the current directory (set B); implies --compare")
+	statsCmd.Flags().BoolVar(&dotOutput, "dot", false, "With --compare, output a single DOT graph of the changes: added green, removed red, version changes amber")
+	statsCmd.Flags().BoolVar(&svgOutput, "svg", false, "With --compare, render the change graph as SVG (requires graphviz 'dot')")
+	statsCmd.Flags().StringSliceVar(&enrichSources, "enrich", []string{}, "With --compare, detect license changes of modules selected at another version (needs depsdev; supported: depsdev, github, osv, proxy)")
+	statsCmd.Flags().StringVar(&enrichCacheDir, "enrich-cache-dir", "", "Directory for cached enrichment data. Defaults to the user cache directory.")
+	statsCmd.Flags().StringVar(&compareGraphFileB, "graph-file-b", "", "Captured `go mod graph` output to use for comparison set B; - reads stdin")
+	addToolsFlag(statsCmd)
 	statsCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
-	statsCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Enter modules whose dependencies should be considered direct dependencies; defaults to the first module encountered in `go mod graph` output")
+	statsCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Enter modules whose dependencies should be considered direct dependencies; defaults to the modules found by --main")
 }
@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxSuggestions is the number of near matches offered for a module that
+// is not in the graph.
+const maxSuggestions = 5
+
+// Kinds of near match, best first.
+const (
+	suggestCase = iota
+	suggestMajorVersion
+	suggestTypo
+	suggestPackage
+	suggestPrefix
+)
+
+// suggestModules returns the modules most likely meant by a target that is
+// not one of them, best first: the same path in another case or with a
+// different or missing major version suffix, else the paths fewest typos
+// (at most two) away, the module containing a package path, and longer
+// paths starting with the target.
+func suggestModules(target string, modules []string, limit int) []string {
+	type candidate struct {
+		module string
+		kind   int
+		cost   int
+	}
+	lower := strings.ToLower(target)
+	base := majorVersionBase(lower)
+	var found []candidate
+	for _, mod := range modules {
+		if mod == target {
+			continue
+		}
+		m := strings.ToLower(mod)
+		switch {
+		case m == lower:
+			found = append(found, candidate{mod, suggestCase, 0})
+		case majorVersionBase(m) == base:
+			found = append(found, candidate{mod, suggestMajorVersion, 0})
+		case abs(len(m)-len(lower)) <= 2 && editDistance(m, lower) <= 2:
+			found = append(found, candidate{mod, suggestTypo, editDistance(m, lower)})
+		case strings.HasPrefix(lower, m+"/"):
+			// the longest module containing the package is the one it is in
+			found = append(found, candidate{mod, suggestPackage, len(lower) - len(m)})
+		case strings.HasPrefix(m, lower):
+			found = append(found, candidate{mod, suggestPrefix, len(m) - len(lower)})
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		a, b := found[i], found[j]
+		if a.kind != b.kind {
+			return a.kind < b.kind
+		}
+		if a.cost != b.cost {
+			return a.cost < b.cost
+		}
+		return a.module < b.module
+	})
+	// the same path in another case or major version is almost certainly
+	// what was meant, and only the closest typos are worth listing
+	kept := found[:0]
+	for _, c := range found {
+		best := found[0]
+		if best.kind <= suggestMajorVersion && c.kind > suggestMajorVersion {
+			break
+		}
+		if best.kind == suggestTypo && c.kind == suggestTypo && c.cost > best.cost {
+			continue
+		}
+		kept = append(kept, c)
+	}
+	found = kept
+	if limit > 0 && len(found) > limit {
+		found = found[:limit]
+	}
+	suggestions := make([]string, 0, len(found))
+	for _, c := range found {
+		suggestions = append(suggestions, c.module)
+	}
+	return suggestions
+}
+
+// editDistance returns the Levenshtein distance between a and b, in bytes.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSuggestModules(t *testing.T) {
+	modules := []string{
+		"k8s.io/klog/v2",
+		"k8s.io/klog",
+		"k8s.io/api",
+		"k8s.io/apimachinery",
+		"github.com/BurntSushi/toml",
+		"golang.org/x/net",
+		"golang.org/x/text",
+		"gopkg.in/yaml.v3",
+	}
+	tests := []struct {
+		target string
+		want   []string
+	}{
+		{"github.com/burntsushi/toml", []string{"github.com/BurntSushi/toml"}},
+		{"k8s.io/klog/v3", []string{"k8s.io/klog", "k8s.io/klog/v2"}},
+		{"gopkg.in/yaml.v2", []string{"gopkg.in/yaml.v3"}},
+		{"golang.org/x/nett", []string{"golang.org/x/net"}},
+		{"golang.org/x/net/http2", []string{"golang.org/x/net"}},
+		{"k8s.io/apimach", []string{"k8s.io/apimachinery"}},
+		{"example.com/unrelated", []string{}},
+	}
+	for _, tt := range tests {
+		if got := suggestModules(tt.target, modules, maxSuggestions); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("suggestModules(%q) = %v, want %v", tt.target, got, tt.want)
+		}
+	}
+	if got := suggestModules("k8s.io/", modules, 2); len(got) != 2 {
+		t.Errorf("expected the limit to apply, got %v", got)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+		want int
+	}{
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"golang.org/x/net", "golang.org/x/net", 0},
+	} {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// GoSumModule is a module version recorded in go.sum or go.work.sum.
+type GoSumModule struct {
+	Module  string `json:"module"`
+	Version string `json:"version"`
+	// GoModOnly is set when only the go.mod file is hashed, i.e. the
+	// module's source was never needed.
+	GoModOnly bool `json:"goModOnly,omitempty"`
+	// File is the base name of the file holding the entry.
+	File string `json:"file"`
+}
+
+// SumsResult reconciles go.sum with the module graph.
+type SumsResult struct {
+	// SumModules is the number of module versions recorded in go.sum.
+	SumModules int `json:"sumModules"`
+	// GraphModules is the number of module versions whose go.mod the graph
+	// was built from, which need a go.sum entry.
+	GraphModules int `json:"graphModules"`
+	// Stale lists the go.sum entries for module versions not in the graph.
+	Stale []GoSumModule `json:"stale"`
+	// Missing lists the module versions the graph was built from without
+	// an entry.
+	Missing []string `json:"missing"`
+}
+
+var sumsCmd = &cobra.Command{
+	Use:   "sums",
+	Short: "Cross-check go.sum against the module graph",
+	Long: `Derives the module set from the go.sum of --dir (and go.work.sum in a
+workspace) and reconciles it with "go mod graph":
+
+  stale    go.sum entries for module versions that are not in the graph,
+           which "go mod tidy" would remove
+  missing  module versions whose go.mod the graph was read from (the left
+           side of an edge) without a go.sum entry, which make builds
+           with -mod=readonly fail
+
+Module versions only appearing on the right side of edges were pruned from
+the graph and need no entry, but one is not stale either: go.sum keeps the
+hashes of modules that provide packages. Modules replaced by a directory
+need no entry; a module replaced by another module version needs the entry
+of the replacement. Exits with code 3 when go.sum and the graph disagree.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("sums does not take any arguments"))
+		}
+		gomod, err := readGoModFile()
+		if err != nil {
+			return err
+		}
+		sums, err := readGoSumModules(filepath.Dir(goModPath()), mainModuleBaseDir())
+		if err != nil {
+			return err
+		}
+		c := goCommand([]string{"mod", "graph"})
+		out, err := c.Output()
+		if err != nil {
+			return goCommandError(c, err)
+		}
+		result := crossCheckGoSum(string(out), gomod, sums)
+		if jsonOutput {
+			if err := writeJSON(os.Stdout, result); err != nil {
+				return err
+			}
+		} else {
+			printSumsResult(result)
+		}
+		if len(result.Stale) > 0 || len(result.Missing) > 0 {
+			cmd.SilenceUsage = true
+			return withExitCode(ExitViolation, fmt.Errorf("go.sum and the module graph disagree: %d stale, %d missing", len(result.Stale), len(result.Missing)))
+		}
+		return nil
+	},
+}
+
+// readGoSumModules returns the module versions recorded in the go.sum of
+// modDir and the go.work.sum of workDir, in file order. Missing files
+// record nothing.
+func readGoSumModules(modDir, workDir string) ([]GoSumModule, error) {
+	var modules []GoSumModule
+	for _, file := range []string{filepath.Join(modDir, "go.sum"), filepath.Join(workDir, "go.work.sum")} {
+		f, err := os.Open(file)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		index := make(map[string]int)
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			fields := strings.Fields(scanner.Text())
+			if len(fields) < 2 {
+				continue
+			}
+			version := strings.TrimSuffix(fields[1], "/go.mod")
+			goModOnly := version != fields[1]
+			key := fields[0] + " " + version
+			if i, ok := index[key]; ok {
+				modules[i].GoModOnly = modules[i].GoModOnly && goModOnly
+				continue
+			}
+			index[key] = len(modules)
+			modules = append(modules, GoSumModule{Module: fields[0], Version: version, GoModOnly: goModOnly, File: filepath.Base(file)})
+		}
+		err = scanner.Err()
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return modules, nil
+}
+
+// readGoSums returns the "path version" pairs recorded in the go.sum of
+// modDir and the go.work.sum of workDir, with /go.mod suffixes removed.
+func readGoSums(modDir, workDir string) (map[string]bool, error) {
+	modules, err := readGoSumModules(modDir, workDir)
+	if err != nil {
+		return nil, err
+	}
+	sums := make(map[string]bool, len(modules))
+	for _, m := range modules {
+		sums[m.Module+" "+m.Version] = true
+	}
+	return sums, nil
+}
+
+// graphSumKeys maps module versions of go mod graph output to the
+// "path version" their go.sum entry is recorded under: that of the
+// replacement when a replace directive substitutes another module version.
+// Modules replaced by a directory have none. With loadedOnly, only the
+// module versions whose go.mod was read, the left sides of the edges, are
+// included: those are the ones that need an entry.
+func graphSumKeys(graphOutput string, gomod *goModFile, loadedOnly bool) map[string]string {
+	keys := make(map[string]string)
+	for _, line := range strings.Split(graphOutput, "\n") {
+		nodes := strings.Fields(line)
+		if loadedOnly && len(nodes) > 1 {
+			nodes = nodes[:1]
+		}
+		for _, node := range nodes {
+			mod := parseModule(node)
+			if mod.version == "" || mod.name == "go" || mod.name == "toolchain" {
+				continue
+			}
+			if _, seen := keys[node]; seen {
+				continue
+			}
+			key := mod.name + " " + mod.version
+			if rep, ok := findReplacement(gomod.Replace, goModVersion{Path: mod.name, Version: mod.version}); ok {
+				if rep.Version == "" {
+					continue
+				}
+				key = rep.Path + " " + rep.Version
+			}
+			keys[node] = key
+		}
+	}
+	return keys
+}
+
+// crossCheckGoSum compares the go.sum modules with the graph.
+func crossCheckGoSum(graphOutput string, gomod *goModFile, sums []GoSumModule) SumsResult {
+	keys := graphSumKeys(graphOutput, gomod, true)
+	known := make(map[string]bool)
+	for _, key := range graphSumKeys(graphOutput, gomod, false) {
+		known[key] = true
+	}
+	recorded := make(map[string]bool, len(sums))
+	result := SumsResult{SumModules: len(sums), GraphModules: len(keys), Stale: []GoSumModule{}, Missing: []string{}}
+	for _, s := range sums {
+		key := s.Module + " " + s.Version
+		recorded[key] = true
+		if !known[key] {
+			result.Stale = append(result.Stale, s)
+		}
+	}
+	for node, key := range keys {
+		if !recorded[key] {
+			result.Missing = append(result.Missing, node)
+		}
+	}
+	sort.Strings(result.Missing)
+	return result
+}
+
+func printSumsResult(result SumsResult) {
+	fmt.Printf("go.sum records %d module versions; the graph was read from %d.\n", result.SumModules, result.GraphModules)
+	if len(result.Stale) == 0 && len(result.Missing) == 0 {
+		fmt.Println("go.sum and the module graph agree.")
+		return
+	}
+	if len(result.Stale) > 0 {
+		fmt.Printf("Stale entries, for module versions not in the graph (%d):\n", len(result.Stale))
+		for _, s := range result.Stale {
+			fmt.Printf("  %s %s (%s)\n", s.Module, s.Version, s.File)
+		}
+	}
+	if len(result.Missing) > 0 {
+		fmt.Printf("Graph module versions without an entry (%d):\n", len(result.Missing))
+		for _, m := range result.Missing {
+			fmt.Printf("  %s\n", m)
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(sumsCmd)
+	sumsCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
+	sumsCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Get the output in JSON format")
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadGoSumModules(t *testing.T) {
+	tmp := t.TempDir()
+	sum := "example.com/a v1.0.0 h1:aaa=\n" +
+		"example.com/a v1.0.0/go.mod h1:bbb=\n" +
+		"example.com/b v1.2.0/go.mod h1:ccc=\n"
+	if err := os.WriteFile(filepath.Join(tmp, "go.sum"), []byte(sum), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := readGoSumModules(tmp, filepath.Join(tmp, "missing"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []GoSumModule{
+		{Module: "example.com/a", Version: "v1.0.0", File: "go.sum"},
+		{Module: "example.com/b", Version: "v1.2.0", GoModOnly: true, File: "go.sum"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readGoSumModules = %+v, want %+v", got, want)
+	}
+}
+
+func TestCrossCheckGoSum(t *testing.T) {
+	graph := "example.com/app example.com/a@v1.0.0\n" +
+		"example.com/app example.com/local@v0.0.0\n" +
+		"example.com/app example.com/forked@v1.0.0\n" +
+		"example.com/a@v1.0.0 example.com/b@v1.2.0\n" +
+		"example.com/forked@v1.0.0 example.com/c@v0.1.0\n" +
+		"example.com/a@v1.0.0 go@1.22\n"
+	gomod := &goModFile{Replace: []goModReplace{
+		{Old: goModVersion{Path: "example.com/local"}, New: goModVersion{Path: "../local"}},
+		{Old: goModVersion{Path: "example.com/forked"}, New: goModVersion{Path: "example.com/fork", Version: "v1.0.1"}},
+	}}
+	sums := []GoSumModule{
+		{Module: "example.com/a", Version: "v1.0.0", File: "go.sum"},
+		// only on the right side of an edge: neither needed nor stale
+		{Module: "example.com/b", Version: "v1.2.0", GoModOnly: true, File: "go.sum"},
+		{Module: "example.com/old", Version: "v0.9.0", GoModOnly: true, File: "go.sum"},
+	}
+
+	got := crossCheckGoSum(graph, gomod, sums)
+	want := SumsResult{
+		SumModules:   3,
+		GraphModules: 2,
+		Stale:        []GoSumModule{sums[2]},
+		Missing:      []string{"example.com/forked@v1.0.0"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("crossCheckGoSum = %+v, want %+v", got, want)
+	}
+
+	sums = append(sums[:2], GoSumModule{Module: "example.com/fork", Version: "v1.0.1", File: "go.sum"})
+	if got := crossCheckGoSum(graph, gomod, sums); len(got.Stale) != 0 || len(got.Missing) != 0 {
+		t.Errorf("reconciled go.sum: got %+v", got)
+	}
+}
@@ -0,0 +1,5 @@
+module example.com/a
+
+go 1.22
+
+require example.com/c v0.0.0
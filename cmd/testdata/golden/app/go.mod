@@ -0,0 +1,14 @@
+module example.com/app
+
+go 1.22
+
+require (
+	example.com/a v0.0.0
+	example.com/b v0.0.0
+)
+
+replace (
+	example.com/a => ../a
+	example.com/b => ../b
+	example.com/c => ../c
+)
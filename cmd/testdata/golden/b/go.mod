@@ -0,0 +1,8 @@
+module example.com/b
+
+go 1.22
+
+require (
+	example.com/a v0.0.0
+	example.com/c v0.0.0
+)
@@ -0,0 +1,289 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// TidyPreviewResult compares the graph with the graph after go mod tidy.
+type TidyPreviewResult struct {
+	WhatIfResult
+	// RequireChanges lists the require directives tidy adds, drops or
+	// changes in go.mod.
+	RequireChanges []RequireChange `json:"requireChanges"`
+	GoModChanged   bool            `json:"goModChanged"`
+	GoSumChanged   bool            `json:"goSumChanged"`
+}
+
+// RequireChange is a require directive of go.mod before and after tidy.
+// Before or After is empty when tidy adds or drops the requirement.
+type RequireChange struct {
+	Module string `json:"module"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+	// Indirect is the // indirect marker after tidy, or before it for
+	// dropped requirements.
+	Indirect bool `json:"indirect"`
+}
+
+var tidyPreviewCmd = &cobra.Command{
+	Use:   "tidy-preview",
+	Short: "Preview the effect of go mod tidy on the dependency graph",
+	Long: `Runs "go mod tidy" in a temporary copy of the module in --dir and reports
+how the dependency set and stats would change, without touching the working
+tree: the dependencies that would disappear, be added or be selected at
+another version, and the require directives tidy would add, drop or change.
+
+The copy holds go.mod, go.sum and the .go files of the module, without
+nested modules, vendor or testdata directories. Replace directives pointing
+at relative directories are rewritten to absolute paths in the copy, so
+they keep resolving. Like go mod tidy itself, the preview ignores go.work:
+both graphs are loaded with GOWORK=off, with the module in --dir as the
+only main module.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("tidy-preview does not take any arguments"))
+		}
+		gomod, err := readGoModFile()
+		if err != nil {
+			return err
+		}
+		oldWork := goWorkOverride
+		goWorkOverride = "off"
+		defer func() { goWorkOverride = oldWork }()
+		before := getDepInfo([]string{gomod.Module.Path})
+		if len(before.MainModules) == 0 {
+			return errNoMainModules
+		}
+		result, err := previewTidy(before)
+		if err != nil {
+			return err
+		}
+		if jsonOutput {
+			return writeJSON(os.Stdout, result)
+		}
+		printTidyPreview(result)
+		return nil
+	},
+}
+
+// previewTidy runs go mod tidy in a copy of the module in --dir and
+// compares the graph there with before, which the caller loaded outside
+// any workspace as well.
+func previewTidy(before *DependencyOverview) (TidyPreviewResult, error) {
+	var result TidyPreviewResult
+	modDir, err := filepath.Abs(filepath.Dir(goModPath()))
+	if err != nil {
+		return result, err
+	}
+	gomodBefore, err := readGoModFile()
+	if err != nil {
+		return result, err
+	}
+	tmp, err := os.MkdirTemp("", "depstat-tidy-")
+	if err != nil {
+		return result, err
+	}
+	defer os.RemoveAll(tmp)
+	if err := copyModuleSources(modDir, tmp); err != nil {
+		return result, fmt.Errorf("copying %s: %w", modDir, err)
+	}
+
+	oldDir := dir
+	dir = tmp
+	defer func() { dir = oldDir }()
+	if err := absolutizeReplaces(gomodBefore, modDir); err != nil {
+		return result, err
+	}
+	goModBefore, _ := os.ReadFile(filepath.Join(tmp, "go.mod"))
+	goSumBefore, _ := os.ReadFile(filepath.Join(tmp, "go.sum"))
+	c := goCommand([]string{"mod", "tidy"})
+	if out, err := c.CombinedOutput(); err != nil {
+		return result, fmt.Errorf("go mod tidy in a copy of %s failed: %w\n%s", modDir, err, strings.TrimSpace(string(out)))
+	}
+	goModAfter, _ := os.ReadFile(filepath.Join(tmp, "go.mod"))
+	goSumAfter, _ := os.ReadFile(filepath.Join(tmp, "go.sum"))
+	gomodAfter, err := readGoModFile()
+	if err != nil {
+		return result, err
+	}
+	after := getDepInfo(before.MainModules)
+
+	result.WhatIfResult = compareWhatIf("go mod tidy", before, after)
+	result.RequireChanges = compareRequires(gomodBefore.Require, gomodAfter.Require)
+	result.GoModChanged = !bytes.Equal(goModBefore, goModAfter)
+	result.GoSumChanged = !bytes.Equal(goSumBefore, goSumAfter)
+	return result, nil
+}
+
+// copyModuleSources copies what go mod tidy reads from the module in src,
+// go.mod, go.sum and the .go files of its packages, to dst.
+func copyModuleSources(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if rel == "." {
+				return nil
+			}
+			name := d.Name()
+			// the go command ignores these directories as well
+			if name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+				return filepath.SkipDir // nested module
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if rel != "go.mod" && rel != "go.sum" && !strings.HasSuffix(rel, ".go") {
+			return nil
+		}
+		return copyFile(path, filepath.Join(dst, rel))
+	})
+}
+
+func copyFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// absolutizeReplaces rewrites the replace directives of the go.mod in --dir
+// that point at directories relative to modDir.
+func absolutizeReplaces(gomod *goModFile, modDir string) error {
+	args := []string{"mod", "edit"}
+	for _, rep := range gomod.Replace {
+		if rep.New.Version != "" || filepath.IsAbs(rep.New.Path) {
+			continue
+		}
+		old := rep.Old.Path
+		if rep.Old.Version != "" {
+			old += "@" + rep.Old.Version
+		}
+		args = append(args, "-replace="+old+"="+filepath.Join(modDir, rep.New.Path))
+	}
+	if len(args) == 2 {
+		return nil
+	}
+	c := goCommand(args)
+	if _, err := c.Output(); err != nil {
+		return goCommandError(c, err)
+	}
+	return nil
+}
+
+// compareRequires returns the require directives added, dropped or changed
+// from before to after, sorted by module.
+func compareRequires(before, after []goModRequirement) []RequireChange {
+	old := make(map[string]goModRequirement, len(before))
+	for _, r := range before {
+		old[r.Path] = r
+	}
+	changes := []RequireChange{}
+	seen := make(map[string]bool, len(after))
+	for _, r := range after {
+		seen[r.Path] = true
+		prev, ok := old[r.Path]
+		if ok && prev.Version == r.Version && prev.Indirect == r.Indirect {
+			continue
+		}
+		change := RequireChange{Module: r.Path, After: r.Version, Indirect: r.Indirect}
+		if ok {
+			change.Before = prev.Version
+		}
+		changes = append(changes, change)
+	}
+	for _, r := range before {
+		if !seen[r.Path] {
+			changes = append(changes, RequireChange{Module: r.Path, Before: r.Version, Indirect: r.Indirect})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Module < changes[j].Module })
+	return changes
+}
+
+func printTidyPreview(result TidyPreviewResult) {
+	printWhatIf(result.WhatIfResult)
+	if !result.GoModChanged && !result.GoSumChanged {
+		fmt.Println("go mod tidy would not change go.mod or go.sum.")
+		return
+	}
+	if len(result.RequireChanges) > 0 {
+		fmt.Printf("go.mod requirements (%d):\n", len(result.RequireChanges))
+		for _, c := range result.RequireChanges {
+			indirect := ""
+			if c.Indirect {
+				indirect = " // indirect"
+			}
+			switch {
+			case c.Before == "":
+				fmt.Printf("  + %s %s%s\n", c.Module, c.After, indirect)
+			case c.After == "":
+				fmt.Printf("  - %s %s%s\n", c.Module, c.Before, indirect)
+			case c.Before == c.After:
+				fmt.Printf("  ~ %s %s%s (marker changed)\n", c.Module, c.After, indirect)
+			default:
+				fmt.Printf("  ~ %s %s -> %s%s\n", c.Module, c.Before, c.After, indirect)
+			}
+		}
+	} else if result.GoModChanged {
+		fmt.Println("go.mod would be reformatted; no requirement changes.")
+	}
+	if result.GoSumChanged {
+		fmt.Println("go.sum would change.")
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(tidyPreviewCmd)
+	tidyPreviewCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
+	tidyPreviewCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Get the output in JSON format")
+	tidyPreviewCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCompareRequires(t *testing.T) {
+	before := []goModRequirement{
+		{Path: "example.com/a", Version: "v1.0.0"},
+		{Path: "example.com/b", Version: "v1.0.0", Indirect: true},
+		{Path: "example.com/c", Version: "v1.0.0", Indirect: true},
+		{Path: "example.com/d", Version: "v1.0.0"},
+	}
+	after := []goModRequirement{
+		{Path: "example.com/a", Version: "v1.0.0"},
+		{Path: "example.com/b", Version: "v1.0.0"},
+		{Path: "example.com/c", Version: "v1.1.0", Indirect: true},
+		{Path: "example.com/e", Version: "v0.1.0", Indirect: true},
+	}
+	want := []RequireChange{
+		{Module: "example.com/b", Before: "v1.0.0", After: "v1.0.0"},
+		{Module: "example.com/c", Before: "v1.0.0", After: "v1.1.0", Indirect: true},
+		{Module: "example.com/d", Before: "v1.0.0"},
+		{Module: "example.com/e", After: "v0.1.0", Indirect: true},
+	}
+	if got := compareRequires(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("compareRequires = %+v, want %+v", got, want)
+	}
+}
+
+func TestCopyModuleSources(t *testing.T) {
+	src := t.TempDir()
+	for _, f := range []string{
+		"go.mod", "go.sum", "main.go", "README.md",
+		"pkg/lib.go", "pkg/testdata/fixture.go",
+		"vendor/modules.txt", ".git/HEAD", "_tools/tool.go",
+		"nested/go.mod", "nested/nested.go",
+	} {
+		path := filepath.Join(src, f)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(f), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dst := t.TempDir()
+	if err := copyModuleSources(src, dst); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	_ = filepath.WalkDir(dst, func(path string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			rel, _ := filepath.Rel(dst, path)
+			got = append(got, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if want := []string{"go.mod", "go.sum", "main.go", "pkg/lib.go"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("copied %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var toolchainNewerOnly bool
+
+// ModuleGoVersion is the go directive declared by a module's go.mod.
+type ModuleGoVersion struct {
+	Module    string   `json:"module"`
+	Version   string   `json:"version"`
+	GoVersion string   `json:"goVersion"`
+	Path      []string `json:"path,omitempty"`
+}
+
+// ToolchainResult lists the go directive of every dependency.
+type ToolchainResult struct {
+	MainGoVersion string            `json:"mainGoVersion"`
+	Modules       []ModuleGoVersion `json:"modules"`
+	NewerThanMain []ModuleGoVersion `json:"newerThanMain"`
+	MainModules   []string          `json:"mainModules"`
+}
+
+var toolchainCmd = &cobra.Command{
+	Use:   "toolchain",
+	Short: "Report the go directive of each dependency",
+	Long: `Lists the go directive declared by each dependency's go.mod, as reported
+by "go list -m -json all", and flags dependencies that require a newer Go
+version than the main module declares. Upgrading such a dependency forces
+the main module's go line up with it.
+
+When several main modules are listed by go, the lowest go directive among
+them is used as the baseline.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("toolchain does not take any arguments"))
+		}
+		depGraph := getDepInfo(mainModules)
+		if len(depGraph.MainModules) == 0 {
+			return errNoMainModules
+		}
+		modules, err := listAllModules(nil)
+		if err != nil {
+			return fmt.Errorf("listing modules: %w", err)
+		}
+		result := findGoVersions(modules, depGraph)
+		if toolchainNewerOnly {
+			result.Modules = nil
+		}
+
+		if jsonOutput {
+			out, err := json.MarshalIndent(result, "", "\t")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+			return nil
+		}
+		printToolchain(result)
+		return nil
+	},
+}
+
+// findGoVersions collects the go directive of every module in the graph and
+// compares it with the main module's.
+func findGoVersions(modules []goModule, depGraph *DependencyOverview) ToolchainResult {
+	inGraph := make(map[string]bool)
+	for _, dep := range getAllDeps(depGraph.DirectDepList, depGraph.TransDepList) {
+		inGraph[dep] = true
+	}
+	result := ToolchainResult{
+		Modules:       []ModuleGoVersion{},
+		NewerThanMain: []ModuleGoVersion{},
+		MainModules:   depGraph.MainModules,
+	}
+	for _, mod := range modules {
+		if mod.Main && mod.GoVersion != "" {
+			if result.MainGoVersion == "" || compareGoVersions(mod.GoVersion, result.MainGoVersion) < 0 {
+				result.MainGoVersion = mod.GoVersion
+			}
+		}
+	}
+	for _, mod := range modules {
+		if mod.Main || !inGraph[mod.Path] || contains(depGraph.MainModules, mod.Path) {
+			continue
+		}
+		entry := ModuleGoVersion{Module: mod.Path, Version: mod.Version, GoVersion: mod.GoVersion}
+		if v := depGraph.Versions[mod.Path]; v != "" {
+			entry.Version = v
+		}
+		result.Modules = append(result.Modules, entry)
+		if result.MainGoVersion != "" && mod.GoVersion != "" && compareGoVersions(mod.GoVersion, result.MainGoVersion) > 0 {
+			entry.Path = shortestPath(depGraph.MainModules, mod.Path, depGraph.Graph)
+			result.NewerThanMain = append(result.NewerThanMain, entry)
+		}
+	}
+	sort.Slice(result.Modules, func(i, j int) bool { return result.Modules[i].Module < result.Modules[j].Module })
+	sort.Slice(result.NewerThanMain, func(i, j int) bool {
+		a, b := result.NewerThanMain[i], result.NewerThanMain[j]
+		if c := compareGoVersions(a.GoVersion, b.GoVersion); c != 0 {
+			return c > 0
+		}
+		return a.Module < b.Module
+	})
+	return result
+}
+
+// compareGoVersions compares Go language versions such as "1.21",
+// "1.21.3" and "1.22rc1". Missing components count as zero, and a
+// pre-release sorts before the release it precedes.
+func compareGoVersions(a, b string) int {
+	pa, preA := parseGoVersion(a)
+	pb, preB := parseGoVersion(b)
+	for i := 0; i < 3; i++ {
+		if pa[i] != pb[i] {
+			if pa[i] < pb[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case preA == preB:
+		return 0
+	case preA == "":
+		return 1
+	case preB == "":
+		return -1
+	case preA < preB:
+		return -1
+	default:
+		return 1
+	}
+}
+
+func parseGoVersion(v string) ([3]int, string) {
+	var parts [3]int
+	v = strings.TrimPrefix(v, "go")
+	pre := ""
+	if i := strings.IndexAny(v, "abcdefghijklmnopqrstuvwxyz"); i >= 0 {
+		v, pre = v[:i], v[i:]
+	}
+	for i, p := range strings.SplitN(v, ".", 3) {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			break
+		}
+		parts[i] = n
+	}
+	return parts, pre
+}
+
+func printToolchain(result ToolchainResult) {
+	mainGo := result.MainGoVersion
+	if mainGo == "" {
+		mainGo = "unknown"
+	}
+	fmt.Printf("Main module go directive: %s\n", mainGo)
+	if len(result.Modules) > 0 {
+		fmt.Println()
+		newer := make(map[string]bool)
+		for _, m := range result.NewerThanMain {
+			newer[m.Module] = true
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "MODULE\tVERSION\tGO\t")
+		for _, m := range result.Modules {
+			goVersion := m.GoVersion
+			if goVersion == "" {
+				goVersion = "-"
+			}
+			if newer[m.Module] {
+				goVersion += " (newer)"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t\n", m.Module, m.Version, goVersion)
+		}
+		_ = w.Flush()
+	}
+	fmt.Println()
+	if len(result.NewerThanMain) == 0 {
+		fmt.Println("No dependencies require a newer Go version than the main module.")
+		return
+	}
+	fmt.Printf("REQUIRE NEWER GO (%d):\n", len(result.NewerThanMain))
+	for _, m := range result.NewerThanMain {
+		fmt.Printf("  %s %s (go %s)\n", m.Module, m.Version, m.GoVersion)
+		if len(m.Path) > 0 {
+			fmt.Printf("    path: %s\n", strings.Join(m.Path, " -> "))
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(toolchainCmd)
+	toolchainCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
+	toolchainCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Get the output in JSON format")
+	toolchainCmd.Flags().BoolVar(&toolchainNewerOnly, "newer-only", false, "Only report dependencies requiring a newer Go version than the main module")
+	toolchainCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
+	toolchainCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Specify main modules")
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompareGoVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.21", "1.21.0", 0},
+		{"1.22", "1.21.9", 1},
+		{"1.21.3", "1.21.10", -1},
+		{"1.22rc1", "1.22", -1},
+		{"1.22rc2", "1.22rc1", 1},
+		{"go1.23", "1.22", 1},
+	}
+	for _, tt := range tests {
+		if got := compareGoVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareGoVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestFindGoVersions(t *testing.T) {
+	depGraph := generateGraph(`main A@v1.0.0
+A@v1.0.0 B@v1.1.0
+main C@v0.3.0`, []string{"main"})
+	modules := []goModule{
+		{Path: "main", Main: true, GoVersion: "1.22"},
+		{Path: "A", Version: "v1.0.0", GoVersion: "1.21"},
+		{Path: "B", Version: "v1.1.0", GoVersion: "1.23.1"},
+		{Path: "C", Version: "v0.3.0"},
+		{Path: "D", Version: "v9.0.0", GoVersion: "1.24"}, // not in graph
+	}
+	result := findGoVersions(modules, &depGraph)
+	if result.MainGoVersion != "1.22" {
+		t.Errorf("MainGoVersion = %q, want 1.22", result.MainGoVersion)
+	}
+	if len(result.Modules) != 3 {
+		t.Errorf("expected 3 modules, got %+v", result.Modules)
+	}
+	if len(result.NewerThanMain) != 1 || result.NewerThanMain[0].Module != "B" {
+		t.Fatalf("unexpected newer modules %+v", result.NewerThanMain)
+	}
+	if p := strings.Join(result.NewerThanMain[0].Path, " -> "); p != "main -> A -> B" {
+		t.Errorf("unexpected path %q", p)
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// toolsScope selects how dependencies only needed by the tool directives of
+// go.mod are treated: "include" keeps them, "exclude" drops them and
+// "only" keeps nothing else.
+var toolsScope = "include"
+
+// addToolsFlag registers --tools on a command analyzing the graph.
+func addToolsFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&toolsScope, "tools", "include", "Dependencies only needed by go.mod tool directives: include, exclude, or only")
+}
+
+func validateToolsScope(scope string) error {
+	switch scope {
+	case "include", "exclude", "only":
+		return nil
+	}
+	return withExitCode(ExitUsage, fmt.Errorf("--tools must be one of: include, exclude, only"))
+}
+
+// classifyToolDeps returns the modules providing packages built by the
+// tools declared in go.mod but not by the packages in --dir or their tests.
+// It returns nil when go.mod declares no tools.
+func classifyToolDeps() (map[string]bool, error) {
+	gomod, err := readGoModFile()
+	if err != nil {
+		return nil, err
+	}
+	if len(gomod.Tool) == 0 {
+		return nil, nil
+	}
+	toolModules, err := listPackageModulesOf([]string{"tool"}, "-deps")
+	if err != nil {
+		return nil, err
+	}
+	codeModules, err := listPackageModulesOf([]string{"./..."}, "-deps", "-test")
+	if err != nil {
+		return nil, err
+	}
+	toolOnly := make(map[string]bool)
+	for mod := range toolModules {
+		if !codeModules[mod] {
+			toolOnly[mod] = true
+		}
+	}
+	return toolOnly, nil
+}
+
+// applyToolsScope narrows the graph to the --tools scope. Since go.mod lists
+// every module providing a package (Go 1.17 graph pruning), each tool-only
+// module stays reachable from the main modules under --tools only.
+func applyToolsScope(depGraph DependencyOverview, scope string, toolOnly map[string]bool) DependencyOverview {
+	var drop []string
+	for _, dep := range getAllDeps(depGraph.DirectDepList, depGraph.TransDepList) {
+		if contains(depGraph.MainModules, dep) {
+			continue
+		}
+		if (scope == "exclude") == toolOnly[dep] {
+			drop = append(drop, dep)
+		}
+	}
+	if len(drop) == 0 {
+		return depGraph
+	}
+	return applyModuleExclusions(depGraph, drop)
+}
+
+// loadToolsScope applies --tools to a graph loaded from --dir.
+func loadToolsScope(depGraph DependencyOverview) (DependencyOverview, error) {
+	if toolsScope == "include" {
+		return depGraph, nil
+	}
+	toolOnly, err := classifyToolDeps()
+	if err != nil {
+		return depGraph, fmt.Errorf("classifying tool dependencies: %w", err)
+	}
+	if toolOnly == nil {
+		warnf("--tools %s: go.mod declares no tool directives\n", toolsScope)
+	}
+	debugf("%d tool-only dependencies\n", len(toolOnly))
+	return applyToolsScope(depGraph, toolsScope, toolOnly), nil
+}
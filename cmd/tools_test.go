@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyToolsScope(t *testing.T) {
+	graph := generateGraph(`example.com/app example.com/lib@v1.0.0
+example.com/app example.com/tool@v1.0.0
+example.com/app example.com/shared@v1.0.0
+example.com/app example.com/tooldep@v1.0.0
+example.com/tool@v1.0.0 example.com/tooldep@v1.0.0
+example.com/tool@v1.0.0 example.com/shared@v1.0.0
+example.com/lib@v1.0.0 example.com/shared@v1.0.0
+`, []string{"example.com/app"})
+	toolOnly := map[string]bool{"example.com/tool": true, "example.com/tooldep": true}
+
+	tests := []struct {
+		scope string
+		want  []string
+	}{
+		{"exclude", []string{"example.com/lib", "example.com/shared"}},
+		{"only", []string{"example.com/tool", "example.com/tooldep"}},
+	}
+	for _, tt := range tests {
+		got := applyToolsScope(graph, tt.scope, toolOnly)
+		deps := sortedCopy(getAllDeps(got.DirectDepList, got.TransDepList))
+		if !reflect.DeepEqual(deps, tt.want) {
+			t.Errorf("--tools %s: got %v, want %v", tt.scope, deps, tt.want)
+		}
+	}
+	if err := validateToolsScope("all"); err == nil {
+		t.Error("expected an error for an unknown scope")
+	}
+}
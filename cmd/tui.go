@@ -0,0 +1,396 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+)
+
+const tuiHelp = `  /          fuzzy-search modules as you type, e.g. /k8sapimach
+  up/down    move in the list (also k/j, pgup/pgdown, home/end)
+  enter      open the selected module (also l, right)
+  d          list dependencies of the current module
+  r          list dependents (reverse dependencies) of the current module
+  w          show why paths from the main modules to the current module
+  b          go back to the previous module (also h, left, backspace)
+  m          go to the main module
+  esc        leave the search, or close help and why paths
+  ?          show or hide this help
+  q          quit (ctrl+c also quits while searching)`
+
+const tuiHints = "enter open  d deps  r dependents  w why  b back  / search  ? help  q quit"
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Explore the dependency graph interactively",
+	Long: `Loads the dependency graph once and opens a full-screen terminal UI to move
+around it without re-running go for every question. The keys are:
+
+` + tuiHelp,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("tui does not take any arguments"))
+		}
+		depGraph := getDepInfo(mainModules)
+		if len(depGraph.MainModules) == 0 {
+			return errNoMainModules
+		}
+		if dryRun {
+			return nil // the session itself runs no go commands
+		}
+		p := tea.NewProgram(newTUIModel(depGraph), tea.WithInput(cmd.InOrStdin()), tea.WithOutput(cmd.OutOrStdout()), tea.WithAltScreen())
+		_, err := p.Run()
+		return err
+	},
+}
+
+// tuiModel is the state of an interactive exploration of the graph, as a
+// bubbletea model.
+type tuiModel struct {
+	depGraph *DependencyOverview
+	reverse  map[string][]string
+	modules  []string
+
+	current string
+	history []string
+
+	// title and list are the module list on screen, cursor the selected
+	// entry and offset the first one visible.
+	title  string
+	list   []string
+	cursor int
+	offset int
+	// why holds the why path lines shown instead of the list, if any.
+	why []string
+
+	searching bool
+	query     string
+	showHelp  bool
+	message   string
+	height    int
+}
+
+func newTUIModel(depGraph *DependencyOverview) *tuiModel {
+	reverse := make(map[string][]string)
+	for from, tos := range depGraph.Graph {
+		for _, to := range tos {
+			reverse[to] = append(reverse[to], from)
+		}
+	}
+	for node := range reverse {
+		sort.Strings(reverse[node])
+	}
+	m := &tuiModel{
+		depGraph: depGraph,
+		reverse:  reverse,
+		modules:  graphNodes(depGraph.Graph),
+		current:  depGraph.MainModules[0],
+		height:   24,
+	}
+	m.showDependencies()
+	return m
+}
+
+func (m *tuiModel) Init() tea.Cmd { return nil }
+
+func (m *tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		if msg.Height > 0 {
+			m.height = msg.Height
+			m.scroll()
+		}
+	case tea.KeyMsg:
+		m.message = ""
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		if m.searching {
+			m.searchKey(msg)
+			return m, nil
+		}
+		if m.handleKey(msg.String()) {
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// searchKey edits the search query, refreshing the matches as it changes.
+func (m *tuiModel) searchKey(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "esc":
+		m.searching = false
+	case "enter":
+		m.searching = false
+		m.open()
+	case "up", "down", "pgup", "pgdown":
+		m.handleKey(msg.String())
+	case "backspace":
+		if m.query != "" {
+			r := []rune(m.query)
+			m.query = string(r[:len(r)-1])
+			m.setList(fmt.Sprintf("Matches for %q", m.query), fuzzySearch(m.query, m.modules))
+		}
+	default:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			m.query += string(msg.Runes)
+			m.setList(fmt.Sprintf("Matches for %q", m.query), fuzzySearch(m.query, m.modules))
+		}
+	}
+}
+
+// handleKey executes a key outside the search and reports whether the
+// session ends.
+func (m *tuiModel) handleKey(key string) bool {
+	switch key {
+	case "q":
+		return true
+	case "?":
+		m.showHelp = !m.showHelp
+	case "esc":
+		m.showHelp = false
+		m.why = nil
+	case "/":
+		m.searching, m.showHelp, m.why = true, false, nil
+		m.query = ""
+		m.setList("Matches for \"\"", fuzzySearch("", m.modules))
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case "pgup":
+		m.move(-m.visibleRows())
+	case "pgdown":
+		m.move(m.visibleRows())
+	case "home", "g":
+		m.move(-len(m.list))
+	case "end", "G":
+		m.move(len(m.list))
+	case "enter", "l", "right":
+		m.open()
+	case "d":
+		m.showDependencies()
+	case "r":
+		m.why = nil
+		m.setList("Dependents of "+m.current, m.reverse[m.current])
+	case "w":
+		m.showWhy()
+	case "b", "h", "left", "backspace":
+		if len(m.history) == 0 {
+			m.message = "No previous module."
+			break
+		}
+		m.current = m.history[len(m.history)-1]
+		m.history = m.history[:len(m.history)-1]
+		m.showDependencies()
+	case "m":
+		m.jump(m.depGraph.MainModules[0])
+	}
+	return false
+}
+
+// open jumps to the selected entry of the list.
+func (m *tuiModel) open() {
+	if m.why != nil || m.showHelp || len(m.list) == 0 {
+		return
+	}
+	m.jump(m.list[m.cursor])
+}
+
+func (m *tuiModel) jump(mod string) {
+	if mod != m.current {
+		m.history = append(m.history, m.current)
+		m.current = mod
+	}
+	m.showDependencies()
+}
+
+func (m *tuiModel) showDependencies() {
+	m.why, m.showHelp = nil, false
+	m.setList("Dependencies of "+m.current, sortedCopy(m.depGraph.Graph[m.current]))
+}
+
+func (m *tuiModel) setList(title string, mods []string) {
+	m.title, m.list = title, mods
+	m.cursor, m.offset = 0, 0
+}
+
+func (m *tuiModel) move(delta int) {
+	m.cursor = max(0, min(m.cursor+delta, len(m.list)-1))
+	m.scroll()
+}
+
+// scroll keeps the cursor within the visible part of the list.
+func (m *tuiModel) scroll() {
+	rows := m.visibleRows()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+rows {
+		m.offset = m.cursor - rows + 1
+	}
+}
+
+// visibleRows is the number of list entries that fit on the screen next to
+// the header, the list title and the footer.
+func (m *tuiModel) visibleRows() int {
+	return max(1, m.height-5)
+}
+
+func (m *tuiModel) showWhy() {
+	var paths [][]string
+	for _, mainMod := range m.depGraph.MainModules {
+		findAllPaths(mainMod, m.current, m.depGraph.Graph, []string{}, make(map[string]bool), &paths, whyDefaultMaxPaths)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if len(paths[i]) != len(paths[j]) {
+			return len(paths[i]) < len(paths[j])
+		}
+		return strings.Join(paths[i], " -> ") < strings.Join(paths[j], " -> ")
+	})
+	total := countPaths(m.depGraph.MainModules, m.current, m.depGraph.Graph)
+	m.showHelp = false
+	m.why = []string{fmt.Sprintf("Why paths to %s (%s in total):", m.current, total)}
+	for i, p := range paths {
+		if i == whyDefaultTextPaths {
+			break
+		}
+		m.why = append(m.why, fmt.Sprintf("  %d. %s", i+1, colorPath(p, m.depGraph.MainModules, m.current)))
+	}
+}
+
+func (m *tuiModel) View() string {
+	var b strings.Builder
+	version := m.depGraph.Versions[m.current]
+	if version != "" {
+		version = "@" + version
+	}
+	fmt.Fprintf(&b, "%s%s: %d dependencies, %d dependents\n\n", colorModule(m.current, m.depGraph.MainModules, ""), version, len(m.depGraph.Graph[m.current]), len(m.reverse[m.current]))
+	switch {
+	case m.showHelp:
+		b.WriteString(tuiHelp + "\n")
+	case m.why != nil:
+		b.WriteString(strings.Join(m.why, "\n") + "\n")
+	default:
+		fmt.Fprintf(&b, "%s (%d):\n", m.title, len(m.list))
+		end := min(len(m.list), m.offset+m.visibleRows())
+		for i := m.offset; i < end; i++ {
+			marker := "  "
+			if i == m.cursor {
+				marker = "> "
+			}
+			fmt.Fprintf(&b, "%s%s\n", marker, colorModule(m.list[i], m.depGraph.MainModules, ""))
+		}
+	}
+	switch {
+	case m.searching:
+		fmt.Fprintf(&b, "\n/%s", m.query)
+	case m.message != "":
+		fmt.Fprintf(&b, "\n%s", m.message)
+	default:
+		fmt.Fprintf(&b, "\n%s", tuiHints)
+	}
+	return b.String()
+}
+
+// fuzzySearch returns the modules containing the characters of query in
+// order, best matches first: modules containing query as a substring,
+// contiguous runs and matches right after a path separator rank higher,
+// then shorter paths.
+func fuzzySearch(query string, modules []string) []string {
+	type match struct {
+		module string
+		score  int
+	}
+	var matches []match
+	for _, mod := range modules {
+		if score, ok := fuzzyScore(strings.ToLower(query), strings.ToLower(mod)); ok {
+			matches = append(matches, match{mod, score})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		if len(matches[i].module) != len(matches[j].module) {
+			return len(matches[i].module) < len(matches[j].module)
+		}
+		return matches[i].module < matches[j].module
+	})
+	result := make([]string, len(matches))
+	for i, m := range matches {
+		result[i] = m.module
+	}
+	return result
+}
+
+func fuzzyScore(query, candidate string) (int, bool) {
+	if query != "" && strings.Contains(candidate, query) {
+		return substringScore(query, candidate), true
+	}
+	score, qi, prev := 0, 0, -2
+	for ci := 0; ci < len(candidate) && qi < len(query); ci++ {
+		if candidate[ci] != query[qi] {
+			continue
+		}
+		score++
+		if ci == prev+1 {
+			score += 2
+		}
+		if ci == 0 || strings.ContainsRune("/.-_", rune(candidate[ci-1])) {
+			score += 3
+		}
+		prev = ci
+		qi++
+	}
+	return score, qi == len(query)
+}
+
+// substringScore scores a candidate containing query: above any scattered
+// match of the same query, and highest when an occurrence starts a path
+// element.
+func substringScore(query, candidate string) int {
+	score := 6 * len(query)
+	for i := 0; i+len(query) <= len(candidate); i++ {
+		if candidate[i:i+len(query)] != query {
+			continue
+		}
+		if i == 0 || strings.ContainsRune("/.-_", rune(candidate[i-1])) {
+			return score + 3
+		}
+	}
+	return score
+}
+
+func sortedCopy(items []string) []string {
+	sorted := append([]string{}, items...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+	tuiCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
+	tuiCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
+	tuiCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Specify main modules")
+}
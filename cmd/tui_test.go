@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFuzzySearch(t *testing.T) {
+	modules := []string{
+		"k8s.io/apimachinery",
+		"k8s.io/api",
+		"github.com/google/gnostic-models",
+		"sigs.k8s.io/yaml",
+	}
+	got := fuzzySearch("k8sapi", modules)
+	want := []string{"k8s.io/api", "k8s.io/apimachinery"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("fuzzySearch = %v, want %v", got, want)
+	}
+	if got := fuzzySearch("zzz", modules); len(got) != 0 {
+		t.Errorf("expected no matches, got %v", got)
+	}
+}
+
+// tuiKeys sends keys to the model: single characters as runes, longer
+// names such as "enter" or "down" as special keys.
+func tuiKeys(t *testing.T, m *tuiModel, keys ...string) {
+	t.Helper()
+	special := map[string]tea.KeyType{"enter": tea.KeyEnter, "down": tea.KeyDown, "up": tea.KeyUp, "esc": tea.KeyEsc, "backspace": tea.KeyBackspace}
+	for _, key := range keys {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		if k, ok := special[key]; ok {
+			msg = tea.KeyMsg{Type: k}
+		}
+		if _, cmd := m.Update(msg); cmd != nil && key != "q" {
+			t.Fatalf("key %q ended the session", key)
+		}
+	}
+}
+
+func TestTUIModel(t *testing.T) {
+	depGraph := generateGraph(`main A@v1.0.0
+main B@v1.0.0
+A@v1.0.0 C@v1.0.0
+B@v1.0.0 C@v1.0.0`, []string{"main"})
+	m := newTUIModel(&depGraph)
+	if view := m.View(); !strings.Contains(view, "Dependencies of main (2):\n> A\n  B\n") {
+		t.Errorf("initial view:\n%s", view)
+	}
+
+	tuiKeys(t, m, "enter", "enter", "r")
+	if m.current != "C" || !strings.Contains(m.View(), "Dependents of C (2):\n> A\n  B\n") {
+		t.Errorf("at %s:\n%s", m.current, m.View())
+	}
+	tuiKeys(t, m, "w")
+	if view := m.View(); !strings.Contains(view, "Why paths to C (2 in total):\n  1. main -> A -> C\n") {
+		t.Errorf("why view:\n%s", view)
+	}
+	tuiKeys(t, m, "b", "b")
+	if m.current != "main" {
+		t.Errorf("expected to be back at main after two b keys, at %s", m.current)
+	}
+	tuiKeys(t, m, "b")
+	if !strings.Contains(m.View(), "No previous module.") {
+		t.Errorf("expected a message at the start of the history:\n%s", m.View())
+	}
+
+	// the search filters as characters are typed
+	tuiKeys(t, m, "/", "b", "x", "backspace", "enter")
+	if m.current != "B" || m.searching {
+		t.Errorf("search opened %s (searching=%v)", m.current, m.searching)
+	}
+	tuiKeys(t, m, "down", "down", "up")
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}); cmd == nil {
+		t.Error("q did not quit")
+	}
+}
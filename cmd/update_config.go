@@ -0,0 +1,227 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var updateConfigFormat string
+var updateConfigDirectory string
+var updateConfigMinGroup int
+
+// UpdateConfigPlan is the update-automation structure derived from the
+// graph: groups of go.mod requirements that move together and requirements
+// that must not be bumped.
+type UpdateConfigPlan struct {
+	Groups  []UpdateGroup   `json:"groups"`
+	Ignored []IgnoredModule `json:"ignored"`
+}
+
+// UpdateGroup is a direct dependency and the go.mod requirements it owns,
+// i.e. that are only in the graph because of it.
+type UpdateGroup struct {
+	Name    string   `json:"name"`
+	Owner   string   `json:"owner"`
+	Modules []string `json:"modules"`
+}
+
+type IgnoredModule struct {
+	Module string `json:"module"`
+	Reason string `json:"reason"`
+}
+
+var updateConfigCmd = &cobra.Command{
+	Use:   "update-config",
+	Short: "Generate Renovate or Dependabot rules that follow the dependency graph",
+	Long: `Emits a configuration fragment for Renovate (--format renovate, JSON) or
+Dependabot (--format dependabot, YAML) derived from the dependency graph:
+
+  - every direct dependency is grouped with the go.mod requirements it
+    dominates (see depstat dominators), so a bump and the indirect bumps it
+    brings land in one pull request;
+  - requirements redirected by replace directives are ignored, since their
+    require version does not decide what is built.
+
+Merge the fragment into renovate.json or .github/dependabot.yml.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("update-config does not take any arguments"))
+		}
+		if updateConfigFormat != "renovate" && updateConfigFormat != "dependabot" {
+			return withExitCode(ExitUsage, fmt.Errorf("--format must be one of: renovate, dependabot"))
+		}
+		depGraph := getDepInfo(mainModules)
+		if len(depGraph.MainModules) == 0 {
+			return errNoMainModules
+		}
+		gomod, err := readGoModFile()
+		if err != nil {
+			return err
+		}
+		plan := computeUpdateConfigPlan(depGraph, gomod, updateConfigMinGroup)
+		if jsonOutput {
+			return writeJSON(os.Stdout, plan)
+		}
+		if updateConfigFormat == "dependabot" {
+			return writeBuffered(os.Stdout, func(w io.Writer) error {
+				writeDependabotConfig(w, plan, updateConfigDirectory)
+				return nil
+			})
+		}
+		return writeJSON(os.Stdout, renovateConfig(plan))
+	},
+}
+
+// computeUpdateConfigPlan groups go.mod requirements under the direct
+// dependency dominating them and lists replaced requirements. Groups with
+// fewer than minGroup modules are dropped.
+func computeUpdateConfigPlan(depGraph *DependencyOverview, gomod *goModFile, minGroup int) UpdateConfigPlan {
+	plan := UpdateConfigPlan{Groups: []UpdateGroup{}, Ignored: []IgnoredModule{}}
+	required := make(map[string]bool)
+	var directs []string
+	for _, r := range gomod.Require {
+		required[r.Path] = true
+		if !r.Indirect {
+			directs = append(directs, r.Path)
+		}
+	}
+
+	replaced := make(map[string]bool)
+	for _, rep := range gomod.Replace {
+		if !required[rep.Old.Path] || replaced[rep.Old.Path] {
+			continue
+		}
+		replaced[rep.Old.Path] = true
+		reason := "replaced by local directory " + rep.New.Path
+		if rep.New.Version != "" {
+			reason = "replaced by " + rep.New.Path + "@" + rep.New.Version
+		}
+		plan.Ignored = append(plan.Ignored, IgnoredModule{Module: rep.Old.Path, Reason: reason})
+	}
+	sort.Slice(plan.Ignored, func(i, j int) bool { return plan.Ignored[i].Module < plan.Ignored[j].Module })
+
+	idom := computeDominators(depGraph.MainModules, depGraph.Graph)
+	owned := make(map[string][]string)
+	for _, r := range gomod.Require {
+		if !r.Indirect || replaced[r.Path] {
+			continue
+		}
+		if owner := lookupDominator(r.Path, idom, directs).Owner; owner != "" {
+			owned[owner] = append(owned[owner], r.Path)
+		}
+	}
+	for _, d := range sortedCopy(directs) {
+		if replaced[d] {
+			continue
+		}
+		modules := append([]string{d}, sortedCopy(owned[d])...)
+		if len(modules) < minGroup {
+			continue
+		}
+		plan.Groups = append(plan.Groups, UpdateGroup{Name: updateGroupName(d), Owner: d, Modules: modules})
+	}
+	return plan
+}
+
+var updateGroupNameSanitizer = regexp.MustCompile(`[^a-z0-9]+`)
+
+// updateGroupName turns a module path into a group identifier accepted by
+// both bots, e.g. k8s.io/client-go -> k8s-io-client-go.
+func updateGroupName(module string) string {
+	return strings.Trim(updateGroupNameSanitizer.ReplaceAllString(strings.ToLower(module), "-"), "-")
+}
+
+type renovatePackageRule struct {
+	Description       string   `json:"description"`
+	MatchManagers     []string `json:"matchManagers"`
+	MatchPackageNames []string `json:"matchPackageNames"`
+	GroupName         string   `json:"groupName,omitempty"`
+	Enabled           *bool    `json:"enabled,omitempty"`
+}
+
+// renovateConfig renders the plan as Renovate packageRules.
+func renovateConfig(plan UpdateConfigPlan) interface{} {
+	rules := []renovatePackageRule{}
+	for _, g := range plan.Groups {
+		rules = append(rules, renovatePackageRule{
+			Description:       fmt.Sprintf("%s and the requirements only it pulls in (generated by depstat)", g.Owner),
+			MatchManagers:     []string{"gomod"},
+			MatchPackageNames: g.Modules,
+			GroupName:         g.Name,
+		})
+	}
+	if len(plan.Ignored) > 0 {
+		disabled := false
+		rule := renovatePackageRule{
+			Description:   "Requirements redirected by replace directives (generated by depstat)",
+			MatchManagers: []string{"gomod"},
+			Enabled:       &disabled,
+		}
+		for _, ig := range plan.Ignored {
+			rule.MatchPackageNames = append(rule.MatchPackageNames, ig.Module)
+		}
+		rules = append(rules, rule)
+	}
+	return map[string]interface{}{"packageRules": rules}
+}
+
+// writeDependabotConfig renders the plan as a dependabot.yml updates entry.
+func writeDependabotConfig(w io.Writer, plan UpdateConfigPlan, directory string) {
+	fmt.Fprintln(w, "# Generated by depstat update-config.")
+	fmt.Fprintln(w, "version: 2")
+	fmt.Fprintln(w, "updates:")
+	fmt.Fprintln(w, "  - package-ecosystem: gomod")
+	fmt.Fprintf(w, "    directory: %q\n", directory)
+	fmt.Fprintln(w, "    schedule:")
+	fmt.Fprintln(w, "      interval: weekly")
+	if len(plan.Groups) > 0 {
+		fmt.Fprintln(w, "    groups:")
+		for _, g := range plan.Groups {
+			fmt.Fprintf(w, "      %s:\n", g.Name)
+			fmt.Fprintln(w, "        patterns:")
+			for _, m := range g.Modules {
+				fmt.Fprintf(w, "          - %q\n", m)
+			}
+		}
+	}
+	if len(plan.Ignored) > 0 {
+		fmt.Fprintln(w, "    ignore:")
+		for _, ig := range plan.Ignored {
+			fmt.Fprintf(w, "      # %s\n", ig.Reason)
+			fmt.Fprintf(w, "      - dependency-name: %q\n", ig.Module)
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(updateConfigCmd)
+	updateConfigCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
+	updateConfigCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Print the computed groups and ignored modules as JSON instead of a bot configuration")
+	updateConfigCmd.Flags().StringVar(&updateConfigFormat, "format", "renovate", "Configuration format: renovate or dependabot")
+	updateConfigCmd.Flags().StringVar(&updateConfigDirectory, "directory", "/", "With --format dependabot, the directory of go.mod within the repository")
+	updateConfigCmd.Flags().IntVar(&updateConfigMinGroup, "min-group-size", 2, "Only emit groups with at least this many modules")
+	updateConfigCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
+	updateConfigCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Specify main modules")
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestComputeUpdateConfigPlan(t *testing.T) {
+	depGraph := &DependencyOverview{
+		MainModules: []string{"m"},
+		Graph: map[string][]string{
+			"m": {"a", "b", "r"},
+			"a": {"a1", "shared"},
+			"b": {"shared"},
+		},
+		DirectDepList: []string{"a", "b", "r"},
+		TransDepList:  []string{"a1", "shared"},
+	}
+	gomod := &goModFile{
+		Require: []goModRequirement{
+			{Path: "a", Version: "v1.0.0"},
+			{Path: "b", Version: "v1.0.0"},
+			{Path: "r", Version: "v1.0.0"},
+			{Path: "a1", Version: "v0.1.0", Indirect: true},
+			{Path: "shared", Version: "v0.1.0", Indirect: true},
+		},
+		Replace: []goModReplace{
+			{Old: goModVersion{Path: "r"}, New: goModVersion{Path: "example.com/fork/r", Version: "v1.0.1"}},
+			{Old: goModVersion{Path: "unrequired"}, New: goModVersion{Path: "../x"}},
+		},
+	}
+	plan := computeUpdateConfigPlan(depGraph, gomod, 2)
+	wantGroups := []UpdateGroup{{Name: "a", Owner: "a", Modules: []string{"a", "a1"}}}
+	if !reflect.DeepEqual(plan.Groups, wantGroups) {
+		t.Errorf("groups = %+v, want %+v", plan.Groups, wantGroups)
+	}
+	wantIgnored := []IgnoredModule{{Module: "r", Reason: "replaced by example.com/fork/r@v1.0.1"}}
+	if !reflect.DeepEqual(plan.Ignored, wantIgnored) {
+		t.Errorf("ignored = %+v, want %+v", plan.Ignored, wantIgnored)
+	}
+
+	var buf bytes.Buffer
+	writeDependabotConfig(&buf, plan, "/")
+	for _, want := range []string{"      a:\n        patterns:\n          - \"a\"\n          - \"a1\"\n", "      - dependency-name: \"r\"\n"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("dependabot config missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestUpdateGroupName(t *testing.T) {
+	if got := updateGroupName("k8s.io/Client-Go/v2"); got != "k8s-io-client-go-v2" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -19,7 +19,6 @@ package cmd
 import (
 	"bufio"
 	"fmt"
-	"log"
 	"os"
 	"os/exec"
 	"path"
@@ -27,6 +26,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"text/tabwriter"
 )
 
 func printChain(slice []string) {
@@ -46,14 +46,41 @@ type DependencyOverview struct {
 	MainModules []string
 	// Versions maps module name to its effective version in the graph
 	Versions map[string]string
+	// Requirements maps module name to the versions requested for it by
+	// modules in the graph, as observed in "go mod graph" output
+	Requirements map[string][]Requirement
+	// Modules maps module name to its "go list -m -json all" metadata when
+	// the golist backend is used, and is nil otherwise
+	Modules map[string]ModuleInfo
+	// ExcludedRequirements maps module name to the requirements naming a
+	// version excluded by go.mod, which "go mod graph" does not show, once
+	// attachExcludedRequirements has run
+	ExcludedRequirements map[string][]Requirement
+
+	// rawGraph is the "go mod graph" output the overview was built from,
+	// before exclusions
+	rawGraph string
+}
+
+// ModuleInfo is the MVS-selected state of a module as reported by
+// "go list -m -json all".
+type ModuleInfo struct {
+	Version  string `json:"version,omitempty"`
+	Indirect bool   `json:"indirect,omitempty"`
+	Replace  string `json:"replace,omitempty"`
+	Update   string `json:"update,omitempty"`
+	Dir      string `json:"dir,omitempty"`
+}
+
+// Requirement is a single versioned requirement edge in the module graph.
+type Requirement struct {
+	From    string `json:"from"`
+	Version string `json:"version"`
 }
 
 // getMainModule returns the main module name using "go list -m"
 func getMainModule() string {
-	goListM := exec.Command("go", "list", "-m")
-	if dir != "" {
-		goListM.Dir = dir
-	}
+	goListM := goCommand([]string{"list", "-m"})
 	output, err := goListM.Output()
 	if err != nil {
 		return ""
@@ -68,68 +95,205 @@ func getMainModule() string {
 }
 
 func getDepInfo(mainModules []string) *DependencyOverview {
-	if len(mainModules) == 0 {
-		mainModules = autoDetectMainModules()
+	depGraph, err := loadDepInfo(mainModules)
+	if err != nil {
+		fatal(err)
 	}
+	return depGraph
+}
 
-	// get output of "go mod graph" in a string
-	goModGraph := exec.Command("go", "mod", "graph")
-	if dir != "" {
-		goModGraph.Dir = dir
+// loadDepInfo is getDepInfo for callers that outlive a failed load, such as
+// the mcp server: it returns the error instead of exiting.
+func loadDepInfo(mainModules []string) (*DependencyOverview, error) {
+	if len(mainModules) == 0 {
+		var err error
+		mainModules, err = autoDetectMainModules()
+		if err != nil {
+			return nil, err
+		}
 	}
-	goModGraphOutput, err := goModGraph.Output()
-	if err != nil {
-		log.Fatal(err)
+
+	// get output of "go mod graph" in a string, unless prefetchGraphs
+	// already ran it
+	goModGraphOutputString, ok := takePrefetchedGraph()
+	if !ok {
+		goModGraph := goCommand([]string{"mod", "graph"})
+		goModGraphOutput, err := goModGraph.Output()
+		if err != nil {
+			return nil, goCommandError(goModGraph, err)
+		}
+		goModGraphOutputString = string(goModGraphOutput)
 	}
-	goModGraphOutputString := string(goModGraphOutput)
 
 	// create a graph of dependencies from that output
 	depGraph := generateGraph(goModGraphOutputString, mainModules)
-	depGraph = applyModuleExclusions(depGraph, excludeModules)
-	return &depGraph
+	depGraph = excludeModulesFrom(depGraph, excludeModules)
+	depGraph = excludeEdgesFrom(depGraph, edgeExclusions)
+	depGraph, err := loadToolsScope(depGraph)
+	if err != nil {
+		return nil, err
+	}
+	if depBackend == "golist" {
+		modules, err := listAllModules(nil)
+		if err != nil {
+			return nil, fmt.Errorf("go list -m -json all: %w", err)
+		}
+		attachModuleMetadata(&depGraph, modules)
+	}
+	depGraph.rawGraph = goModGraphOutputString
+	return &depGraph, nil
 }
 
-func autoDetectMainModules() []string {
-	if !autoMainModules {
-		if mainMod := getMainModule(); mainMod != "" {
-			return []string{mainMod}
+// attachModuleMetadata records "go list" metadata for every module in the
+// graph and replaces the versions observed in "go mod graph" with the
+// versions MVS actually selected.
+func attachModuleMetadata(depGraph *DependencyOverview, modules []goModule) {
+	depGraph.Modules = make(map[string]ModuleInfo)
+	for _, mod := range modules {
+		if _, ok := depGraph.Versions[mod.Path]; !ok && !contains(depGraph.MainModules, mod.Path) {
+			continue
 		}
-		return nil
+		info := ModuleInfo{Version: mod.Version, Indirect: mod.Indirect, Dir: mod.Dir}
+		if mod.Replace != nil {
+			info.Replace = mod.Replace.Path
+			if mod.Replace.Version != "" {
+				info.Replace += "@" + mod.Replace.Version
+			}
+		}
+		if mod.Update != nil {
+			info.Update = mod.Update.Version
+		}
+		depGraph.Modules[mod.Path] = info
+		if mod.Version != "" && depGraph.Versions[mod.Path] != "" {
+			depGraph.Versions[mod.Path] = mod.Version
+		}
+	}
+}
+
+// printModuleInfo prints dependencies with their "go list" metadata.
+func printModuleInfo(deps []string, modules map[string]ModuleInfo) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MODULE\tVERSION\tINDIRECT\tREPLACE\tUPDATE\t")
+	for _, dep := range deps {
+		info := modules[dep]
+		replace, update := info.Replace, info.Update
+		if replace == "" {
+			replace = "-"
+		}
+		if update == "" {
+			update = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%t\t%s\t%s\t\n", dep, info.Version, info.Indirect, replace, update)
+	}
+	_ = w.Flush()
+}
+
+// graphSource selects where a dependency graph is loaded from: a captured
+// "go mod graph" output file, a module directory, or (when both are empty)
+// the directory given by --dir.
+type graphSource struct {
+	Dir       string
+	GraphFile string
+	// GraphFlag names the flag GraphFile was given to, --graph-file when
+	// empty; "-" reads the graph from stdin.
+	GraphFlag string
+}
+
+// load returns the dependency graph for the source, applying the current
+// module exclusions.
+func (s graphSource) load(mainModules []string) (*DependencyOverview, error) {
+	if s.GraphFile != "" {
+		flag := s.GraphFlag
+		if flag == "" {
+			flag = "--graph-file"
+		}
+		data, err := readInputFile(flag, s.GraphFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading graph file: %w", err)
+		}
+		if toolsScope != "include" {
+			return nil, fmt.Errorf("--tools %s needs the module directory; it cannot be used with a graph file", toolsScope)
+		}
+		depGraph := generateGraph(string(data), mainModules)
+		depGraph = excludeModulesFrom(depGraph, excludeModules)
+		depGraph = excludeEdgesFrom(depGraph, edgeExclusions)
+		depGraph.rawGraph = string(data)
+		return &depGraph, nil
+	}
+	if s.Dir != "" {
+		oldDir := dir
+		dir = s.Dir
+		defer func() { dir = oldDir }()
+	}
+	return loadDepInfo(mainModules)
+}
+
+// autoDetectMainModules returns the main modules the --main strategy finds
+// when --mainModules is not given.
+func autoDetectMainModules() ([]string, error) {
+	baseDir := mainModuleBaseDir()
+	switch mainStrategy {
+	case "list":
+		return nil, nil
+	case "gomod":
+		modPath, err := modulePathFromDir(baseDir)
+		if err != nil {
+			return nil, fmt.Errorf("--main=gomod: %w", err)
+		}
+		return []string{modPath}, nil
+	case "all-workspace":
+		modules, err := workspaceModules(baseDir)
+		if err != nil {
+			return nil, fmt.Errorf("--main=all-workspace: parsing go.work: %w", err)
+		}
+		if len(modules) == 0 {
+			return nil, fmt.Errorf("--main=all-workspace: no go.work modules found in %s", baseDir)
+		}
+		infof("Using %d go.work modules:\n", len(modules))
+		for _, mod := range modules {
+			infof("  - %s\n", mod)
+		}
+		return modules, nil
 	}
 
-	baseDir := dir
-	if baseDir == "" {
-		wd, err := os.Getwd()
-		if err == nil {
-			baseDir = wd
+	if !autoMainModules {
+		if mainMod := getMainModule(); mainMod != "" {
+			return []string{mainMod}, nil
 		}
+		return nil, nil
 	}
 
-	modules, err := detectModulesFromGoWork(baseDir)
+	modules, err := workspaceModules(baseDir)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "warning: failed to parse go.work: %v\n", err)
+		warnf("failed to parse go.work: %v\n", err)
 	}
 	if len(modules) == 0 {
 		modules, err = detectModulesByScan(baseDir)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "warning: failed to scan modules: %v\n", err)
+			warnf("failed to scan modules: %v\n", err)
 		}
 	}
 	if len(modules) == 0 {
 		if mainMod := getMainModule(); mainMod != "" {
-			return []string{mainMod}
+			return []string{mainMod}, nil
 		}
-		return nil
+		return nil, nil
 	}
 
 	filtered := filterDefaultModuleExclusions(modules)
 	printAutoModuleSelection(filtered, modules)
-	return filtered
+	return filtered, nil
 }
 
 func detectModulesFromGoWork(baseDir string) ([]string, error) {
-	path := filepath.Join(baseDir, "go.work")
-	data, err := os.ReadFile(path)
+	return detectModulesFromGoWorkFile(filepath.Join(baseDir, "go.work"))
+}
+
+// detectModulesFromGoWorkFile returns the modules used by a go.work file;
+// relative use directives are resolved against the file's directory.
+func detectModulesFromGoWorkFile(workFile string) ([]string, error) {
+	baseDir := filepath.Dir(workFile)
+	data, err := os.ReadFile(workFile)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
@@ -178,7 +342,7 @@ func detectModulesByScan(baseDir string) ([]string, error) {
 		}
 		if d.IsDir() {
 			name := d.Name()
-			if strings.HasPrefix(name, ".") || name == "vendor" {
+			if strings.HasPrefix(name, ".") || name == "vendor" || name == "testdata" {
 				return filepath.SkipDir
 			}
 			return nil
@@ -242,12 +406,12 @@ func printAutoModuleSelection(selected []string, discovered []string) {
 	if len(selected) == 0 {
 		return
 	}
-	fmt.Fprintf(os.Stderr, "Auto-detected %d modules (excluding tools/*):\n", len(selected))
+	infof("Auto-detected %d modules (excluding tools/*):\n", len(selected))
 	for _, mod := range selected {
-		fmt.Fprintf(os.Stderr, "  - %s\n", mod)
+		infof("  - %s\n", mod)
 	}
 	if len(discovered) != len(selected) {
-		fmt.Fprintln(os.Stderr, "Use --mainModules to override.")
+		infof("Use --mainModules to override.\n")
 	}
 }
 
@@ -338,20 +502,79 @@ func parseModule(s string) module {
 // a set of module names that are only reachable through test imports.
 // A module is test-only if the shortest import path from the main module
 // passes through a .test pseudo-package (generated by `go test`).
+// With --classifier=packages, classifyByPackages is used instead.
+//
+// Results are cached by the hash of go.mod and go.sum (see classifyCacheKey),
+// so only modules missing from the cache are passed to go mod why.
 func classifyTestDeps(deps []string) (map[string]bool, error) {
+	return classifyTestDepsWith(testClassifier, deps)
+}
+
+// classifyTestDepsWith classifies deps with the named classifier.
+func classifyTestDepsWith(classifier string, deps []string) (map[string]bool, error) {
 	if len(deps) == 0 {
 		return map[string]bool{}, nil
 	}
-	args := append([]string{"mod", "why", "-m"}, deps...)
-	cmd := exec.Command("go", args...)
-	if dir != "" {
-		cmd.Dir = dir
+	key := ""
+	if !noCache {
+		key = classifyCacheKey(classifier)
+	}
+	var cached map[string]bool
+	if key != "" {
+		cached = loadClassifyCache(key)
+	}
+	var missing []string
+	for _, dep := range deps {
+		if _, ok := cached[dep]; !ok {
+			missing = append(missing, dep)
+		}
+	}
+	if len(missing) > 0 {
+		if len(cached) > 0 {
+			debugf("classification cache: %d of %d modules cached\n", len(deps)-len(missing), len(deps))
+		}
+		var testOnly map[string]bool
+		var err error
+		if classifier == "packages" {
+			testOnly, err = classifyByPackages(missing)
+		} else {
+			var output string
+			output, err = runModWhy(missing)
+			testOnly = parseModWhyOutput(output)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if cached == nil {
+			cached = make(map[string]bool)
+		}
+		for _, dep := range missing {
+			cached[dep] = testOnly[dep]
+		}
+		if key != "" {
+			storeClassifyCache(key, cached)
+		}
+	} else {
+		debugf("classification cache: all %d modules cached\n", len(deps))
+	}
+	result := make(map[string]bool)
+	for _, dep := range deps {
+		if cached[dep] {
+			result[dep] = true
+		}
 	}
+	return result, nil
+}
+
+// runModWhy runs `go mod why -m` for the given modules and returns its output.
+func runModWhy(deps []string) (string, error) {
+	args := append([]string{"mod", "why", "-m"}, deps...)
+	cmd := goCommand(args)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return nil, fmt.Errorf("go mod why -m failed: %w: %s", err, strings.TrimSpace(string(output)))
+		return "", fmt.Errorf("go mod why -m failed: %w: %s", err, strings.TrimSpace(string(output)))
 	}
-	return parseModWhyOutput(string(output)), nil
+	return string(output), nil
 }
 
 // parseModWhyOutput parses `go mod why -m` batch output and returns
@@ -409,6 +632,30 @@ func parseModWhyOutput(output string) map[string]bool {
 	return testOnly
 }
 
+// parseModWhyPaths parses `go mod why -m` batch output and returns the
+// package import chain reported for each module. Modules the main module
+// does not need are omitted.
+func parseModWhyPaths(output string) map[string][]string {
+	paths := make(map[string][]string)
+	scanner := bufio.NewScanner(strings.NewReader(output))
+
+	var currentModule string
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "# "):
+			currentModule = strings.TrimPrefix(line, "# ")
+		case line == "" || currentModule == "":
+		case strings.HasPrefix(line, "(main module does not need"):
+			delete(paths, currentModule)
+			currentModule = ""
+		default:
+			paths[currentModule] = append(paths[currentModule], line)
+		}
+	}
+	return paths
+}
+
 // VendorModule represents a module entry from vendor/modules.txt.
 type VendorModule struct {
 	Path    string `json:"path"`
@@ -422,6 +669,7 @@ func gitShowFile(ref, filePath string) (string, bool) {
 	if dir != "" {
 		cmd.Dir = dir
 	}
+	logCommand(cmd)
 	out, err := cmd.Output()
 	if err != nil {
 		return "", false
@@ -459,6 +707,7 @@ func gitDiffFiles(baseRef, headRef, pathPrefix string) (added []string, deleted
 	if dir != "" {
 		addCmd.Dir = dir
 	}
+	logCommand(addCmd)
 	addOut, err := addCmd.Output()
 	if err != nil {
 		return nil, nil, fmt.Errorf("git diff --diff-filter=A: %w", err)
@@ -473,6 +722,7 @@ func gitDiffFiles(baseRef, headRef, pathPrefix string) (added []string, deleted
 	if dir != "" {
 		delCmd.Dir = dir
 	}
+	logCommand(delCmd)
 	delOut, err := delCmd.Output()
 	if err != nil {
 		return nil, nil, fmt.Errorf("git diff --diff-filter=D: %w", err)
@@ -493,6 +743,7 @@ func generateGraph(goModGraphOutputString string, mainModules []string) Dependen
 	graph := make(map[string][]string)
 	scanner := bufio.NewScanner(strings.NewReader(goModGraphOutputString))
 
+	requirements := make(map[string][]Requirement)
 	var versionedMainModules []module
 	var seenVersionedMainModules = map[module]bool{}
 	for scanner.Scan() {
@@ -540,6 +791,11 @@ func generateGraph(goModGraphOutputString string, mainModules []string) Dependen
 		}
 	}
 
+	if selectedOnly {
+		// edges and reachability below then only follow selected versions
+		effectiveVersions = mvsSelectedVersions(versionedGraph, versionedMainModules)
+	}
+
 	type edge struct {
 		from module
 		to   module
@@ -575,6 +831,15 @@ func generateGraph(goModGraphOutputString string, mainModules []string) Dependen
 		}
 	}
 
+	if selectedOnly {
+		// drop modules only required by versions that were not selected
+		for name := range effectiveVersions {
+			if _, reachable := reachableModules[name]; !reachable {
+				delete(effectiveVersions, name)
+			}
+		}
+	}
+
 	for _, lhs := range lhss {
 		if _, reachable := reachableModules[lhs.name]; !reachable {
 			// this is not reachable via required versions, skip it
@@ -591,6 +856,7 @@ func generateGraph(goModGraphOutputString string, mainModules []string) Dependen
 			if !contains(graph[lhs.name], rhs.name) {
 				graph[lhs.name] = append(graph[lhs.name], rhs.name)
 			}
+			requirements[rhs.name] = append(requirements[rhs.name], Requirement{From: lhs.name, Version: rhs.version})
 
 			// if the LHS is a mainModule
 			// then RHS is a direct dep else transitive dep
@@ -610,8 +876,24 @@ func generateGraph(goModGraphOutputString string, mainModules []string) Dependen
 		}
 	}
 
+	// sort everything so results do not depend on the order of the lines
+	for _, tos := range graph {
+		sort.Strings(tos)
+	}
+	for _, reqs := range requirements {
+		sort.Slice(reqs, func(i, j int) bool {
+			if reqs[i].From != reqs[j].From {
+				return reqs[i].From < reqs[j].From
+			}
+			return reqs[i].Version < reqs[j].Version
+		})
+	}
+	sort.Strings(depGraph.DirectDepList)
+	sort.Strings(depGraph.TransDepList)
+
 	depGraph.Graph = graph
 	depGraph.Versions = effectiveVersions
+	depGraph.Requirements = requirements
 
 	return depGraph
 }
@@ -622,13 +904,11 @@ func applyModuleExclusions(depGraph DependencyOverview, patterns []string) Depen
 	}
 
 	mainModules := make([]string, 0, len(depGraph.MainModules))
-	mainSet := map[string]bool{}
 	for _, m := range depGraph.MainModules {
 		if moduleExcluded(m, patterns) {
 			continue
 		}
 		mainModules = append(mainModules, m)
-		mainSet[m] = true
 	}
 	if len(mainModules) == 0 {
 		return DependencyOverview{
@@ -637,9 +917,22 @@ func applyModuleExclusions(depGraph DependencyOverview, patterns []string) Depen
 			TransDepList:  []string{},
 			MainModules:   []string{},
 			Versions:      map[string]string{},
+			Requirements:  map[string][]Requirement{},
 		}
 	}
 
+	return filterReachable(depGraph, mainModules, func(from, to string) bool {
+		return !moduleExcluded(to, patterns)
+	})
+}
+
+// filterReachable keeps the part of the graph reachable from mainModules
+// through the edges keep accepts.
+func filterReachable(depGraph DependencyOverview, mainModules []string, keep func(from, to string) bool) DependencyOverview {
+	mainSet := map[string]bool{}
+	for _, m := range mainModules {
+		mainSet[m] = true
+	}
 	reachable := map[string]bool{}
 	queue := append([]string{}, mainModules...)
 	for len(queue) > 0 {
@@ -650,7 +943,7 @@ func applyModuleExclusions(depGraph DependencyOverview, patterns []string) Depen
 		}
 		reachable[current] = true
 		for _, next := range depGraph.Graph[current] {
-			if moduleExcluded(next, patterns) {
+			if !keep(current, next) {
 				continue
 			}
 			if !reachable[next] {
@@ -669,7 +962,7 @@ func applyModuleExclusions(depGraph DependencyOverview, patterns []string) Depen
 			continue
 		}
 		for _, rhs := range rhsList {
-			if !reachable[rhs] {
+			if !reachable[rhs] || !keep(lhs, rhs) {
 				continue
 			}
 			filteredGraph[lhs] = append(filteredGraph[lhs], rhs)
@@ -695,6 +988,17 @@ func applyModuleExclusions(depGraph DependencyOverview, patterns []string) Depen
 			filteredVersions[module] = version
 		}
 	}
+	filteredRequirements := map[string][]Requirement{}
+	for module, reqs := range depGraph.Requirements {
+		if !reachable[module] {
+			continue
+		}
+		for _, r := range reqs {
+			if reachable[r.From] && keep(r.From, module) {
+				filteredRequirements[module] = append(filteredRequirements[module], r)
+			}
+		}
+	}
 
 	return DependencyOverview{
 		Graph:         filteredGraph,
@@ -702,6 +1006,7 @@ func applyModuleExclusions(depGraph DependencyOverview, patterns []string) Depen
 		TransDepList:  transDeps,
 		MainModules:   mainModules,
 		Versions:      filteredVersions,
+		Requirements:  filteredRequirements,
 	}
 }
 
@@ -714,7 +1019,13 @@ func moduleExcluded(modulePath string, patterns []string) bool {
 	return false
 }
 
+// matchModulePattern reports whether modulePath matches the path.Match
+// pattern. With --normalize-paths, paths on case-insensitive hosts match
+// regardless of case.
 func matchModulePattern(modulePath, pattern string) bool {
+	if normalizePaths && caseFoldedHost(modulePath) {
+		modulePath, pattern = strings.ToLower(modulePath), strings.ToLower(pattern)
+	}
 	matched, err := path.Match(pattern, modulePath)
 	return err == nil && matched
 }
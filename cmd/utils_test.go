@@ -17,7 +17,12 @@ limitations under the License.
 package cmd
 
 import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func Test_getChains_simple(t *testing.T) {
@@ -376,8 +381,8 @@ D@1.2 E@1.8`
 func Test_generateGraph_empty_mainModule(t *testing.T) {
 	depGraph := generateGraph(getGoModGraphTestData(), nil)
 
-	transitiveDependencyList := []string{"F", "C", "E"}
-	directDependencyList := []string{"G", "B", "D"}
+	transitiveDependencyList := []string{"C", "E", "F"}
+	directDependencyList := []string{"B", "D", "G"}
 
 	if depGraph.MainModules[0] != "A" {
 		t.Errorf(`"A" must be the main module`)
@@ -396,8 +401,8 @@ func Test_generateGraph_custom_mainModule(t *testing.T) {
 	mainModules := []string{"A", "D"}
 	depGraph := generateGraph(getGoModGraphTestData(), mainModules)
 
-	transitiveDependencyList := []string{"F", "C"}
-	directDependencyList := []string{"G", "B", "C", "E"}
+	transitiveDependencyList := []string{"C", "F"}
+	directDependencyList := []string{"B", "C", "E", "G"}
 
 	if !isSliceSame(depGraph.MainModules, mainModules) {
 		t.Errorf("Expected mainModules are %s but got %s", mainModules, depGraph.MainModules)
@@ -412,6 +417,20 @@ func Test_generateGraph_custom_mainModule(t *testing.T) {
 	}
 }
 
+func Test_generateGraph_orderIndependent(t *testing.T) {
+	lines := strings.Split(getGoModGraphTestData(), "\n")
+	reversed := make([]string, len(lines))
+	for i, line := range lines {
+		reversed[len(lines)-1-i] = line
+	}
+	mainModules := []string{"A"}
+	want := generateGraph(getGoModGraphTestData(), mainModules)
+	got := generateGraph(strings.Join(reversed, "\n"), mainModules)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("graph depends on the order of go mod graph lines:\ngot  %+v\nwant %+v", got, want)
+	}
+}
+
 func Test_parseModWhyOutput_testOnly(t *testing.T) {
 	output := `# github.com/prod/dep
 main/pkg
@@ -716,6 +735,26 @@ func Test_computeStats_noMainModule(t *testing.T) {
 	}
 }
 
+func Test_longestChainFromMains(t *testing.T) {
+	depGraph := &DependencyOverview{
+		MainModules: []string{"A", "X"},
+		Graph: map[string][]string{
+			"A": {"B"},
+			"X": {"Y"},
+			"Y": {"B", "Z"},
+			"Z": {"B"},
+		},
+	}
+	got := longestChainFromMains(depGraph)
+	if want := (Chain{"X", "Y", "Z", "B"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("longestChainFromMains() = %v, want %v", got, want)
+	}
+	snapshot := snapshotFromGraph(depGraph)
+	if want := map[string]int{"A": 2, "X": 4}; snapshot.MaxDepth != 4 || !reflect.DeepEqual(snapshot.MaxDepthByModule, want) {
+		t.Errorf("MaxDepth = %d, MaxDepthByModule = %v, want 4 and %v", snapshot.MaxDepth, snapshot.MaxDepthByModule, want)
+	}
+}
+
 func Test_matchModulePattern(t *testing.T) {
 	tests := []struct {
 		module  string
@@ -742,6 +781,42 @@ func Test_matchModulePattern(t *testing.T) {
 	}
 }
 
+func Test_matchModulePattern_normalized(t *testing.T) {
+	normalizePaths = true
+	defer func() { normalizePaths = false }()
+	tests := []struct {
+		module  string
+		pattern string
+		want    bool
+	}{
+		// Case-insensitive hosts match regardless of case
+		{"github.com/Sirupsen/logrus", "github.com/sirupsen/*", true},
+		{"GitHub.com/foo/bar", "github.com/Foo/bar", true},
+		// Other hosts stay case-sensitive
+		{"example.com/Foo", "example.com/foo", false},
+	}
+	for _, tt := range tests {
+		got := matchModulePattern(tt.module, tt.pattern)
+		if got != tt.want {
+			t.Errorf("matchModulePattern(%q, %q) = %v, want %v", tt.module, tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func Test_countProjects(t *testing.T) {
+	got := countProjects([]string{
+		"github.com/Sirupsen/logrus",
+		"github.com/sirupsen/logrus",
+		"github.com/foo/bar",
+		"github.com/foo/bar/v2",
+		"example.com/Foo",
+		"example.com/foo",
+	})
+	if got != 4 {
+		t.Errorf("countProjects = %d, want 4", got)
+	}
+}
+
 func Test_applyModuleExclusions_empty(t *testing.T) {
 	original := DependencyOverview{
 		MainModules:   []string{"main"},
@@ -871,3 +946,200 @@ func Test_applyModuleExclusions_excludeMainModule(t *testing.T) {
 		t.Errorf("A should remain (reachable from main2)")
 	}
 }
+
+func Test_computeDepthHistogram(t *testing.T) {
+	depGraph := &DependencyOverview{
+		MainModules:   []string{"main"},
+		DirectDepList: []string{"A", "B"},
+		TransDepList:  []string{"C", "D", "E"},
+		Graph: map[string][]string{
+			"main": {"A", "B"},
+			"A":    {"C"},
+			"B":    {"C", "D"},
+			"D":    {"E"},
+		},
+	}
+	h := computeDepthHistogram(depGraph)
+	want := []DepthBucket{{Depth: 1, Count: 2}, {Depth: 2, Count: 2}, {Depth: 3, Count: 1}}
+	if !reflect.DeepEqual(h.Buckets, want) {
+		t.Errorf("unexpected buckets %v", h.Buckets)
+	}
+	if h.P50 != 2 || h.P90 != 3 || h.Max != 3 {
+		t.Errorf("unexpected percentiles p50=%d p90=%d max=%d", h.P50, h.P90, h.Max)
+	}
+}
+
+func Test_topLongestChains(t *testing.T) {
+	depGraph := &DependencyOverview{
+		MainModules:   []string{"main"},
+		DirectDepList: []string{"A", "B"},
+		TransDepList:  []string{"C", "D", "E"},
+		Graph: map[string][]string{
+			"main": {"A", "B"},
+			"A":    {"C"},
+			"B":    {"C", "E"},
+			"C":    {"D"},
+		},
+	}
+	got := topLongestChains(depGraph, 3)
+	want := []Chain{
+		{"main", "A", "C", "D"},
+		{"main", "B", "E"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected chains %v", got)
+	}
+}
+
+func Test_countByOrg(t *testing.T) {
+	got := countByOrg([]string{
+		"github.com/google/btree",
+		"github.com/google/uuid",
+		"golang.org/x/net",
+		"golang.org/x/sys",
+		"k8s.io/klog/v2",
+		"github.com/spf13/cobra",
+	})
+	want := []OrgCount{
+		{Org: "github.com/google", Count: 2, Modules: []string{"github.com/google/btree", "github.com/google/uuid"}},
+		{Org: "golang.org/x", Count: 2, Modules: []string{"golang.org/x/net", "golang.org/x/sys"}},
+		{Org: "github.com/spf13", Count: 1, Modules: []string{"github.com/spf13/cobra"}},
+		{Org: "k8s.io", Count: 1, Modules: []string{"k8s.io/klog/v2"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected breakdown %v", got)
+	}
+}
+
+func Test_compareDependencySets(t *testing.T) {
+	a := generateGraph(`main A@v1.0.0
+main B@v1.0.0
+A@v1.0.0 C@v1.0.0`, nil)
+	b := generateGraph(`main A@v1.1.0
+main D@v1.0.0`, nil)
+	onlyInA, onlyInB, changes := compareDependencySets(&a, &b)
+	if !reflect.DeepEqual(onlyInA, []string{"B", "C"}) {
+		t.Errorf("unexpected onlyInA %v", onlyInA)
+	}
+	if !reflect.DeepEqual(onlyInB, []string{"D"}) {
+		t.Errorf("unexpected onlyInB %v", onlyInB)
+	}
+	if !reflect.DeepEqual(changes, []VersionChange{{Path: "A", Before: "v1.0.0", After: "v1.1.0"}}) {
+		t.Errorf("unexpected version changes %v", changes)
+	}
+}
+
+func Test_graphSource_loadGraphFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "graph.txt")
+	if err := os.WriteFile(path, []byte("main A@v1.0.0\nA@v1.0.0 B@v1.0.0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	depGraph, err := graphSource{GraphFile: path}.load(nil)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if !reflect.DeepEqual(depGraph.MainModules, []string{"main"}) || !reflect.DeepEqual(depGraph.TransDepList, []string{"B"}) {
+		t.Errorf("unexpected graph %+v", depGraph)
+	}
+	if _, err := (graphSource{GraphFile: path + ".missing"}).load(nil); err == nil {
+		t.Errorf("expected error for missing graph file")
+	}
+}
+
+func Test_attachModuleMetadata(t *testing.T) {
+	depGraph := generateGraph(`main A@v1.0.0
+main B@v1.0.0
+A@v1.0.0 B@v1.2.0`, []string{"main"})
+	attachModuleMetadata(&depGraph, []goModule{
+		{Path: "main", Main: true},
+		{Path: "A", Version: "v1.0.0", Replace: &goModule{Path: "../a"}},
+		{Path: "B", Version: "v1.2.0", Indirect: true, Update: &goModule{Path: "B", Version: "v1.3.0"}},
+		{Path: "C", Version: "v0.1.0"},
+	})
+	if got := depGraph.Versions["B"]; got != "v1.2.0" {
+		t.Errorf("expected selected version v1.2.0 for B, got %s", got)
+	}
+	want := map[string]ModuleInfo{
+		"main": {},
+		"A":    {Version: "v1.0.0", Replace: "../a"},
+		"B":    {Version: "v1.2.0", Indirect: true, Update: "v1.3.0"},
+	}
+	if !reflect.DeepEqual(depGraph.Modules, want) {
+		t.Errorf("Modules = %+v, want %+v", depGraph.Modules, want)
+	}
+}
+
+func Test_outputDOT_graphDiff(t *testing.T) {
+	a := generateGraph(`main A@v1.0.0
+main B@v1.0.0`, nil)
+	b := generateGraph(`main A@v1.1.0
+main C@v1.0.0`, nil)
+	dot, err := captureDOTOutput(func() error {
+		return outputDOT(graphDiffResult(&a, &b, "A", "B"), &a, &b)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"A" [fillcolor="#ffd591", style="filled", label="A\nv1.0.0 → v1.1.0"];`,
+		`"B" [fillcolor="#ffcccc", style="filled,dashed", label="B"];`,
+		`"C" [fillcolor="#ccffcc", style="filled", label="C"];`,
+		`"main" -> "A" [color="#f0a020"];`,
+		`"main" -> "B" [color="red", style="dashed"];`,
+		`"main" -> "C" [color="green", style="bold"];`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT output missing %s:\n%s", want, dot)
+		}
+	}
+}
+
+func Test_appendStatsCSV(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "stats.csv")
+	result := &StatsSnapshot{DirectDeps: 2, TransDeps: 5, TotalDeps: 7, MaxDepth: 3}
+	first := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := appendStatsCSV(file, result, first, "abc123"); err != nil {
+		t.Fatal(err)
+	}
+	result.TransDeps = 6
+	if err := appendStatsCSV(file, result, first.Add(24*time.Hour), ""); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Timestamp,Commit,Direct,Transitive,Total,MaxDepth\n" +
+		"2026-01-02T03:04:05Z,abc123,2,5,7,3\n" +
+		"2026-01-03T03:04:05Z,,2,6,7,3\n"
+	if string(data) != want {
+		t.Errorf("appended CSV =\n%s\nwant\n%s", data, want)
+	}
+
+	testOnly, nonTest := 1, 6
+	result.TestOnlyDeps, result.NonTestOnly = &testOnly, &nonTest
+	if err := appendStatsCSV(file, result, first, ""); err == nil || !strings.Contains(err.Error(), "has header") {
+		t.Errorf("appending other columns: got %v, want a header mismatch error", err)
+	}
+}
+
+func Test_computeEdgeStats(t *testing.T) {
+	depGraph := &DependencyOverview{
+		MainModules:   []string{"main"},
+		DirectDepList: []string{"A", "B"},
+		TransDepList:  []string{"C", "D"},
+		Graph: map[string][]string{
+			"main": {"A", "B"},
+			"A":    {"C", "D"},
+			"B":    {"C", "C"},
+		},
+	}
+	got := computeEdgeStats(depGraph)
+	want := &EdgeStats{TotalEdges: 5, AverageOutDegree: 1, MaxOutDegree: 2, MaxOutDegreeModule: "A", Density: 0.25}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("computeEdgeStats = %+v, want %+v", got, want)
+	}
+	if empty := computeEdgeStats(&DependencyOverview{MainModules: []string{"main"}}); empty.TotalEdges != 0 || empty.Density != 0 {
+		t.Errorf("unexpected stats for an empty graph: %+v", empty)
+	}
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var verifyBase string
+var verifyMaxAdded int
+var verifyMaxTotalDelta int
+var verifyMaxDepthDelta int
+var verifyPolicyFile string
+
+// VerifyResult compares the current checkout against a base and lists the
+// thresholds and policies it violates.
+type VerifyResult struct {
+	Base           string            `json:"base"`
+	MergeBase      string            `json:"mergeBase"`
+	MainModules    []string          `json:"mainModules"`
+	Before         StatsSnapshot     `json:"before"`
+	After          StatsSnapshot     `json:"after"`
+	Delta          StatsSnapshot     `json:"delta"`
+	Added          []string          `json:"added"`
+	Removed        []string          `json:"removed"`
+	VersionChanges []VersionChange   `json:"versionChanges"`
+	Violations     []PolicyViolation `json:"violations"`
+}
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Fail CI when dependency growth against a base exceeds thresholds",
+	Long: `Compares the dependency graph of the current checkout against the merge
+base with --base and fails when the change exceeds any configured threshold:
+
+  --max-added N          more than N modules added
+  --max-total-delta N    total dependencies grew by more than N
+  --max-depth-delta N    maximum depth grew by more than N
+
+--anomalies also flags structural changes the counts hide, each with an
+explanation: a module reached in --depth-jump (default 3) or more fewer hops
+than at the base, a module only needed by tests at the base that non-test
+code now needs (uses test-only classification on both checkouts), and a
+module path host that did not appear at the base.
+
+--policy additionally evaluates the built-in rules of a depstat check policy
+file against the current graph.
+
+With --notify, a summary of the added modules, deltas and violations is
+posted to a Slack- or Teams-compatible incoming webhook when verification
+fails (or always, with --notify-always).`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("verify does not take any arguments"))
+		}
+		if err := validateNotifyFormat(notifyFormat); err != nil {
+			return err
+		}
+		policy, err := loadPolicy(verifyPolicyFile)
+		if err != nil {
+			return err
+		}
+		if verifyMaxAdded < 0 && verifyMaxTotalDelta < 0 && verifyMaxDepthDelta < 0 && policy.empty() && !verifyAnomalies {
+			return fmt.Errorf("no thresholds configured; pass --max-added, --max-total-delta, --max-depth-delta, --policy or --anomalies")
+		}
+		if verifyDepthJump < 1 {
+			return withExitCode(ExitUsage, fmt.Errorf("--depth-jump must be >= 1"))
+		}
+		if notifyURL != "" {
+			if err := requireNetwork("--notify"); err != nil {
+				return err
+			}
+		}
+		mergeBase, err := gitMergeBase(verifyBase, "HEAD")
+		if err != nil {
+			return err
+		}
+		worktreeDir, cleanup, err := gitTempWorktree(mergeBase)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+
+		if err := prefetchGraphs(graphSource{Dir: worktreeDir}, graphSource{}); err != nil {
+			return err
+		}
+		excludes := excludeModules
+		before, err := computeStatsSnapshotFrom(graphSource{Dir: worktreeDir}, mainModules, excludes, false)
+		if err != nil {
+			return err
+		}
+		after, err := computeStatsSnapshotFrom(graphSource{}, mainModules, excludes, false)
+		if err != nil {
+			return err
+		}
+		result := VerifyResult{
+			Base:        verifyBase,
+			MergeBase:   mergeBase,
+			MainModules: after.MainModules,
+			Before:      *before,
+			After:       *after,
+			Delta: StatsSnapshot{
+				DirectDeps: after.DirectDeps - before.DirectDeps,
+				TransDeps:  after.TransDeps - before.TransDeps,
+				TotalDeps:  after.TotalDeps - before.TotalDeps,
+				MaxDepth:   after.MaxDepth - before.MaxDepth,
+			},
+		}
+		result.Removed, result.Added, result.VersionChanges = compareDependencySets(before.graph, after.graph)
+		result.Violations = checkVerifyThresholds(result)
+		policyViolations, err := evaluatePolicy(policy, after.graph)
+		if err != nil {
+			return err
+		}
+		result.Violations = append(result.Violations, policyViolations...)
+		if verifyAnomalies {
+			beforeTestOnly, afterTestOnly, err := findTestOnlyPromotions(worktreeDir, before.graph, after.graph)
+			if err != nil {
+				return err
+			}
+			result.Violations = append(result.Violations, findGraphAnomalies(before.graph, after.graph, beforeTestOnly, afterTestOnly, verifyDepthJump)...)
+		}
+
+		if jsonOutput {
+			if err := writeJSON(os.Stdout, result); err != nil {
+				return err
+			}
+		} else {
+			printVerifyResult(result)
+		}
+		if notifyURL != "" && (len(result.Violations) > 0 || notifyAlways) {
+			if err := postNotification(notifyURL, notifyFormat, verifyNotificationTitle(result), renderVerifySummary(result)); err != nil {
+				warnf("notification failed: %v\n", err)
+			}
+		}
+		if len(result.Violations) > 0 {
+			cmd.SilenceUsage = true
+			return withExitCode(ExitViolation, fmt.Errorf("verify failed: %d violation(s)", len(result.Violations)))
+		}
+		return nil
+	},
+}
+
+// checkVerifyThresholds reports every exceeded threshold. Negative limits
+// are disabled.
+func checkVerifyThresholds(r VerifyResult) []PolicyViolation {
+	violations := []PolicyViolation{}
+	if verifyMaxAdded >= 0 && len(r.Added) > verifyMaxAdded {
+		violations = append(violations, PolicyViolation{
+			Rule:    "max-added",
+			Message: fmt.Sprintf("%d modules added (limit %d)", len(r.Added), verifyMaxAdded),
+		})
+	}
+	if verifyMaxTotalDelta >= 0 && r.Delta.TotalDeps > verifyMaxTotalDelta {
+		violations = append(violations, PolicyViolation{
+			Rule:    "max-total-delta",
+			Message: fmt.Sprintf("total dependencies grew by %d (limit %d)", r.Delta.TotalDeps, verifyMaxTotalDelta),
+		})
+	}
+	if verifyMaxDepthDelta >= 0 && r.Delta.MaxDepth > verifyMaxDepthDelta {
+		violations = append(violations, PolicyViolation{
+			Rule:    "max-depth-delta",
+			Message: fmt.Sprintf("maximum depth grew by %d (limit %d)", r.Delta.MaxDepth, verifyMaxDepthDelta),
+		})
+	}
+	return violations
+}
+
+func printVerifyResult(r VerifyResult) {
+	fmt.Printf("Verify against %s (merge base %s)\n", r.Base, shortCommit(r.MergeBase))
+	fmt.Printf("Direct Dependencies: %d -> %d (delta %s)\n", r.Before.DirectDeps, r.After.DirectDeps, colorDelta(fmt.Sprintf("%+d", r.Delta.DirectDeps), r.Delta.DirectDeps))
+	fmt.Printf("Transitive Dependencies: %d -> %d (delta %s)\n", r.Before.TransDeps, r.After.TransDeps, colorDelta(fmt.Sprintf("%+d", r.Delta.TransDeps), r.Delta.TransDeps))
+	fmt.Printf("Total Dependencies: %d -> %d (delta %s)\n", r.Before.TotalDeps, r.After.TotalDeps, colorDelta(fmt.Sprintf("%+d", r.Delta.TotalDeps), r.Delta.TotalDeps))
+	fmt.Printf("Max Depth Of Dependencies: %d -> %d (delta %s)\n", r.Before.MaxDepth, r.After.MaxDepth, colorDelta(fmt.Sprintf("%+d", r.Delta.MaxDepth), r.Delta.MaxDepth))
+	if len(r.Added) > 0 {
+		fmt.Printf("Added (%d): %s\n", len(r.Added), strings.Join(r.Added, ", "))
+	}
+	if len(r.Removed) > 0 {
+		fmt.Printf("Removed (%d): %s\n", len(r.Removed), strings.Join(r.Removed, ", "))
+	}
+	if len(r.Violations) == 0 {
+		fmt.Println("No threshold or policy violations found.")
+		return
+	}
+	printCheckResult(CheckResult{Violations: r.Violations, MainModules: r.MainModules})
+}
+
+// shortCommit abbreviates a commit hash for display.
+func shortCommit(commit string) string {
+	if len(commit) > 12 {
+		return commit[:12]
+	}
+	return commit
+}
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+	verifyCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
+	verifyCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Get the output in JSON format")
+	verifyCmd.Flags().StringVar(&verifyBase, "base", "origin/main", "Base branch to compute the merge base against")
+	verifyCmd.Flags().IntVar(&verifyMaxAdded, "max-added", -1, "Fail when more than this many modules are added (-1 disables)")
+	verifyCmd.Flags().IntVar(&verifyMaxTotalDelta, "max-total-delta", -1, "Fail when total dependencies grow by more than this (-1 disables)")
+	verifyCmd.Flags().IntVar(&verifyMaxDepthDelta, "max-depth-delta", -1, "Fail when the maximum depth grows by more than this (-1 disables)")
+	verifyCmd.Flags().BoolVar(&verifyAnomalies, "anomalies", false, "Also fail on structural anomalies: large depth jumps, test-only modules becoming production dependencies and new module hosts")
+	verifyCmd.Flags().IntVar(&verifyDepthJump, "depth-jump", 3, "With --anomalies, flag modules now reached in at least this many fewer hops")
+	verifyCmd.Flags().StringVar(&verifyPolicyFile, "policy", "", "JSON policy file with built-in rules evaluated against the current graph (see depstat check); - reads stdin")
+	addNotifyFlags(verifyCmd)
+	verifyCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
+	verifyCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Specify main modules")
+}
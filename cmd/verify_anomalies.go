@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var verifyAnomalies bool
+var verifyDepthJump int
+
+// classifyTestDepsIn classifies deps as test-only in the module at
+// moduleDir instead of --dir.
+func classifyTestDepsIn(moduleDir string, deps []string) (map[string]bool, error) {
+	oldDir := dir
+	dir = moduleDir
+	defer func() { dir = oldDir }()
+	return classifyTestDeps(deps)
+}
+
+// findTestOnlyPromotions classifies the modules present in both graphs and
+// returns the ones test-only at the base that the current code needs
+// outside tests. Only the base's test-only modules are classified again.
+func findTestOnlyPromotions(baseDir string, before, after *DependencyOverview) (beforeTestOnly, afterTestOnly map[string]bool, err error) {
+	inBefore := make(map[string]bool)
+	for _, mod := range getAllDeps(before.DirectDepList, before.TransDepList) {
+		inBefore[mod] = true
+	}
+	var common []string
+	for _, mod := range uniqueStrings(getAllDeps(after.DirectDepList, after.TransDepList)) {
+		if inBefore[mod] {
+			common = append(common, mod)
+		}
+	}
+	beforeTestOnly, err = classifyTestDepsIn(baseDir, common)
+	if err != nil {
+		return nil, nil, fmt.Errorf("classifying the base: %w", err)
+	}
+	var candidates []string
+	for _, mod := range common {
+		if beforeTestOnly[mod] {
+			candidates = append(candidates, mod)
+		}
+	}
+	afterTestOnly, err = classifyTestDeps(candidates)
+	if err != nil {
+		return nil, nil, fmt.Errorf("classifying the current checkout: %w", err)
+	}
+	return beforeTestOnly, afterTestOnly, nil
+}
+
+// findGraphAnomalies compares the structure of two graphs and explains the
+// changes the counters of verify do not show: a module now reached in at
+// least depthJump fewer hops, a test-only module now needed outside tests,
+// and a module path host not seen before. beforeTestOnly and afterTestOnly
+// may be nil to skip the test-only check.
+func findGraphAnomalies(before, after *DependencyOverview, beforeTestOnly, afterTestOnly map[string]bool, depthJump int) []PolicyViolation {
+	anomalies := []PolicyViolation{}
+	beforeDepth := shortestDepthByModule(before.MainModules, before.Graph)
+	afterDepth := shortestDepthByModule(after.MainModules, after.Graph)
+	afterDeps := uniqueStrings(getAllDeps(after.DirectDepList, after.TransDepList))
+	for _, mod := range afterDeps {
+		from, ok := beforeDepth[mod]
+		to, ok2 := afterDepth[mod]
+		if !ok || !ok2 || from-to < depthJump {
+			continue
+		}
+		path := shortestPath(after.MainModules, mod, after.Graph)
+		anomalies = append(anomalies, PolicyViolation{
+			Rule:    "depth-jump",
+			Module:  mod,
+			Message: fmt.Sprintf("%s moved from depth %d to depth %d: it is now reached through %s", mod, from, to, strings.Join(path[:len(path)-1], " -> ")),
+			Path:    path,
+		})
+	}
+	if beforeTestOnly != nil {
+		for _, mod := range afterDeps {
+			isTestOnly, classified := afterTestOnly[mod]
+			if !beforeTestOnly[mod] || !classified || isTestOnly {
+				continue
+			}
+			anomalies = append(anomalies, PolicyViolation{
+				Rule:    "test-only-to-production",
+				Module:  mod,
+				Message: fmt.Sprintf("%s was only needed by tests at the base and is now needed by non-test code, so it ships in binaries", mod),
+				Path:    shortestPath(after.MainModules, mod, after.Graph),
+			})
+		}
+	}
+	beforeHosts := make(map[string]bool)
+	for _, mod := range getAllDeps(before.DirectDepList, before.TransDepList) {
+		beforeHosts[moduleHost(mod)] = true
+	}
+	newHosts := make(map[string][]string)
+	for _, mod := range afterDeps {
+		if host := moduleHost(mod); !beforeHosts[host] {
+			newHosts[host] = append(newHosts[host], mod)
+		}
+	}
+	var hosts []string
+	for host := range newHosts {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	for _, host := range hosts {
+		mods := newHosts[host]
+		anomalies = append(anomalies, PolicyViolation{
+			Rule:    "new-host",
+			Module:  mods[0],
+			Message: fmt.Sprintf("new host %s in module paths, first seen in %s; code is now fetched from a source not trusted before", host, exclusionExampleList(mods)),
+			Path:    shortestPath(after.MainModules, mods[0], after.Graph),
+		})
+	}
+	return anomalies
+}
+
+// moduleHost returns the first element of a module path, its host for
+// modules fetched over the network.
+func moduleHost(mod string) string {
+	host, _, _ := strings.Cut(mod, "/")
+	return host
+}
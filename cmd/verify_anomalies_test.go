@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindGraphAnomalies(t *testing.T) {
+	before := generateGraph(`main a.io/a@v1.0.0
+main a.io/testlib@v1.0.0
+a.io/a@v1.0.0 a.io/b@v1.0.0
+a.io/b@v1.0.0 a.io/c@v1.0.0
+a.io/c@v1.0.0 a.io/d@v1.0.0
+a.io/d@v1.0.0 a.io/deep@v1.0.0`, []string{"main"})
+	after := generateGraph(`main a.io/a@v1.0.0
+main a.io/testlib@v1.0.0
+main a.io/deep@v1.0.0
+main b.io/new@v1.0.0
+a.io/a@v1.0.0 a.io/b@v1.0.0
+a.io/b@v1.0.0 a.io/c@v1.0.0
+a.io/c@v1.0.0 a.io/d@v1.0.0
+a.io/d@v1.0.0 a.io/deep@v1.0.0`, []string{"main"})
+	beforeTestOnly := map[string]bool{"a.io/testlib": true}
+	afterTestOnly := map[string]bool{"a.io/testlib": false}
+
+	var rules, modules []string
+	for _, a := range findGraphAnomalies(&before, &after, beforeTestOnly, afterTestOnly, 3) {
+		rules = append(rules, a.Rule)
+		modules = append(modules, a.Module)
+	}
+	if want := []string{"depth-jump", "test-only-to-production", "new-host"}; !reflect.DeepEqual(rules, want) {
+		t.Errorf("rules = %v, want %v", rules, want)
+	}
+	if want := []string{"a.io/deep", "a.io/testlib", "b.io/new"}; !reflect.DeepEqual(modules, want) {
+		t.Errorf("modules = %v, want %v", modules, want)
+	}
+
+	if got := findGraphAnomalies(&before, &after, nil, nil, 5); len(got) != 1 || got[0].Rule != "new-host" {
+		t.Errorf("with --depth-jump 5 and no classification: got %+v", got)
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// DepstatBuildDate is set at release time with
+// -ldflags "-X github.com/kubernetes-sigs/depstat/cmd.DepstatBuildDate=...".
+var DepstatBuildDate string
+
+// BuildInfo describes the depstat binary and the Go toolchain it drives.
+type BuildInfo struct {
+	Version    string `json:"version"`
+	Commit     string `json:"commit,omitempty"`
+	CommitDate string `json:"commitDate,omitempty"`
+	BuildDate  string `json:"buildDate,omitempty"`
+	// Modified is set when the binary was built from a dirty checkout.
+	Modified  bool   `json:"modified,omitempty"`
+	GoVersion string `json:"goVersion"`
+	Platform  string `json:"platform"`
+	// GoToolchain is the version of the go command found in PATH, which
+	// depstat runs to load the graph.
+	GoToolchain string `json:"goToolchain,omitempty"`
+}
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print depstat version and build metadata",
+	Long: `Prints the depstat version, the commit it was built from and its date,
+the build date of release binaries, the Go version it was compiled with, and
+the go toolchain found in PATH. Please include this output in bug reports.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("version does not take any arguments"))
+		}
+		info := readBuildInfo(debug.ReadBuildInfo)
+		info.GoToolchain = goToolchainVersion()
+		if jsonOutput {
+			return writeJSON(cmd.OutOrStdout(), info)
+		}
+		fmt.Fprint(cmd.OutOrStdout(), formatBuildInfo(info))
+		return nil
+	},
+}
+
+// readBuildInfo fills a BuildInfo from the module and VCS data embedded by
+// the go command. A version set with -ldflags "-X .../cmd.DepstatVersion=..."
+// takes precedence over the module version.
+func readBuildInfo(read func() (*debug.BuildInfo, bool)) BuildInfo {
+	info := BuildInfo{
+		Version:   DepstatVersion,
+		BuildDate: DepstatBuildDate,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	if bi, ok := read(); ok {
+		if info.Version == "" {
+			info.Version = bi.Main.Version
+		}
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				info.Commit = s.Value
+			case "vcs.time":
+				info.CommitDate = s.Value
+			case "vcs.modified":
+				info.Modified = s.Value == "true"
+			}
+		}
+	}
+	if info.Version == "" {
+		info.Version = "(devel)"
+	}
+	return info
+}
+
+func goToolchainVersion() string {
+	c := goCommand([]string{"env", "GOVERSION"})
+	out, err := c.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func formatBuildInfo(info BuildInfo) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "depstat %s\n", info.Version)
+	commit := info.Commit
+	if commit == "" {
+		commit = "unknown"
+	} else if info.Modified {
+		commit += " (modified)"
+	}
+	fmt.Fprintf(&b, "  commit:     %s\n", commit)
+	if info.CommitDate != "" {
+		fmt.Fprintf(&b, "  committed:  %s\n", info.CommitDate)
+	}
+	if info.BuildDate != "" {
+		fmt.Fprintf(&b, "  built:      %s\n", info.BuildDate)
+	}
+	fmt.Fprintf(&b, "  go:         %s %s\n", info.GoVersion, info.Platform)
+	toolchain := info.GoToolchain
+	if toolchain == "" {
+		toolchain = "not found in PATH"
+	}
+	fmt.Fprintf(&b, "  go in PATH: %s\n", toolchain)
+	return b.String()
+}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+	versionCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Output in JSON format")
+
+	info := readBuildInfo(debug.ReadBuildInfo)
+	rootCmd.Version = info.Version
+	if info.Commit != "" {
+		rootCmd.Version += " (commit " + info.Commit + ")"
+	}
+}
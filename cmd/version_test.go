@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+func TestReadBuildInfo(t *testing.T) {
+	read := func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{
+			Main: debug.Module{Version: "v0.9.0"},
+			Settings: []debug.BuildSetting{
+				{Key: "vcs.revision", Value: "abc123"},
+				{Key: "vcs.time", Value: "2026-01-02T03:04:05Z"},
+				{Key: "vcs.modified", Value: "true"},
+			},
+		}, true
+	}
+	info := readBuildInfo(read)
+	if info.Version != "v0.9.0" || info.Commit != "abc123" || info.CommitDate != "2026-01-02T03:04:05Z" || !info.Modified {
+		t.Errorf("unexpected build info %+v", info)
+	}
+	if out := formatBuildInfo(info); !strings.Contains(out, "commit:     abc123 (modified)") || !strings.Contains(out, "go in PATH: not found in PATH") {
+		t.Errorf("unexpected formatted output:\n%s", out)
+	}
+
+	defer func() { DepstatVersion = "" }()
+	DepstatVersion = "v1.0.0"
+	if info := readBuildInfo(read); info.Version != "v1.0.0" {
+		t.Errorf("ldflags version should take precedence, got %q", info.Version)
+	}
+	DepstatVersion = ""
+	if info := readBuildInfo(func() (*debug.BuildInfo, bool) { return nil, false }); info.Version != "(devel)" || info.Commit != "" {
+		t.Errorf("unexpected build info without embedded data: %+v", info)
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// watchMode and watchInterval are set by --watch and --watch-interval on
+// stats and graph.
+var watchMode bool
+var watchInterval time.Duration
+
+// watchedFileNames are the files whose changes trigger a re-run.
+var watchedFileNames = []string{"go.mod", "go.sum", "go.work", "go.work.sum"}
+
+// watchAndRun runs the analysis, then polls the module files in --dir and
+// runs it again every time their content changes, until interrupted. An
+// error on the first run is returned; later errors are reported and the
+// watch goes on, since go.mod is often briefly invalid while being edited.
+func watchAndRun(run func() error) error {
+	if watchInterval <= 0 {
+		return withExitCode(ExitUsage, fmt.Errorf("--watch-interval must be > 0"))
+	}
+	paths := watchedFiles()
+	if err := run(); err != nil {
+		return err
+	}
+	if dryRun {
+		return nil // the later runs repeat the first one
+	}
+	last := fingerprintFiles(paths)
+	infof("Watching %s for changes (Ctrl-C to stop)\n", strings.Join(watchedFileNames, ", "))
+	for !watchDone() {
+		time.Sleep(watchInterval)
+		current := fingerprintFiles(paths)
+		if current == last {
+			continue
+		}
+		last = current
+		if err := validateGoMod(); err != nil {
+			warnf("go.mod changed but cannot be parsed yet, waiting for the next change: %v\n", err)
+			continue
+		}
+		infof("\n--- module files changed at %s, re-running ---\n", time.Now().Format("15:04:05"))
+		if err := run(); err != nil {
+			warnf("%v\n", err)
+		}
+	}
+	return nil
+}
+
+// watchDone ends the watch loop; it never does outside of tests.
+var watchDone = func() bool { return false }
+
+// watchedFiles returns the paths of watchedFileNames in --dir.
+func watchedFiles() []string {
+	paths := make([]string, len(watchedFileNames))
+	for i, name := range watchedFileNames {
+		paths[i] = filepath.Join(dir, name)
+	}
+	return paths
+}
+
+// fingerprintFiles hashes the content of paths. Missing files are recorded
+// as such, so creating or removing one counts as a change.
+func fingerprintFiles(paths []string) string {
+	h := sha256.New()
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			fmt.Fprintf(h, "%s\x00missing\x00", p)
+			continue
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00", p, len(data))
+		h.Write(data)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// validateGoMod checks that go.mod parses, so a half-edited file does not
+// abort the watch in the middle of loading the graph.
+func validateGoMod() error {
+	c := goCommand([]string{"mod", "edit", "-json"})
+	if _, err := c.Output(); err != nil {
+		return goCommandError(c, err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFingerprintFiles(t *testing.T) {
+	base := t.TempDir()
+	mod := filepath.Join(base, "go.mod")
+	sum := filepath.Join(base, "go.sum")
+	if err := os.WriteFile(mod, []byte("module example.com/m\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	paths := []string{mod, sum}
+	before := fingerprintFiles(paths)
+	if again := fingerprintFiles(paths); again != before {
+		t.Fatal("fingerprint of unchanged files changed")
+	}
+	if err := os.WriteFile(sum, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	created := fingerprintFiles(paths)
+	if created == before {
+		t.Error("creating an empty go.sum did not change the fingerprint")
+	}
+	if err := os.WriteFile(mod, []byte("module example.com/m\n\nrequire example.com/x v1.0.0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if fingerprintFiles(paths) == created {
+		t.Error("editing go.mod did not change the fingerprint")
+	}
+}
+
+func TestGraphWatchSurvivesBrokenGoMod(t *testing.T) {
+	tmp := t.TempDir()
+	goMod := filepath.Join(tmp, "go.mod")
+	valid := []byte("module example.com/m\n\ngo 1.22\n")
+	if err := os.WriteFile(goMod, valid, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GOFLAGS", "-mod=mod")
+	t.Setenv("GOPROXY", "off")
+	t.Setenv("GOWORK", "off")
+	stdout, err := os.Create(filepath.Join(tmp, "stdout"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stdout.Close()
+	oldStdout, oldLog, oldDir, oldOutput, oldInterval, oldDone := os.Stdout, logOutput, dir, graphOutputPath, watchInterval, watchDone
+	os.Stdout, logOutput, dir, graphOutputPath, watchInterval = stdout, io.Discard, tmp, filepath.Join(tmp, "graph.dot"), 10*time.Millisecond
+	defer func() {
+		os.Stdout, logOutput, dir, graphOutputPath, watchInterval, watchDone = oldStdout, oldLog, oldDir, oldOutput, oldInterval, oldDone
+	}()
+
+	var errs []error
+	watchDone = func() bool {
+		// edit go.mod between runs, after the watch recorded its content
+		next := valid
+		if len(errs) == 1 {
+			// parses, but go mod graph cannot resolve the requirement
+			next = []byte("module example.com/m\n\ngo 1.22\n\nrequire example.com/missing v1.0.0\n")
+		}
+		if err := os.WriteFile(goMod, next, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return len(errs) >= 3
+	}
+	err = watchAndRun(func() error {
+		err := runGraph(graphCmd, nil)
+		errs = append(errs, err)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 3 || errs[0] != nil || errs[1] == nil || errs[2] != nil {
+		t.Errorf("run errors = %v, want only the second run to fail", errs)
+	}
+}
@@ -0,0 +1,426 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var whatifRemove []string
+var whatifUpgrade []string
+
+// whatifFetch resolves a module version and returns its go.mod; tests
+// replace it.
+var whatifFetch = fetchModuleGoMod
+
+// WhatIfResult compares the graph with the graph after a simulated change.
+type WhatIfResult struct {
+	Scenario string        `json:"scenario"`
+	Before   StatsSnapshot `json:"before"`
+	After    StatsSnapshot `json:"after"`
+	Delta    StatsSnapshot `json:"delta"`
+	// Removed lists the dependencies no longer in the graph, Added the new
+	// ones and VersionChanges the ones selected at another version.
+	Removed        []string        `json:"removed"`
+	Added          []string        `json:"added"`
+	VersionChanges []VersionChange `json:"versionChanges"`
+	// Retained lists the removed direct dependencies other dependencies
+	// still require.
+	Retained []RetainedModule `json:"retained,omitempty"`
+}
+
+// RetainedModule is a module kept in the graph by the modules requiring it.
+type RetainedModule struct {
+	Module     string   `json:"module"`
+	RequiredBy []string `json:"requiredBy"`
+}
+
+var whatifCmd = &cobra.Command{
+	Use:   "whatif",
+	Short: "Simulate a change to the dependency graph and report its effect",
+	Long: `Recomputes the stats and the dependency set as if a change were made,
+without touching go.mod.
+
+--remove deletes the requirement of a direct dependency by the main
+modules, with its edges, and lists the transitive modules that would
+disappear. Since Go 1.17 go.mod also requires the dependencies of direct
+dependencies as // indirect; like go mod tidy, those no other dependency
+still requires are dropped along with the removed module. A removed module
+other dependencies still require stays in the graph and is reported with
+the modules requiring it.
+
+--upgrade module@version downloads the go.mod of that version through the
+module proxy (as go mod download does, honoring GOPROXY and GOPRIVATE),
+splices its requirements into the graph and reports the dependencies added,
+removed and selected at another version, as "go get" would change them.
+The go.mod of every module whose selected version rises is fetched in turn
+when it is part of the pruned module graph. The version may be a query such
+as "latest". Modules dropped by the new requirements stay as long as go.mod
+still requires them; modules go get would add to go.mod for imported
+packages are not predicted.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("whatif does not take any arguments"))
+		}
+		if (len(whatifRemove) == 0) == (len(whatifUpgrade) == 0) {
+			return withExitCode(ExitUsage, fmt.Errorf("whatif needs exactly one change to simulate: --remove or --upgrade"))
+		}
+		depGraph := getDepInfo(mainModules)
+		if len(depGraph.MainModules) == 0 {
+			return errNoMainModules
+		}
+		var result WhatIfResult
+		if len(whatifRemove) > 0 {
+			gomod, err := readGoModFile()
+			if err != nil {
+				return err
+			}
+			var indirect []string
+			for _, r := range gomod.Require {
+				if r.Indirect {
+					indirect = append(indirect, r.Path)
+				}
+			}
+			after, err := simulateRemoval(*depGraph, whatifRemove, indirect)
+			if err != nil {
+				return err
+			}
+			result = compareWhatIf("remove "+strings.Join(whatifRemove, ", "), depGraph, &after)
+			result.Retained = retainedModules(&after, whatifRemove)
+		} else {
+			after, err := simulateUpgrade(*depGraph, whatifUpgrade, whatifFetch)
+			if err != nil {
+				return err
+			}
+			after = applyEdgeExclusions(applyModuleExclusions(after, excludeModules), edgeExclusions)
+			result = compareWhatIf("upgrade "+strings.Join(whatifUpgrade, ", "), depGraph, &after)
+		}
+		if jsonOutput {
+			return writeJSON(os.Stdout, result)
+		}
+		printWhatIf(result)
+		return nil
+	},
+}
+
+// simulateRemoval drops the edges from the main modules to the given direct
+// dependencies and everything only reachable through them. indirect lists
+// the // indirect requirements of the main modules: as go mod tidy would,
+// those the other dependencies reached before the removal but no longer
+// reach are dropped too.
+func simulateRemoval(depGraph DependencyOverview, remove, indirect []string) (DependencyOverview, error) {
+	for _, mod := range remove {
+		if !contains(depGraph.DirectDepList, mod) {
+			return depGraph, fmt.Errorf("%s is not a direct dependency of %s", mod, strings.Join(depGraph.MainModules, ", "))
+		}
+	}
+	isMain := make(map[string]bool)
+	for _, m := range depGraph.MainModules {
+		isMain[m] = true
+	}
+	isIndirect := make(map[string]bool)
+	for _, m := range indirect {
+		isIndirect[m] = true
+	}
+	// reachable returns the modules reached without the main modules'
+	// indirect requirements and without the skipped main requirements
+	reachable := func(skip []string) map[string]bool {
+		seen := make(map[string]bool)
+		queue := append([]string{}, depGraph.MainModules...)
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			if seen[current] {
+				continue
+			}
+			seen[current] = true
+			for _, next := range depGraph.Graph[current] {
+				if isMain[current] && (isIndirect[next] || contains(skip, next)) {
+					continue
+				}
+				queue = append(queue, next)
+			}
+		}
+		return seen
+	}
+	before, after := reachable(nil), reachable(remove)
+	dropped := append([]string{}, remove...)
+	for _, mod := range indirect {
+		if before[mod] && !after[mod] && !contains(dropped, mod) {
+			dropped = append(dropped, mod)
+		}
+	}
+	return filterReachable(depGraph, depGraph.MainModules, func(from, to string) bool {
+		return !isMain[from] || !contains(dropped, to)
+	}), nil
+}
+
+// compareWhatIf computes the stats of both graphs and the dependencies the
+// change removes, adds or selects at another version.
+func compareWhatIf(scenario string, before, after *DependencyOverview) WhatIfResult {
+	b := snapshotFromGraph(before)
+	a := snapshotFromGraph(after)
+	result := WhatIfResult{
+		Scenario: scenario,
+		Before:   *b,
+		After:    *a,
+		Delta:    snapshotDelta(b, a),
+	}
+	result.Removed, result.Added, result.VersionChanges = compareDependencySets(before, after)
+	return result
+}
+
+// simulateUpgrade raises the main modules' requirements to the given
+// module@version upgrades and, as go would, reloads the requirements of
+// every module whose selected version rises and whose go.mod is part of the
+// pruned module graph (Go 1.17+): modules the main modules require, and
+// modules required by a go.mod older than 1.17, which is not pruned.
+// Versions never go down, since go.mod keeps requiring the current ones.
+func simulateUpgrade(depGraph DependencyOverview, upgrades []string, fetch func(mod, query string) (string, *goModFile, error)) (DependencyOverview, error) {
+	graph := make(map[string][]string, len(depGraph.Graph))
+	for mod, deps := range depGraph.Graph {
+		graph[mod] = append([]string{}, deps...)
+	}
+	versions := make(map[string]string, len(depGraph.Versions))
+	for mod, version := range depGraph.Versions {
+		versions[mod] = version
+	}
+	requirements := make(map[string][]Requirement, len(depGraph.Requirements))
+	for mod, reqs := range depGraph.Requirements {
+		requirements[mod] = append([]Requirement{}, reqs...)
+	}
+
+	// spliced records the version whose requirements are in graph, and
+	// unpruned the modules required by a go.mod older than Go 1.17.
+	spliced := make(map[string]string)
+	unpruned := make(map[string]bool)
+	splice := func(mod string, gomod *goModFile) {
+		spliceRequirements(graph, requirements, mod, gomod.Require)
+		spliced[mod] = versions[mod]
+		if compareGoVersions(gomod.Go, "1.17") < 0 {
+			for _, r := range gomod.Require {
+				unpruned[r.Path] = true
+			}
+		}
+	}
+	var queue []string
+	for _, u := range upgrades {
+		mod, query, ok := strings.Cut(u, "@")
+		if !ok || mod == "" || query == "" {
+			return depGraph, fmt.Errorf("--upgrade %q: want module@version", u)
+		}
+		if _, ok := versions[mod]; !ok {
+			return depGraph, fmt.Errorf("%s is not in the dependency graph", mod)
+		}
+		version, gomod, err := fetch(mod, query)
+		if err != nil {
+			return depGraph, err
+		}
+		if !versionGreater(version, versions[mod]) {
+			return depGraph, fmt.Errorf("%s@%s is not newer than the selected %s", mod, version, versions[mod])
+		}
+		versions[mod] = version
+		var kept []Requirement
+		for _, r := range requirements[mod] {
+			if !contains(depGraph.MainModules, r.From) {
+				kept = append(kept, r)
+			}
+		}
+		for _, m := range depGraph.MainModules {
+			kept = append(kept, Requirement{From: m, Version: version})
+			if !contains(graph[m], mod) {
+				graph[m] = append(graph[m], mod)
+			}
+		}
+		requirements[mod] = kept
+		splice(mod, gomod)
+		queue = append(queue, mod)
+	}
+
+	requiredByMain := func(mod string) bool {
+		for _, m := range depGraph.MainModules {
+			if contains(graph[m], mod) {
+				return true
+			}
+		}
+		return false
+	}
+	for len(queue) > 0 {
+		mod := queue[0]
+		queue = queue[1:]
+		if spliced[mod] != versions[mod] {
+			if !requiredByMain(mod) && !unpruned[mod] {
+				continue // pruned out: its go.mod is not loaded
+			}
+			_, gomod, err := fetch(mod, versions[mod])
+			if err != nil {
+				return depGraph, err
+			}
+			splice(mod, gomod)
+		}
+		for _, dep := range graph[mod] {
+			if required := requiredVersion(requirements[dep], mod); versionGreater(required, versions[dep]) {
+				versions[dep] = required
+				queue = append(queue, dep)
+			}
+		}
+	}
+
+	return filterReachable(DependencyOverview{
+		Graph:        graph,
+		MainModules:  depGraph.MainModules,
+		Versions:     versions,
+		Requirements: requirements,
+	}, depGraph.MainModules, func(from, to string) bool { return true }), nil
+}
+
+// spliceRequirements replaces the edges and requirements from mod with the
+// require directives of its new go.mod.
+func spliceRequirements(graph map[string][]string, requirements map[string][]Requirement, mod string, reqs []goModRequirement) {
+	for _, dep := range graph[mod] {
+		var kept []Requirement
+		for _, r := range requirements[dep] {
+			if r.From != mod {
+				kept = append(kept, r)
+			}
+		}
+		requirements[dep] = kept
+	}
+	graph[mod] = nil
+	for _, r := range reqs {
+		if !contains(graph[mod], r.Path) {
+			graph[mod] = append(graph[mod], r.Path)
+		}
+		requirements[r.Path] = append(requirements[r.Path], Requirement{From: mod, Version: r.Version})
+	}
+}
+
+// requiredVersion returns the highest version of a module required by from.
+func requiredVersion(reqs []Requirement, from string) string {
+	version := ""
+	for _, r := range reqs {
+		if r.From == from && versionGreater(r.Version, version) {
+			version = r.Version
+		}
+	}
+	return version
+}
+
+// fetchModuleGoMod resolves the module query with go mod download, which
+// fetches the go.mod through the module proxy, and returns the version with
+// its parsed go.mod.
+func fetchModuleGoMod(mod, query string) (string, *goModFile, error) {
+	c := goCommand([]string{"mod", "download", "-json", mod + "@" + query})
+	out, err := c.Output()
+	var info struct {
+		Version string
+		GoMod   string
+		Error   string
+	}
+	if jsonErr := json.Unmarshal(out, &info); jsonErr == nil && info.Error != "" {
+		return "", nil, fmt.Errorf("downloading %s@%s: %s", mod, query, info.Error)
+	}
+	if err != nil {
+		return "", nil, goCommandError(c, err)
+	}
+	if info.GoMod == "" {
+		return "", nil, fmt.Errorf("downloading %s@%s: no go.mod reported by go mod download", mod, query)
+	}
+	c = goCommand([]string{"mod", "edit", "-json", info.GoMod})
+	out, err = c.Output()
+	if err != nil {
+		return "", nil, goCommandError(c, err)
+	}
+	var gomod goModFile
+	if err := json.Unmarshal(out, &gomod); err != nil {
+		return "", nil, fmt.Errorf("parsing go.mod of %s@%s: %w", mod, info.Version, err)
+	}
+	return info.Version, &gomod, nil
+}
+
+// snapshotDelta returns the change of the basic counters from before to after.
+func snapshotDelta(before, after *StatsSnapshot) StatsSnapshot {
+	return StatsSnapshot{
+		DirectDeps: after.DirectDeps - before.DirectDeps,
+		TransDeps:  after.TransDeps - before.TransDeps,
+		TotalDeps:  after.TotalDeps - before.TotalDeps,
+		MaxDepth:   after.MaxDepth - before.MaxDepth,
+	}
+}
+
+// retainedModules returns the modules still in the graph after their
+// removal, with the modules requiring them.
+func retainedModules(after *DependencyOverview, remove []string) []RetainedModule {
+	deps := getAllDeps(after.DirectDepList, after.TransDepList)
+	var retained []RetainedModule
+	for _, mod := range remove {
+		if !contains(deps, mod) {
+			continue
+		}
+		by := graphParents(after.Graph, mod)
+		sort.Strings(by)
+		retained = append(retained, RetainedModule{Module: mod, RequiredBy: by})
+	}
+	return retained
+}
+
+func printWhatIf(result WhatIfResult) {
+	fmt.Printf("What if: %s\n", result.Scenario)
+	fmt.Printf("Direct Dependencies: %d -> %d (delta %s)\n", result.Before.DirectDeps, result.After.DirectDeps, colorDelta(fmt.Sprintf("%+d", result.Delta.DirectDeps), result.Delta.DirectDeps))
+	fmt.Printf("Transitive Dependencies: %d -> %d (delta %s)\n", result.Before.TransDeps, result.After.TransDeps, colorDelta(fmt.Sprintf("%+d", result.Delta.TransDeps), result.Delta.TransDeps))
+	fmt.Printf("Total Dependencies: %d -> %d (delta %s)\n", result.Before.TotalDeps, result.After.TotalDeps, colorDelta(fmt.Sprintf("%+d", result.Delta.TotalDeps), result.Delta.TotalDeps))
+	fmt.Printf("Max Depth Of Dependencies: %d -> %d (delta %s)\n", result.Before.MaxDepth, result.After.MaxDepth, colorDelta(fmt.Sprintf("%+d", result.Delta.MaxDepth), result.Delta.MaxDepth))
+	if len(result.Removed) > 0 {
+		fmt.Printf("Would disappear (%d):\n", len(result.Removed))
+		for _, mod := range result.Removed {
+			fmt.Printf("  %s\n", colorize(ansiGreen, mod))
+		}
+	} else {
+		fmt.Println("No dependency would disappear.")
+	}
+	if len(result.Added) > 0 {
+		fmt.Printf("Would be added (%d):\n", len(result.Added))
+		for _, mod := range result.Added {
+			fmt.Printf("  %s\n", colorize(ansiRed, mod))
+		}
+	}
+	if len(result.VersionChanges) > 0 {
+		fmt.Printf("Version changes (%d):\n", len(result.VersionChanges))
+		for _, c := range result.VersionChanges {
+			fmt.Printf("  %s: %s -> %s\n", c.Path, c.Before, c.After)
+		}
+	}
+	for _, r := range result.Retained {
+		fmt.Printf("%s stays, required by: %s\n", r.Module, strings.Join(r.RequiredBy, ", "))
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(whatifCmd)
+	whatifCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate. Defaults to the current directory.")
+	whatifCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Get the output in JSON format")
+	whatifCmd.Flags().StringSliceVar(&whatifRemove, "remove", []string{}, "Direct dependency to remove from the main modules' requirements (repeatable)")
+	whatifCmd.Flags().StringSliceVar(&whatifUpgrade, "upgrade", []string{}, "module@version to upgrade to, fetching its go.mod from the module proxy (repeatable)")
+	whatifCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
+	whatifCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Enter modules whose dependencies should be considered direct dependencies; defaults to the modules found by --main")
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSimulateRemoval(t *testing.T) {
+	depGraph := generateGraph(`main A@v1.0.0
+main B@v1.0.0
+main C@v1.0.0
+A@v1.0.0 D@v1.0.0
+A@v1.0.0 E@v1.0.0
+B@v1.0.0 E@v1.0.0
+B@v1.0.0 C@v1.0.0`, []string{"main"})
+
+	after, err := simulateRemoval(depGraph, []string{"A", "C"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := compareWhatIf("remove A, C", &depGraph, &after)
+	if !reflect.DeepEqual(result.Removed, []string{"A", "D"}) {
+		t.Errorf("expected A and D to disappear, got %v", result.Removed)
+	}
+	if result.Delta.DirectDeps != -2 || result.Delta.TotalDeps != -2 {
+		t.Errorf("unexpected delta %+v", result.Delta)
+	}
+	retained := retainedModules(&after, []string{"A", "C"})
+	if !reflect.DeepEqual(retained, []RetainedModule{{Module: "C", RequiredBy: []string{"B"}}}) {
+		t.Errorf("expected C to be kept by B, got %+v", retained)
+	}
+
+	if _, err := simulateRemoval(depGraph, []string{"D"}, nil); err == nil {
+		t.Error("expected an error removing a transitive dependency")
+	}
+}
+
+func TestSimulateRemovalIndirect(t *testing.T) {
+	// go.mod lists the dependencies of direct dependencies as // indirect:
+	// D only through A, E through A and B, F without any other requirer
+	depGraph := generateGraph(`main A@v1.0.0
+main B@v1.0.0
+main D@v1.0.0
+main E@v1.0.0
+main F@v1.0.0
+A@v1.0.0 D@v1.0.0
+A@v1.0.0 E@v1.0.0
+B@v1.0.0 E@v1.0.0`, []string{"main"})
+
+	after, err := simulateRemoval(depGraph, []string{"A"}, []string{"D", "E", "F"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := compareWhatIf("remove A", &depGraph, &after)
+	if !reflect.DeepEqual(result.Removed, []string{"A", "D"}) {
+		t.Errorf("expected A and its indirect requirement D to disappear, got %v", result.Removed)
+	}
+	if result.Before.DirectDeps != 5 || result.After.DirectDeps != 3 {
+		t.Errorf("expected 5 -> 3 direct dependencies, got %d -> %d", result.Before.DirectDeps, result.After.DirectDeps)
+	}
+}
+
+func TestSimulateUpgrade(t *testing.T) {
+	depGraph := generateGraph(`main A@v1.0.0
+main B@v1.0.0
+main C@v1.1.0
+A@v1.0.0 C@v1.0.0
+A@v1.0.0 D@v1.0.0
+B@v1.0.0 C@v1.1.0`, []string{"main"})
+	// C is required by main, so its new go.mod is loaded; E is not and is
+	// pruned out. C's go.mod predates pruning, so F's go.mod is loaded too.
+	goMods := map[string]*goModFile{
+		"A@v1.2.0": {Go: "1.21", Require: []goModRequirement{{Path: "C", Version: "v1.2.0"}, {Path: "E", Version: "v0.1.0"}}},
+		"C@v1.2.0": {Go: "1.16", Require: []goModRequirement{{Path: "F", Version: "v1.0.0"}}},
+		"F@v1.0.0": {Go: "1.21", Require: []goModRequirement{{Path: "G", Version: "v1.0.0"}}},
+	}
+	var fetched []string
+	fetch := func(mod, query string) (string, *goModFile, error) {
+		if query == "latest" {
+			query = "v1.2.0"
+		}
+		gomod, ok := goMods[mod+"@"+query]
+		if !ok {
+			t.Fatalf("unexpected fetch of %s@%s", mod, query)
+		}
+		fetched = append(fetched, mod+"@"+query)
+		return query, gomod, nil
+	}
+
+	after, err := simulateUpgrade(depGraph, []string{"A@latest"}, fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := compareWhatIf("upgrade A@latest", &depGraph, &after)
+	if !reflect.DeepEqual(result.Added, []string{"E", "F", "G"}) || !reflect.DeepEqual(result.Removed, []string{"D"}) {
+		t.Errorf("unexpected added %v and removed %v", result.Added, result.Removed)
+	}
+	want := []VersionChange{{Path: "A", Before: "v1.0.0", After: "v1.2.0"}, {Path: "C", Before: "v1.1.0", After: "v1.2.0"}}
+	if !reflect.DeepEqual(result.VersionChanges, want) {
+		t.Errorf("unexpected version changes %+v", result.VersionChanges)
+	}
+	if !reflect.DeepEqual(fetched, []string{"A@v1.2.0", "C@v1.2.0", "F@v1.0.0"}) {
+		t.Errorf("unexpected fetches %v", fetched)
+	}
+
+	if _, err := simulateUpgrade(depGraph, []string{"A@v1.0.0"}, func(mod, query string) (string, *goModFile, error) {
+		return query, &goModFile{}, nil
+	}); err == nil {
+		t.Error("expected an error for a version that is not newer")
+	}
+	if _, err := simulateUpgrade(depGraph, []string{"X@v1.0.0"}, fetch); err == nil {
+		t.Error("expected an error for a module outside the graph")
+	}
+}
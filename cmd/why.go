@@ -17,8 +17,11 @@ limitations under the License.
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
+	"io"
+	"math/big"
+	"math/rand"
+	"os"
 	"sort"
 	"strings"
 
@@ -33,13 +36,34 @@ type WhyPath struct {
 
 // WhyResult holds the result of why analysis
 type WhyResult struct {
-	Target      string    `json:"target"`
-	Found       bool      `json:"found"`
-	Paths       []WhyPath `json:"paths"`
-	DirectDeps  []string  `json:"directDependents"` // modules that directly depend on target
-	MainModules []string  `json:"mainModules"`
-	Truncated   bool      `json:"truncated,omitempty"`
-	TotalPaths  int       `json:"totalPaths,omitempty"`
+	Target          string    `json:"target"`
+	Version         string    `json:"version,omitempty"`         // requested version when the target is module@version
+	SelectedVersion string    `json:"selectedVersion,omitempty"` // version selected in the graph when Version is set
+	Found           bool      `json:"found"`
+	Paths           []WhyPath `json:"paths"`
+	DirectDeps      []string  `json:"directDependents"` // modules that directly depend on target
+	MainModules     []string  `json:"mainModules"`
+	Truncated       bool      `json:"truncated,omitempty"`
+	TotalPaths      int       `json:"totalPaths,omitempty"`
+	// PathCount is the exact number of paths in the graph with back edges
+	// dropped, computed without enumerating them. Unlike TotalPaths it is
+	// not capped by --max-paths.
+	PathCount *big.Int `json:"pathCount,omitempty"`
+	// Depth is the number of hops from the nearest main module to the
+	// target, following the edges the paths may use.
+	Depth int `json:"depth,omitempty"`
+	// MaxDepth is the --max-depth limit applied to the search, if any.
+	MaxDepth int `json:"maxDepth,omitempty"`
+	// Sampled is the --sample-strategy used to pick Paths, if any.
+	Sampled string `json:"sampled,omitempty"`
+	// Suggestions are the modules of the graph the target may have meant
+	// when it is not in the graph.
+	Suggestions []string `json:"suggestions,omitempty"`
+
+	// testOnly marks modules classified as test-only, when known.
+	testOnly map[string]bool
+	// weights holds transitive weights for --weight-nodes.
+	weights map[string]int
 }
 
 const (
@@ -48,7 +72,11 @@ const (
 )
 
 var whyMaxPaths int
+var whyMaxDepth int
+var whyAutoLimit bool
 var whySplitTestOnly bool
+var whyWeightNodes bool
+var whyFailIfNotFound bool
 
 var whyCmd = &cobra.Command{
 	Use:   "why <dependency>",
@@ -62,6 +90,9 @@ Examples:
   # Find why a dependency is included
   depstat why github.com/google/btree
 
+  # Only show paths whose last edge requests a specific version
+  depstat why golang.org/x/net@v0.17.0
+
   # Output as JSON
   depstat why github.com/google/btree --json
 
@@ -69,39 +100,95 @@ Examples:
   depstat why github.com/google/btree --dot | dot -Tsvg -o why.svg
 
   # Output as self-contained SVG
-  depstat why github.com/google/btree --svg > why.svg`,
+  depstat why github.com/google/btree --svg > why.svg
+
+  # Output as a standalone HTML page with collapsible path groups
+  depstat why github.com/google/btree --html > why.html`,
 	Args: cobra.ExactArgs(1),
 	RunE: runWhy,
 }
 
-func runWhy(cmd *cobra.Command, args []string) error {
-	target := args[0]
+func runWhy(cmd *cobra.Command, args []string) (runErr error) {
+	target, version, _ := strings.Cut(args[0], "@")
+	outputs := 0
+	for _, set := range []bool{jsonOutput, dotOutput, svgOutput, htmlOutput, ndjsonOutput} {
+		if set {
+			outputs++
+		}
+	}
+	if outputs > 1 {
+		return withExitCode(ExitUsage, fmt.Errorf("--json, --ndjson, --dot, --svg, and --html are mutually exclusive"))
+	}
+	if err := validateToolsScope(toolsScope); err != nil {
+		return err
+	}
+	if whyMaxDepth < 0 {
+		return withExitCode(ExitUsage, fmt.Errorf("--max-depth must be >= 0"))
+	}
+	if whyAutoLimit && (cmd.Flags().Changed("max-paths") || cmd.Flags().Changed("max-depth")) {
+		return withExitCode(ExitUsage, fmt.Errorf("--auto-limit chooses --max-paths and --max-depth; do not set them explicitly"))
+	}
+	if whySample < 0 {
+		return withExitCode(ExitUsage, fmt.Errorf("--sample must be >= 0"))
+	}
+	if whySample > 0 {
+		if err := validateSampleStrategy(whySampleStrategy); err != nil {
+			return err
+		}
+		if ndjsonOutput || whyAutoLimit || whyMaxDepth > 0 {
+			return withExitCode(ExitUsage, fmt.Errorf("--sample cannot be combined with --ndjson, --auto-limit or --max-depth"))
+		}
+	}
+	if whyBundle != "" && ndjsonOutput {
+		return withExitCode(ExitUsage, fmt.Errorf("--bundle cannot be combined with --ndjson"))
+	}
+	// with --fail-if-not-found a missing dependency is a result, not misuse
+	cmd.SilenceUsage = whyFailIfNotFound
 
-	depGraph := getDepInfo(mainModules)
+	depGraph, err := graphSource{GraphFile: whyGraphFile}.load(mainModules)
+	if err != nil {
+		return err
+	}
 	if len(depGraph.MainModules) == 0 {
-		return fmt.Errorf("no main modules remain after exclusions; adjust --exclude-modules or --mainModules")
+		return errNoMainModules
 	}
 
 	// Find all paths to the target
 	result := WhyResult{
 		Target:      target,
+		Version:     version,
 		Found:       false,
 		MainModules: depGraph.MainModules,
 	}
+	if whyBundle != "" {
+		// the bundle holds the final result, found or not
+		defer func() {
+			if runErr != nil && exitCode(runErr) != ExitNotFound {
+				return
+			}
+			if err := writeWhyBundle(whyBundle, cmd, result, depGraph); err != nil {
+				runErr = fmt.Errorf("--bundle: %w", err)
+			}
+		}()
+	}
 	if whySplitTestOnly {
 		allDeps := getAllDeps(depGraph.DirectDepList, depGraph.TransDepList)
 		testOnlySet, err := classifyTestDeps(allDeps)
 		if err != nil {
 			return fmt.Errorf("failed to classify dependencies: %w", err)
 		}
+		result.testOnly = testOnlySet
 		if testOnlySet[target] {
 			result.Found = true
 			result.Paths = []WhyPath{}
 			if jsonOutput {
-				return outputWhyJSON(result)
+				return whyNotFound(outputWhyJSON(result), args[0])
+			}
+			if ndjsonOutput {
+				return whyNotFound(nil, args[0])
 			}
-			fmt.Printf("Dependency %q is test-only. No non-test paths available.\n", target)
-			return nil
+			whyNotice("Dependency %q is test-only. No non-test paths available.\n", target)
+			return whyNotFound(nil, args[0])
 		}
 	}
 
@@ -115,30 +202,95 @@ func runWhy(cmd *cobra.Command, args []string) error {
 	}
 
 	if !result.Found {
+		result.Suggestions = suggestModules(target, allDeps, maxSuggestions)
 		if jsonOutput {
-			return outputWhyJSON(result)
+			return whyNotFound(outputWhyJSON(result), args[0], result.Suggestions...)
 		}
-		fmt.Printf("Dependency %q not found in the dependency graph.\n", target)
-		return nil
+		if htmlOutput {
+			return whyNotFound(outputWhyHTML(result), args[0], result.Suggestions...)
+		}
+		if ndjsonOutput {
+			return whyNotFound(nil, args[0], result.Suggestions...)
+		}
+		whyNotice("Dependency %q not found in the dependency graph.\n", target)
+		if len(result.Suggestions) > 0 {
+			whyNotice("Did you mean:\n")
+			for _, s := range result.Suggestions {
+				whyNotice("  %s\n", s)
+			}
+		}
+		return whyNotFound(nil, args[0], result.Suggestions...)
 	}
 
 	// Find all modules that directly depend on target
-	for from, tos := range depGraph.Graph {
-		for _, to := range tos {
-			if to == target {
-				result.DirectDeps = append(result.DirectDeps, from)
+	searchGraph := depGraph.Graph
+	if version != "" {
+		result.SelectedVersion = depGraph.Versions[target]
+		result.DirectDeps = requestersOfVersion(depGraph.Requirements[target], version)
+		if len(result.DirectDeps) == 0 {
+			result.Found = false
+			if jsonOutput {
+				return whyNotFound(outputWhyJSON(result), args[0])
+			}
+			if ndjsonOutput {
+				return whyNotFound(nil, args[0])
+			}
+			whyNotice("No module in the dependency graph requests %s@%s (selected version is %s).\n", target, version, result.SelectedVersion)
+			return whyNotFound(nil, args[0])
+		}
+		searchGraph = restrictIncomingEdges(depGraph.Graph, target, result.DirectDeps)
+	} else {
+		for from, tos := range depGraph.Graph {
+			for _, to := range tos {
+				if to == target {
+					result.DirectDeps = append(result.DirectDeps, from)
+				}
 			}
 		}
+		sort.Strings(result.DirectDeps)
+	}
+
+	result.Depth = shortestDepthByModule(depGraph.MainModules, searchGraph)[target]
+
+	var lengths []*big.Int
+	if whyAutoLimit || whyMaxDepth > 0 {
+		// The counts leave out the edges closing a cycle; follow paths
+		// without them too, so the limits hold for the paths listed.
+		searchGraph = pruneBackEdges(depGraph.MainModules, searchGraph)
+		lengths = pathCountsByLength(depGraph.MainModules, target, searchGraph)
+	}
+	if whyAutoLimit {
+		whyMaxPaths = whyDefaultMaxPaths
+		whyMaxDepth = autoMaxDepth(lengths, whyMaxPaths)
+		if whyMaxDepth > 0 {
+			warnf("--auto-limit: %s paths lead to %s; following paths of at most %d hops (%s of them) and at most %d paths\n",
+				sumCounts(lengths, len(lengths)), target, whyMaxDepth, sumCounts(lengths, whyMaxDepth+1), whyMaxPaths)
+		}
+	}
+
+	if ndjsonOutput {
+		return streamWhyPaths(os.Stdout, depGraph.MainModules, target, searchGraph, whyMaxPaths, whyMaxDepth)
 	}
-	sort.Strings(result.DirectDeps)
 
 	// Find all paths from main modules to target.
 	var allPaths [][]string
-	for _, mainMod := range depGraph.MainModules {
-		findAllPaths(mainMod, target, depGraph.Graph, []string{}, make(map[string]bool), &allPaths, whyMaxPaths)
-		if whyMaxPaths > 0 && len(allPaths) >= whyMaxPaths {
+	if whySample > 0 {
+		rng := rand.New(rand.NewSource(whySeed))
+		allPaths = sampleWhyPaths(depGraph.MainModules, target, searchGraph, result.DirectDeps, whySample, whySampleStrategy, rng)
+		result.Sampled = whySampleStrategy
+	} else {
+		for _, mainMod := range depGraph.MainModules {
+			findAllPathsWithin(mainMod, target, searchGraph, &allPaths, whyMaxPaths, whyMaxDepth)
+			if whyMaxPaths > 0 && len(allPaths) >= whyMaxPaths {
+				result.Truncated = true
+				break
+			}
+		}
+	}
+	if whyMaxDepth > 0 {
+		result.MaxDepth = whyMaxDepth
+		if sumCounts(lengths, len(lengths)).Cmp(sumCounts(lengths, whyMaxDepth+1)) > 0 {
 			result.Truncated = true
-			break
 		}
 	}
 	for _, path := range allPaths {
@@ -157,6 +309,13 @@ func runWhy(cmd *cobra.Command, args []string) error {
 		return strings.Join(result.Paths[i].Path, " -> ") < strings.Join(result.Paths[j].Path, " -> ")
 	})
 	result.TotalPaths = len(result.Paths)
+	result.PathCount = countPaths(depGraph.MainModules, target, searchGraph)
+	if result.Sampled != "" && result.PathCount.Cmp(big.NewInt(int64(result.TotalPaths))) > 0 {
+		result.Truncated = true
+	}
+	if whyWeightNodes {
+		result.weights = transitiveWeights(depGraph.Graph)
+	}
 
 	if jsonOutput {
 		return outputWhyJSON(result)
@@ -167,67 +326,368 @@ func runWhy(cmd *cobra.Command, args []string) error {
 	if svgOutput {
 		return outputWhySVG(result)
 	}
+	if htmlOutput {
+		return outputWhyHTML(result)
+	}
 	return outputWhyText(result)
 }
 
+// whyNotice reports that there is no path to show: on stdout for text
+// output, and on stderr for DOT and SVG, so stdout only ever holds a graph.
+func whyNotice(format string, args ...interface{}) {
+	if dotOutput || svgOutput {
+		warnf(format, args...)
+		return
+	}
+	fmt.Printf(format, args...)
+}
+
+// whyNotFound returns err, the result of writing the output of a query that
+// found no paths, or with --fail-if-not-found an ExitNotFound error when
+// the output was written successfully, naming the suggested modules.
+func whyNotFound(err error, query string, suggestions ...string) error {
+	if err != nil || !whyFailIfNotFound {
+		return err
+	}
+	if len(suggestions) > 0 {
+		return withExitCode(ExitNotFound, fmt.Errorf("no dependency paths found for %s; did you mean %s?", query, strings.Join(suggestions, ", ")))
+	}
+	return withExitCode(ExitNotFound, fmt.Errorf("no dependency paths found for %s", query))
+}
+
+// requestersOfVersion returns the sorted modules whose requirement edge asks
+// for the given version.
+func requestersOfVersion(reqs []Requirement, version string) []string {
+	var from []string
+	for _, r := range reqs {
+		if r.Version == version && !contains(from, r.From) {
+			from = append(from, r.From)
+		}
+	}
+	sort.Strings(from)
+	return from
+}
+
+// restrictIncomingEdges returns a copy of graph in which target can only be
+// reached from the given modules.
+func restrictIncomingEdges(graph map[string][]string, target string, allowed []string) map[string][]string {
+	restricted := make(map[string][]string, len(graph))
+	for from, tos := range graph {
+		if contains(allowed, from) {
+			restricted[from] = tos
+			continue
+		}
+		for _, to := range tos {
+			if to != target {
+				restricted[from] = append(restricted[from], to)
+			}
+		}
+	}
+	return restricted
+}
+
 // findAllPaths finds paths from start to target using DFS and appends to out.
 // If maxPaths > 0, search stops once out reaches maxPaths.
 func findAllPaths(start, target string, graph map[string][]string, currentPath []string, visited map[string]bool, out *[][]string, maxPaths int) {
 	if maxPaths > 0 && len(*out) >= maxPaths {
 		return
 	}
+	walkPaths(start, target, graph, currentPath, visited, 0, func(path []string) bool {
+		*out = append(*out, path)
+		return maxPaths <= 0 || len(*out) < maxPaths
+	})
+}
 
+// findAllPathsWithin is findAllPaths that also skips paths longer than
+// maxDepth hops if maxDepth > 0.
+func findAllPathsWithin(start, target string, graph map[string][]string, out *[][]string, maxPaths, maxDepth int) {
+	if maxPaths > 0 && len(*out) >= maxPaths {
+		return
+	}
+	walkPaths(start, target, graph, nil, make(map[string]bool), maxDepth, func(path []string) bool {
+		*out = append(*out, path)
+		return maxPaths <= 0 || len(*out) < maxPaths
+	})
+}
+
+// walkPaths calls visit with a copy of each path from start to target as the
+// DFS finds it, so callers can stream paths without collecting them. Paths
+// longer than maxDepth hops are not followed if maxDepth > 0. The walk stops
+// as soon as visit returns false; the result reports whether it ran to
+// completion.
+func walkPaths(start, target string, graph map[string][]string, currentPath []string, visited map[string]bool, maxDepth int, visit func([]string) bool) bool {
 	currentPath = append(currentPath, start)
+	if maxDepth > 0 && len(currentPath)-1 > maxDepth {
+		return true
+	}
 
 	if start == target {
-		// Found the target, append a copy of the path.
 		pathCopy := make([]string, len(currentPath))
 		copy(pathCopy, currentPath)
-		*out = append(*out, pathCopy)
-		return
+		return visit(pathCopy)
 	}
 
 	if visited[start] {
-		return
+		return true
 	}
 	visited[start] = true
 	defer func() { visited[start] = false }()
 
 	for _, next := range graph[start] {
-		findAllPaths(next, target, graph, currentPath, visited, out, maxPaths)
-		if maxPaths > 0 && len(*out) >= maxPaths {
-			return
+		if !walkPaths(next, target, graph, currentPath, visited, maxDepth, visit) {
+			return false
 		}
 	}
+	return true
 }
 
-func outputWhyJSON(result WhyResult) error {
-	out, err := json.MarshalIndent(result, "", "\t")
+// pathCounter counts the paths from modules to target by dynamic
+// programming over the DAG left after dropping the back edges of a DFS from
+// the starts. It runs in linear time where enumerating the paths can take
+// exponential time. In a graph with cycles the counts exclude the paths that
+// only exist through a back edge. countPaths, pathCountsByLength and the
+// --sample draws share it, so they agree on which paths exist.
+type pathCounter struct {
+	dag     map[string][]string
+	target  string
+	lengths map[string][]*big.Int
+	totals  map[string]*big.Int
+}
+
+func newPathCounter(starts []string, target string, graph map[string][]string) *pathCounter {
+	return &pathCounter{
+		dag:     pruneBackEdges(starts, graph),
+		target:  target,
+		lengths: make(map[string][]*big.Int),
+		totals:  make(map[string]*big.Int),
+	}
+}
+
+// byLength returns, indexed by the number of hops, how many paths lead
+// from node to the target.
+func (c *pathCounter) byLength(node string) []*big.Int {
+	if node == c.target {
+		return []*big.Int{big.NewInt(1)}
+	}
+	if l, ok := c.lengths[node]; ok {
+		return l
+	}
+	var l []*big.Int
+	for _, next := range c.dag[node] {
+		for i, n := range c.byLength(next) {
+			for len(l) <= i+1 {
+				l = append(l, new(big.Int))
+			}
+			l[i+1].Add(l[i+1], n)
+		}
+	}
+	c.lengths[node] = l
+	return l
+}
+
+// count returns the number of paths from node to the target.
+func (c *pathCounter) count(node string) *big.Int {
+	if t, ok := c.totals[node]; ok {
+		return t
+	}
+	t := sumCounts(c.byLength(node), len(c.byLength(node)))
+	c.totals[node] = t
+	return t
+}
+
+// total returns the number of paths from the starts to the target.
+func (c *pathCounter) total(starts []string) *big.Int {
+	total := new(big.Int)
+	for _, start := range starts {
+		total.Add(total, c.count(start))
+	}
+	return total
+}
+
+// countPaths returns the exact number of paths from the start modules to
+// target, as counted by pathCounter.
+func countPaths(starts []string, target string, graph map[string][]string) *big.Int {
+	return newPathCounter(starts, target, graph).total(starts)
+}
+
+// pathCountsByLength returns, indexed by the number of hops, how many paths
+// lead from the start modules to target in the DAG used by countPaths.
+func pathCountsByLength(starts []string, target string, graph map[string][]string) []*big.Int {
+	c := newPathCounter(starts, target, graph)
+	var total []*big.Int
+	for _, start := range starts {
+		for i, n := range c.byLength(start) {
+			for len(total) <= i {
+				total = append(total, new(big.Int))
+			}
+			total[i].Add(total[i], n)
+		}
+	}
+	return total
+}
+
+// sumCounts returns the number of paths shorter than n hops.
+func sumCounts(lengths []*big.Int, n int) *big.Int {
+	sum := new(big.Int)
+	for i := 0; i < n && i < len(lengths); i++ {
+		sum.Add(sum, lengths[i])
+	}
+	return sum
+}
+
+// autoMaxDepth picks the largest hop limit keeping the number of paths
+// within budget, or the shortest path length when even those exceed it. It
+// returns 0 when all paths fit.
+func autoMaxDepth(lengths []*big.Int, budget int) int {
+	limit := big.NewInt(int64(budget))
+	if sumCounts(lengths, len(lengths)).Cmp(limit) <= 0 {
+		return 0
+	}
+	depth := 0
+	for d := range lengths {
+		if lengths[d].Sign() == 0 {
+			continue
+		}
+		if depth > 0 && sumCounts(lengths, d+1).Cmp(limit) > 0 {
+			break
+		}
+		depth = d
+	}
+	return depth
+}
+
+// pruneBackEdges returns the subgraph reachable from starts without the
+// edges that close a cycle in a DFS visiting neighbors in graph order.
+func pruneBackEdges(starts []string, graph map[string][]string) map[string][]string {
+	const (
+		onStack = 1
+		done    = 2
+	)
+	dag := make(map[string][]string)
+	state := make(map[string]int)
+	var visit func(node string)
+	visit = func(node string) {
+		state[node] = onStack
+		for _, next := range graph[node] {
+			switch state[next] {
+			case onStack:
+				continue
+			case 0:
+				visit(next)
+			}
+			dag[node] = append(dag[node], next)
+		}
+		state[node] = done
+	}
+	for _, start := range starts {
+		if state[start] == 0 {
+			visit(start)
+		}
+	}
+	return dag
+}
+
+// streamWhyPaths writes every path from the main modules to target as one
+// NDJSON line, in DFS order, stopping after maxPaths paths if maxPaths > 0
+// and skipping paths longer than maxDepth hops if maxDepth > 0.
+func streamWhyPaths(w io.Writer, mainMods []string, target string, graph map[string][]string, maxPaths, maxDepth int) error {
+	nw := newNDJSONWriter(w)
+	var err error
+	count := 0
+	for _, mainMod := range mainMods {
+		complete := walkPaths(mainMod, target, graph, nil, make(map[string]bool), maxDepth, func(path []string) bool {
+			if err = nw.Write(WhyPath{Path: path, Direct: len(path) == 2 && contains(mainMods, path[0])}); err != nil {
+				return false
+			}
+			count++
+			return maxPaths <= 0 || count < maxPaths
+		})
+		if !complete {
+			break
+		}
+	}
 	if err != nil {
 		return err
 	}
-	fmt.Println(string(out))
+	return nw.Flush()
+}
+
+// shortestPath returns one shortest path from any of the start modules to
+// target using BFS, or nil if target is unreachable. Neighbors are visited in
+// sorted order so the result is deterministic.
+func shortestPath(starts []string, target string, graph map[string][]string) []string {
+	parent := make(map[string]string)
+	seen := make(map[string]bool)
+	var queue []string
+	for _, s := range starts {
+		if seen[s] {
+			continue
+		}
+		seen[s] = true
+		queue = append(queue, s)
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current == target {
+			path := []string{current}
+			for {
+				p, ok := parent[path[0]]
+				if !ok {
+					break
+				}
+				path = append([]string{p}, path...)
+			}
+			return path
+		}
+		next := append([]string{}, graph[current]...)
+		sort.Strings(next)
+		for _, n := range next {
+			if seen[n] {
+				continue
+			}
+			seen[n] = true
+			parent[n] = current
+			queue = append(queue, n)
+		}
+	}
 	return nil
 }
 
+func outputWhyJSON(result WhyResult) error {
+	return writeJSON(os.Stdout, result)
+}
+
 func outputWhyText(result WhyResult) error {
-	fmt.Printf("Why is %s included?\n", result.Target)
+	if result.Version != "" {
+		fmt.Printf("Why is %s@%s requested?\n", colorize(ansiYellow, result.Target), result.Version)
+	} else {
+		fmt.Printf("Why is %s included?\n", colorize(ansiYellow, result.Target))
+	}
 	fmt.Println(strings.Repeat("=", 50))
 	fmt.Println()
 
 	if !result.Found {
 		fmt.Println("Not found in dependency graph.")
+		if len(result.Suggestions) > 0 {
+			fmt.Printf("Did you mean: %s\n", strings.Join(result.Suggestions, ", "))
+		}
 		return nil
 	}
 
 	// Show direct dependents
-	fmt.Printf("Directly depended on by (%d modules):\n", len(result.DirectDeps))
+	if result.Version != "" {
+		fmt.Printf("Selected version: %s\n", result.SelectedVersion)
+		fmt.Printf("Requested at %s by (%d modules):\n", result.Version, len(result.DirectDeps))
+	} else {
+		fmt.Printf("Directly depended on by (%d modules):\n", len(result.DirectDeps))
+	}
 	for _, dep := range result.DirectDeps {
 		marker := "  "
 		if contains(result.MainModules, dep) {
 			marker = "* " // Mark main modules
 		}
-		fmt.Printf("  %s%s\n", marker, dep)
+		fmt.Printf("  %s%s\n", marker, colorModule(dep, result.MainModules, result.Target))
 	}
 	fmt.Println()
 
@@ -245,15 +705,23 @@ func outputWhyText(result WhyResult) error {
 		} else {
 			fmt.Printf("  %d. ", i+1)
 		}
-		fmt.Println(strings.Join(wp.Path, " -> "))
+		fmt.Println(colorPath(wp.Path, result.MainModules, result.Target))
 	}
 
 	if len(result.Paths) > len(pathsToShow) || result.Truncated {
 		fmt.Println()
-		if result.Truncated {
+		if result.Truncated && result.Sampled != "" {
+			fmt.Printf("  (%d paths sampled (%s, --seed %d) from %s paths in total)\n", result.TotalPaths, result.Sampled, whySeed, result.PathCount)
+		} else if result.Truncated && result.MaxDepth > 0 && result.PathCount != nil {
+			fmt.Printf("  (search limited to --max-depth=%d and --max-paths=%d; %s paths in total)\n", result.MaxDepth, whyMaxPaths, result.PathCount)
+		} else if result.Truncated && result.MaxDepth > 0 {
+			fmt.Printf("  (search limited to --max-depth=%d and --max-paths=%d)\n", result.MaxDepth, whyMaxPaths)
+		} else if result.Truncated && result.PathCount != nil {
+			fmt.Printf("  (search truncated at --max-paths=%d; %s paths in total)\n", whyMaxPaths, result.PathCount)
+		} else if result.Truncated {
 			fmt.Printf("  (search truncated at --max-paths=%d)\n", whyMaxPaths)
 		} else {
-			fmt.Printf("  (showing first %d in text output; use --json/--dot/--svg for full set)\n", whyDefaultTextPaths)
+			fmt.Printf("  (showing first %d in text output; use --json/--dot/--svg/--html for full set)\n", whyDefaultTextPaths)
 		}
 	}
 
@@ -261,10 +729,18 @@ func outputWhyText(result WhyResult) error {
 }
 
 func outputWhyDOT(result WhyResult, depGraph *DependencyOverview) error {
-	fmt.Println("strict digraph {")
-	fmt.Printf("graph [overlap=false, label=\"Why: %s\", labelloc=t];\n", result.Target)
-	fmt.Println("node [shape=box, style=filled, fillcolor=white];")
-	fmt.Println()
+	return writeBuffered(os.Stdout, func(w io.Writer) error {
+		writeWhyDOT(w, result)
+		return nil
+	})
+}
+
+// writeWhyDOT writes the subgraph spanned by the why paths as DOT.
+func writeWhyDOT(w io.Writer, result WhyResult) {
+	fmt.Fprintln(w, "strict digraph {")
+	fmt.Fprintf(w, "graph [overlap=false, label=\"Why: %s\", labelloc=t];\n", result.Target)
+	fmt.Fprintln(w, "node [shape=box, style=filled, fillcolor=white];")
+	fmt.Fprintln(w)
 
 	// Collect all nodes and edges from paths
 	nodes := make(map[string]bool)
@@ -281,25 +757,32 @@ func outputWhyDOT(result WhyResult, depGraph *DependencyOverview) error {
 	}
 
 	// Output nodes with colors
-	fmt.Println("// Nodes")
+	fmt.Fprintln(w, "// Nodes")
 	nodeList := make([]string, 0, len(nodes))
 	for node := range nodes {
 		nodeList = append(nodeList, node)
 	}
 	sort.Strings(nodeList)
+	maxWeight := whyMaxWeight(result, nodes)
 	for _, node := range nodeList {
 		color := "white"
 		if node == result.Target {
 			color = "#ffffcc" // yellow for target
 		} else if contains(result.MainModules, node) {
 			color = "#ccffcc" // green for main modules
+		} else if result.weights != nil {
+			color = weightColor(weightFraction(result.weights[node], maxWeight))
+		}
+		if result.weights != nil {
+			fmt.Fprintf(w, "\"%s\" [fillcolor=\"%s\", label=\"%s (%d)\"];\n", node, color, node, result.weights[node])
+			continue
 		}
-		fmt.Printf("\"%s\" [fillcolor=\"%s\"];\n", node, color)
+		fmt.Fprintf(w, "\"%s\" [fillcolor=\"%s\"];\n", node, color)
 	}
-	fmt.Println()
+	fmt.Fprintln(w)
 
 	// Output edges
-	fmt.Println("// Edges")
+	fmt.Fprintln(w, "// Edges")
 	edgeList := make([]string, 0, len(edges))
 	for edge := range edges {
 		edgeList = append(edgeList, edge)
@@ -308,22 +791,36 @@ func outputWhyDOT(result WhyResult, depGraph *DependencyOverview) error {
 	for _, edge := range edgeList {
 		parts := strings.Split(edge, " -> ")
 		if len(parts) == 2 {
-			fmt.Printf("\"%s\" -> \"%s\";\n", parts[0], parts[1])
+			fmt.Fprintf(w, "\"%s\" -> \"%s\";\n", parts[0], parts[1])
 		}
 	}
 
-	fmt.Println("}")
-	return nil
+	fmt.Fprintln(w, "}")
 }
 
 func init() {
 	rootCmd.AddCommand(whyCmd)
 	whyCmd.Flags().StringVarP(&dir, "dir", "d", "", "Directory containing the module to evaluate")
 	whyCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "Output in JSON format")
+	whyCmd.Flags().BoolVar(&ndjsonOutput, "ndjson", false, "Stream paths as newline-delimited JSON, one {\"path\", \"direct\"} object per line in search order")
 	whyCmd.Flags().BoolVarP(&dotOutput, "dot", "", false, "Output in DOT format for Graphviz")
 	whyCmd.Flags().BoolVarP(&svgOutput, "svg", "s", false, "Output as self-contained SVG diagram")
+	whyCmd.Flags().StringVar(&svgThemeFlag, "svg-theme", "light", "SVG color theme: light, dark, or a JSON file overriding light theme colors")
+	whyCmd.Flags().StringVar(&svgTitle, "svg-title", "", "Title of the SVG diagram (default \"Why is <dependency> included?\")")
+	whyCmd.Flags().BoolVar(&svgCommandFooter, "svg-command-footer", false, "Show the depstat command line in the SVG footer")
+	whyCmd.Flags().BoolVar(&whyWeightNodes, "weight-nodes", false, "Shade --svg/--dot/--html nodes by the number of transitive dependencies they pull in")
+	whyCmd.Flags().BoolVar(&htmlOutput, "html", false, "Output as a standalone HTML page with the SVG diagram and collapsible path groups")
+	whyCmd.Flags().BoolVar(&whyFailIfNotFound, "fail-if-not-found", false, "Exit with code 2 when no path to the dependency exists (absent, version not requested, or test-only with --split-test-only)")
 	whyCmd.Flags().IntVar(&whyMaxPaths, "max-paths", whyDefaultMaxPaths, "Maximum dependency paths to search. Set 0 for no limit")
+	whyCmd.Flags().IntVar(&whyMaxDepth, "max-depth", 0, "Only follow paths of at most this many hops. Set 0 for no limit")
+	whyCmd.Flags().IntVar(&whySample, "sample", 0, "Return N distinct paths sampled at random from all paths instead of the first N found by the search")
+	whyCmd.Flags().StringVar(&whySampleStrategy, "sample-strategy", "uniform", "With --sample: uniform over all paths, or stratified evenly across the direct dependents of the target")
+	whyCmd.Flags().Int64Var(&whySeed, "seed", 1, "Random seed for --sample, so repeated runs return the same paths")
+	whyCmd.Flags().BoolVar(&whyAutoLimit, "auto-limit", false, "Count the paths before searching and choose --max-paths and --max-depth to keep the search bounded, with a warning")
+	whyCmd.Flags().StringVar(&whyBundle, "bundle", "", "Also write a .tar.gz with the go mod graph, the command and flags, the JSON result and the SVG diagram, to reproduce or re-query the analysis later")
+	whyCmd.Flags().StringVar(&whyGraphFile, "graph-file", "", "Read captured `go mod graph` output, such as the graph.txt of a --bundle, instead of running go in --dir; - reads stdin")
 	whyCmd.Flags().BoolVar(&whySplitTestOnly, "split-test-only", false, "Exclude test-only dependencies when finding paths (uses go mod why -m)")
+	addToolsFlag(whyCmd)
 	whyCmd.Flags().StringSliceVar(&excludeModules, "exclude-modules", []string{}, "Exclude module path patterns (repeatable, supports * wildcard)")
 	whyCmd.Flags().StringSliceVarP(&mainModules, "mainModules", "m", []string{}, "Specify main modules")
 }
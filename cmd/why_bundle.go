@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var whyBundle string
+var whyGraphFile string
+
+// Files of a why bundle.
+const (
+	bundleGraphFile   = "graph.txt"
+	bundleCommandFile = "command.json"
+	bundleResultFile  = "result.json"
+	bundleSVGFile     = "why.svg"
+)
+
+// whyBundleSkipFlags are left out of the reproduce command: they select the
+// input or the output format, which the bundle replaces.
+var whyBundleSkipFlags = []string{"bundle", "dir", "graph-file", "mainModules", "json", "ndjson", "dot", "svg", "html", "digest", "in-toto"}
+
+// WhyBundleCommand is the command.json of a why bundle: how the analysis
+// was run and how to run it again from the bundled graph.
+type WhyBundleCommand struct {
+	Command        []string          `json:"command"`
+	Flags          map[string]string `json:"flags,omitempty"`
+	MainModules    []string          `json:"mainModules"`
+	Reproduce      string            `json:"reproduce"`
+	DepstatVersion string            `json:"depstatVersion,omitempty"`
+	CreatedAt      time.Time         `json:"createdAt"`
+	GoEnv          *GoEnvironment    `json:"goEnv,omitempty"`
+	Git            *GitMetadata      `json:"git,omitempty"`
+}
+
+// writeWhyBundle writes the graph, command, JSON result and SVG diagram of
+// a why analysis to a .tar.gz at path.
+func writeWhyBundle(path string, cmd *cobra.Command, result WhyResult, depGraph *DependencyOverview) error {
+	if err := applySVGTheme(); err != nil {
+		return err
+	}
+	command := newWhyBundleCommand(cmd, result)
+	var commandJSON, resultJSON bytes.Buffer
+	if err := writeJSON(&commandJSON, command); err != nil {
+		return err
+	}
+	if err := writeJSON(&resultJSON, result); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, f := range []struct {
+		name string
+		data []byte
+	}{
+		{bundleGraphFile, []byte(depGraph.rawGraph)},
+		{bundleCommandFile, commandJSON.Bytes()},
+		{bundleResultFile, resultJSON.Bytes()},
+		{bundleSVGFile, []byte(renderWhySVG(result))},
+	} {
+		hdr := &tar.Header{Name: f.name, Mode: 0644, Size: int64(len(f.data)), ModTime: command.CreatedAt}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(f.data); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return err
+	}
+	infof("Wrote why bundle to %s\n", path)
+	return nil
+}
+
+func newWhyBundleCommand(cmd *cobra.Command, result WhyResult) WhyBundleCommand {
+	command := WhyBundleCommand{
+		Command:        os.Args,
+		Flags:          map[string]string{},
+		MainModules:    result.MainModules,
+		DepstatVersion: rootCmd.Version,
+		CreatedAt:      time.Now().UTC(),
+		GoEnv:          goEnvironmentForOutput(),
+		Git:            gitMetadataForOutput(),
+	}
+	query := result.Target
+	if result.Version != "" {
+		query += "@" + result.Version
+	}
+	reproduce := []string{"depstat", "why", query, "--graph-file", bundleGraphFile, "--mainModules", strings.Join(result.MainModules, ","), "--json"}
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		command.Flags[f.Name] = f.Value.String()
+		if contains(whyBundleSkipFlags, f.Name) {
+			return
+		}
+		values := []string{f.Value.String()}
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			values = sv.GetSlice()
+		}
+		for _, v := range values {
+			reproduce = append(reproduce, fmt.Sprintf("--%s=%s", f.Name, shellQuote(v)))
+		}
+	})
+	command.Reproduce = strings.Join(reproduce, " ")
+	return command
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestWriteWhyBundle(t *testing.T) {
+	t.Setenv("GOFLAGS", "-mod=mod")
+	raw := "main A@v1.0.0\nA@v1.0.0 B@v1.0.0\n"
+	depGraph := generateGraph(raw, nil)
+	depGraph.rawGraph = raw
+	result := WhyResult{
+		Target:      "B",
+		Found:       true,
+		Paths:       []WhyPath{{Path: []string{"main", "A", "B"}}},
+		DirectDeps:  []string{"A"},
+		MainModules: []string{"main"},
+	}
+	cmd := &cobra.Command{Use: "why"}
+	var excludes []string
+	var bundle string
+	cmd.Flags().StringSliceVar(&excludes, "exclude-modules", nil, "")
+	cmd.Flags().StringVar(&bundle, "bundle", "", "")
+	if err := cmd.Flags().Parse([]string{"--exclude-modules", "x/*,y", "--bundle", "out.tar.gz"}); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "out.tar.gz")
+	if err := writeWhyBundle(path, cmd, result, &depGraph); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string]string)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[hdr.Name] = string(content)
+	}
+
+	if files[bundleGraphFile] != raw {
+		t.Errorf("graph.txt = %q, want the raw graph", files[bundleGraphFile])
+	}
+	var got WhyResult
+	if err := json.Unmarshal([]byte(files[bundleResultFile]), &got); err != nil || got.Target != "B" || len(got.Paths) != 1 {
+		t.Errorf("unexpected result.json (%v):\n%s", err, files[bundleResultFile])
+	}
+	if !strings.Contains(files[bundleSVGFile], "<svg") {
+		t.Errorf("why.svg is not an SVG document:\n%s", files[bundleSVGFile])
+	}
+	var command WhyBundleCommand
+	if err := json.Unmarshal([]byte(files[bundleCommandFile]), &command); err != nil {
+		t.Fatal(err)
+	}
+	want := "depstat why B --graph-file graph.txt --mainModules main --json --exclude-modules='x/*' --exclude-modules=y"
+	if command.Reproduce != want {
+		t.Errorf("reproduce = %q, want %q", command.Reproduce, want)
+	}
+	if command.Flags["bundle"] != "out.tar.gz" {
+		t.Errorf("flags = %v, want the bundle flag recorded", command.Flags)
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+)
+
+var htmlOutput bool
+
+// whyPathGroup is the set of why paths ending in the same direct dependent
+// of the target.
+type whyPathGroup struct {
+	Dependent string
+	IsMain    bool
+	Paths     [][]string
+}
+
+// groupWhyPaths groups paths by the module directly depending on the target,
+// ordering groups by path count and then by name.
+func groupWhyPaths(result WhyResult) []whyPathGroup {
+	byDependent := make(map[string]*whyPathGroup)
+	for _, wp := range result.Paths {
+		if len(wp.Path) < 2 {
+			continue
+		}
+		dependent := wp.Path[len(wp.Path)-2]
+		g, ok := byDependent[dependent]
+		if !ok {
+			g = &whyPathGroup{Dependent: dependent, IsMain: contains(result.MainModules, dependent)}
+			byDependent[dependent] = g
+		}
+		g.Paths = append(g.Paths, wp.Path)
+	}
+	groups := make([]whyPathGroup, 0, len(byDependent))
+	for _, g := range byDependent {
+		groups = append(groups, *g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if len(groups[i].Paths) != len(groups[j].Paths) {
+			return len(groups[i].Paths) > len(groups[j].Paths)
+		}
+		return groups[i].Dependent < groups[j].Dependent
+	})
+	return groups
+}
+
+var whyHTMLTemplate = template.Must(template.New("why").Funcs(template.FuncMap{
+	"join": strings.Join,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Why {{.Title}}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em auto; max-width: 1200px; color: #24292f; }
+code { background: #f6f8fa; padding: 0 .2em; }
+details { border: 1px solid #d0d7de; border-radius: 6px; margin: .5em 0; padding: .5em 1em; }
+summary { cursor: pointer; }
+ol { font-family: monospace; font-size: 90%; }
+.graph { overflow-x: auto; border: 1px solid #d0d7de; border-radius: 6px; margin: 1em 0; }
+.main { color: #1a7f37; }
+</style>
+</head>
+<body>
+<h1>Why is <code>{{.Title}}</code> {{if .Result.Version}}requested{{else}}included{{end}}?</h1>
+<p>Main modules: {{join .Result.MainModules ", "}}</p>
+{{- if not .Result.Found}}
+<p>Not found in dependency graph.</p>
+{{- with .Result.Suggestions}}
+<p>Did you mean: {{range $i, $s := .}}{{if $i}}, {{end}}<code>{{$s}}</code>{{end}}?</p>
+{{- end}}
+{{- else}}
+{{- if .Result.Version}}
+<p>Selected version: {{.Result.SelectedVersion}}</p>
+{{- end}}
+<p>{{.Result.TotalPaths}} path(s) through {{len .Groups}} direct dependent(s){{if .Result.Truncated}} (search truncated{{with .Result.PathCount}}; {{.}} paths in total{{end}}){{end}}.</p>
+<div class="graph">{{.SVG}}</div>
+<h2>Paths by direct dependent</h2>
+{{- range $i, $g := .Groups}}
+<details{{if eq $i 0}} open{{end}}>
+<summary><code{{if $g.IsMain}} class="main"{{end}}>{{$g.Dependent}}</code> ({{len $g.Paths}} path(s))</summary>
+<ol>
+{{- range $g.Paths}}
+<li>{{join . " → "}}</li>
+{{- end}}
+</ol>
+</details>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
+
+// renderWhyHTML renders the why result as a standalone HTML page with the
+// SVG subgraph embedded and paths grouped in collapsible sections.
+func renderWhyHTML(result WhyResult) (string, error) {
+	title := result.Target
+	if result.Version != "" {
+		title += "@" + result.Version
+	}
+	var b strings.Builder
+	err := whyHTMLTemplate.Execute(&b, struct {
+		Title  string
+		Result WhyResult
+		Groups []whyPathGroup
+		SVG    template.HTML
+	}{
+		Title:  title,
+		Result: result,
+		Groups: groupWhyPaths(result),
+		// renderWhySVG escapes every module name it emits
+		SVG: template.HTML(renderWhySVG(result)),
+	})
+	return b.String(), err
+}
+
+func outputWhyHTML(result WhyResult) error {
+	if err := applySVGTheme(); err != nil {
+		return err
+	}
+	page, err := renderWhyHTML(result)
+	if err != nil {
+		return err
+	}
+	fmt.Print(page)
+	return nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strings"
+)
+
+var whySample int
+var whySampleStrategy string
+var whySeed int64
+
+// whySampleAttempts bounds the draws per requested path, since draws
+// that repeat an already sampled path are discarded.
+const whySampleAttempts = 20
+
+func validateSampleStrategy(strategy string) error {
+	if strategy != "uniform" && strategy != "stratified" {
+		return withExitCode(ExitUsage, fmt.Errorf("--sample-strategy must be one of: uniform, stratified"))
+	}
+	return nil
+}
+
+// sampleWhyPaths returns up to n distinct paths from the start modules to
+// target. "uniform" draws every path with the same probability; "stratified"
+// splits n evenly across the direct dependents of target and samples
+// uniformly within each, so a dependent with few paths is still shown.
+func sampleWhyPaths(starts []string, target string, graph map[string][]string, directs []string, n int, strategy string, rng *rand.Rand) [][]string {
+	if strategy != "stratified" || len(directs) == 0 {
+		return samplePathsUniform(starts, target, graph, n, rng)
+	}
+	var paths [][]string
+	for i, d := range directs {
+		quota := n / len(directs)
+		if i < n%len(directs) {
+			quota++
+		}
+		if quota == 0 {
+			continue
+		}
+		restricted := restrictIncomingEdges(graph, target, []string{d})
+		paths = append(paths, samplePathsUniform(starts, target, restricted, quota, rng)...)
+	}
+	return paths
+}
+
+// samplePathsUniform draws paths from the DAG used by countPaths by walking
+// from the starts and picking each next module with probability
+// proportional to the number of paths through it. When there are at most n
+// paths, all of them are returned.
+func samplePathsUniform(starts []string, target string, graph map[string][]string, n int, rng *rand.Rand) [][]string {
+	counter := newPathCounter(starts, target, graph)
+	dag, count := counter.dag, counter.count
+	total := counter.total(starts)
+	if total.Sign() == 0 {
+		return nil
+	}
+
+	var paths [][]string
+	if total.Cmp(big.NewInt(int64(n))) <= 0 {
+		for _, start := range starts {
+			walkPaths(start, target, dag, nil, make(map[string]bool), 0, func(path []string) bool {
+				paths = append(paths, path)
+				return true
+			})
+		}
+		return paths
+	}
+
+	// pick returns the candidate whose cumulative count range contains r.
+	pick := func(candidates []string, r *big.Int) string {
+		for _, c := range candidates {
+			if r.Cmp(count(c)) < 0 {
+				return c
+			}
+			r.Sub(r, count(c))
+		}
+		return candidates[len(candidates)-1]
+	}
+	seen := make(map[string]bool)
+	for attempt := 0; len(paths) < n && attempt < n*whySampleAttempts; attempt++ {
+		node := pick(starts, new(big.Int).Rand(rng, total))
+		path := []string{node}
+		for node != target {
+			node = pick(dag[node], new(big.Int).Rand(rng, count(node)))
+			path = append(path, node)
+		}
+		key := strings.Join(path, " ")
+		if !seen[key] {
+			seen[key] = true
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
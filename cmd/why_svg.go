@@ -17,8 +17,11 @@ limitations under the License.
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"math"
+	"os"
 	"sort"
 	"strings"
 )
@@ -32,7 +35,82 @@ type nodePos struct {
 }
 
 type nodeColor struct {
-	Fill, Stroke, Text string
+	Fill   string `json:"fill"`
+	Stroke string `json:"stroke"`
+	Text   string `json:"text"`
+}
+
+// svgTheme is the palette of generated SVG diagrams.
+type svgTheme struct {
+	Background string    `json:"background"`
+	Title      string    `json:"title"`
+	Subtitle   string    `json:"subtitle"`
+	LegendText string    `json:"legendText"`
+	Footer     string    `json:"footer"`
+	Edge       string    `json:"edge"`
+	TargetEdge string    `json:"targetEdge"`
+	Main       nodeColor `json:"main"`
+	Target     nodeColor `json:"target"`
+	DirectDep  nodeColor `json:"directDependency"`
+	TestOnly   nodeColor `json:"testOnly"`
+	SameOrg    nodeColor `json:"sameOrg"`
+	External   nodeColor `json:"external"`
+}
+
+var svgThemes = map[string]svgTheme{
+	"light": {
+		Title:      "#333",
+		Subtitle:   "#888",
+		LegendText: "#555",
+		Footer:     "#aaa",
+		Edge:       "#888",
+		TargetEdge: "#D32F2F",
+		Main:       nodeColor{"#E8F5E9", "#388E3C", "#1B5E20"},
+		Target:     nodeColor{"#FFE0E0", "#D32F2F", "#B71C1C"},
+		DirectDep:  nodeColor{"#F3E5F5", "#8E24AA", "#4A148C"},
+		TestOnly:   nodeColor{"#F5F5F5", "#9E9E9E", "#616161"},
+		SameOrg:    nodeColor{"#E3F2FD", "#1976D2", "#0D47A1"},
+		External:   nodeColor{"#FFF3E0", "#F57C00", "#E65100"},
+	},
+	"dark": {
+		Background: "#0D1117",
+		Title:      "#E6EDF3",
+		Subtitle:   "#8B949E",
+		LegendText: "#C9D1D9",
+		Footer:     "#6E7681",
+		Edge:       "#8B949E",
+		TargetEdge: "#F85149",
+		Main:       nodeColor{"#12261E", "#3FB950", "#AFF5B4"},
+		Target:     nodeColor{"#3C1618", "#F85149", "#FFDCD7"},
+		DirectDep:  nodeColor{"#271052", "#A371F7", "#E2D1FF"},
+		TestOnly:   nodeColor{"#21262D", "#6E7681", "#B1BAC4"},
+		SameOrg:    nodeColor{"#0C2D6B", "#58A6FF", "#CAE8FF"},
+		External:   nodeColor{"#3D2A12", "#D29922", "#F8E3A1"},
+	},
+}
+
+var svgThemeFlag string
+var svgTitle string
+var svgCommandFooter bool
+
+// currentSVGTheme is the palette used by renderWhySVG.
+var currentSVGTheme = svgThemes["light"]
+
+// loadSVGTheme resolves --svg-theme: a built-in theme name, or a JSON file
+// whose colors override the light theme.
+func loadSVGTheme(name string) (svgTheme, error) {
+	if theme, ok := svgThemes[name]; ok {
+		return theme, nil
+	}
+	raw, err := readInputFile("--svg-theme", name)
+	if err != nil {
+		return svgTheme{}, fmt.Errorf("--svg-theme must be light, dark or a JSON theme file: %w", err)
+	}
+	theme := svgThemes["light"]
+	if err := json.Unmarshal(raw, &theme); err != nil {
+		return svgTheme{}, fmt.Errorf("parsing SVG theme %s: %w", name, err)
+	}
+	return theme, nil
 }
 
 const (
@@ -50,12 +128,41 @@ const (
 )
 
 func outputWhySVG(result WhyResult) error {
+	if err := applySVGTheme(); err != nil {
+		return err
+	}
+	return writeBuffered(os.Stdout, func(w io.Writer) error {
+		writeWhySVG(w, result)
+		return nil
+	})
+}
+
+// applySVGTheme makes the theme selected with --svg-theme current.
+func applySVGTheme() error {
+	theme, err := loadSVGTheme(svgThemeFlag)
+	if err != nil {
+		return err
+	}
+	currentSVGTheme = theme
+	return nil
+}
+
+// renderWhySVG lays out the why paths as a self-contained SVG document.
+func renderWhySVG(result WhyResult) string {
+	var b strings.Builder
+	writeWhySVG(&b, result)
+	return b.String()
+}
+
+// writeWhySVG streams the SVG document of renderWhySVG to w. Write errors
+// are left to the caller, which is expected to check them when flushing.
+func writeWhySVG(w io.Writer, result WhyResult) {
 	if !result.Found || len(result.Paths) == 0 {
-		fmt.Printf(`<svg xmlns="http://www.w3.org/2000/svg" width="400" height="80">
+		fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="400" height="80">
 <text x="200" y="40" text-anchor="middle" font-family="sans-serif" font-size="14">No dependency paths found for %s</text>
 </svg>
 `, xmlEscape(result.Target))
-		return nil
+		return
 	}
 
 	// Extract unique nodes and edges from paths
@@ -89,8 +196,12 @@ func outputWhySVG(result WhyResult) error {
 	// Compute node labels and widths
 	labels := make(map[string]string)
 	widths := make(map[string]float64)
+	maxWeight := whyMaxWeight(result, nodeSet)
 	for node := range nodeSet {
 		label := abbreviateModule(node, result.MainModules)
+		if result.weights != nil {
+			label = fmt.Sprintf("%s (%d)", label, result.weights[node])
+		}
 		labels[node] = label
 		w := math.Max(svgMinNodeWidth, float64(len(label))*svgCharWidth+24)
 		widths[node] = w
@@ -109,7 +220,11 @@ func outputWhySVG(result WhyResult) error {
 		}
 	}
 	svgWidth := math.Max(svgMinWidth, math.Min(svgMaxWidth, maxLayerWidth+2*svgPaddingX))
-	svgHeight := svgPaddingTop + float64(numLayers-1)*svgLayerSpacing + svgNodeHeight + 40
+	legend := svgLegendEntries(currentSVGTheme)
+	perRow := int(math.Max(1, math.Floor((svgWidth-32)/svgLegendEntryWidth)))
+	legendRows := (len(legend) + perRow - 1) / perRow
+	paddingTop := svgPaddingTop + float64(legendRows-1)*svgLegendRowHeight
+	svgHeight := paddingTop + float64(numLayers-1)*svgLayerSpacing + svgNodeHeight + 40
 
 	// Compute positions (centered per layer)
 	positions := make(map[string]nodePos)
@@ -121,7 +236,7 @@ func outputWhySVG(result WhyResult) error {
 		}
 		totalW += float64(len(nodes)-1) * svgNodeSpacing
 		x := (svgWidth - totalW) / 2
-		y := svgPaddingTop + float64(l)*svgLayerSpacing
+		y := paddingTop + float64(l)*svgLayerSpacing
 		for _, n := range nodes {
 			positions[n] = nodePos{X: x, Y: y, W: widths[n], H: svgNodeHeight}
 			x += widths[n] + svgNodeSpacing
@@ -129,29 +244,41 @@ func outputWhySVG(result WhyResult) error {
 	}
 
 	// Build SVG
-	var b strings.Builder
-	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f" font-family="system-ui,-apple-system,sans-serif">`, svgWidth, svgHeight, svgWidth, svgHeight)
-	fmt.Fprintln(&b)
+	fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f" font-family="system-ui,-apple-system,sans-serif">`, svgWidth, svgHeight, svgWidth, svgHeight)
+	fmt.Fprintln(w)
+	theme := currentSVGTheme
+	if theme.Background != "" {
+		fmt.Fprintf(w, `<rect width="100%%" height="100%%" fill="%s"/>`, theme.Background)
+		fmt.Fprintln(w)
+	}
 
 	// Defs: arrow markers
-	fmt.Fprint(&b, `<defs>
+	fmt.Fprintf(w, `<defs>
   <marker id="a" viewBox="0 0 10 6" refX="10" refY="3" markerWidth="8" markerHeight="5" orient="auto-start-reverse">
-    <path d="M0 0L10 3L0 6z" fill="#888"/>
+    <path d="M0 0L10 3L0 6z" fill="%s"/>
   </marker>
   <marker id="ar" viewBox="0 0 10 6" refX="10" refY="3" markerWidth="8" markerHeight="5" orient="auto-start-reverse">
-    <path d="M0 0L10 3L0 6z" fill="#D32F2F"/>
+    <path d="M0 0L10 3L0 6z" fill="%s"/>
   </marker>
 </defs>
-`)
+`, theme.Edge, theme.TargetEdge)
 
 	// Title
-	fmt.Fprintf(&b, `<text x="%.1f" y="28" text-anchor="middle" font-size="14" font-weight="600" fill="#333">Why is %s included?</text>`, svgWidth/2, xmlEscape(result.Target))
-	fmt.Fprintln(&b)
-	fmt.Fprintf(&b, `<text x="%.1f" y="46" text-anchor="middle" font-size="11" fill="#888">%d paths, %d direct dependent(s)</text>`, svgWidth/2, len(result.Paths), len(result.DirectDeps))
-	fmt.Fprintln(&b)
+	title := svgTitle
+	if title == "" {
+		title = fmt.Sprintf("Why is %s included?", result.Target)
+	}
+	fmt.Fprintf(w, `<text x="%.1f" y="28" text-anchor="middle" font-size="14" font-weight="600" fill="%s">%s</text>`, svgWidth/2, theme.Title, xmlEscape(title))
+	fmt.Fprintln(w)
+	paths := fmt.Sprintf("%d paths", len(result.Paths))
+	if result.Truncated && result.PathCount != nil {
+		paths = fmt.Sprintf("%d of %s paths", len(result.Paths), result.PathCount)
+	}
+	fmt.Fprintf(w, `<text x="%.1f" y="46" text-anchor="middle" font-size="11" fill="%s">%s, %d direct dependent(s)</text>`, svgWidth/2, theme.Subtitle, paths, len(result.DirectDeps))
+	fmt.Fprintln(w)
 
 	// Legend
-	renderSVGLegend(&b, 16, 60)
+	renderSVGLegend(w, legend, theme, 16, 60, perRow)
 
 	// Edges (before nodes so nodes draw on top)
 	directDepSet := make(map[string]bool)
@@ -159,7 +286,7 @@ func outputWhySVG(result WhyResult) error {
 		directDepSet[d] = true
 	}
 
-	for e := range edgeSet {
+	for _, e := range sortedSVGEdges(edgeSet) {
 		fp := positions[e.From]
 		tp := positions[e.To]
 		path := svgBezierPath(fp, tp)
@@ -167,21 +294,21 @@ func outputWhySVG(result WhyResult) error {
 		isDirectToTarget := e.To == result.Target && directDepSet[e.From]
 		layerDiff := layerOf[e.To] - layerOf[e.From]
 
-		stroke := "#888"
+		stroke := theme.Edge
 		sw := "1.3"
 		marker := "url(#a)"
 		dash := ""
 
 		if isDirectToTarget {
-			stroke = "#D32F2F"
+			stroke = theme.TargetEdge
 			sw = "2.2"
 			marker = "url(#ar)"
 		} else if layerDiff > 1 {
 			dash = ` stroke-dasharray="5,3"`
 		}
 
-		fmt.Fprintf(&b, `<path d="%s" fill="none" stroke="%s" stroke-width="%s" marker-end="%s"%s/>`, path, stroke, sw, marker, dash)
-		fmt.Fprintln(&b)
+		fmt.Fprintf(w, `<path d="%s" fill="none" stroke="%s" stroke-width="%s" marker-end="%s"%s/>`, path, stroke, sw, marker, dash)
+		fmt.Fprintln(w)
 	}
 
 	// Nodes
@@ -191,29 +318,59 @@ func outputWhySVG(result WhyResult) error {
 	}
 	sort.Strings(sortedNodes)
 
+	directSet := directDependencySet(result)
 	for _, node := range sortedNodes {
 		p := positions[node]
-		c := classifyNodeColor(node, result)
+		c := classifyNodeColor(node, result, directSet, theme)
+		if result.weights != nil && node != result.Target && !contains(result.MainModules, node) {
+			t := weightFraction(result.weights[node], maxWeight)
+			c.Fill = weightColor(t)
+			if t > 0.6 {
+				c.Text = "#FFFFFF"
+			}
+		}
 		sw := "1.5"
 		if node == result.Target || contains(result.MainModules, node) {
 			sw = "2"
 		}
-		fmt.Fprintf(&b, `<g><title>%s</title>`, xmlEscape(node))
-		fmt.Fprintf(&b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" rx="%.0f" fill="%s" stroke="%s" stroke-width="%s"/>`,
-			p.X, p.Y, p.W, p.H, svgCornerRadius, c.Fill, c.Stroke, sw)
-		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="middle" dominant-baseline="central" font-size="%.0f" fill="%s">%s</text>`,
+		dash := ""
+		if result.testOnly[node] {
+			dash = ` stroke-dasharray="4,2"`
+		}
+		fmt.Fprintf(w, `<g><title>%s</title>`, xmlEscape(node))
+		fmt.Fprintf(w, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" rx="%.0f" fill="%s" stroke="%s" stroke-width="%s"%s/>`,
+			p.X, p.Y, p.W, p.H, svgCornerRadius, c.Fill, c.Stroke, sw, dash)
+		fmt.Fprintf(w, `<text x="%.1f" y="%.1f" text-anchor="middle" dominant-baseline="central" font-size="%.0f" fill="%s">%s</text>`,
 			p.X+p.W/2, p.Y+p.H/2, svgFontSize, c.Text, xmlEscape(labels[node]))
-		fmt.Fprintln(&b, `</g>`)
+		fmt.Fprintln(w, `</g>`)
 	}
 
 	// Footer
-	fmt.Fprintf(&b, `<text x="%.1f" y="%.0f" text-anchor="middle" font-size="10" fill="#aaa">generated by depstat</text>`,
-		svgWidth/2, svgHeight-12)
-	fmt.Fprintln(&b)
+	footer := "generated by depstat"
+	if svgCommandFooter {
+		footer = "generated by: " + strings.Join(append([]string{"depstat"}, os.Args[1:]...), " ")
+	}
+	fmt.Fprintf(w, `<text x="%.1f" y="%.0f" text-anchor="middle" font-size="10" fill="%s">%s</text>`,
+		svgWidth/2, svgHeight-12, theme.Footer, xmlEscape(footer))
+	fmt.Fprintln(w)
 
-	fmt.Fprintln(&b, `</svg>`)
-	fmt.Print(b.String())
-	return nil
+	fmt.Fprintln(w, `</svg>`)
+}
+
+// sortedSVGEdges returns the edges ordered by source, then target, so the
+// SVG is the same on every run.
+func sortedSVGEdges(edgeSet map[svgEdge]bool) []svgEdge {
+	edges := make([]svgEdge, 0, len(edgeSet))
+	for e := range edgeSet {
+		edges = append(edges, e)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		return edges[i].To < edges[j].To
+	})
+	return edges
 }
 
 // assignLayers does BFS from main modules, using the longest path from root
@@ -223,7 +380,7 @@ func assignLayers(nodeSet map[string]bool, edgeSet map[svgEdge]bool, result WhyR
 
 	// Build adjacency list from edge set
 	adj := make(map[string][]string)
-	for e := range edgeSet {
+	for _, e := range sortedSVGEdges(edgeSet) {
 		adj[e.From] = append(adj[e.From], e.To)
 	}
 
@@ -266,12 +423,42 @@ func assignLayers(nodeSet map[string]bool, edgeSet map[svgEdge]bool, result WhyR
 	return layerOf
 }
 
-func classifyNodeColor(node string, result WhyResult) nodeColor {
+// whyMaxWeight returns the largest transitive weight among the non-main
+// nodes, which anchors the shading scale.
+func whyMaxWeight(result WhyResult, nodeSet map[string]bool) int {
+	maxWeight := 0
+	for node := range nodeSet {
+		if !contains(result.MainModules, node) {
+			maxWeight = max(maxWeight, result.weights[node])
+		}
+	}
+	return maxWeight
+}
+
+// directDependencySet returns the nodes that are direct dependencies of a
+// main module on some path.
+func directDependencySet(result WhyResult) map[string]bool {
+	direct := make(map[string]bool)
+	for _, wp := range result.Paths {
+		if len(wp.Path) > 1 && contains(result.MainModules, wp.Path[0]) {
+			direct[wp.Path[1]] = true
+		}
+	}
+	return direct
+}
+
+func classifyNodeColor(node string, result WhyResult, directSet map[string]bool, theme svgTheme) nodeColor {
 	if node == result.Target {
-		return nodeColor{"#FFE0E0", "#D32F2F", "#B71C1C"}
+		return theme.Target
 	}
 	if contains(result.MainModules, node) {
-		return nodeColor{"#E8F5E9", "#388E3C", "#1B5E20"}
+		return theme.Main
+	}
+	if result.testOnly[node] {
+		return theme.TestOnly
+	}
+	if directSet[node] {
+		return theme.DirectDep
 	}
 	// Check if same org as first main module
 	if len(result.MainModules) > 0 {
@@ -279,11 +466,11 @@ func classifyNodeColor(node string, result WhyResult) nodeColor {
 		if idx := strings.Index(main, "/"); idx > 0 {
 			prefix := main[:idx+1]
 			if strings.HasPrefix(node, prefix) {
-				return nodeColor{"#E3F2FD", "#1976D2", "#0D47A1"}
+				return theme.SameOrg
 			}
 		}
 	}
-	return nodeColor{"#FFF3E0", "#F57C00", "#E65100"}
+	return theme.External
 }
 
 func abbreviateModule(mod string, mainModules []string) string {
@@ -319,21 +506,40 @@ func svgBezierPath(from, to nodePos) string {
 	return fmt.Sprintf("M%.1f %.1fQ%.1f %.1f %.1f %.1f", x1, y1, cx, cy, x2, y2)
 }
 
-func renderSVGLegend(b *strings.Builder, x, y float64) {
-	entries := []struct {
-		fill, stroke, label string
-	}{
-		{"#E8F5E9", "#388E3C", "Main module"},
-		{"#E3F2FD", "#1976D2", "Same org"},
-		{"#FFF3E0", "#F57C00", "External"},
-		{"#FFE0E0", "#D32F2F", "Target"},
+const (
+	svgLegendEntryWidth = 120.0
+	svgLegendRowHeight  = 18.0
+)
+
+type svgLegendEntry struct {
+	color  nodeColor
+	label  string
+	dashed bool
+}
+
+func svgLegendEntries(theme svgTheme) []svgLegendEntry {
+	return []svgLegendEntry{
+		{color: theme.Main, label: "Main module"},
+		{color: theme.DirectDep, label: "Direct dependency"},
+		{color: theme.SameOrg, label: "Same org"},
+		{color: theme.External, label: "External"},
+		{color: theme.TestOnly, label: "Test-only", dashed: true},
+		{color: theme.Target, label: "Target"},
 	}
+}
+
+func renderSVGLegend(w io.Writer, entries []svgLegendEntry, theme svgTheme, x, y float64, perRow int) {
 	for i, e := range entries {
-		ex := x + float64(i)*110
-		fmt.Fprintf(b, `<rect x="%.0f" y="%.0f" width="12" height="12" rx="3" fill="%s" stroke="%s" stroke-width="1"/>`, ex, y, e.fill, e.stroke)
-		fmt.Fprintf(b, `<text x="%.0f" y="%.0f" font-size="11" dominant-baseline="central" fill="#555">%s</text>`, ex+16, y+6, e.label)
+		ex := x + float64(i%perRow)*svgLegendEntryWidth
+		ey := y + float64(i/perRow)*svgLegendRowHeight
+		dash := ""
+		if e.dashed {
+			dash = ` stroke-dasharray="3,1"`
+		}
+		fmt.Fprintf(w, `<rect x="%.0f" y="%.0f" width="12" height="12" rx="3" fill="%s" stroke="%s" stroke-width="1"%s/>`, ex, ey, e.color.Fill, e.color.Stroke, dash)
+		fmt.Fprintf(w, `<text x="%.0f" y="%.0f" font-size="11" dominant-baseline="central" fill="%s">%s</text>`, ex+16, ey+6, theme.LegendText, e.label)
 	}
-	fmt.Fprintln(b)
+	fmt.Fprintln(w)
 }
 
 func xmlEscape(s string) string {
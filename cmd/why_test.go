@@ -3,7 +3,9 @@ package cmd
 import (
 	"bytes"
 	"io"
+	"math/rand"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -86,3 +88,298 @@ func captureStdout(t *testing.T, fn func()) string {
 	}
 	return buf.String()
 }
+
+func TestWhyVersionQualifiedPaths(t *testing.T) {
+	depGraph := generateGraph(`main A@v1.0.0
+main B@v1.0.0
+main N@v0.17.0
+A@v1.0.0 N@v0.10.0
+B@v1.0.0 N@v0.17.0`, nil)
+
+	requesters := requestersOfVersion(depGraph.Requirements["N"], "v0.10.0")
+	if strings.Join(requesters, ",") != "A" {
+		t.Fatalf("expected only A to request v0.10.0, got %v", requesters)
+	}
+
+	var paths [][]string
+	restricted := restrictIncomingEdges(depGraph.Graph, "N", requesters)
+	findAllPaths("main", "N", restricted, []string{}, map[string]bool{}, &paths, 0)
+	if len(paths) != 1 || strings.Join(paths[0], " -> ") != "main -> A -> N" {
+		t.Fatalf("unexpected paths %v", paths)
+	}
+	if len(depGraph.Graph["B"]) != 1 {
+		t.Fatalf("restrictIncomingEdges must not modify the original graph")
+	}
+}
+
+func TestRenderWhyHTML(t *testing.T) {
+	result := WhyResult{
+		Target:      "D",
+		Found:       true,
+		MainModules: []string{"main"},
+		Paths: []WhyPath{
+			{Path: []string{"main", "D"}, Direct: true},
+			{Path: []string{"main", "A", "D"}},
+			{Path: []string{"main", "B", "A", "D"}},
+		},
+		DirectDeps: []string{"A", "main"},
+		TotalPaths: 3,
+	}
+	groups := groupWhyPaths(result)
+	if len(groups) != 2 || groups[0].Dependent != "A" || len(groups[0].Paths) != 2 || !groups[1].IsMain {
+		t.Fatalf("unexpected groups %+v", groups)
+	}
+
+	page, err := renderWhyHTML(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"<title>Why D</title>",
+		"3 path(s) through 2 direct dependent(s).",
+		`<details open>`,
+		`<summary><code>A</code> (2 path(s))</summary>`,
+		"<li>main → B → A → D</li>",
+		`<div class="graph"><svg`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("HTML page missing %q", want)
+		}
+	}
+}
+
+func TestRenderWhySVGTheme(t *testing.T) {
+	result := WhyResult{
+		Target:      "D",
+		Found:       true,
+		MainModules: []string{"example.com/main"},
+		Paths: []WhyPath{
+			{Path: []string{"example.com/main", "A", "D"}},
+		},
+		DirectDeps: []string{"A"},
+		testOnly:   map[string]bool{"D": true},
+	}
+	defer func(theme svgTheme) { currentSVGTheme = theme }(currentSVGTheme)
+	currentSVGTheme = svgThemes["dark"]
+
+	svg := renderWhySVG(result)
+	for _, want := range []string{
+		`<rect width="100%" height="100%" fill="#0D1117"/>`,
+		">Direct dependency</text>",
+		">Test-only</text>",
+		`fill="#271052" stroke="#A371F7"`, // A is a direct dependency
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("SVG missing %q", want)
+		}
+	}
+
+	themeFile := t.TempDir() + "/theme.json"
+	if err := os.WriteFile(themeFile, []byte(`{"background":"#000","target":{"fill":"#111","stroke":"#222","text":"#333"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	theme, err := loadSVGTheme(themeFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if theme.Background != "#000" || theme.Target.Stroke != "#222" || theme.Main != svgThemes["light"].Main {
+		t.Errorf("unexpected custom theme %+v", theme)
+	}
+	if _, err := loadSVGTheme("no-such-theme"); err == nil {
+		t.Error("expected error for unknown theme")
+	}
+}
+
+func TestRenderWhySVGWeights(t *testing.T) {
+	result := WhyResult{
+		Target:      "D",
+		Found:       true,
+		MainModules: []string{"main"},
+		Paths:       []WhyPath{{Path: []string{"main", "A", "D"}}, {Path: []string{"main", "B", "D"}}},
+		DirectDeps:  []string{"A", "B"},
+		weights:     map[string]int{"main": 4, "A": 3, "B": 1, "D": 0},
+	}
+	svg := renderWhySVG(result)
+	for _, want := range []string{">A (3)</text>", ">B (1)</text>", `fill="#D84315"`} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("weighted SVG missing %q", want)
+		}
+	}
+}
+
+func TestStreamWhyPaths(t *testing.T) {
+	graph := map[string][]string{
+		"main": {"A", "B"},
+		"A":    {"C"},
+		"B":    {"C"},
+	}
+	var buf bytes.Buffer
+	if err := streamWhyPaths(&buf, []string{"main"}, "C", graph, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"path":["main","A","C"],"direct":false}
+{"path":["main","B","C"],"direct":false}
+`
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := streamWhyPaths(&buf, []string{"main"}, "C", graph, 1, 0); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != 1 {
+		t.Errorf("expected 1 line with max paths 1, got %d:\n%s", lines, buf.String())
+	}
+}
+
+func TestCountPaths(t *testing.T) {
+	// three stacked diamonds: 2*2*2 paths from main to D
+	graph := map[string][]string{
+		"main": {"A1", "A2"},
+		"A1":   {"B"},
+		"A2":   {"B"},
+		"B":    {"C1", "C2"},
+		"C1":   {"C"},
+		"C2":   {"C"},
+		"C":    {"D1", "D2"},
+		"D1":   {"D"},
+		"D2":   {"D"},
+	}
+	if got := countPaths([]string{"main"}, "D", graph); got.Int64() != 8 {
+		t.Errorf("countPaths = %s, want 8", got)
+	}
+	var paths [][]string
+	findAllPaths("main", "D", graph, []string{}, make(map[string]bool), &paths, 3)
+	if len(paths) != 3 {
+		t.Fatalf("expected enumeration truncated at 3, got %d", len(paths))
+	}
+
+	// the back edge C -> A1 is dropped and does not loop forever
+	graph["C"] = append(graph["C"], "A1")
+	if got := countPaths([]string{"main"}, "D", graph); got.Int64() != 8 {
+		t.Errorf("countPaths with cycle = %s, want 8", got)
+	}
+	if got := countPaths([]string{"main"}, "missing", graph); got.Sign() != 0 {
+		t.Errorf("countPaths to unreachable target = %s, want 0", got)
+	}
+}
+
+func TestAutoLimit(t *testing.T) {
+	// one direct path, two of 3 hops and four of 4 hops
+	graph := map[string][]string{
+		"main": {"T", "A1", "A2", "B"},
+		"A1":   {"X"},
+		"A2":   {"X"},
+		"X":    {"T"},
+		"B":    {"C1", "C2"},
+		"C1":   {"D1", "D2"},
+		"C2":   {"D1", "D2"},
+		"D1":   {"T"},
+		"D2":   {"T"},
+	}
+	lengths := pathCountsByLength([]string{"main"}, "T", graph)
+	var got []int64
+	for _, n := range lengths {
+		got = append(got, n.Int64())
+	}
+	if want := []int64{0, 1, 0, 2, 4}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("pathCountsByLength = %v, want %v", got, want)
+	}
+
+	for _, tt := range []struct{ budget, want int }{{10, 0}, {7, 0}, {3, 3}, {1, 1}} {
+		if got := autoMaxDepth(lengths, tt.budget); got != tt.want {
+			t.Errorf("autoMaxDepth(budget %d) = %d, want %d", tt.budget, got, tt.want)
+		}
+	}
+
+	var paths [][]string
+	findAllPathsWithin("main", "T", graph, &paths, 0, 3)
+	if len(paths) != 3 {
+		t.Errorf("expected the 3 paths of at most 3 hops, got %v", paths)
+	}
+}
+
+func TestPathCountsMatchPrunedSearch(t *testing.T) {
+	// main -> B -> A -> T only exists through B -> A, which closes the
+	// cycle A -> B -> A in the DFS from main
+	graph := map[string][]string{
+		"main": {"A", "B"},
+		"A":    {"B", "T"},
+		"B":    {"A"},
+	}
+	dag := pruneBackEdges([]string{"main"}, graph)
+	lengths := pathCountsByLength([]string{"main"}, "T", dag)
+	var paths [][]string
+	findAllPathsWithin("main", "T", dag, &paths, 0, 0)
+	if total := sumCounts(lengths, len(lengths)); total.Int64() != int64(len(paths)) {
+		t.Errorf("counted %s paths, enumerated %v", total, paths)
+	}
+	paths = nil
+	findAllPathsWithin("main", "T", graph, &paths, 0, 0)
+	if len(paths) != 2 {
+		t.Errorf("expected 2 paths in the full graph, got %v", paths)
+	}
+}
+
+func TestSampleWhyPaths(t *testing.T) {
+	// A has one path to T, B has eight
+	graph := map[string][]string{
+		"main": {"A", "B"},
+		"A":    {"T"},
+		"B":    {"C1", "C2"},
+		"C1":   {"D"},
+		"C2":   {"D"},
+		"D":    {"E1", "E2"},
+		"E1":   {"F"},
+		"E2":   {"F"},
+		"F":    {"G1", "G2"},
+		"G1":   {"Z"},
+		"G2":   {"Z"},
+		"Z":    {"T"},
+	}
+	rng := rand.New(rand.NewSource(1))
+	all := sampleWhyPaths([]string{"main"}, "T", graph, nil, 20, "uniform", rng)
+	if len(all) != 9 {
+		t.Fatalf("expected all 9 paths when n exceeds the total, got %d", len(all))
+	}
+
+	paths := sampleWhyPaths([]string{"main"}, "T", graph, nil, 4, "uniform", rng)
+	if len(paths) != 4 {
+		t.Fatalf("expected 4 sampled paths, got %d", len(paths))
+	}
+	seen := map[string]bool{}
+	for _, p := range paths {
+		key := strings.Join(p, " ")
+		if seen[key] || p[0] != "main" || p[len(p)-1] != "T" {
+			t.Errorf("unexpected or duplicate path %v", p)
+		}
+		seen[key] = true
+	}
+
+	stratified := sampleWhyPaths([]string{"main"}, "T", graph, []string{"A", "Z"}, 2, "stratified", rng)
+	if len(stratified) != 2 || !reflect.DeepEqual(stratified[0], []string{"main", "A", "T"}) || stratified[1][len(stratified[1])-2] != "Z" {
+		t.Errorf("expected one path through each direct dependent, got %v", stratified)
+	}
+}
+
+func TestOutputWhyTextDepthLimitWithoutCount(t *testing.T) {
+	result := WhyResult{
+		Target:      "T",
+		Found:       true,
+		MainModules: []string{"main"},
+		DirectDeps:  []string{"main"},
+		Paths:       []WhyPath{{Path: []string{"main", "T"}, Direct: true}},
+		TotalPaths:  1,
+		Truncated:   true,
+		MaxDepth:    1,
+	}
+	output := captureStdout(t, func() {
+		if err := outputWhyText(result); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if strings.Contains(output, "<nil>") || !strings.Contains(output, "search limited to --max-depth=1") {
+		t.Errorf("unexpected output:\n%s", output)
+	}
+}
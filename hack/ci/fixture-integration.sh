@@ -131,7 +131,7 @@ jq -e '.directDependencies >= 2 and .transitiveDependencies >= 1 and .totalDepen
 
 echo "==> Testing stats --csv..."
 "${DEPSTAT_BIN}" stats --csv > stats.csv
-grep -q '^Direct,Transitive,Total,MaxDepth$' stats.csv \
+grep -q '^Direct,Transitive,Total,MaxDepth,Edges,AvgOutDegree,MaxOutDegree,MaxOutDegreeModule,Density,Gini,TopShare$' stats.csv \
   || { echo "FAIL: stats CSV missing expected header"; exit 1; }
 
 echo "==> Testing list..."
@@ -39,7 +39,7 @@ echo "==> Testing stats (verbose, json, csv)..."
 "${DEPSTAT_BIN}" stats -m "${main_modules}" --csv > "${ARTIFACT_DIR}/stats.csv"
 jq -e '.directDependencies >= 1 and .totalDependencies >= .directDependencies and .maxDepthOfDependencies >= 1' "${ARTIFACT_DIR}/stats.json" >/dev/null \
   || { echo "FAIL: stats JSON field values out of range"; exit 1; }
-grep -q '^Direct,Transitive,Total,MaxDepth$' "${ARTIFACT_DIR}/stats.csv" \
+grep -q '^Direct,Transitive,Total,MaxDepth,Edges,AvgOutDegree,MaxOutDegree,MaxOutDegreeModule,Density,Gini,TopShare$' "${ARTIFACT_DIR}/stats.csv" \
   || { echo "FAIL: stats CSV missing expected header"; exit 1; }
 
 echo "==> Testing list..."